@@ -0,0 +1,107 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gonum.org/v1/plot/vg"
+)
+
+// Summary returns a text summary of the plot, giving its title,
+// the labels and ranges of its axes and the names of the plotters
+// labelled in its legend. The summary may be used as the
+// alternative text or Description of the plot.
+func (p *Plot) Summary() string {
+	var parts []string
+	if p.Title.Text != "" {
+		parts = append(parts, p.Title.Text+".")
+	}
+	parts = append(parts, p.X.summary("X")+".", p.Y.summary("Y")+".")
+	var names []string
+	for _, e := range p.Legend.entries {
+		if e.text != "" && !e.heading {
+			names = append(names, e.text)
+		}
+	}
+	switch len(names) {
+	case 0:
+	case 1:
+		parts = append(parts, "Series: "+names[0]+".")
+	default:
+		parts = append(parts, fmt.Sprintf("%d series: %s.", len(names), strings.Join(names, ", ")))
+	}
+	return strings.Join(parts, " ")
+}
+
+// summary returns a summary of the axis with the given name.
+func (a *Axis) summary(name string) string {
+	if label := a.labelText(); label != "" {
+		return fmt.Sprintf("%s axis %q from %g to %g", name, label, a.Min, a.Max)
+	}
+	return fmt.Sprintf("%s axis from %g to %g", name, a.Min, a.Max)
+}
+
+// group returns the accessibility metadata of the part of the
+// plot passed to the part function of draw. The axes are titled
+// with their labels and described by their ranges, plotters are
+// titled with their legend labels, and the remaining parts have
+// no metadata.
+func (p *Plot) group(name string, pl Plotter) vg.Group {
+	switch name {
+	case "x", "y":
+		a := &p.X
+		if name == "y" {
+			a = &p.Y
+		}
+		title := a.labelText()
+		if title == "" {
+			title = strings.ToUpper(name) + " axis"
+		}
+		return vg.Group{Role: "graphics-object", Title: title, Description: a.summary(strings.ToUpper(name))}
+	case "plotter":
+		return vg.Group{Role: "graphics-object", Title: p.Legend.label(pl)}
+	case "legend":
+		if len(p.Legend.entries) == 0 {
+			return vg.Group{}
+		}
+		return vg.Group{Role: "graphics-object", Title: "Legend"}
+	}
+	return vg.Group{}
+}
+
+// label returns the text of the first legend entry
+// with the plotter pl as a thumbnail, or the empty
+// string if there is no such entry.
+func (l *Legend) label(pl Plotter) string {
+	for _, e := range l.entries {
+		for _, th := range e.thumbs {
+			if same(th, pl) {
+				return e.text
+			}
+		}
+	}
+	return ""
+}
+
+// same returns whether a and b hold the same value,
+// without panicking for values of types that cannot
+// be compared.
+func same(a, b interface{}) (ok bool) {
+	t := reflect.TypeOf(a)
+	if t == nil || t != reflect.TypeOf(b) || !t.Comparable() {
+		return false
+	}
+	// Values of comparable struct and array types may
+	// hold uncomparable values in interface fields.
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	return a == b
+}
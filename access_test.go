@@ -0,0 +1,131 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// seriesPlotter is a Plotter and Thumbnailer
+// drawing nothing.
+type seriesPlotter struct{ _ int }
+
+func (*seriesPlotter) Plot(c draw.Canvas, p *Plot) {}
+
+func (*seriesPlotter) Thumbnail(c *draw.Canvas) {}
+
+func TestSummary(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = -1, 1
+	if got, want := p.Summary(), "X axis from 0 to 10. Y axis from -1 to 1."; got != want {
+		t.Errorf("unexpected summary: got:%q want:%q", got, want)
+	}
+
+	p.Title.Text = "Waves"
+	p.X.Label.Text = "Time"
+	p.X.Unit = Second
+	p.Legend.Add("sin", &seriesPlotter{})
+	p.Legend.Add("cos", &seriesPlotter{})
+	want := `Waves. X axis "Time (s)" from 0 to 10. Y axis from -1 to 1. 2 series: sin, cos.`
+	if got := p.Summary(); got != want {
+		t.Errorf("unexpected summary: got:%q want:%q", got, want)
+	}
+}
+
+func TestAccessibleSVG(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "Waves & <noise>"
+	p.X.Label.Text = "Time"
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = -1, 1
+	a, b := &seriesPlotter{}, &seriesPlotter{}
+	p.Add(a, b)
+	p.Legend.Add("sin", a)
+	p.Description = p.Summary()
+
+	wt, err := p.WriterTo(10*vg.Centimeter, 10*vg.Centimeter, "svg")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	_, err = wt.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Collect the titles and descriptions of the groups.
+	svg := buf.String()
+	type group struct {
+		Role, Label string
+	}
+	var groups []group
+	dec := xml.NewDecoder(&buf)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error parsing SVG: %v", err)
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok || se.Name.Local != "g" {
+			continue
+		}
+		var g group
+		for _, attr := range se.Attr {
+			switch attr.Name.Local {
+			case "role":
+				g.Role = attr.Value
+			case "aria-label":
+				g.Label = attr.Value
+			}
+		}
+		if g.Role == "" {
+			continue
+		}
+		groups = append(groups, g)
+	}
+
+	want := []group{
+		{Role: "graphics-document", Label: "Waves & <noise>"},
+		{Role: "graphics-object", Label: "Time"},
+		{Role: "graphics-object", Label: "Y axis"},
+		{Role: "graphics-object", Label: "sin"},
+		{Role: "graphics-object"},
+		{Role: "graphics-object", Label: "Legend"},
+	}
+	if len(groups) != len(want) {
+		t.Fatalf("unexpected groups: got:%+v want:%+v", groups, want)
+	}
+	for i := range want {
+		if groups[i] != want[i] {
+			t.Errorf("unexpected group %d: got:%+v want:%+v", i, groups[i], want[i])
+		}
+	}
+	for _, want := range []string{
+		"<title>Waves &amp; &lt;noise&gt;</title>",
+		"<desc>Waves &amp; &lt;noise&gt;. X axis &#34;Time&#34; from 0 to 10. Y axis from -1 to 1. Series: sin.</desc>",
+		"<desc>Y axis from -1 to 1</desc>",
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("missing %q in SVG output", want)
+		}
+	}
+}
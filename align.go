@@ -117,3 +117,100 @@ func Align(plots [][]*Plot, t draw.Tiles, dc draw.Canvas) [][]draw.Canvas {
 	}
 	return o
 }
+
+// SharedAxes specifies the axes shared by the plots of
+// each column and row of a grid of aligned plots.
+type SharedAxes struct {
+	// X specifies that the plots in each column share
+	// their X axis range, and that only the bottom plot
+	// of each column labels the axis and its ticks.
+	X bool
+
+	// Y specifies that the plots in each row share
+	// their Y axis range, and that only the leftmost
+	// plot of each row labels the axis and its ticks.
+	Y bool
+}
+
+// AlignShared returns a two-dimensional row-major array of Canvases
+// as Align does, after making the shared axes of the plots consistent.
+// The range of each shared axis is set to the union of the ranges
+// of the plots sharing it, and the axis label and tick labels of
+// each plot that is not on the outer edge of the grid are removed,
+// so that the data areas of the plots are aligned exactly and the
+// space between them is not taken by repeated labels.
+//
+// AlignShared modifies the plots in place, so the same plots
+// may be aligned repeatedly with the same result.
+func AlignShared(plots [][]*Plot, t draw.Tiles, dc draw.Canvas, share SharedAxes) [][]draw.Canvas {
+	if share.X {
+		for i := 0; i < t.Cols; i++ {
+			min, max := math.Inf(1), math.Inf(-1)
+			for j := range plots {
+				if i < len(plots[j]) && plots[j][i] != nil {
+					min = math.Min(min, plots[j][i].X.Min)
+					max = math.Max(max, plots[j][i].X.Max)
+				}
+			}
+			var below bool
+			for j := len(plots) - 1; j >= 0; j-- {
+				if i >= len(plots[j]) || plots[j][i] == nil {
+					continue
+				}
+				p := plots[j][i]
+				p.X.Min, p.X.Max = min, max
+				if below {
+					unlabelAxis(&p.X)
+				}
+				below = true
+			}
+		}
+	}
+	if share.Y {
+		for _, row := range plots {
+			min, max := math.Inf(1), math.Inf(-1)
+			for _, p := range row {
+				if p != nil {
+					min = math.Min(min, p.Y.Min)
+					max = math.Max(max, p.Y.Max)
+				}
+			}
+			var left bool
+			for _, p := range row {
+				if p == nil {
+					continue
+				}
+				p.Y.Min, p.Y.Max = min, max
+				if left {
+					unlabelAxis(&p.Y)
+				}
+				left = true
+			}
+		}
+	}
+	return Align(plots, t, dc)
+}
+
+// unlabelAxis removes the axis label and tick labels of a.
+func unlabelAxis(a *Axis) {
+	a.Label.Text = ""
+	if _, ok := a.Tick.Marker.(unlabelledTicks); !ok {
+		a.Tick.Marker = unlabelledTicks{a.Tick.Marker}
+	}
+}
+
+// unlabelledTicks is a Ticker returning the ticks of
+// another Ticker without labels.
+type unlabelledTicks struct {
+	Ticker
+}
+
+// Ticks returns the ticks of the wrapped Ticker
+// without labels.
+func (t unlabelledTicks) Ticks(min, max float64) []Tick {
+	ticks := t.Ticker.Ticks(min, max)
+	for i := range ticks {
+		ticks[i].Label = ""
+	}
+	return ticks
+}
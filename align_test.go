@@ -5,6 +5,7 @@
 package plot
 
 import (
+	"fmt"
 	"math"
 	"os"
 	"testing"
@@ -95,3 +96,137 @@ func ExampleAlign() {
 func TestAlign(t *testing.T) {
 	cmpimg.CheckPlot(ExampleAlign, t, "align.png")
 }
+
+func ExampleAlignShared() {
+	const rows, cols = 2, 3
+	plots := make([][]*Plot, rows)
+	for j := 0; j < rows; j++ {
+		plots[j] = make([]*Plot, cols)
+		for i := 0; i < cols; i++ {
+			p, err := New()
+			if err != nil {
+				panic(err)
+			}
+			p.Title.Text = fmt.Sprintf("Panel %d", j*cols+i+1)
+			p.X.Label.Text = "Time"
+			p.Y.Label.Text = "Count"
+
+			// The ranges and so the tick label widths
+			// differ between the plots before alignment.
+			p.X.Min, p.X.Max = 0, float64(10*(i+1))
+			p.Y.Min, p.Y.Max = 0, math.Pow(100, float64(i+j))
+			plots[j][i] = p
+		}
+	}
+
+	img := vgimg.New(vg.Points(300), vg.Points(200))
+	dc := draw.New(img)
+
+	t := draw.Tiles{
+		Rows:      rows,
+		Cols:      cols,
+		PadX:      vg.Points(4),
+		PadY:      vg.Points(4),
+		PadTop:    vg.Points(2),
+		PadBottom: vg.Points(2),
+		PadLeft:   vg.Points(2),
+		PadRight:  vg.Points(2),
+	}
+
+	canvases := AlignShared(plots, t, dc, SharedAxes{X: true, Y: true})
+	for j := 0; j < rows; j++ {
+		for i := 0; i < cols; i++ {
+			plots[j][i].Draw(canvases[j][i])
+		}
+	}
+
+	w, err := os.Create("testdata/alignShared.png")
+	if err != nil {
+		panic(err)
+	}
+
+	png := vgimg.PngCanvas{Canvas: img}
+	if _, err := png.WriteTo(w); err != nil {
+		panic(err)
+	}
+}
+
+func TestAlignShared(t *testing.T) {
+	cmpimg.CheckPlot(ExampleAlignShared, t, "alignShared.png")
+}
+
+func TestAlignSharedDataAreas(t *testing.T) {
+	plots := [][]*Plot{make([]*Plot, 2), make([]*Plot, 2)}
+	for j, row := range plots {
+		for i := range row {
+			p, err := New()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			p.X.Label.Text = "x"
+			p.Y.Label.Text = "y"
+			p.X.Min, p.X.Max = 0, float64(i+1)
+			p.Y.Min, p.Y.Max = 0, math.Pow(1e3, float64(i+j))
+			row[i] = p
+		}
+	}
+	// Leave a hole so that the plot above it labels its X axis.
+	plots[1][1] = nil
+
+	dc := draw.New(vgimg.New(vg.Points(200), vg.Points(200)))
+	tiles := draw.Tiles{Rows: 2, Cols: 2, PadX: vg.Points(5), PadY: vg.Points(5)}
+	cs := AlignShared(plots, tiles, dc, SharedAxes{X: true, Y: true})
+
+	data := [][]draw.Canvas{make([]draw.Canvas, 2), make([]draw.Canvas, 2)}
+	for j, row := range plots {
+		for i, p := range row {
+			if p != nil {
+				data[j][i] = p.DataCanvas(cs[j][i])
+			}
+		}
+	}
+	const tol = 1e-9
+	same := func(a, b vg.Length) bool { return math.Abs(float64(a-b)) < tol }
+	if !same(data[0][0].Min.X, data[1][0].Min.X) || !same(data[0][0].Max.X, data[1][0].Max.X) {
+		t.Errorf("data areas of column 0 not aligned: %v %v", data[0][0].Rectangle, data[1][0].Rectangle)
+	}
+	if !same(data[0][0].Min.Y, data[0][1].Min.Y) || !same(data[0][0].Max.Y, data[0][1].Max.Y) {
+		t.Errorf("data areas of row 0 not aligned: %v %v", data[0][0].Rectangle, data[0][1].Rectangle)
+	}
+	w := data[0][0].Max.X - data[0][0].Min.X
+	h := data[0][0].Max.Y - data[0][0].Min.Y
+	if !same(data[0][1].Max.X-data[0][1].Min.X, w) || !same(data[1][0].Max.Y-data[1][0].Min.Y, h) {
+		t.Errorf("data areas differ in size")
+	}
+
+	for _, test := range []struct {
+		name     string
+		axis     Axis
+		min, max float64
+		labelled bool
+	}{
+		{name: "top left X", axis: plots[0][0].X, min: 0, max: 1, labelled: false},
+		{name: "top left Y", axis: plots[0][0].Y, min: 0, max: 1e3, labelled: true},
+		{name: "top right X", axis: plots[0][1].X, min: 0, max: 2, labelled: true},
+		{name: "top right Y", axis: plots[0][1].Y, min: 0, max: 1e3, labelled: false},
+		{name: "bottom left X", axis: plots[1][0].X, min: 0, max: 1, labelled: true},
+		{name: "bottom left Y", axis: plots[1][0].Y, min: 0, max: 1e3, labelled: true},
+	} {
+		if test.axis.Min != test.min || test.axis.Max != test.max {
+			t.Errorf("unexpected range of %s: got:[%v, %v] want:[%v, %v]",
+				test.name, test.axis.Min, test.axis.Max, test.min, test.max)
+		}
+		if got := test.axis.Label.Text != ""; got != test.labelled {
+			t.Errorf("unexpected labelling of %s: got:%t want:%t", test.name, got, test.labelled)
+		}
+		var hasTickLabel bool
+		for _, tick := range test.axis.Tick.Marker.Ticks(test.axis.Min, test.axis.Max) {
+			if tick.Label != "" {
+				hasTickLabel = true
+			}
+		}
+		if hasTickLabel != test.labelled {
+			t.Errorf("unexpected tick labelling of %s: got:%t want:%t", test.name, hasTickLabel, test.labelled)
+		}
+	}
+}
@@ -20,6 +20,53 @@ type Ticker interface {
 	Ticks(min, max float64) []Tick
 }
 
+// SizedTicker is a Ticker that chooses its ticks to suit the
+// space available along the axis, so that tick labels neither
+// overlap on short axes nor are sparse on long ones. An Axis
+// whose Tick.Marker is a SizedTicker uses SizedTicks in place
+// of Ticks when it is drawn.
+type SizedTicker interface {
+	Ticker
+
+	// SizedTicks returns Ticks in a specified range
+	// for an axis with the given space.
+	SizedTicks(min, max float64, space TickSpace) []Tick
+}
+
+// A StyledTicker is a Ticker that styles
+// its tick marks individually.
+type StyledTicker interface {
+	Ticker
+
+	// TickStyle returns the style of the tick mark t,
+	// as labeled on the axis, or nil for the tick
+	// style of the axis.
+	TickStyle(t Tick) *TickStyle
+}
+
+// TickSpace describes the space along an axis available
+// for its tick labels.
+type TickSpace struct {
+	// Length is the length of the axis.
+	Length vg.Length
+
+	// Label is the style of the tick labels.
+	Label draw.TextStyle
+
+	// Vertical is whether the axis is vertical.
+	Vertical bool
+}
+
+// Extent returns the extent along the axis of the
+// tick label txt.
+func (s TickSpace) Extent(txt string) vg.Length {
+	r := s.Label.Rectangle(txt)
+	if s.Vertical {
+		return r.Max.Y - r.Min.Y
+	}
+	return r.Max.X - r.Min.X
+}
+
 // Normalizer rescales values from the data coordinate system to the
 // normalized coordinate system.
 type Normalizer interface {
@@ -46,14 +93,47 @@ type Axis struct {
 		draw.TextStyle
 	}
 
-	// LineStyle is the style of the axis line.
+	// LineStyle is the style of the axis line. The
+	// axis line is hidden if its width is zero.
 	draw.LineStyle
 
+	// OppositeLine is the style of the line drawn along
+	// the edge of the data area opposite the axis line,
+	// the top edge for the X axis and the right edge for
+	// the Y axis, at the Padding of the axis from the
+	// data. The line is not drawn if its width is zero,
+	// the default.
+	OppositeLine draw.LineStyle
+
+	// Cross, if true, positions the axis line and its tick
+	// marks across the data area at the value CrossAt of
+	// the other axis, such as zero for axes in the style of
+	// a mathematics textbook, in place of the edge of the
+	// data area. The tick labels remain at the edge. The
+	// line is not drawn if CrossAt is outside the range of
+	// the other axis.
+	Cross   bool
+	CrossAt float64
+
+	// Arrow, if true, draws an arrowhead at the end of
+	// the axis line at Max.
+	Arrow bool
+
 	// Padding between the axis line and the data.  Having
 	// non-zero padding ensures that the data is never drawn
-	// on the axis, thus making it easier to see.
+	// on the axis, thus making it easier to see. Increasing
+	// the padding offsets, or detaches, the axis line from
+	// the data area.
 	Padding vg.Length
 
+	// MinPadding and MaxPadding are the space along the
+	// axis between the ends of the data area and the
+	// positions of Min and Max, so that the data can be
+	// inset from the frame of the plot independently at
+	// each end of the axis. The axis line spans the data
+	// between the padding.
+	MinPadding, MaxPadding vg.Length
+
 	Tick struct {
 		// Label is the TextStyle on the tick labels.
 		Label draw.TextStyle
@@ -70,12 +150,66 @@ type Axis struct {
 		// returned by the Marker function that are not in
 		// range of the axis are not drawn.
 		Marker Ticker
+
+		// Formatter, if not nil, formats the labels of the
+		// tick marks returned by the DefaultTicks, FitTicks
+		// and LogTicks markers in place of their own
+		// formatting.
+		Formatter Formatter
 	}
 
+	// Unit is the unit of the values of the axis. If
+	// the unit has a symbol, the symbol is appended to
+	// a non-empty axis label and, unless the unit has
+	// NoPrefix set, the labels of the DefaultTicks,
+	// FitTicks and LogTicks markers are scaled by the
+	// SI prefix suited to the axis range, so that an
+	// axis in seconds spanning 0 to 0.002 is labelled
+	// from 0 to 2 ms.
+	Unit Unit
+
 	// Scale transforms a value given in the data coordinate system
 	// to the normalized coordinate system of the axis—its distance
 	// along the axis as a fraction of the axis range.
 	Scale Normalizer
+
+	// Invert specifies that the axis runs from Max at
+	// its origin end to Min, so that Max is drawn at the
+	// left of a horizontal axis or the bottom of a vertical
+	// axis. Tick marks and labels follow the inversion.
+	Invert bool
+
+	// AutoScale, if not nil, adjusts the range fitted to the
+	// data of the plot's plotters when the plot is drawn.
+	// Limits that have been set explicitly, rather than by
+	// adding plotters, are not adjusted. If AutoScale is nil,
+	// the axis range fits the data tightly.
+	AutoScale AutoScaler
+
+	// Constraint, if not nil, constrains the range of the
+	// axis when the plot is drawn, after the range has been
+	// fitted to the data and adjusted by AutoScale, so that
+	// interactive tools can bound the ranges users choose.
+	Constraint RangeConstrainer
+
+	// Resolved, if not nil, is called with the final range
+	// of the axis each time the plot is drawn, after the
+	// range has been adjusted and constrained, so that
+	// interactive tools and linked plots can react to the
+	// range chosen for the data.
+	Resolved func(min, max float64)
+
+	// dataMin and dataMax are the range of the data
+	// added to the plot, and autoMin and autoMax are
+	// the limits last set using AutoScale.
+	dataMin, dataMax float64
+	autoMin, autoMax float64
+
+	// length is the approximate length of the axis
+	// when it was last laid out for drawing, and
+	// vertical is whether the axis is vertical.
+	length   vg.Length
+	vertical bool
 }
 
 // makeAxis returns a default Axis.
@@ -94,14 +228,19 @@ func makeAxis(orientation bool) (Axis, error) {
 	}
 
 	a := Axis{
-		Min: math.Inf(1),
-		Max: math.Inf(-1),
+		Min:     math.Inf(1),
+		Max:     math.Inf(-1),
+		dataMin: math.Inf(1),
+		dataMax: math.Inf(-1),
+		autoMin: math.NaN(),
+		autoMax: math.NaN(),
 		LineStyle: draw.LineStyle{
 			Color: color.Black,
 			Width: vg.Points(0.5),
 		},
-		Padding: vg.Points(5),
-		Scale:   LinearScale{},
+		Padding:  vg.Points(5),
+		Scale:    LinearScale{},
+		vertical: orientation == vertical,
 	}
 	a.Label.TextStyle = draw.TextStyle{
 		Color:  color.Black,
@@ -132,6 +271,7 @@ func makeAxis(orientation bool) (Axis, error) {
 // sanitizeRange ensures that the range of the
 // axis makes sense.
 func (a *Axis) sanitizeRange() {
+	a.autoScale()
 	if math.IsInf(a.Min, 0) {
 		a.Min = 0
 	}
@@ -141,12 +281,156 @@ func (a *Axis) sanitizeRange() {
 	if a.Min > a.Max {
 		a.Min, a.Max = a.Max, a.Min
 	}
+	if a.Constraint != nil {
+		min, max := a.Constraint.Constrain(a.Min, a.Max)
+		// Keep tracking the limits set by autoScale
+		// so that they follow changes in the data.
+		if a.Min == a.autoMin {
+			a.autoMin = min
+		}
+		if a.Max == a.autoMax {
+			a.autoMax = max
+		}
+		a.Min, a.Max = min, max
+	}
 	if a.Min == a.Max {
 		a.Min--
 		a.Max++
 	}
 }
 
+// fit extends the data range of the axis and the
+// axis range to include min and max.
+func (a *Axis) fit(min, max float64) {
+	a.dataMin = math.Min(a.dataMin, min)
+	a.dataMax = math.Max(a.dataMax, max)
+	a.Min = math.Min(a.Min, min)
+	a.Max = math.Max(a.Max, max)
+}
+
+// autoScale applies the axis AutoScaler to the data
+// range of the axis, updating each limit that is still
+// set to the data range or to the value last set by
+// autoScale. On a log scale, a lower limit that is not
+// positive is clamped to the smallest data value.
+func (a *Axis) autoScale() {
+	if a.AutoScale == nil || math.IsInf(a.dataMin, 0) || math.IsInf(a.dataMax, 0) {
+		return
+	}
+	min, max := a.AutoScale.AutoScale(a.dataMin, a.dataMax)
+	if _, ok := a.Scale.(LogScale); ok && min <= 0 && a.dataMin > 0 {
+		min = a.dataMin
+	}
+	if a.Min == a.dataMin || a.Min == a.autoMin {
+		a.Min, a.autoMin = min, min
+	}
+	if a.Max == a.dataMax || a.Max == a.autoMax {
+		a.Max, a.autoMax = max, max
+	}
+}
+
+// AutoScaler computes the range of an axis from the
+// range of the data drawn against it.
+type AutoScaler interface {
+	// AutoScale returns the axis range to use for
+	// data in the range [min, max].
+	AutoScale(min, max float64) (float64, float64)
+}
+
+// RangeConstrainer constrains the range of an axis.
+type RangeConstrainer interface {
+	// Constrain returns the constrained range of the
+	// axis range [min, max]. Constraining a constrained
+	// range must not change it.
+	Constrain(min, max float64) (float64, float64)
+}
+
+// RangeLimits is a RangeConstrainer limiting the extent of
+// an axis range and the values it may include. The zero
+// value of RangeLimits does not constrain the range.
+type RangeLimits struct {
+	// MinSpan and MaxSpan, if positive, are the
+	// minimum and maximum extent of the range. A
+	// narrower or wider range is widened or narrowed
+	// about its center.
+	MinSpan, MaxSpan float64
+
+	// Lower and Upper are hard limits of the range,
+	// applied if Lower is less than Upper. A range
+	// extending beyond a limit is moved within it,
+	// keeping its extent if the limits allow.
+	Lower, Upper float64
+}
+
+var _ RangeConstrainer = RangeLimits{}
+
+// Constrain implements the RangeConstrainer interface.
+func (l RangeLimits) Constrain(min, max float64) (float64, float64) {
+	mid := (min + max) / 2
+	if span := max - min; l.MinSpan > 0 && span < l.MinSpan {
+		min, max = mid-l.MinSpan/2, mid+l.MinSpan/2
+	} else if l.MaxSpan > 0 && span > l.MaxSpan {
+		min, max = mid-l.MaxSpan/2, mid+l.MaxSpan/2
+	}
+	if l.Lower < l.Upper {
+		if min < l.Lower {
+			max += l.Lower - min
+			min = l.Lower
+		}
+		if max > l.Upper {
+			min -= max - l.Upper
+			max = l.Upper
+		}
+		min = math.Max(min, l.Lower)
+	}
+	return min, max
+}
+
+// ScalePolicy is an AutoScaler that extends the data
+// range by a margin, optionally including zero and
+// rounding the limits to nice numbers. The zero value
+// of ScalePolicy fits the data tightly. When an axis has
+// a log scale, a lower limit that the margin or the
+// inclusion of zero takes to zero or below is clamped to
+// the smallest data value.
+type ScalePolicy struct {
+	// Margin is the fraction of the data range
+	// added below and above the data.
+	Margin float64
+
+	// Zero specifies that the range must include
+	// zero. Zero is included after the margin is
+	// added, so data on one side of zero extends
+	// from zero without a margin.
+	Zero bool
+
+	// Nice specifies that the limits are rounded
+	// outward to the nice numbers chosen for the
+	// labels of DefaultTicks.
+	Nice bool
+}
+
+var _ AutoScaler = ScalePolicy{}
+
+// AutoScale implements the AutoScaler interface.
+func (s ScalePolicy) AutoScale(min, max float64) (float64, float64) {
+	pad := s.Margin * (max - min)
+	min -= pad
+	max += pad
+	if s.Zero {
+		min = math.Min(min, 0)
+		max = math.Max(max, 0)
+	}
+	if s.Nice && min < max {
+		// Use the number of ticks suggested by DefaultTicks
+		// so that the limits coincide with tick marks.
+		const suggestedTicks = 3
+		labels, _, _, _ := talbotLinHanrahan(min, max, suggestedTicks, containData, nil, nil, nil)
+		min, max = labels[0], labels[len(labels)-1]
+	}
+	return min, max
+}
+
 // LinearScale an be used as the value of an Axis.Scale function to
 // set the axis to a standard linear scale.
 type LinearScale struct{}
@@ -175,8 +459,45 @@ func (LogScale) Normalize(min, max, x float64) float64 {
 // system, normalized to its distance as a fraction of the
 // range of this axis.  For example, if x is a.Min then the return
 // value is 0, and if x is a.Max then the return value is 1.
+// If the axis is inverted, the normalized value is reflected
+// so that a.Min returns 1 and a.Max returns 0.
 func (a Axis) Norm(x float64) float64 {
-	return a.Scale.Normalize(a.Min, a.Max, x)
+	n := a.Scale.Normalize(a.Min, a.Max, x)
+	if a.Invert {
+		return 1 - n
+	}
+	return n
+}
+
+// Ticks returns the tick marks of the axis. If the Tick.Marker of
+// the axis is a SizedTicker and the axis has been laid out by
+// drawing its plot, the ticks are chosen for the length of the
+// axis when it was drawn.
+//
+// The labels of the ticks returned by the DefaultTicks, FitTicks
+// and LogTicks markers, including when wrapped in StyledTicks, are
+// scaled by the SI prefix of the axis Unit and formatted by the
+// Tick.Formatter of the axis if it is not nil.
+func (a Axis) Ticks() []Tick {
+	var ticks []Tick
+	if st, ok := a.Tick.Marker.(SizedTicker); ok && a.length > 0 {
+		ticks = st.SizedTicks(a.Min, a.Max, TickSpace{
+			Length:   a.length,
+			Label:    a.Tick.Label,
+			Vertical: a.vertical,
+		})
+	} else {
+		ticks = a.Tick.Marker.Ticks(a.Min, a.Max)
+	}
+	m := a.Tick.Marker
+	if s, ok := m.(StyledTicks); ok {
+		m = s.Ticker
+	}
+	switch m.(type) {
+	case DefaultTicks, FitTicks, LogTicks:
+		a.relabel(ticks)
+	}
+	return ticks
 }
 
 // drawTicks returns true if the tick marks should be drawn.
@@ -190,19 +511,28 @@ type horizontalAxis struct {
 	Axis
 }
 
+// endPadding returns the padding of the axis at its
+// low and high ends, following the inversion of the axis.
+func (a Axis) endPadding() (lo, hi vg.Length) {
+	if a.Invert {
+		return a.MaxPadding, a.MinPadding
+	}
+	return a.MinPadding, a.MaxPadding
+}
+
 // size returns the height of the axis.
 func (a horizontalAxis) size() (h vg.Length) {
-	if a.Label.Text != "" { // We assume that the label isn't rotated.
+	if label := a.labelText(); label != "" { // We assume that the label isn't rotated.
 		h -= a.Label.Font.Extents().Descent
-		h += a.Label.Height(a.Label.Text)
+		h += a.Label.Height(label)
 	}
 
-	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
+	marks := a.Ticks()
 	if len(marks) > 0 {
 		if a.drawTicks() {
 			h += a.Tick.Length
 		}
-		h += tickLabelHeight(a.Tick.Label, marks)
+		h += a.tickLabelHeight(marks)
 	}
 	h += a.Width / 2
 	h += a.Padding
@@ -213,20 +543,20 @@ func (a horizontalAxis) size() (h vg.Length) {
 // draw draws the axis along the lower edge of a draw.Canvas.
 func (a horizontalAxis) draw(c draw.Canvas) {
 	y := c.Min.Y
-	if a.Label.Text != "" {
+	if label := a.labelText(); label != "" {
 		y -= a.Label.Font.Extents().Descent
-		c.FillText(a.Label.TextStyle, vg.Point{X: c.Center().X, Y: y}, a.Label.Text)
-		y += a.Label.Height(a.Label.Text)
+		c.FillText(a.Label.TextStyle, vg.Point{X: c.Center().X, Y: y}, label)
+		y += a.Label.Height(label)
 	}
 
-	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
-	ticklabelheight := tickLabelHeight(a.Tick.Label, marks)
+	marks := a.Ticks()
+	ticklabelheight := a.tickLabelHeight(marks)
 	for _, t := range marks {
 		x := c.X(a.Norm(t.Value))
 		if !c.ContainsX(x) || t.IsMinor() {
 			continue
 		}
-		c.FillText(a.Tick.Label, vg.Point{X: x, Y: y + ticklabelheight}, t.Label)
+		a.drawTickLabel(c, t, vg.Point{X: x, Y: y + ticklabelheight})
 	}
 
 	if len(marks) > 0 {
@@ -237,30 +567,72 @@ func (a horizontalAxis) draw(c draw.Canvas) {
 
 	if len(marks) > 0 && a.drawTicks() {
 		len := a.Tick.Length
-		for _, t := range marks {
-			x := c.X(a.Norm(t.Value))
-			if !c.ContainsX(x) {
-				continue
-			}
-			start := t.lengthOffset(len)
-			c.StrokeLine2(a.Tick.LineStyle, x, y+start, x, y+len)
+		if !a.Cross {
+			a.strokeTicks(c, marks, y+len)
 		}
 		y += len
 	}
 
+	if !a.Cross {
+		a.drawLine(c, y)
+	}
+}
+
+// strokeTicks draws the tick marks below the line at y.
+func (a horizontalAxis) strokeTicks(c draw.Canvas, marks []Tick, y vg.Length) {
+	for _, t := range marks {
+		x := c.X(a.Norm(t.Value))
+		if !c.ContainsX(x) {
+			continue
+		}
+		a.drawTick(c, t, vg.Point{X: x, Y: y}, vg.Point{Y: -1})
+	}
+}
+
+// drawLine draws the axis line across c at y.
+func (a horizontalAxis) drawLine(c draw.Canvas, y vg.Length) {
 	c.StrokeLine2(a.LineStyle, c.Min.X, y, c.Max.X, y)
+	if a.Arrow {
+		from, to := vg.Point{X: c.Min.X, Y: y}, vg.Point{X: c.Max.X, Y: y}
+		if a.Invert {
+			from, to = to, from
+		}
+		drawArrowhead(c, a.LineStyle, from, to)
+	}
+}
+
+// drawSpines draws the opposite line of the axis along
+// the top of the data area dc and, if the axis crosses the
+// data area, the axis line and tick marks at the position
+// of CrossAt on the vertical axis y.
+func (a horizontalAxis) drawSpines(dc draw.Canvas, y Axis) {
+	if a.OppositeLine.Width > 0 {
+		top := dc.Max.Y + a.Padding + a.OppositeLine.Width/2
+		dc.StrokeLine2(a.OppositeLine, dc.Min.X, top, dc.Max.X, top)
+	}
+	if !a.Cross {
+		return
+	}
+	pos := dc.Y(y.Norm(a.CrossAt))
+	if !dc.ContainsY(pos) {
+		return
+	}
+	if a.drawTicks() {
+		a.strokeTicks(dc, a.Ticks(), pos)
+	}
+	a.drawLine(dc, pos)
 }
 
 // GlyphBoxes returns the GlyphBoxes for the tick labels.
 func (a horizontalAxis) GlyphBoxes(*Plot) []GlyphBox {
 	var boxes []GlyphBox
-	for _, t := range a.Tick.Marker.Ticks(a.Min, a.Max) {
+	for _, t := range a.Ticks() {
 		if t.IsMinor() {
 			continue
 		}
 		box := GlyphBox{
 			X:         a.Norm(t.Value),
-			Rectangle: a.Tick.Label.Rectangle(t.Label),
+			Rectangle: a.tickLabelRect(t),
 		}
 		boxes = append(boxes, box)
 	}
@@ -274,14 +646,14 @@ type verticalAxis struct {
 
 // size returns the width of the axis.
 func (a verticalAxis) size() (w vg.Length) {
-	if a.Label.Text != "" { // We assume that the label isn't rotated.
+	if label := a.labelText(); label != "" { // We assume that the label isn't rotated.
 		w -= a.Label.Font.Extents().Descent
-		w += a.Label.Height(a.Label.Text)
+		w += a.Label.Height(label)
 	}
 
-	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
+	marks := a.Ticks()
 	if len(marks) > 0 {
-		if lwidth := tickLabelWidth(a.Tick.Label, marks); lwidth > 0 {
+		if lwidth := a.tickLabelWidth(marks); lwidth > 0 {
 			w += lwidth
 			w += a.Label.Width(" ")
 		}
@@ -298,15 +670,15 @@ func (a verticalAxis) size() (w vg.Length) {
 // draw draws the axis along the left side of a draw.Canvas.
 func (a verticalAxis) draw(c draw.Canvas) {
 	x := c.Min.X
-	if a.Label.Text != "" {
+	if label := a.labelText(); label != "" {
 		sty := a.Label.TextStyle
 		sty.Rotation += math.Pi / 2
-		x += a.Label.Height(a.Label.Text)
-		c.FillText(sty, vg.Point{X: x, Y: c.Center().Y}, a.Label.Text)
+		x += a.Label.Height(label)
+		c.FillText(sty, vg.Point{X: x, Y: c.Center().Y}, label)
 		x += -a.Label.Font.Extents().Descent
 	}
-	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
-	if w := tickLabelWidth(a.Tick.Label, marks); len(marks) > 0 && w > 0 {
+	marks := a.Ticks()
+	if w := a.tickLabelWidth(marks); len(marks) > 0 && w > 0 {
 		x += w
 	}
 
@@ -316,7 +688,7 @@ func (a verticalAxis) draw(c draw.Canvas) {
 		if !c.ContainsY(y) || t.IsMinor() {
 			continue
 		}
-		c.FillText(a.Tick.Label, vg.Point{X: x, Y: y}, t.Label)
+		a.drawTickLabel(c, t, vg.Point{X: x, Y: y})
 		major = true
 	}
 	if major {
@@ -324,30 +696,90 @@ func (a verticalAxis) draw(c draw.Canvas) {
 	}
 	if a.drawTicks() && len(marks) > 0 {
 		len := a.Tick.Length
-		for _, t := range marks {
-			y := c.Y(a.Norm(t.Value))
-			if !c.ContainsY(y) {
-				continue
-			}
-			start := t.lengthOffset(len)
-			c.StrokeLine2(a.Tick.LineStyle, x+start, y, x+len, y)
+		if !a.Cross {
+			a.strokeTicks(c, marks, x+len)
 		}
 		x += len
 	}
 
+	if !a.Cross {
+		a.drawLine(c, x)
+	}
+}
+
+// strokeTicks draws the tick marks left of the line at x.
+func (a verticalAxis) strokeTicks(c draw.Canvas, marks []Tick, x vg.Length) {
+	for _, t := range marks {
+		y := c.Y(a.Norm(t.Value))
+		if !c.ContainsY(y) {
+			continue
+		}
+		a.drawTick(c, t, vg.Point{X: x, Y: y}, vg.Point{X: -1})
+	}
+}
+
+// drawLine draws the axis line up c at x.
+func (a verticalAxis) drawLine(c draw.Canvas, x vg.Length) {
 	c.StrokeLine2(a.LineStyle, x, c.Min.Y, x, c.Max.Y)
+	if a.Arrow {
+		from, to := vg.Point{X: x, Y: c.Min.Y}, vg.Point{X: x, Y: c.Max.Y}
+		if a.Invert {
+			from, to = to, from
+		}
+		drawArrowhead(c, a.LineStyle, from, to)
+	}
+}
+
+// drawSpines draws the opposite line of the axis along
+// the right of the data area dc and, if the axis crosses
+// the data area, the axis line and tick marks at the
+// position of CrossAt on the horizontal axis x.
+func (a verticalAxis) drawSpines(dc draw.Canvas, x Axis) {
+	if a.OppositeLine.Width > 0 {
+		right := dc.Max.X + a.Padding + a.OppositeLine.Width/2
+		dc.StrokeLine2(a.OppositeLine, right, dc.Min.Y, right, dc.Max.Y)
+	}
+	if !a.Cross {
+		return
+	}
+	pos := dc.X(x.Norm(a.CrossAt))
+	if !dc.ContainsX(pos) {
+		return
+	}
+	if a.drawTicks() {
+		a.strokeTicks(dc, a.Ticks(), pos)
+	}
+	a.drawLine(dc, pos)
+}
+
+// drawArrowhead draws a filled arrowhead in the color of
+// sty with its tip at the end, to, of the line from from.
+func drawArrowhead(c draw.Canvas, sty draw.LineStyle, from, to vg.Point) {
+	if sty.Color == nil || sty.Width <= 0 {
+		return
+	}
+	const size = 6
+	d := to.Sub(from)
+	n := vg.Length(math.Hypot(float64(d.X), float64(d.Y)))
+	if n == 0 {
+		return
+	}
+	dir := d.Scale(vg.Points(size) / n)
+	side := vg.Point{X: -dir.Y / 3, Y: dir.X / 3}
+	base := to.Sub(dir)
+	c.FillPolygon(sty.Color, []vg.Point{to, base.Add(side), base.Sub(side)})
 }
 
 // GlyphBoxes returns the GlyphBoxes for the tick labels
 func (a verticalAxis) GlyphBoxes(*Plot) []GlyphBox {
 	var boxes []GlyphBox
-	for _, t := range a.Tick.Marker.Ticks(a.Min, a.Max) {
+	for _, t := range a.Ticks() {
 		if t.IsMinor() {
 			continue
 		}
 		box := GlyphBox{
 			Y:         a.Norm(t.Value),
-			Rectangle: a.Tick.Label.Rectangle(t.Label),
+			Rectangle: a.tickLabelRect(t),
 		}
 		boxes = append(boxes, box)
 	}
@@ -362,12 +794,83 @@ var _ Ticker = DefaultTicks{}
 
 // Ticks returns Ticks in the specified range.
 func (DefaultTicks) Ticks(min, max float64) []Tick {
+	const suggestedTicks = 3
+	return linearTicks(min, max, suggestedTicks)
+}
+
+// FitTicks is suitable for the Tick.Marker field of an Axis
+// with a linear scale. It is a SizedTicker returning as many
+// labelled ticks as fit along the axis with at least Spacing
+// between adjacent labels.
+type FitTicks struct {
+	// Spacing is the space between adjacent tick
+	// labels. If Spacing is zero, three times the
+	// size of the tick label font is used.
+	Spacing vg.Length
+}
+
+var _ SizedTicker = FitTicks{}
+
+// Ticks returns Ticks in the specified range, as
+// DefaultTicks does.
+func (FitTicks) Ticks(min, max float64) []Tick {
+	return DefaultTicks{}.Ticks(min, max)
+}
+
+// SizedTicks returns Ticks in the specified range with labels
+// fitting in the given space.
+func (t FitTicks) SizedTicks(min, max float64, space TickSpace) []Tick {
+	spacing := t.Spacing
+	if spacing == 0 {
+		spacing = 3 * space.Label.Font.Size
+	}
+
+	// Start with the number of ticks whose labels, as
+	// wide as the labels of the range limits, would fit,
+	// and reduce it until no labels are closer than spacing.
+	extent := math.Max(
+		float64(space.Extent(strconv.FormatFloat(min, 'g', 3, 64))),
+		float64(space.Extent(strconv.FormatFloat(max, 'g', 3, 64))),
+	)
+	n := int(float64(space.Length) / (extent + float64(spacing)))
+	for ; n > 2; n-- {
+		ticks := linearTicks(min, max, n)
+		if fitsLabels(ticks, min, max, space, spacing) {
+			return ticks
+		}
+	}
+	return linearTicks(min, max, 2)
+}
+
+// fitsLabels returns whether the labels of ticks in the range
+// [min, max] drawn along a linear axis with the given space are
+// separated by at least spacing.
+func fitsLabels(ticks []Tick, min, max float64, space TickSpace, spacing vg.Length) bool {
+	var (
+		prevPos, prevExt vg.Length
+		prev             bool
+	)
+	for _, t := range ticks {
+		if t.IsMinor() || t.Value < min || max < t.Value {
+			continue
+		}
+		pos := space.Length * vg.Length((t.Value-min)/(max-min))
+		ext := space.Extent(t.Label)
+		if prev && pos-prevPos < (ext+prevExt)/2+spacing {
+			return false
+		}
+		prevPos, prevExt, prev = pos, ext, true
+	}
+	return true
+}
+
+// linearTicks returns ticks in the range [min, max] with
+// about the suggested number of labelled ticks.
+func linearTicks(min, max float64, suggestedTicks int) []Tick {
 	if max <= min {
 		panic("illegal range")
 	}
 
-	const suggestedTicks = 3
-
 	labels, step, q, mag := talbotLinHanrahan(min, max, suggestedTicks, withinData, nil, nil, nil)
 	majorDelta := step * math.Pow10(mag)
 	if q == 0 {
@@ -452,30 +955,121 @@ func maxInt(a, b int) int {
 
 // LogTicks is suitable for the Tick.Marker field of an Axis,
 // it returns tick marks suitable for a log-scale axis.
-type LogTicks struct{}
+//
+// Each decade of the range is labelled, with unlabelled minor
+// ticks at two to nine times the decade. When the range spans
+// more decades than can be labelled, only every nth decade is
+// labelled and the others are marked by minor ticks. When the
+// range holds fewer than two decades, the ticks within the
+// decades are labelled by their mantissas instead.
+type LogTicks struct {
+	// MaxDecades is the maximum number of labelled
+	// decades. If MaxDecades is zero, at most eight
+	// decades are labelled.
+	MaxDecades int
+
+	// Format returns the label of the tick at v. If
+	// Format is nil, v is formatted as for the 'g'
+	// format of strconv.FormatFloat with the smallest
+	// precision representing v.
+	Format func(v float64) string
+}
 
 var _ Ticker = LogTicks{}
 
 // Ticks returns Ticks in a specified range
-func (LogTicks) Ticks(min, max float64) []Tick {
+func (t LogTicks) Ticks(min, max float64) []Tick {
 	if min <= 0 {
 		panic("Values must be greater than 0 for a log scale.")
 	}
 
-	val := math.Pow10(int(math.Log10(min)))
-	max = math.Pow10(int(math.Ceil(math.Log10(max))))
+	format := t.Format
+	if format == nil {
+		format = func(v float64) string { return formatFloatTick(v, -1) }
+	}
+	lo := int(math.Floor(math.Log10(min)))
+	hi := int(math.Ceil(math.Log10(max)))
+
+	var decades int
+	for k := lo; k <= hi; k++ {
+		if v := math.Pow10(k); min <= v && v <= max {
+			decades++
+		}
+	}
+	if decades < 2 {
+		return logMantissaTicks(min, max, lo, hi, format, t.Format != nil)
+	}
+
+	maxDecades := t.MaxDecades
+	if maxDecades <= 0 {
+		maxDecades = 8
+	}
+	step := (hi - lo + maxDecades) / maxDecades
+
 	var ticks []Tick
-	for val < max {
-		for i := 1; i < 10; i++ {
-			if i == 1 {
-				ticks = append(ticks, Tick{Value: val, Label: formatFloatTick(val, -1)})
-			}
+	for k := lo; k <= hi; k++ {
+		val := math.Pow10(k)
+		if k%step != 0 {
+			ticks = append(ticks, Tick{Value: val})
+			continue
+		}
+		ticks = append(ticks, Tick{Value: val, Label: format(val)})
+		if step != 1 || k == hi {
+			continue
+		}
+		for i := 2; i < 10; i++ {
 			ticks = append(ticks, Tick{Value: val * float64(i)})
 		}
-		val *= 10
 	}
-	ticks = append(ticks, Tick{Value: val, Label: formatFloatTick(val, -1)})
+	return ticks
+}
 
+// logMantissaTicks returns the ticks of the range [min, max],
+// within the decades lo to hi, of a log-scale axis holding fewer
+// than two decades. The ticks at one, two and five times each
+// decade are labelled if at least two of them are in the range,
+// and otherwise all the ticks at integer multiples of the decades
+// are labelled. If fewer than two ticks would be labelled, linear
+// ticks are returned, labelled by format if custom is true.
+func logMantissaTicks(min, max float64, lo, hi int, format func(float64) string, custom bool) []Tick {
+	var ticks []Tick
+	for _, mantissas := range [][]int{{1, 2, 5}, {1, 2, 3, 4, 5, 6, 7, 8, 9}} {
+		ticks = ticks[:0]
+		var labels int
+		for k := lo; k <= hi; k++ {
+			for i := 1; i < 10; i++ {
+				val := float64(i) * math.Pow10(k)
+				if val < min || max < val {
+					ticks = append(ticks, Tick{Value: val})
+					continue
+				}
+				labelled := false
+				for _, m := range mantissas {
+					if i == m {
+						labelled = true
+						break
+					}
+				}
+				if !labelled {
+					ticks = append(ticks, Tick{Value: val})
+					continue
+				}
+				ticks = append(ticks, Tick{Value: val, Label: format(val)})
+				labels++
+			}
+		}
+		if labels >= 2 {
+			return ticks
+		}
+	}
+	ticks = linearTicks(min, max, 3)
+	if custom {
+		for i := range ticks {
+			if !ticks[i].IsMinor() {
+				ticks[i].Label = format(ticks[i].Value)
+			}
+		}
+	}
 	return ticks
 }
 
@@ -556,6 +1150,105 @@ func (t Tick) IsMinor() bool {
 	return t.Label == ""
 }
 
+// TickStyle is the style of an individual tick mark,
+// overriding the tick style of its axis.
+type TickStyle struct {
+	// LineStyle, if its width is positive, is the
+	// style of the tick mark.
+	draw.LineStyle
+
+	// Length, if positive, is the length of the
+	// tick mark. Minor tick marks are half of the
+	// length.
+	Length vg.Length
+
+	// Inside specifies that the tick mark is drawn
+	// from the axis line toward the data area rather
+	// than away from it.
+	Inside bool
+
+	// LabelColor, if not nil, is the color of
+	// the tick label.
+	LabelColor color.Color
+
+	// LabelGraphic, if not nil, is drawn as the
+	// label of the tick mark in place of its text.
+	// The Label of the tick must not be empty.
+	LabelGraphic TickGraphic
+
+	// Draw, if not nil, draws the tick mark t in
+	// place of its line. The tick is at pt on the
+	// axis line, and dir is the unit vector of the
+	// direction of the tick mark, away from the data
+	// area or toward it if Inside is true.
+	Draw func(c draw.Canvas, t Tick, pt, dir vg.Point)
+}
+
+// drawTick draws the tick mark t at pt on the axis line
+// of the axis, where out is the unit vector pointing away
+// from the data area.
+func (a Axis) drawTick(c draw.Canvas, t Tick, pt, out vg.Point) {
+	sty := a.Tick.LineStyle
+	len := a.Tick.Length
+	if s := a.tickStyle(t); s != nil {
+		if s.Width > 0 {
+			sty = s.LineStyle
+		}
+		if s.Length > 0 {
+			len = s.Length
+		}
+		if s.Inside {
+			out = out.Scale(-1)
+		}
+		if s.Draw != nil {
+			s.Draw(c, t, pt, out)
+			return
+		}
+	}
+	end := pt.Add(out.Scale(len - t.lengthOffset(len)))
+	c.StrokeLine2(sty, end.X, end.Y, pt.X, pt.Y)
+}
+
+// tickLabel returns the style of the label of the
+// tick mark t.
+func (a Axis) tickLabel(t Tick) draw.TextStyle {
+	sty := a.Tick.Label
+	if s := a.tickStyle(t); s != nil && s.LabelColor != nil {
+		sty.Color = s.LabelColor
+	}
+	return sty
+}
+
+// tickStyle returns the style of the tick mark t, or nil
+// if the Tick.Marker of the axis is not a StyledTicker.
+func (a Axis) tickStyle(t Tick) *TickStyle {
+	if st, ok := a.Tick.Marker.(StyledTicker); ok {
+		return st.TickStyle(t)
+	}
+	return nil
+}
+
+// StyledTicks is a StyledTicker that styles the ticks
+// returned by its Ticker with its Style function.
+type StyledTicks struct {
+	Ticker
+
+	// Style returns the style of the tick
+	// mark t, or nil for the style of the axis.
+	Style func(t Tick) *TickStyle
+}
+
+var _ StyledTicker = StyledTicks{}
+
+// TickStyle implements the TickStyle method
+// of the StyledTicker interface.
+func (s StyledTicks) TickStyle(t Tick) *TickStyle {
+	if s.Style == nil {
+		return nil
+	}
+	return s.Style(t)
+}
+
 // lengthOffset returns an offset that should be added to the
 // tick mark's line to accout for its length.  I.e., the start of
 // the line for a minor tick mark must be shifted by half of
@@ -567,38 +1260,6 @@ func (t Tick) lengthOffset(len vg.Length) vg.Length {
 	return 0
 }
 
-// tickLabelHeight returns height of the tick mark labels.
-func tickLabelHeight(sty draw.TextStyle, ticks []Tick) vg.Length {
-	maxHeight := vg.Length(0)
-	for _, t := range ticks {
-		if t.IsMinor() {
-			continue
-		}
-		r := sty.Rectangle(t.Label)
-		h := r.Max.Y - r.Min.Y
-		if h > maxHeight {
-			maxHeight = h
-		}
-	}
-	return maxHeight
-}
-
-// tickLabelWidth returns the width of the widest tick mark label.
-func tickLabelWidth(sty draw.TextStyle, ticks []Tick) vg.Length {
-	maxWidth := vg.Length(0)
-	for _, t := range ticks {
-		if t.IsMinor() {
-			continue
-		}
-		r := sty.Rectangle(t.Label)
-		w := r.Max.X - r.Min.X
-		if w > maxWidth {
-			maxWidth = w
-		}
-	}
-	return maxWidth
-}
-
 func log(x float64) float64 {
 	if x <= 0 {
 		panic("Values must be greater than 0 for a log scale.")
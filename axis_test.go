@@ -7,7 +7,12 @@ package plot
 import (
 	"math"
 	"reflect"
+	"strconv"
 	"testing"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
 )
 
 var axisSmallTickTests = []struct {
@@ -105,3 +110,274 @@ func labelsOf(ticks []Tick) []string {
 	}
 	return labels
 }
+
+func TestScalePolicy(t *testing.T) {
+	for _, test := range []struct {
+		policy           ScalePolicy
+		min, max         float64
+		wantMin, wantMax float64
+	}{
+		{policy: ScalePolicy{}, min: 1, max: 9, wantMin: 1, wantMax: 9},
+		{policy: ScalePolicy{Margin: 0.25}, min: 1, max: 9, wantMin: -1, wantMax: 11},
+		{policy: ScalePolicy{Zero: true}, min: 1, max: 9, wantMin: 0, wantMax: 9},
+		{policy: ScalePolicy{Zero: true}, min: -9, max: -1, wantMin: -9, wantMax: 0},
+		{policy: ScalePolicy{Margin: 0.25, Zero: true}, min: 4, max: 8, wantMin: 0, wantMax: 9},
+		{policy: ScalePolicy{Nice: true}, min: 0.3, max: 9.2, wantMin: 0, wantMax: 10},
+	} {
+		gotMin, gotMax := test.policy.AutoScale(test.min, test.max)
+		if gotMin != test.wantMin || gotMax != test.wantMax {
+			t.Errorf("unexpected range for %+v of [%v, %v]: got:[%v, %v] want:[%v, %v]",
+				test.policy, test.min, test.max, gotMin, gotMax, test.wantMin, test.wantMax)
+		}
+	}
+}
+
+func TestAxisAutoScale(t *testing.T) {
+	a, err := makeAxis(horizontal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a.AutoScale = ScalePolicy{Margin: 0.5}
+
+	a.fit(2, 4)
+	a.sanitizeRange()
+	if a.Min != 1 || a.Max != 5 {
+		t.Errorf("unexpected range: got:[%v, %v] want:[1, 5]", a.Min, a.Max)
+	}
+
+	// Scaling must not be applied cumulatively.
+	a.sanitizeRange()
+	if a.Min != 1 || a.Max != 5 {
+		t.Errorf("unexpected range after rescaling: got:[%v, %v] want:[1, 5]", a.Min, a.Max)
+	}
+
+	// Data added after scaling is rescaled.
+	a.fit(1.5, 6)
+	a.sanitizeRange()
+	if a.Min != -0.75 || a.Max != 8.25 {
+		t.Errorf("unexpected range after fit: got:[%v, %v] want:[-0.75, 8.25]", a.Min, a.Max)
+	}
+
+	// Explicit limits are retained.
+	a.Min = 0
+	a.sanitizeRange()
+	if a.Min != 0 || a.Max != 8.25 {
+		t.Errorf("unexpected range with explicit limit: got:[%v, %v] want:[0, 8.25]", a.Min, a.Max)
+	}
+}
+
+func TestFitTicks(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sty := p.X.Tick.Label
+
+	var prev int
+	for _, length := range []vg.Length{vg.Points(60), vg.Points(200), vg.Points(800)} {
+		space := TickSpace{Length: length, Label: sty}
+		ticks := FitTicks{}.SizedTicks(0, 1000, space)
+		spacing := 3 * sty.Font.Size
+		if !fitsLabels(ticks, 0, 1000, space, spacing) {
+			t.Errorf("tick labels overlap for axis length %v: %v", length, labelsOf(ticks))
+		}
+		var n int
+		for _, tk := range ticks {
+			if !tk.IsMinor() {
+				n++
+			}
+		}
+		if n < prev {
+			t.Errorf("fewer labelled ticks for axis length %v than shorter axis: got:%d want>=%d", length, n, prev)
+		}
+		prev = n
+	}
+	if prev < 5 {
+		t.Errorf("too few labelled ticks on long axis: got:%d", prev)
+	}
+}
+
+// recordingTicker is a SizedTicker recording the
+// space of the axis it is used for.
+type recordingTicker struct {
+	DefaultTicks
+	space *TickSpace
+}
+
+func (r recordingTicker) SizedTicks(min, max float64, space TickSpace) []Tick {
+	*r.space = space
+	return r.Ticks(min, max)
+}
+
+func TestSizedTickerSpace(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var x, y TickSpace
+	p.X.Tick.Marker = recordingTicker{space: &x}
+	p.Y.Tick.Marker = recordingTicker{space: &y}
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+
+	c := draw.New(vgimg.New(vg.Points(300), vg.Points(200)))
+	p.Draw(c)
+	dc := p.DataCanvas(c)
+	if x.Vertical || !y.Vertical {
+		t.Errorf("unexpected axis orientations: x vertical:%t y vertical:%t", x.Vertical, y.Vertical)
+	}
+	for _, test := range []struct {
+		name      string
+		got, data vg.Length
+	}{
+		{name: "x", got: x.Length, data: dc.Max.X - dc.Min.X},
+		{name: "y", got: y.Length, data: dc.Max.Y - dc.Min.Y},
+	} {
+		// The laid out length includes the axis padding.
+		if test.got < test.data || test.got > test.data+2*p.X.Padding+vg.Points(1) {
+			t.Errorf("unexpected %s axis length: got:%v data length:%v", test.name, test.got, test.data)
+		}
+	}
+}
+
+func TestLogTicks(t *testing.T) {
+	for _, test := range []struct {
+		ticker     LogTicks
+		min, max   float64
+		wantLabels []string
+		wantMinor  int
+	}{
+		{
+			min: 1, max: 1000,
+			wantLabels: []string{"1", "10", "100", "1000"},
+			wantMinor:  24,
+		},
+		{
+			min: 0.05, max: 20,
+			wantLabels: []string{"0.01", "0.1", "1", "10", "100"},
+			wantMinor:  32,
+		},
+		{
+			min: 1e-10, max: 1e10,
+			wantLabels: []string{"1e-09", "1e-06", "0.001", "1", "1000", "1e+06", "1e+09"},
+			wantMinor:  14,
+		},
+		{
+			ticker: LogTicks{MaxDecades: 21},
+			min:    1e-10, max: 1e10,
+			wantMinor: 20 * 8,
+		},
+		{
+			min: 1.5, max: 40,
+			wantLabels: []string{"2", "5", "10", "20"},
+		},
+		{
+			min: 3, max: 9,
+			wantLabels: []string{"3", "4", "5", "6", "7", "8", "9"},
+		},
+		{
+			ticker: LogTicks{Format: func(v float64) string { return "10^" + strconv.Itoa(int(math.Log10(v))) }},
+			min:    1, max: 100,
+			wantLabels: []string{"10^0", "10^1", "10^2"},
+			wantMinor:  16,
+		},
+	} {
+		ticks := test.ticker.Ticks(test.min, test.max)
+		var minor int
+		for _, tk := range ticks {
+			if tk.IsMinor() {
+				minor++
+			}
+		}
+		if test.wantLabels != nil && !reflect.DeepEqual(labelsOf(ticks), test.wantLabels) {
+			t.Errorf("unexpected labels for [%v, %v]: got:%q want:%q", test.min, test.max, labelsOf(ticks), test.wantLabels)
+		}
+		if test.wantMinor != 0 && minor != test.wantMinor {
+			t.Errorf("unexpected number of minor ticks for [%v, %v]: got:%d want:%d", test.min, test.max, minor, test.wantMinor)
+		}
+	}
+
+	// Ranges within a decade holding too few
+	// mantissas fall back to linear ticks.
+	got := LogTicks{}.Ticks(3.1, 3.9)
+	want := linearTicks(3.1, 3.9, 3)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected ticks for narrow range: got:%v want:%v", got, want)
+	}
+}
+
+func TestRangeLimits(t *testing.T) {
+	for _, test := range []struct {
+		limits           RangeLimits
+		min, max         float64
+		wantMin, wantMax float64
+	}{
+		{limits: RangeLimits{}, min: -3, max: 5, wantMin: -3, wantMax: 5},
+		{limits: RangeLimits{MinSpan: 10}, min: -3, max: 5, wantMin: -4, wantMax: 6},
+		{limits: RangeLimits{MaxSpan: 4}, min: -3, max: 5, wantMin: -1, wantMax: 3},
+		{limits: RangeLimits{Lower: 0, Upper: 100}, min: -3, max: 5, wantMin: 0, wantMax: 8},
+		{limits: RangeLimits{Lower: 0, Upper: 100}, min: 95, max: 105, wantMin: 90, wantMax: 100},
+		{limits: RangeLimits{Lower: 0, Upper: 4}, min: -3, max: 5, wantMin: 0, wantMax: 4},
+		{limits: RangeLimits{MinSpan: 10, Lower: 0, Upper: 4}, min: 1, max: 2, wantMin: 0, wantMax: 4},
+		{limits: RangeLimits{MinSpan: 2, Lower: 0, Upper: 100}, min: 0, max: 0, wantMin: 0, wantMax: 2},
+	} {
+		min, max := test.limits.Constrain(test.min, test.max)
+		if min != test.wantMin || max != test.wantMax {
+			t.Errorf("unexpected range for %+v of [%v, %v]: got:[%v, %v] want:[%v, %v]",
+				test.limits, test.min, test.max, min, max, test.wantMin, test.wantMax)
+		}
+		again, againMax := test.limits.Constrain(min, max)
+		if again != min || againMax != max {
+			t.Errorf("constraint of %+v not idempotent: got:[%v, %v] want:[%v, %v]",
+				test.limits, again, againMax, min, max)
+		}
+	}
+}
+
+func TestAxisConstraint(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.AutoScale = ScalePolicy{Margin: 0.1}
+	p.X.Constraint = RangeLimits{Lower: 0, Upper: 100}
+	p.X.fit(0, 10)
+
+	var resolved [][2]float64
+	p.X.Resolved = func(min, max float64) {
+		resolved = append(resolved, [2]float64{min, max})
+	}
+	c := draw.New(vgimg.New(vg.Points(200), vg.Points(200)))
+	p.Draw(c)
+	if want := [][2]float64{{0, 12}}; !reflect.DeepEqual(resolved, want) {
+		t.Errorf("unexpected resolved ranges: got:%v want:%v", resolved, want)
+	}
+
+	// The constrained limits follow the data.
+	p.X.fit(0, 20)
+	p.Draw(c)
+	if want := [2]float64{0, 24}; resolved[len(resolved)-1] != want {
+		t.Errorf("unexpected resolved range after data change: got:%v want:%v", resolved[len(resolved)-1], want)
+	}
+}
+
+func TestAxisAutoScaleLog(t *testing.T) {
+	a, err := makeAxis(vertical)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a.Scale = LogScale{}
+	for _, policy := range []ScalePolicy{
+		{Margin: 0.5},
+		{Zero: true},
+		{Nice: true},
+	} {
+		a.AutoScale = policy
+		a.Min, a.Max = 2, 100
+		a.dataMin, a.dataMax = 2, 100
+		a.sanitizeRange()
+		if !(a.Min > 0) {
+			t.Errorf("unexpected log axis minimum for %+v: got:%v want:>0", policy, a.Min)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot_test
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"log"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ExamplePlot_background draws a plot with a transparent
+// background and a shaded data area, for placing over
+// other content.
+func ExamplePlot_background() {
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Transparent background"
+	p.BackgroundColor = nil
+	p.DataBackgroundColor = color.Gray{Y: 232}
+	p.Add(plotter.NewGrid())
+
+	l, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 0}, {X: 1, Y: 3}, {X: 2, Y: 1}, {X: 3, Y: 4}})
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Add(l)
+
+	// The canvas of the image is white unless
+	// a transparent background is requested.
+	err = p.Save(200, 150, "testdata/background.png", draw.Background(nil))
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestBackgroundPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExamplePlot_background, t, "background.png")
+}
+
+func TestBackground(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.DataBackgroundColor = color.NRGBA{R: 255, A: 255}
+
+	for _, test := range []struct {
+		background color.Color
+		opts       []draw.FormatOption
+		wantCorner color.NRGBA
+	}{
+		{background: color.White, wantCorner: color.NRGBA{R: 255, G: 255, B: 255, A: 255}},
+		{background: color.NRGBA{B: 255, A: 255}, wantCorner: color.NRGBA{B: 255, A: 255}},
+
+		// Without a background option, plots with no
+		// background are drawn over white.
+		{background: nil, wantCorner: color.NRGBA{R: 255, G: 255, B: 255, A: 255}},
+		{background: color.Transparent, wantCorner: color.NRGBA{R: 255, G: 255, B: 255, A: 255}},
+
+		{background: nil, opts: []draw.FormatOption{draw.Background(nil)}, wantCorner: color.NRGBA{}},
+		{background: color.Transparent, opts: []draw.FormatOption{draw.Background(nil)}, wantCorner: color.NRGBA{}},
+		{background: color.White, opts: []draw.FormatOption{draw.Background(nil)}, wantCorner: color.NRGBA{R: 255, G: 255, B: 255, A: 255}},
+	} {
+		p.BackgroundColor = test.background
+		var buf bytes.Buffer
+		err := p.Stream(&buf, 2*vg.Inch, 2*vg.Inch, "png", test.opts...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		img, err := png.Decode(&buf)
+		if err != nil {
+			t.Fatalf("unexpected error decoding png: %v", err)
+		}
+		b := img.Bounds()
+		corner := color.NRGBAModel.Convert(img.At(b.Min.X, b.Min.Y))
+		if corner != test.wantCorner {
+			t.Errorf("unexpected corner color for background %v with %d options: got:%v want:%v", test.background, len(test.opts), corner, test.wantCorner)
+		}
+		center := color.NRGBAModel.Convert(img.At((b.Min.X+b.Max.X)/2, (b.Min.Y+b.Max.Y)/2))
+		if want := (color.NRGBA{R: 255, A: 255}); center != want {
+			t.Errorf("unexpected data area color for background %v: got:%v want:%v", test.background, center, want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"image/color"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Selector is the interface implemented by Plotters whose data
+// elements can be selected by a region of the canvas on which
+// they are drawn.
+type Selector interface {
+	// Select returns the indices of the data elements of
+	// the Plotter drawn on the data canvas c of plt whose
+	// locations on the canvas are reported to be within
+	// a region by in.
+	Select(c draw.Canvas, plt *Plot, in func(vg.Point) bool) []int
+}
+
+// Brush is a region of a canvas, such as a rectangle dragged
+// out or a lasso drawn with a mouse pointer, that selects the
+// data elements of plots drawn within it. The same Brush may
+// select from several plots, so that elements selected in one
+// plot may be highlighted in others showing the same data.
+type Brush struct {
+	// Polygon is the boundary of the region, in
+	// canvas coordinates.
+	Polygon []vg.Point
+
+	// Color is the fill color of the region when it
+	// is drawn. If Color is nil the region is not
+	// filled.
+	Color color.Color
+
+	// LineStyle is the style of the boundary of the
+	// region when it is drawn.
+	LineStyle draw.LineStyle
+}
+
+// NewRectBrush returns a Brush selecting the rectangle r,
+// drawn as a translucent gray rectangle.
+func NewRectBrush(r vg.Rectangle) *Brush {
+	return NewLassoBrush([]vg.Point{
+		r.Min, {X: r.Max.X, Y: r.Min.Y},
+		r.Max, {X: r.Min.X, Y: r.Max.Y},
+	})
+}
+
+// NewLassoBrush returns a Brush selecting the polygon with the
+// given vertices, drawn as a translucent gray polygon. The polygon
+// is closed by joining its last vertex to its first.
+func NewLassoBrush(pts []vg.Point) *Brush {
+	return &Brush{
+		Polygon: pts,
+		Color:   color.NRGBA{R: 128, G: 128, B: 128, A: 64},
+		LineStyle: draw.LineStyle{
+			Color:  color.Gray{Y: 96},
+			Width:  vg.Points(0.5),
+			Dashes: []vg.Length{vg.Points(2), vg.Points(2)},
+		},
+	}
+}
+
+// Contains returns whether the point pt is within the region
+// of the brush. Points in areas of a self-intersecting polygon
+// that are enclosed an even number of times are not within it.
+func (b *Brush) Contains(pt vg.Point) bool {
+	var in bool
+	for i, j := 0, len(b.Polygon)-1; i < len(b.Polygon); j, i = i, i+1 {
+		pi, pj := b.Polygon[i], b.Polygon[j]
+		if (pi.Y > pt.Y) != (pj.Y > pt.Y) &&
+			pt.X < pi.X+(pt.Y-pi.Y)*(pj.X-pi.X)/(pj.Y-pi.Y) {
+			in = !in
+		}
+	}
+	return in
+}
+
+// Select returns the indices of the data elements within the
+// region of the brush of each of the Plotters of p implementing
+// Selector, when p is drawn to c. Plotters without selected
+// elements are not included in the returned map.
+func (b *Brush) Select(p *Plot, c draw.Canvas) map[Plotter][]int {
+	dc := p.DataCanvas(c)
+	sel := make(map[Plotter][]int)
+	for _, pl := range p.plotters {
+		s, ok := pl.(Selector)
+		if !ok {
+			continue
+		}
+		if idx := s.Select(dc, p, b.Contains); len(idx) != 0 {
+			sel[pl] = idx
+		}
+	}
+	return sel
+}
+
+// Draw draws the region of the brush to c.
+func (b *Brush) Draw(c draw.Canvas) {
+	if len(b.Polygon) == 0 {
+		return
+	}
+	if b.Color != nil {
+		c.FillPolygon(b.Color, b.Polygon)
+	}
+	if b.LineStyle.Color != nil && b.LineStyle.Width != 0 {
+		c.StrokeLines(b.LineStyle, append(b.Polygon[:len(b.Polygon):len(b.Polygon)], b.Polygon[0]))
+	}
+}
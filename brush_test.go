@@ -0,0 +1,35 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"testing"
+
+	"gonum.org/v1/plot/vg"
+)
+
+func TestBrushContains(t *testing.T) {
+	rect := NewRectBrush(vg.Rectangle{Max: vg.Point{X: 10, Y: 5}})
+	// A self-intersecting bow tie, crossing at (5, 5).
+	bowtie := NewLassoBrush([]vg.Point{{X: 0, Y: 0}, {X: 10, Y: 10}, {X: 10, Y: 0}, {X: 0, Y: 10}})
+	for _, test := range []struct {
+		brush *Brush
+		pt    vg.Point
+		want  bool
+	}{
+		{brush: rect, pt: vg.Point{X: 5, Y: 2}, want: true},
+		{brush: rect, pt: vg.Point{X: 11, Y: 2}, want: false},
+		{brush: rect, pt: vg.Point{X: 5, Y: 6}, want: false},
+		{brush: bowtie, pt: vg.Point{X: 2, Y: 5}, want: true},
+		{brush: bowtie, pt: vg.Point{X: 8, Y: 5}, want: true},
+		{brush: bowtie, pt: vg.Point{X: 5, Y: 2}, want: false},
+		{brush: bowtie, pt: vg.Point{X: 5, Y: 8}, want: false},
+		{brush: &Brush{}, pt: vg.Point{}, want: false},
+	} {
+		if got := test.brush.Contains(test.pt); got != test.want {
+			t.Errorf("unexpected containment of %v in %v: got:%t want:%t", test.pt, test.brush.Polygon, got, test.want)
+		}
+	}
+}
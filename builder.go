@@ -0,0 +1,111 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Builder wraps a Plot so that it may be constructed and
+// rendered from multiple goroutines.
+//
+// A Plot is not safe for concurrent use; in particular
+// Draw modifies the axis ranges of the Plot. All access
+// to a Plot wrapped by a Builder must go through the
+// Builder's methods, which serialize it.
+//
+// The Plotters held by the Plot must not be modified while
+// the Plot is being drawn.
+type Builder struct {
+	mu   sync.Mutex
+	plot *Plot
+}
+
+// NewBuilder returns a Builder wrapping p.
+func NewBuilder(p *Plot) *Builder {
+	return &Builder{plot: p}
+}
+
+// Add adds Plotters to the plot as for Plot.Add.
+func (b *Builder) Add(ps ...Plotter) {
+	b.mu.Lock()
+	b.plot.Add(ps...)
+	b.mu.Unlock()
+}
+
+// AddLegend adds an entry to the plot's legend as
+// for Legend.Add.
+func (b *Builder) AddLegend(name string, thumbs ...Thumbnailer) {
+	b.mu.Lock()
+	b.plot.Legend.Add(name, thumbs...)
+	b.mu.Unlock()
+}
+
+// Collect adds each Plotter received from ps to the
+// plot until ps is closed. Plotters are added in the
+// order in which they are received.
+func (b *Builder) Collect(ps <-chan Plotter) {
+	for p := range ps {
+		b.Add(p)
+	}
+}
+
+// Update calls fn with exclusive access to the plot.
+// The plot must not be retained by fn.
+func (b *Builder) Update(fn func(*Plot)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	fn(b.plot)
+}
+
+// Draw draws the plot to c as for Plot.Draw.
+func (b *Builder) Draw(c draw.Canvas) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.plot.Draw(c)
+}
+
+// WriterTo returns an io.WriterTo that will write the plot
+// as for Plot.WriterTo.
+func (b *Builder) WriterTo(w, h vg.Length, format string, opts ...draw.FormatOption) (io.WriterTo, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.plot.WriterTo(w, h, format, opts...)
+}
+
+// WriterToContext returns an io.WriterTo that will write
+// the plot as for Plot.WriterToContext.
+func (b *Builder) WriterToContext(ctx context.Context, w, h vg.Length, format string, opts ...draw.FormatOption) (io.WriterTo, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.plot.WriterToContext(ctx, w, h, format, opts...)
+}
+
+// Save saves the plot to an image file as for Plot.Save.
+func (b *Builder) Save(w, h vg.Length, file string, opts ...draw.FormatOption) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.plot.Save(w, h, file, opts...)
+}
+
+// SaveContext saves the plot to an image file as for
+// Plot.SaveContext.
+func (b *Builder) SaveContext(ctx context.Context, w, h vg.Length, file string, opts ...draw.FormatOption) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.plot.SaveContext(ctx, w, h, file, opts...)
+}
+
+// Plot returns the wrapped plot. The returned plot must
+// only be used once no other goroutine is using the
+// Builder.
+func (b *Builder) Plot() *Plot {
+	return b.plot
+}
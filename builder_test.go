@@ -0,0 +1,113 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+func TestBuilder(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b := plot.NewBuilder(p)
+
+	const n = 16
+	ps := make(chan plot.Plotter)
+	done := make(chan struct{})
+	go func() {
+		b.Collect(ps)
+		close(done)
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			l, err := plotter.NewLine(plotter.XYs{{0, 0}, {float64(i), float64(i)}})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if i%2 == 0 {
+				ps <- l
+			} else {
+				b.Add(l)
+			}
+			b.AddLegend(fmt.Sprint(i), l)
+			if _, err := b.WriterTo(4*vg.Centimeter, 4*vg.Centimeter, "svg"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(ps)
+	<-done
+
+	b.Update(func(p *plot.Plot) {
+		if p.X.Max != n-1 {
+			t.Errorf("unexpected x range maximum: got:%v want:%v", p.X.Max, n-1)
+		}
+	})
+}
+
+func TestBuilderOptions(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.BackgroundColor = nil
+	b := plot.NewBuilder(p)
+
+	// Format options are passed on to the encoder.
+	red := color.NRGBA{R: 255, A: 255}
+	wt, err := b.WriterTo(2*vg.Centimeter, 2*vg.Centimeter, "png", draw.Background(red))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if _, err := wt.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := color.NRGBAModel.Convert(img.At(0, 0)); got != red {
+		t.Errorf("unexpected background: got:%v want:%v", got, red)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = b.WriterToContext(ctx, 2*vg.Centimeter, 2*vg.Centimeter, "png")
+	if err != context.Canceled {
+		t.Errorf("unexpected error for canceled context: got:%v want:%v", err, context.Canceled)
+	}
+	dir, err := ioutil.TempDir("", "builder")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	err = b.SaveContext(ctx, 2*vg.Centimeter, 2*vg.Centimeter, filepath.Join(dir, "canceled.png"))
+	if err != context.Canceled {
+		t.Errorf("unexpected error for canceled save: got:%v want:%v", err, context.Canceled)
+	}
+}
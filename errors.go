@@ -0,0 +1,93 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// DrawError is an error that prevented a part of a plot
+// from being drawn.
+type DrawError struct {
+	// Part is the name of the part of the plot that
+	// failed: "background", "title", "x", "y" or "twin"
+	// for the axes, "plotter", "legend", or "plot" for a
+	// failure in the layout of the plot.
+	Part string
+
+	// Plotter is the plotter that failed, or nil
+	// if the part is not a plotter.
+	Plotter Plotter
+
+	// Err is the error of the part.
+	Err error
+}
+
+func (e *DrawError) Error() string {
+	if e.Plotter != nil {
+		return fmt.Sprintf("plot: drawing %s %T: %v", e.Part, e.Plotter, e.Err)
+	}
+	return fmt.Sprintf("plot: drawing %s: %v", e.Part, e.Err)
+}
+
+// Unwrap returns the error of the part.
+func (e *DrawError) Unwrap() error { return e.Err }
+
+// DrawErrors is the list of errors collected while
+// drawing a plot with DrawContext.
+type DrawErrors []*DrawError
+
+func (e DrawErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Fail reports an error that prevents the part of the plot
+// being drawn, such as a plotter, from being drawn. Plotters
+// call Fail from their Plot method rather than panicking, and
+// then return.
+//
+// When the plot is drawn by DrawContext, or by one of the
+// SaveContext, WriterToContext and StreamContext methods, the
+// error is collected and returned as a DrawError once the plot
+// has been drawn, and the remaining parts of the plot are drawn.
+// Otherwise, as when the plot is drawn by Draw or Save, Fail
+// panics with err.
+func (p *Plot) Fail(err error) {
+	if p.errs == nil {
+		panic(err)
+	}
+	*p.errs = append(*p.errs, &DrawError{Part: p.part, Plotter: p.partPlotter, Err: err})
+}
+
+// guard calls fn. If the errors of the drawing are being
+// collected, a panic in fn is recovered and collected as a
+// failure of the part of the plot being drawn. Run-time
+// errors, such as out of range indexing, are programming
+// errors and are not recovered.
+func (p *Plot) guard(fn func()) {
+	if p.errs != nil {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			if _, ok := r.(runtime.Error); ok {
+				panic(r)
+			}
+			err, ok := r.(error)
+			if !ok {
+				err = fmt.Errorf("%v", r)
+			}
+			p.Fail(err)
+		}()
+	}
+	fn()
+}
@@ -0,0 +1,142 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+var errFailed = errors.New("failed")
+
+// failPlotter reports a failure when it is drawn.
+type failPlotter struct{}
+
+func (failPlotter) Plot(_ draw.Canvas, p *plot.Plot) { p.Fail(errFailed) }
+
+// panicPlotter panics when it is drawn.
+type panicPlotter struct{}
+
+func (panicPlotter) Plot(draw.Canvas, *plot.Plot) { panic("panicked") }
+
+func TestDrawErrors(t *testing.T) {
+	var got []string
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(failPlotter{}, panicPlotter{}, orderPlotter{name: "after", order: &got})
+
+	c := draw.Canvas{Canvas: new(recorder.Canvas), Rectangle: vg.Rectangle{Max: vg.Point{X: 10 * vg.Centimeter, Y: 10 * vg.Centimeter}}}
+	err = p.DrawContext(context.Background(), c)
+	errs, ok := err.(plot.DrawErrors)
+	if !ok {
+		t.Fatalf("unexpected error type: %T", err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("unexpected number of errors: got:%d want:2", len(errs))
+	}
+	if errs[0].Part != "plotter" || errs[0].Plotter != (failPlotter{}) || !errors.Is(errs[0], errFailed) {
+		t.Errorf("unexpected failure error: %+v", errs[0])
+	}
+	if errs[1].Plotter != (panicPlotter{}) || errs[1].Err.Error() != "panicked" {
+		t.Errorf("unexpected panic error: %+v", errs[1])
+	}
+	if len(got) != 1 {
+		t.Error("expected plotters after a failure to be drawn")
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != errFailed {
+				t.Errorf("unexpected panic from Draw: got:%v want:%v", r, errFailed)
+			}
+		}()
+		p.Draw(c)
+	}()
+}
+
+// indexPlotter indexes out of range when it is drawn.
+type indexPlotter struct{ data []float64 }
+
+func (p indexPlotter) Plot(draw.Canvas, *plot.Plot) { _ = p.data[len(p.data)] }
+
+func TestDrawErrorsRuntime(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(indexPlotter{})
+
+	// Run-time errors are not collected.
+	defer func() {
+		r := recover()
+		if _, ok := r.(runtime.Error); !ok {
+			t.Errorf("unexpected panic from DrawContext: got:%v want runtime error", r)
+		}
+	}()
+	c := draw.Canvas{Canvas: new(recorder.Canvas), Rectangle: vg.Rectangle{Max: vg.Point{X: 10 * vg.Centimeter, Y: 10 * vg.Centimeter}}}
+	err = p.DrawContext(context.Background(), c)
+	t.Errorf("unexpected return from DrawContext with error: %v", err)
+}
+
+func TestDrawErrorsPlotter(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h := plotter.NewHeatMap(squareGrid{}, palette.Heat(12, 1))
+	h.Min, h.Max = 1, 0
+	p.Add(h)
+
+	err = p.StreamContext(context.Background(), new(bytes.Buffer), 10*vg.Centimeter, 10*vg.Centimeter, "png")
+	if err == nil || !strings.Contains(err.Error(), "invalid Z range") {
+		t.Errorf("unexpected error for invalid heat map: %v", err)
+	}
+
+	// The methods without a context panic on failure.
+	for _, write := range []struct {
+		name string
+		fn   func() error
+	}{
+		{name: "Stream", fn: func() error {
+			return p.Stream(new(bytes.Buffer), 10*vg.Centimeter, 10*vg.Centimeter, "png")
+		}},
+		{name: "WriterTo", fn: func() error {
+			_, err := p.WriterTo(10*vg.Centimeter, 10*vg.Centimeter, "png")
+			return err
+		}},
+	} {
+		func() {
+			defer func() {
+				r, _ := recover().(error)
+				if r == nil || !strings.Contains(r.Error(), "invalid Z range") {
+					t.Errorf("unexpected panic from %s for invalid heat map: %v", write.name, r)
+				}
+			}()
+			err := write.fn()
+			t.Errorf("unexpected return from %s with error: %v", write.name, err)
+		}()
+	}
+}
+
+// squareGrid is a 2×2 plotter.GridXYZ.
+type squareGrid struct{}
+
+func (squareGrid) Dims() (c, r int)   { return 2, 2 }
+func (squareGrid) Z(c, r int) float64 { return float64(c + r) }
+func (squareGrid) X(c int) float64    { return float64(c) }
+func (squareGrid) Y(r int) float64    { return float64(r) }
@@ -0,0 +1,125 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"math"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// Formatter formats the values of tick labels.
+type Formatter interface {
+	// Format returns the label of the value v, which
+	// needs prec digits after the decimal point to be
+	// distinguished from the values of the other
+	// labelled ticks.
+	Format(v float64, prec int) string
+}
+
+// FormatterFunc is a function formatting the values
+// of tick labels. It implements the Formatter interface.
+type FormatterFunc func(v float64, prec int) string
+
+// Format implements the Format method of the Formatter interface.
+func (f FormatterFunc) Format(v float64, prec int) string { return f(v, prec) }
+
+// NumberStyle is the style of numbers formatted by a
+// NumberFormat.
+type NumberStyle int
+
+const (
+	// DecimalStyle formats values as decimal numbers.
+	DecimalStyle NumberStyle = iota
+
+	// PercentStyle formats values as percentages,
+	// so that 0.25 is formatted as 25%.
+	PercentStyle
+
+	// CurrencyStyle formats values as decimal numbers
+	// prefixed by the symbol of a currency.
+	CurrencyStyle
+)
+
+// NumberFormat is a Formatter formatting numbers using the
+// conventions of a locale for the decimal separator, the
+// grouping of digits and the placement of the percent sign.
+type NumberFormat struct {
+	// Locale is the locale whose conventions are used.
+	// The zero value, language.Und, uses the conventions
+	// of the root locale, which are those of English.
+	Locale language.Tag
+
+	// Style is the style of the formatted numbers.
+	Style NumberStyle
+
+	// Currency is the currency of numbers formatted
+	// with CurrencyStyle.
+	Currency currency.Unit
+
+	// NoGrouping specifies that the digits of the integer
+	// part of numbers are not grouped, so that 1234.5 is
+	// formatted as 1234.5 rather than 1,234.5 in English.
+	NoGrouping bool
+}
+
+// Format implements the Format method of the Formatter interface.
+func (f NumberFormat) Format(v float64, prec int) string {
+	if f.Style == PercentStyle {
+		prec -= 2
+	}
+	if prec < 0 {
+		prec = 0
+	}
+	opts := []number.Option{number.MinFractionDigits(prec), number.MaxFractionDigits(prec)}
+	if f.NoGrouping {
+		opts = append(opts, number.NoSeparator())
+	}
+
+	p := message.NewPrinter(f.Locale)
+	switch f.Style {
+	case DecimalStyle:
+		return p.Sprint(number.Decimal(v, opts...))
+	case PercentStyle:
+		return p.Sprint(number.Percent(v, opts...))
+	case CurrencyStyle:
+		sign := ""
+		if v < 0 {
+			sign = "-"
+			v = -v
+		}
+		return sign + p.Sprint(currency.Symbol(f.Currency)) + p.Sprint(number.Decimal(v, opts...))
+	default:
+		panic("plot: unknown number style")
+	}
+}
+
+// labelPrecision returns the number of digits after the
+// decimal point needed to represent the values of the labelled
+// ticks in ticks.
+func labelPrecision(ticks []Tick) int {
+	const maxPrec = 15
+	for prec := 0; prec < maxPrec; prec++ {
+		scale := math.Pow10(prec)
+		exact := true
+		for _, t := range ticks {
+			if t.IsMinor() {
+				continue
+			}
+			v := t.Value * scale
+			if math.Abs(v-math.Round(v)) > 1e-6*math.Max(1, math.Abs(v)) {
+				exact = false
+				break
+			}
+		}
+		if exact {
+			return prec
+		}
+	}
+	return maxPrec
+}
@@ -0,0 +1,115 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+
+	"gonum.org/v1/plot/internal/cmpimg"
+)
+
+// ExampleNumberFormat labels the ticks of the axes of a plot
+// using German conventions for the decimal separator and the
+// grouping of digits.
+func ExampleNumberFormat() {
+	p, err := New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Umsatz"
+	p.X.Label.Text = "Rabatt"
+	p.Y.Label.Text = "Erlös"
+	p.X.Min, p.X.Max = 0, 0.3
+	p.Y.Min, p.Y.Max = 0, 25000
+
+	p.X.Tick.Formatter = NumberFormat{Locale: language.German, Style: PercentStyle}
+	p.Y.Tick.Formatter = NumberFormat{Locale: language.German, Style: CurrencyStyle, Currency: currency.EUR}
+
+	err = p.Save(200, 150, "testdata/numberFormat.png")
+	if err != nil {
+		panic(err)
+	}
+}
+
+func TestNumberFormatPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleNumberFormat, t, "numberFormat.png")
+}
+
+func TestNumberFormat(t *testing.T) {
+	for _, test := range []struct {
+		f    NumberFormat
+		v    float64
+		prec int
+		want string
+	}{
+		{f: NumberFormat{}, v: 1234.5, prec: 1, want: "1,234.5"},
+		{f: NumberFormat{NoGrouping: true}, v: 1234.5, prec: 2, want: "1234.50"},
+		{f: NumberFormat{Locale: language.German}, v: -1234567.25, prec: 2, want: "-1.234.567,25"},
+		{f: NumberFormat{Locale: language.French}, v: 0.5, prec: 1, want: "0,5"},
+		{f: NumberFormat{Style: PercentStyle}, v: 0.25, prec: 2, want: "25%"},
+		{f: NumberFormat{Style: PercentStyle}, v: 0.125, prec: 3, want: "12.5%"},
+		{f: NumberFormat{Locale: language.German, Style: PercentStyle}, v: 0.5, prec: 1, want: "50\u00a0%"},
+		{f: NumberFormat{Locale: language.AmericanEnglish, Style: CurrencyStyle, Currency: currency.USD}, v: 2500, prec: 0, want: "$2,500"},
+		{f: NumberFormat{Style: CurrencyStyle, Currency: currency.EUR}, v: -2.5, prec: 1, want: "-€2.5"},
+	} {
+		if got := test.f.Format(test.v, test.prec); got != test.want {
+			t.Errorf("unexpected format of %v with %+v: got:%q want:%q", test.v, test.f, got, test.want)
+		}
+	}
+}
+
+func TestAxisFormatter(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 1
+	p.X.Tick.Formatter = FormatterFunc(func(v float64, prec int) string {
+		return NumberFormat{Locale: language.German}.Format(v, prec)
+	})
+	got := labelsOf(p.X.Ticks())
+	var want []string
+	for _, l := range labelsOf(DefaultTicks{}.Ticks(0, 1)) {
+		want = append(want, strings.Replace(l, ".", ",", 1))
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected labels: got:%q want:%q", got, want)
+	}
+
+	// Styling the ticks keeps the formatting of their labels.
+	p.X.Tick.Marker = StyledTicks{Ticker: DefaultTicks{}}
+	if got := labelsOf(p.X.Ticks()); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected labels of styled ticks: got:%q want:%q", got, want)
+	}
+
+	// Formatters are not applied to the labels of other tickers.
+	p.X.Tick.Marker = ConstantTicks{{Value: 0.5, Label: "half"}}
+	if got := labelsOf(p.X.Ticks()); !reflect.DeepEqual(got, []string{"half"}) {
+		t.Errorf("unexpected labels of constant ticks: got:%q", got)
+	}
+
+	for _, test := range []struct {
+		values []float64
+		want   int
+	}{
+		{values: []float64{0, 500, 1000}, want: 0},
+		{values: []float64{0, 0.1 + 0.2, 0.6}, want: 1},
+		{values: []float64{0.25, 0.5, 0.75}, want: 2},
+		{values: []float64{1e-4, 1e-3}, want: 4},
+	} {
+		var ticks []Tick
+		for _, v := range test.values {
+			ticks = append(ticks, Tick{Value: v, Label: "x"})
+		}
+		if got := labelPrecision(ticks); got != test.want {
+			t.Errorf("unexpected precision of %v: got:%d want:%d", test.values, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geo
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// DefaultHatchSpacing is the default spacing between
+// the lines hatching regions without values.
+var DefaultHatchSpacing = vg.Points(4)
+
+// Choropleth implements the plot.Plotter interface, drawing
+// regions filled with colors representing their values.
+type Choropleth struct {
+	// Values holds the value of each region, keyed by
+	// region name.
+	Values map[string]float64
+
+	// ColorMap is used to fill regions with values.
+	// Values outside the range of the ColorMap are
+	// drawn with the color of the nearest limit.
+	ColorMap palette.ColorMap
+
+	// LineStyle is the style of region boundaries.
+	draw.LineStyle
+
+	// Missing is the fill color of regions that have
+	// no value. If Missing is nil, the regions are
+	// not filled.
+	Missing color.Color
+
+	// Hatch is the style of the diagonal lines drawn
+	// across regions that have no value. Hatching is
+	// not drawn if Hatch.Color is nil or Hatch.Width
+	// is zero.
+	Hatch draw.LineStyle
+
+	// HatchSpacing is the distance between the
+	// hatching lines.
+	HatchSpacing vg.Length
+
+	regions []region
+}
+
+// region is a named projected region of a Choropleth.
+type region struct {
+	name     string
+	polygons []plotter.Polygon
+}
+
+// NewChoropleth returns a Choropleth drawing the polygons of
+// the features, projected using p, with the values of the
+// features given by values keyed by feature name. The range
+// of cmap is set to the range of the values, and regions
+// without values are hatched using the default grid line
+// style.
+func NewChoropleth(p Projection, fs []Feature, values map[string]float64, cmap palette.ColorMap) (*Choropleth, error) {
+	if len(values) == 0 {
+		return nil, errors.New("geo: no values for choropleth")
+	}
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, errors.New("geo: invalid choropleth value")
+		}
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	if min == max {
+		min--
+		max++
+	}
+	cmap.SetMin(min)
+	cmap.SetMax(max)
+
+	ch := &Choropleth{
+		Values:       values,
+		ColorMap:     cmap,
+		LineStyle:    plotter.DefaultLineStyle,
+		Hatch:        plotter.DefaultGridLineStyle,
+		HatchSpacing: DefaultHatchSpacing,
+	}
+	for _, f := range fs {
+		r := region{name: f.Name()}
+		for _, poly := range f.Polygons {
+			rings, err := projectPolygon(p, poly)
+			if err != nil {
+				return nil, err
+			}
+			r.polygons = append(r.polygons, plotter.Polygon{XYs: rings})
+		}
+		ch.regions = append(ch.regions, r)
+	}
+	return ch, nil
+}
+
+// Plot draws the Choropleth, implementing the
+// plot.Plotter interface.
+func (ch *Choropleth) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for _, r := range ch.regions {
+		v, ok := ch.Values[r.name]
+		col := ch.Missing
+		if ok {
+			col = ch.color(v)
+		}
+		for i := range r.polygons {
+			poly := &r.polygons[i]
+			poly.Color = col
+			poly.LineStyle = ch.LineStyle
+			poly.Plot(c, plt)
+
+			if !ok && ch.Hatch.Color != nil && ch.Hatch.Width > 0 {
+				rings := make([][]vg.Point, len(poly.XYs))
+				for j, ring := range poly.XYs {
+					rings[j] = make([]vg.Point, len(ring))
+					for k, p := range ring {
+						rings[j][k] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+					}
+				}
+				c.StrokeLines(ch.Hatch, c.ClipLinesXY(hatch(rings, ch.HatchSpacing)...)...)
+			}
+		}
+	}
+}
+
+// color returns the color of the value v.
+func (ch *Choropleth) color(v float64) color.Color {
+	v = math.Max(ch.ColorMap.Min(), math.Min(v, ch.ColorMap.Max()))
+	col, err := ch.ColorMap.At(v)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// DataRange returns the minimum and maximum projected
+// x and y values, implementing the plot.DataRanger
+// interface.
+func (ch *Choropleth) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	for _, r := range ch.regions {
+		for i := range r.polygons {
+			pxmin, pxmax, pymin, pymax := r.polygons[i].DataRange()
+			xmin = math.Min(xmin, pxmin)
+			xmax = math.Max(xmax, pxmax)
+			ymin = math.Min(ymin, pymin)
+			ymax = math.Max(ymax, pymax)
+		}
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// ColorBar returns a plotter.ColorBar showing the
+// ColorMap of the Choropleth.
+func (ch *Choropleth) ColorBar() *plotter.ColorBar {
+	return &plotter.ColorBar{ColorMap: ch.ColorMap}
+}
+
+// hatch returns the segments of diagonal lines, spaced
+// by the given distance, lying within the polygon with
+// the given rings, using the even-odd rule.
+func hatch(rings [][]vg.Point, spacing vg.Length) [][]vg.Point {
+	if spacing <= 0 {
+		spacing = DefaultHatchSpacing
+	}
+
+	// Hatching lines have constant u = x - y and
+	// points along them are ordered by v = x + y.
+	umin, umax := vg.Length(math.Inf(1)), vg.Length(math.Inf(-1))
+	for _, ring := range rings {
+		for _, p := range ring {
+			u := p.X - p.Y
+			umin = vg.Length(math.Min(float64(umin), float64(u)))
+			umax = vg.Length(math.Max(float64(umax), float64(u)))
+		}
+	}
+	// The perpendicular distance between lines of
+	// constant u is their separation in u over √2.
+	step := spacing * math.Sqrt2
+
+	var segs [][]vg.Point
+	var vs []float64
+	for u := vg.Length(math.Ceil(float64(umin/step))) * step; u <= umax; u += step {
+		vs = vs[:0]
+		for _, ring := range rings {
+			for i := range ring {
+				p, q := ring[i], ring[(i+1)%len(ring)]
+				pu, qu := p.X-p.Y, q.X-q.Y
+				if (pu < u) == (qu < u) {
+					continue
+				}
+				t := (u - pu) / (qu - pu)
+				x := p.X + t*(q.X-p.X)
+				y := p.Y + t*(q.Y-p.Y)
+				vs = append(vs, float64(x+y))
+			}
+		}
+		sort.Float64s(vs)
+		for i := 0; i+1 < len(vs); i += 2 {
+			v0, v1 := vg.Length(vs[i]), vg.Length(vs[i+1])
+			segs = append(segs, []vg.Point{
+				{X: (u + v0) / 2, Y: (v0 - u) / 2},
+				{X: (u + v1) / 2, Y: (v1 - u) / 2},
+			})
+		}
+	}
+	return segs
+}
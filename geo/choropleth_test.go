@@ -0,0 +1,91 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geo_test
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/geo"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// ExampleChoropleth draws a grid of regions colored by value,
+// with a color bar below the map. Regions without a value are
+// hatched.
+func ExampleChoropleth() {
+	var (
+		regions []geo.Feature
+		values  = make(map[string]float64)
+	)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 3; j++ {
+			name := fmt.Sprintf("%c%d", 'A'+i, j+1)
+			lon, lat := float64(10*i), float64(40+10*j)
+			regions = append(regions, geo.Feature{
+				Properties: map[string]interface{}{"name": name},
+				Polygons: []geo.Polygon{{{
+					{Lon: lon, Lat: lat}, {Lon: lon + 10, Lat: lat},
+					{Lon: lon + 10, Lat: lat + 10}, {Lon: lon, Lat: lat + 10},
+				}}},
+			})
+			if (i+j)%5 != 4 {
+				values[name] = float64(i*j + i)
+			}
+		}
+	}
+
+	ch, err := geo.NewChoropleth(geo.Mercator{}, regions, values, moreland.SmoothBlueRed())
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Choropleth"
+	p.HideAxes()
+	p.DataAspect = 1
+	p.Add(ch)
+
+	bar, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	bar.Add(ch.ColorBar())
+	bar.HideY()
+	bar.X.Padding = 0
+
+	img := vgimg.New(10*vg.Centimeter, 10*vg.Centimeter)
+	dc := draw.New(img)
+	const barHeight = 1.5 * vg.Centimeter
+	p.Draw(draw.Crop(dc, 0, 0, barHeight, 0))
+	bar.Draw(draw.Crop(dc, 0, 0, 0, barHeight-dc.Max.Y))
+
+	f, err := os.Create("testdata/choropleth.png")
+	if err != nil {
+		log.Panic(err)
+	}
+	_, err = vgimg.PngCanvas{Canvas: img}.WriteTo(f)
+	if err != nil {
+		log.Panic(err)
+	}
+	err = f.Close()
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestChoropleth(t *testing.T) {
+	cmpimg.CheckPlot(ExampleChoropleth, t, "choropleth.png")
+}
@@ -0,0 +1,334 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geo
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Point is a geographic location.
+type Point struct {
+	// Lon and Lat are the longitude and
+	// latitude of the point in degrees.
+	Lon, Lat float64
+}
+
+// LineString is a sequence of points joined by lines.
+type LineString []Point
+
+// Polygon is an area bounded by rings. The first ring is
+// the exterior of the polygon, and any other rings are
+// holes within it.
+type Polygon []LineString
+
+// Feature is a geographic feature such as a coastline,
+// border or region.
+type Feature struct {
+	// Properties holds the properties of the feature.
+	Properties map[string]interface{}
+
+	// Polygons and Lines are the areal and
+	// linear geometries of the feature.
+	Polygons []Polygon
+	Lines    []LineString
+}
+
+// Name returns the value of the "name" property of the
+// feature if it is a string, or the empty string otherwise.
+func (f Feature) Name() string {
+	name, _ := f.Properties["name"].(string)
+	return name
+}
+
+// ReadGeoJSON reads features from a GeoJSON document in r.
+// The document may hold a FeatureCollection, a single Feature
+// or a bare geometry. Polygon, MultiPolygon, LineString and
+// MultiLineString geometries and GeometryCollections of them
+// are read; point geometries are ignored.
+func ReadGeoJSON(r io.Reader) ([]Feature, error) {
+	var obj geoJSON
+	err := json.NewDecoder(r).Decode(&obj)
+	if err != nil {
+		return nil, err
+	}
+
+	switch obj.Type {
+	case "FeatureCollection":
+		fs := make([]Feature, len(obj.Features))
+		for i, f := range obj.Features {
+			fs[i], err = f.feature()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return fs, nil
+	case "Feature":
+		f, err := obj.feature()
+		if err != nil {
+			return nil, err
+		}
+		return []Feature{f}, nil
+	default:
+		var f Feature
+		err = obj.addGeometry(&f)
+		if err != nil {
+			return nil, err
+		}
+		return []Feature{f}, nil
+	}
+}
+
+// geoJSON is a GeoJSON object.
+type geoJSON struct {
+	Type string `json:"type"`
+
+	// Feature and FeatureCollection members.
+	Features   []geoJSON              `json:"features"`
+	Geometry   *geoJSON               `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+
+	// Geometry members.
+	Coordinates json.RawMessage `json:"coordinates"`
+	Geometries  []geoJSON       `json:"geometries"`
+}
+
+// feature returns the Feature described by g.
+func (g geoJSON) feature() (Feature, error) {
+	if g.Type != "Feature" {
+		return Feature{}, fmt.Errorf("geo: unexpected GeoJSON type %q in feature collection", g.Type)
+	}
+	f := Feature{Properties: g.Properties}
+	if g.Geometry == nil {
+		return f, nil
+	}
+	err := g.Geometry.addGeometry(&f)
+	return f, err
+}
+
+// addGeometry adds the geometry described by g to f.
+func (g geoJSON) addGeometry(f *Feature) error {
+	var err error
+	switch g.Type {
+	case "Polygon":
+		var c [][][]float64
+		err = json.Unmarshal(g.Coordinates, &c)
+		if err == nil {
+			var p Polygon
+			p, err = polygon(c)
+			f.Polygons = append(f.Polygons, p)
+		}
+	case "MultiPolygon":
+		var c [][][][]float64
+		err = json.Unmarshal(g.Coordinates, &c)
+		for _, pc := range c {
+			if err != nil {
+				break
+			}
+			var p Polygon
+			p, err = polygon(pc)
+			f.Polygons = append(f.Polygons, p)
+		}
+	case "LineString":
+		var c [][]float64
+		err = json.Unmarshal(g.Coordinates, &c)
+		if err == nil {
+			var l LineString
+			l, err = lineString(c)
+			f.Lines = append(f.Lines, l)
+		}
+	case "MultiLineString":
+		var c [][][]float64
+		err = json.Unmarshal(g.Coordinates, &c)
+		for _, lc := range c {
+			if err != nil {
+				break
+			}
+			var l LineString
+			l, err = lineString(lc)
+			f.Lines = append(f.Lines, l)
+		}
+	case "GeometryCollection":
+		for _, sub := range g.Geometries {
+			err = sub.addGeometry(f)
+			if err != nil {
+				break
+			}
+		}
+	case "Point", "MultiPoint":
+		// Point geometries are not drawn.
+	default:
+		return fmt.Errorf("geo: unknown GeoJSON type %q", g.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("geo: invalid %s geometry: %v", g.Type, err)
+	}
+	return nil
+}
+
+// polygon returns the Polygon with the given rings of
+// GeoJSON positions.
+func polygon(c [][][]float64) (Polygon, error) {
+	p := make(Polygon, len(c))
+	for i, rc := range c {
+		var err error
+		p[i], err = lineString(rc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// lineString returns the LineString with the given
+// GeoJSON positions.
+func lineString(c [][]float64) (LineString, error) {
+	l := make(LineString, len(c))
+	for i, pos := range c {
+		if len(pos) < 2 {
+			return nil, errors.New("position with fewer than two coordinates")
+		}
+		l[i] = Point{Lon: pos[0], Lat: pos[1]}
+	}
+	return l, nil
+}
+
+// Shape types of ESRI shapefiles.
+const (
+	shpNull        = 0
+	shpPolyLine    = 3
+	shpPolygon     = 5
+	shpPolyLineZ   = 13
+	shpPolygonZ    = 15
+	shpPolyLineM   = 23
+	shpPolygonM    = 25
+	shpFileCode    = 9994
+	shpHeaderBytes = 100
+)
+
+// ReadShapefile reads features from the main file, with the
+// extension .shp, of an ESRI shapefile in r. Each shape record
+// is returned as a Feature without properties, since attributes
+// are held in a separate file. Polygon and PolyLine shapes,
+// including their Z and M variants, are read; other shapes are
+// returned as empty features.
+//
+// The rings of polygon shapes are grouped into polygons by
+// winding order, with clockwise rings starting a new polygon
+// and counterclockwise rings forming holes in the preceding
+// polygon.
+func ReadShapefile(r io.Reader) ([]Feature, error) {
+	br := bufio.NewReader(r)
+	var hdr [shpHeaderBytes]byte
+	_, err := io.ReadFull(br, hdr[:])
+	if err != nil {
+		return nil, fmt.Errorf("geo: failed to read shapefile header: %v", err)
+	}
+	if code := binary.BigEndian.Uint32(hdr[0:]); code != shpFileCode {
+		return nil, fmt.Errorf("geo: invalid shapefile code: %d", code)
+	}
+
+	var fs []Feature
+	for {
+		var rec [8]byte
+		_, err = io.ReadFull(br, rec[:])
+		if err == io.EOF {
+			return fs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("geo: failed to read shapefile record: %v", err)
+		}
+		// Content length is given in 16-bit words.
+		content := make([]byte, 2*int(binary.BigEndian.Uint32(rec[4:])))
+		_, err = io.ReadFull(br, content)
+		if err != nil {
+			return nil, fmt.Errorf("geo: failed to read shapefile record: %v", err)
+		}
+		f, err := shape(content)
+		if err != nil {
+			return nil, fmt.Errorf("geo: invalid shapefile record %d: %v", binary.BigEndian.Uint32(rec[0:]), err)
+		}
+		fs = append(fs, f)
+	}
+}
+
+// shape returns the Feature held in the content of a
+// shapefile record.
+func shape(b []byte) (Feature, error) {
+	var f Feature
+	if len(b) < 4 {
+		return f, errors.New("short record")
+	}
+	typ := binary.LittleEndian.Uint32(b)
+	switch typ {
+	case shpPolyLine, shpPolyLineZ, shpPolyLineM, shpPolygon, shpPolygonZ, shpPolygonM:
+	default:
+		return f, nil
+	}
+
+	// Skip the shape type and bounding box.
+	const partsOffset = 4 + 4*8
+	if len(b) < partsOffset+8 {
+		return f, errors.New("short record")
+	}
+	nParts := int(binary.LittleEndian.Uint32(b[partsOffset:]))
+	nPoints := int(binary.LittleEndian.Uint32(b[partsOffset+4:]))
+	pointsOffset := partsOffset + 8 + 4*nParts
+	if nParts < 0 || nPoints < 0 || len(b) < pointsOffset+16*nPoints {
+		return f, errors.New("short record")
+	}
+
+	parts := make([]LineString, nParts)
+	for i := range parts {
+		start := int(binary.LittleEndian.Uint32(b[partsOffset+8+4*i:]))
+		end := nPoints
+		if i+1 < nParts {
+			end = int(binary.LittleEndian.Uint32(b[partsOffset+8+4*(i+1):]))
+		}
+		if start < 0 || end < start || nPoints < end {
+			return f, errors.New("invalid part index")
+		}
+		l := make(LineString, end-start)
+		for j := range l {
+			off := pointsOffset + 16*(start+j)
+			l[j] = Point{
+				Lon: math.Float64frombits(binary.LittleEndian.Uint64(b[off:])),
+				Lat: math.Float64frombits(binary.LittleEndian.Uint64(b[off+8:])),
+			}
+		}
+		parts[i] = l
+	}
+
+	switch typ {
+	case shpPolyLine, shpPolyLineZ, shpPolyLineM:
+		f.Lines = parts
+	default:
+		for _, ring := range parts {
+			if signedArea(ring) <= 0 || len(f.Polygons) == 0 {
+				f.Polygons = append(f.Polygons, Polygon{ring})
+				continue
+			}
+			last := &f.Polygons[len(f.Polygons)-1]
+			*last = append(*last, ring)
+		}
+	}
+	return f, nil
+}
+
+// signedArea returns twice the signed area of the ring,
+// which is positive for counterclockwise rings.
+func signedArea(ring LineString) float64 {
+	var a float64
+	for i := range ring {
+		p, q := ring[i], ring[(i+1)%len(ring)]
+		a += p.Lon*q.Lat - q.Lon*p.Lat
+	}
+	return a
+}
@@ -0,0 +1,141 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testGeoJSON = `{
+	"type": "FeatureCollection",
+	"features": [
+		{
+			"type": "Feature",
+			"properties": {"name": "square"},
+			"geometry": {
+				"type": "Polygon",
+				"coordinates": [
+					[[0, 0], [10, 0], [10, 10], [0, 10], [0, 0]],
+					[[2, 2], [2, 4], [4, 4], [4, 2], [2, 2]]
+				]
+			}
+		},
+		{
+			"type": "Feature",
+			"properties": {"name": "river"},
+			"geometry": {
+				"type": "GeometryCollection",
+				"geometries": [
+					{"type": "MultiLineString", "coordinates": [[[0, 0, 5], [1, 1, 5]], [[2, 2], [3, 3]]]},
+					{"type": "Point", "coordinates": [1, 1]}
+				]
+			}
+		}
+	]
+}`
+
+func TestReadGeoJSON(t *testing.T) {
+	got, err := ReadGeoJSON(strings.NewReader(testGeoJSON))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Feature{
+		{
+			Properties: map[string]interface{}{"name": "square"},
+			Polygons: []Polygon{{
+				{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+				{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}},
+			}},
+		},
+		{
+			Properties: map[string]interface{}{"name": "river"},
+			Lines: []LineString{
+				{{0, 0}, {1, 1}},
+				{{2, 2}, {3, 3}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected features:\ngot: %#v\nwant:%#v", got, want)
+	}
+	if name := got[1].Name(); name != "river" {
+		t.Errorf("unexpected name: got:%q want:%q", name, "river")
+	}
+
+	_, err = ReadGeoJSON(strings.NewReader(`{"type": "Polygon", "coordinates": [[[0]]]}`))
+	if err == nil {
+		t.Error("expected error for invalid position")
+	}
+}
+
+func TestReadShapefile(t *testing.T) {
+	// A clockwise exterior ring with a counterclockwise
+	// hole, followed by a second clockwise exterior ring.
+	rings := []LineString{
+		{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}},
+		{{2, 2}, {4, 2}, {4, 4}, {2, 4}, {2, 2}},
+		{{20, 0}, {20, 5}, {25, 5}, {20, 0}},
+	}
+
+	var content bytes.Buffer
+	le := func(v interface{}) { binary.Write(&content, binary.LittleEndian, v) }
+	le(int32(shpPolygon))
+	le([4]float64{0, 0, 25, 10})
+	le(int32(len(rings)))
+	var n int32
+	for _, r := range rings {
+		n += int32(len(r))
+	}
+	le(n)
+	var start int32
+	for _, r := range rings {
+		le(start)
+		start += int32(len(r))
+	}
+	for _, r := range rings {
+		for _, p := range r {
+			le([2]float64{p.Lon, p.Lat})
+		}
+	}
+
+	var shp bytes.Buffer
+	hdr := make([]byte, shpHeaderBytes)
+	binary.BigEndian.PutUint32(hdr, shpFileCode)
+	binary.LittleEndian.PutUint32(hdr[32:], shpPolygon)
+	shp.Write(hdr)
+	binary.Write(&shp, binary.BigEndian, [2]int32{1, int32(content.Len() / 2)})
+	shp.Write(content.Bytes())
+	binary.Write(&shp, binary.BigEndian, [2]int32{2, 2})
+	binary.Write(&shp, binary.LittleEndian, int32(shpNull))
+
+	got, err := ReadShapefile(&shp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Feature{
+		{Polygons: []Polygon{{rings[0], rings[1]}, {rings[2]}}},
+		{},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected features:\ngot: %#v\nwant:%#v", got, want)
+	}
+
+	_, err = ReadShapefile(bytes.NewReader(make([]byte, shpHeaderBytes)))
+	if err == nil {
+		t.Error("expected error for invalid file code")
+	}
+}
+
+func TestSignedArea(t *testing.T) {
+	ccw := LineString{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	if got := signedArea(ccw); math.Abs(got-2) > 1e-15 {
+		t.Errorf("unexpected signed area: got:%v want:2", got)
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geo
+
+import (
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Map implements the plot.Plotter interface, drawing
+// projected geographic features such as coastlines and
+// borders.
+type Map struct {
+	// Color is the fill color of the polygons of the
+	// features. If Color is nil, polygons are not filled.
+	Color color.Color
+
+	// LineStyle is the style of polygon boundaries
+	// and lines.
+	draw.LineStyle
+
+	polygons []plotter.Polygon
+	lines    []plotter.XYs
+}
+
+// NewMap returns a Map drawing the features, projected using
+// p, with the default line style and no fill color. An error
+// is returned if a projected coordinate is NaN or infinite.
+func NewMap(p Projection, fs ...Feature) (*Map, error) {
+	m := &Map{LineStyle: plotter.DefaultLineStyle}
+	for _, f := range fs {
+		for _, poly := range f.Polygons {
+			rings, err := projectPolygon(p, poly)
+			if err != nil {
+				return nil, err
+			}
+			m.polygons = append(m.polygons, plotter.Polygon{XYs: rings})
+		}
+		for _, l := range f.Lines {
+			xys, err := project(p, l)
+			if err != nil {
+				return nil, err
+			}
+			m.lines = append(m.lines, xys)
+		}
+	}
+	return m, nil
+}
+
+// Plot draws the Map, implementing the plot.Plotter interface.
+func (m *Map) Plot(c draw.Canvas, plt *plot.Plot) {
+	for i := range m.polygons {
+		poly := &m.polygons[i]
+		poly.Color = m.Color
+		poly.LineStyle = m.LineStyle
+		poly.Plot(c, plt)
+	}
+
+	trX, trY := plt.Transforms(&c)
+	lines := make([][]vg.Point, len(m.lines))
+	for i, l := range m.lines {
+		lines[i] = make([]vg.Point, len(l))
+		for j, p := range l {
+			lines[i][j] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+		}
+	}
+	c.StrokeLines(m.LineStyle, c.ClipLinesXY(lines...)...)
+}
+
+// DataRange returns the minimum and maximum projected
+// x and y values, implementing the plot.DataRanger
+// interface.
+func (m *Map) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	extend := func(xys plotter.XYs) {
+		for _, p := range xys {
+			xmin = math.Min(xmin, p.X)
+			xmax = math.Max(xmax, p.X)
+			ymin = math.Min(ymin, p.Y)
+			ymax = math.Max(ymax, p.Y)
+		}
+	}
+	for _, poly := range m.polygons {
+		for _, ring := range poly.XYs {
+			extend(ring)
+		}
+	}
+	for _, l := range m.lines {
+		extend(l)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// Thumbnail draws the thumbnail for the Map,
+// implementing the plot.Thumbnailer interface.
+func (m *Map) Thumbnail(c *draw.Canvas) {
+	poly := plotter.Polygon{Color: m.Color, LineStyle: m.LineStyle}
+	poly.Thumbnail(c)
+}
+
+// projectPolygon returns the rings of poly projected using p.
+func projectPolygon(p Projection, poly Polygon) ([]plotter.XYs, error) {
+	rings := make([]plotter.XYs, len(poly))
+	for i, ring := range poly {
+		var err error
+		rings[i], err = project(p, ring)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rings, nil
+}
+
+// project returns the points of l projected using p.
+func project(p Projection, l LineString) (plotter.XYs, error) {
+	return plotter.CopyXYs(Projected{XYer: lineXYer(l), Projection: p})
+}
+
+// lineXYer implements the plotter.XYer interface
+// for the longitudes and latitudes of a LineString.
+type lineXYer LineString
+
+func (l lineXYer) Len() int                    { return len(l) }
+func (l lineXYer) XY(i int) (float64, float64) { return l[i].Lon, l[i].Lat }
+
+// Graticule implements the plot.Plotter interface, drawing
+// the meridians and parallels of a map projection.
+type Graticule struct {
+	// Projection is the projection of the map.
+	Projection Projection
+
+	// Step holds the spacing in degrees between
+	// meridians, in Lon, and between parallels,
+	// in Lat.
+	Step Point
+
+	// Min and Max are the south-western and
+	// north-eastern corners of the area covered
+	// by the graticule.
+	Min, Max Point
+
+	// Resolution is the spacing in degrees of the
+	// points joined to draw each meridian and
+	// parallel, which may be curved when projected.
+	Resolution float64
+
+	// LineStyle is the style of the graticule lines.
+	draw.LineStyle
+}
+
+// NewGraticule returns a Graticule for the projection p with
+// lines every 30 degrees, covering latitudes between 80°S and
+// 80°N, drawn with the default grid line style.
+func NewGraticule(p Projection) *Graticule {
+	return &Graticule{
+		Projection: p,
+		Step:       Point{Lon: 30, Lat: 30},
+		Min:        Point{Lon: -180, Lat: -80},
+		Max:        Point{Lon: 180, Lat: 80},
+		Resolution: 1,
+		LineStyle:  plotter.DefaultGridLineStyle,
+	}
+}
+
+// Plot draws the Graticule, implementing the
+// plot.Plotter interface.
+func (g *Graticule) Plot(c draw.Canvas, plt *plot.Plot) {
+	if !(g.Step.Lon > 0 && g.Step.Lat > 0 && g.Resolution > 0) {
+		panic("geo: invalid graticule spacing")
+	}
+	trX, trY := plt.Transforms(&c)
+	pt := func(lon, lat float64) vg.Point {
+		x, y := g.Projection.Project(lon, lat)
+		return vg.Point{X: trX(x), Y: trY(y)}
+	}
+
+	var lines [][]vg.Point
+	for lon := math.Ceil(g.Min.Lon/g.Step.Lon) * g.Step.Lon; lon <= g.Max.Lon; lon += g.Step.Lon {
+		var l []vg.Point
+		for lat := g.Min.Lat; lat < g.Max.Lat; lat += g.Resolution {
+			l = append(l, pt(lon, lat))
+		}
+		lines = append(lines, append(l, pt(lon, g.Max.Lat)))
+	}
+	for lat := math.Ceil(g.Min.Lat/g.Step.Lat) * g.Step.Lat; lat <= g.Max.Lat; lat += g.Step.Lat {
+		var l []vg.Point
+		for lon := g.Min.Lon; lon < g.Max.Lon; lon += g.Resolution {
+			l = append(l, pt(lon, lat))
+		}
+		lines = append(lines, append(l, pt(g.Max.Lon, lat)))
+	}
+	c.StrokeLines(g.LineStyle, c.ClipLinesXY(lines...)...)
+}
@@ -0,0 +1,94 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geo_test
+
+import (
+	"image/color"
+	"log"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/geo"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// islands is a GeoJSON document describing
+// some imaginary islands.
+const islands = `{
+	"type": "FeatureCollection",
+	"features": [
+		{
+			"type": "Feature",
+			"properties": {"name": "Northland"},
+			"geometry": {
+				"type": "Polygon",
+				"coordinates": [[
+					[-40, 35], [-10, 30], [15, 42], [20, 60], [-5, 70], [-35, 62], [-40, 35]
+				], [
+					[-15, 48], [-5, 48], [-5, 55], [-15, 55], [-15, 48]
+				]]
+			}
+		},
+		{
+			"type": "Feature",
+			"properties": {"name": "Southland"},
+			"geometry": {
+				"type": "MultiPolygon",
+				"coordinates": [
+					[[[30, -10], [60, -20], [70, 10], [45, 25], [30, -10]]],
+					[[[80, -40], [100, -45], [95, -25], [80, -40]]]
+				]
+			}
+		}
+	]
+}`
+
+// ExampleMap draws a map of some islands on a Mercator
+// projection, with their capitals overlaid using the
+// Scatter plotter.
+func ExampleMap() {
+	features, err := geo.ReadGeoJSON(strings.NewReader(islands))
+	if err != nil {
+		log.Panic(err)
+	}
+	proj := geo.Mercator{}
+
+	m, err := geo.NewMap(proj, features...)
+	if err != nil {
+		log.Panic(err)
+	}
+	m.Color = color.RGBA{R: 196, G: 220, B: 160, A: 255}
+
+	capitals, err := plotter.NewScatter(geo.Projected{
+		XYer:       plotter.XYs{{X: -20, Y: 58}, {X: 50, Y: 5}},
+		Projection: proj,
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+	capitals.Color = color.RGBA{R: 200, A: 255}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Islands"
+	p.HideAxes()
+	p.DataAspect = 1
+	p.BackgroundColor = color.RGBA{R: 200, G: 225, B: 255, A: 255}
+	p.Add(geo.NewGraticule(proj), m, capitals)
+
+	err = p.Save(10*vg.Centimeter, 10*vg.Centimeter, "testdata/map.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestMap(t *testing.T) {
+	cmpimg.CheckPlot(ExampleMap, t, "map.png")
+}
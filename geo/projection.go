@@ -0,0 +1,190 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package geo provides map projections and plotters for
+// drawing geographic data.
+//
+// Geographic coordinates are given as longitude and
+// latitude in degrees. Projections transform them to
+// planar coordinates that are plotted on linear axes, so
+// other plotters may be overlaid on a map by projecting
+// their data with Projected or ProjectGrid. Longitudes
+// are not wrapped about the central meridian of a
+// projection, so that features crossing the antimeridian
+// are not split.
+package geo // import "gonum.org/v1/plot/geo"
+
+import (
+	"math"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// Projection transforms geographic coordinates to planar
+// coordinates.
+type Projection interface {
+	// Project returns the planar coordinates of the
+	// location at the given longitude and latitude,
+	// in degrees.
+	Project(lon, lat float64) (x, y float64)
+}
+
+// Cylindrical is a Projection in which the x coordinate
+// depends only on longitude and the y coordinate depends
+// only on latitude, so that meridians and parallels are
+// straight lines.
+type Cylindrical interface {
+	Projection
+
+	// X returns the x coordinate of the given
+	// longitude, in degrees.
+	X(lon float64) float64
+
+	// Y returns the y coordinate of the given
+	// latitude, in degrees.
+	Y(lat float64) float64
+}
+
+// Equirectangular is the equirectangular projection,
+// mapping longitude and latitude linearly to x and y.
+// Projected coordinates are in radians of arc on a unit
+// sphere.
+type Equirectangular struct {
+	// Lon0 is the central meridian in degrees.
+	Lon0 float64
+
+	// Lat1 is the standard parallel in degrees, at
+	// which the scale is true. The zero value gives
+	// the plate carrée projection.
+	Lat1 float64
+}
+
+var _ Cylindrical = Equirectangular{}
+
+// Project implements the Projection interface.
+func (p Equirectangular) Project(lon, lat float64) (x, y float64) {
+	return p.X(lon), p.Y(lat)
+}
+
+// X implements the Cylindrical interface.
+func (p Equirectangular) X(lon float64) float64 {
+	return radians(lon-p.Lon0) * math.Cos(radians(p.Lat1))
+}
+
+// Y implements the Cylindrical interface.
+func (p Equirectangular) Y(lat float64) float64 {
+	return radians(lat)
+}
+
+// MercatorMaxLatitude is the latitude, in degrees, at which
+// the Mercator projection is truncated, making the projected
+// map square.
+const MercatorMaxLatitude = 85.0511287798066
+
+// Mercator is the Mercator projection. Latitudes are clamped
+// to ±MercatorMaxLatitude since the poles are projected to
+// infinity. Projected coordinates are for a unit sphere.
+type Mercator struct {
+	// Lon0 is the central meridian in degrees.
+	Lon0 float64
+}
+
+var _ Cylindrical = Mercator{}
+
+// Project implements the Projection interface.
+func (p Mercator) Project(lon, lat float64) (x, y float64) {
+	return p.X(lon), p.Y(lat)
+}
+
+// X implements the Cylindrical interface.
+func (p Mercator) X(lon float64) float64 {
+	return radians(lon - p.Lon0)
+}
+
+// Y implements the Cylindrical interface.
+func (p Mercator) Y(lat float64) float64 {
+	lat = math.Max(-MercatorMaxLatitude, math.Min(lat, MercatorMaxLatitude))
+	return math.Log(math.Tan(math.Pi/4 + radians(lat)/2))
+}
+
+// LambertConformal is the Lambert conformal conic projection,
+// commonly used for maps of mid-latitude regions. Projected
+// coordinates are for a unit sphere.
+type LambertConformal struct {
+	// Lon0 and Lat0 are the longitude and latitude
+	// in degrees of the origin of the projection.
+	Lon0, Lat0 float64
+
+	// Lat1 and Lat2 are the standard parallels in
+	// degrees. If they are equal, the projection
+	// has a single standard parallel. If they are
+	// symmetric about the equator, or both are on
+	// it, the cone of the projection flattens to a
+	// cylinder and the Mercator projection, true
+	// to scale at the standard parallels, is used.
+	Lat1, Lat2 float64
+}
+
+var _ Projection = LambertConformal{}
+
+// Project implements the Projection interface.
+func (p LambertConformal) Project(lon, lat float64) (x, y float64) {
+	phi1, phi2 := radians(p.Lat1), radians(p.Lat2)
+	n := math.Sin(phi1)
+	if phi1 != phi2 {
+		n = math.Log(math.Cos(phi1)/math.Cos(phi2)) /
+			math.Log(math.Tan(math.Pi/4+phi2/2)/math.Tan(math.Pi/4+phi1/2))
+	}
+	if n == 0 {
+		m := Mercator{Lon0: p.Lon0}
+		k := math.Cos(phi1)
+		return k * m.X(lon), k * (m.Y(lat) - m.Y(p.Lat0))
+	}
+	f := math.Cos(phi1) * math.Pow(math.Tan(math.Pi/4+phi1/2), n) / n
+	rho := func(lat float64) float64 {
+		return f / math.Pow(math.Tan(math.Pi/4+radians(lat)/2), n)
+	}
+
+	r, r0 := rho(lat), rho(p.Lat0)
+	theta := n * radians(lon-p.Lon0)
+	return r * math.Sin(theta), r0 - r*math.Cos(theta)
+}
+
+// Projected implements the plotter.XYer interface, projecting
+// the longitude, latitude pairs of XYer, held in x and y
+// respectively, so that they can be drawn by other plotters
+// on a map.
+type Projected struct {
+	plotter.XYer
+	Projection Projection
+}
+
+// XY implements the XY method of the plotter.XYer interface.
+func (p Projected) XY(i int) (float64, float64) {
+	return p.Projection.Project(p.XYer.XY(i))
+}
+
+// ProjectGrid returns a plotter.GridXYZ that projects the
+// longitudes and latitudes of the columns and rows of g, so
+// that gridded data can be drawn by the HeatMap and Contour
+// plotters on a map. Only cylindrical projections preserve
+// the rectilinear structure of the grid.
+func ProjectGrid(g plotter.GridXYZ, p Cylindrical) plotter.GridXYZ {
+	return projectedGrid{GridXYZ: g, proj: p}
+}
+
+// projectedGrid is a plotter.GridXYZ with projected
+// column and row coordinates.
+type projectedGrid struct {
+	plotter.GridXYZ
+	proj Cylindrical
+}
+
+func (g projectedGrid) X(c int) float64 { return g.proj.X(g.GridXYZ.X(c)) }
+func (g projectedGrid) Y(r int) float64 { return g.proj.Y(g.GridXYZ.Y(r)) }
+
+// radians returns deg converted to radians.
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
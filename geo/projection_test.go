@@ -0,0 +1,74 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geo
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestProjections(t *testing.T) {
+	const tol = 1e-12
+	for _, test := range []struct {
+		proj         Projection
+		lon, lat     float64
+		wantX, wantY float64
+	}{
+		{proj: Equirectangular{}, lon: 90, lat: 45, wantX: math.Pi / 2, wantY: math.Pi / 4},
+		{proj: Equirectangular{Lon0: 90, Lat1: 60}, lon: 0, lat: -90, wantX: -math.Pi / 4, wantY: -math.Pi / 2},
+		{proj: Mercator{}, lon: 0, lat: 0, wantX: 0, wantY: 0},
+		{proj: Mercator{}, lon: 180, lat: 90, wantX: math.Pi, wantY: math.Pi},
+		{proj: Mercator{Lon0: -90}, lon: 0, lat: 45, wantX: math.Pi / 2, wantY: math.Log(math.Tan(3 * math.Pi / 8))},
+
+		// Example from Snyder, Map Projections: A Working Manual, p. 296.
+		{
+			proj:  LambertConformal{Lon0: -96, Lat0: 23, Lat1: 33, Lat2: 45},
+			lon:   -75,
+			lat:   35,
+			wantX: 0.2966785,
+			wantY: 0.2462112,
+		},
+
+		// Standard parallels on or symmetric about the
+		// equator give the Mercator projection.
+		{proj: LambertConformal{}, lon: 180, lat: 90, wantX: math.Pi, wantY: math.Pi},
+		{
+			proj:  LambertConformal{Lon0: -90, Lat0: 45, Lat1: -60, Lat2: 60},
+			lon:   0,
+			lat:   0,
+			wantX: math.Pi / 4,
+			wantY: -math.Log(math.Tan(3*math.Pi/8)) / 2,
+		},
+	} {
+		x, y := test.proj.Project(test.lon, test.lat)
+		if !floats.EqualWithinAbsOrRel(x, test.wantX, 1e-7, tol) || !floats.EqualWithinAbsOrRel(y, test.wantY, 1e-7, tol) {
+			t.Errorf("unexpected projection of (%v, %v) by %#v: got:(%v, %v) want:(%v, %v)",
+				test.lon, test.lat, test.proj, x, y, test.wantX, test.wantY)
+		}
+	}
+}
+
+type lonLatGrid struct{ mat.Matrix }
+
+func (g lonLatGrid) Dims() (c, r int)   { r, c = g.Matrix.Dims(); return c, r }
+func (g lonLatGrid) Z(c, r int) float64 { return g.Matrix.At(r, c) }
+func (g lonLatGrid) X(c int) float64    { return -180 + 90*float64(c) }
+func (g lonLatGrid) Y(r int) float64    { return -45 + 45*float64(r) }
+
+func TestProjectGrid(t *testing.T) {
+	g := ProjectGrid(lonLatGrid{mat.NewDense(3, 5, nil)}, Equirectangular{})
+	if c, r := g.Dims(); c != 5 || r != 3 {
+		t.Errorf("unexpected dimensions: got:(%d, %d) want:(5, 3)", c, r)
+	}
+	if got := g.X(4); got != math.Pi {
+		t.Errorf("unexpected x coordinate: got:%v want:%v", got, math.Pi)
+	}
+	if got := g.Y(0); got != -math.Pi/4 {
+		t.Errorf("unexpected y coordinate: got:%v want:%v", got, -math.Pi/4)
+	}
+}
@@ -0,0 +1,471 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geo
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// CalmSpeed is the wind speed in knots below which the
+// wind is calm and drawn as a circle rather than a barb.
+const CalmSpeed = 2.5
+
+// DefaultBarbLength is the default length of the staffs
+// of wind barbs.
+var DefaultBarbLength = vg.Points(20)
+
+// DefaultStationRadius is the default radius of the
+// circles of station models.
+var DefaultStationRadius = vg.Points(4)
+
+// Wind is a wind observation at a location.
+type Wind struct {
+	// Point is the location of the observation. If
+	// the wind is drawn without a projection, Lon and
+	// Lat are its x and y coordinates on the plot.
+	Point
+
+	// Speed is the wind speed in knots.
+	Speed float64
+
+	// Direction is the direction from which the wind
+	// blows, in degrees clockwise from north.
+	Direction float64
+}
+
+// WindBarbs implements the plot.Plotter interface, drawing
+// wind observations over a map or a grid as wind barbs. The
+// staff of each barb points into the wind, with a pennant for
+// each 50 knots, a full barb for each 10 knots and a half barb
+// for 5 knots of the speed rounded to the nearest 5 knots.
+// Calm winds are drawn as circles. Winds at locations outside
+// the data area are not drawn.
+type WindBarbs struct {
+	// Length is the length of the staffs of the barbs.
+	Length vg.Length
+
+	// CalmRadius is the radius of the circles
+	// drawn for calm winds.
+	CalmRadius vg.Length
+
+	// LineStyle is the style of the barbs. Pennants
+	// are filled with its color.
+	draw.LineStyle
+
+	winds []Wind
+	pos   windPositions
+}
+
+// NewWindBarbs returns WindBarbs drawing the winds, with their
+// locations projected using p, with the default line style and
+// barb length. If p is nil, the locations are the coordinates
+// of the winds on the plot and north is up. An error is
+// returned if a projected coordinate is NaN or infinite.
+func NewWindBarbs(p Projection, ws []Wind) (*WindBarbs, error) {
+	pos, err := newWindPositions(p, ws)
+	if err != nil {
+		return nil, err
+	}
+	return &WindBarbs{
+		Length:     DefaultBarbLength,
+		CalmRadius: DefaultStationRadius,
+		LineStyle:  plotter.DefaultLineStyle,
+		winds:      append([]Wind(nil), ws...),
+		pos:        pos,
+	}, nil
+}
+
+// Plot draws the WindBarbs, implementing the plot.Plotter
+// interface.
+func (b *WindBarbs) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for i, w := range b.winds {
+		pt, up := b.pos.canvas(i, w.Direction, trX, trY)
+		if !c.Contains(pt) {
+			continue
+		}
+		if w.Speed < CalmSpeed {
+			c.StrokeLines(b.LineStyle, circle(pt, b.CalmRadius))
+			continue
+		}
+		drawBarb(&c, b.LineStyle, pt, up, b.Length, w.Speed, b.pos.flip(i))
+	}
+}
+
+// DataRange returns the minimum and maximum projected x and
+// y values of the locations of the winds, implementing the
+// plot.DataRanger interface.
+func (b *WindBarbs) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return plotter.XYRange(b.pos.at)
+}
+
+// GlyphBoxes returns a glyph box at the location of each
+// wind bounding its barb in any direction, implementing the
+// plot.GlyphBoxer interface.
+func (b *WindBarbs) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	return windGlyphBoxes(plt, b.pos.at, barbReach(b.Length))
+}
+
+// Thumbnail draws a barb of 10 knots across the thumbnail,
+// implementing the plot.Thumbnailer interface.
+func (b *WindBarbs) Thumbnail(c *draw.Canvas) {
+	drawThumbnailBarb(c, b.LineStyle)
+}
+
+// Station is a meteorological observation at a
+// weather station.
+type Station struct {
+	// Wind is the wind observed at the station.
+	Wind
+
+	// Temperature and DewPoint are the air and dew
+	// point temperatures. They are not drawn if NaN.
+	Temperature, DewPoint float64
+
+	// Pressure is the sea level pressure in
+	// hectopascals, drawn as its last three digits
+	// in tenths of a hectopascal. It is not drawn
+	// if NaN.
+	Pressure float64
+
+	// CloudCover is the fraction of the sky covered
+	// by cloud, from 0 to 1. If it is NaN, the sky is
+	// obscured or was not observed.
+	CloudCover float64
+}
+
+// StationModels implements the plot.Plotter interface, drawing
+// observations at weather stations over a map or a grid as
+// station models. Each station is drawn as a circle, filled
+// clockwise from the top in proportion to the cloud cover or
+// crossed if it is unknown, with a wind barb pointing into the
+// wind from the circle. The temperature is written to the upper
+// left of the circle, the dew point to the lower left and the
+// pressure to the upper right. Stations at locations outside
+// the data area are not drawn.
+type StationModels struct {
+	// Radius is the radius of the station circles.
+	Radius vg.Length
+
+	// BarbLength is the length of the staffs
+	// of the wind barbs.
+	BarbLength vg.Length
+
+	// LineStyle is the style of the station circles
+	// and wind barbs. Cloud cover and pennants are
+	// filled with its color.
+	draw.LineStyle
+
+	// TextStyle is the style of the observed values.
+	TextStyle draw.TextStyle
+
+	stations []Station
+	pos      windPositions
+}
+
+// NewStationModels returns StationModels drawing the stations,
+// with their locations projected using p, with the default line
+// style, the default font, the default station radius and the
+// default barb length. If p is nil, the locations are the
+// coordinates of the stations on the plot and north is up. An
+// error is returned if a projected coordinate is NaN or infinite.
+func NewStationModels(p Projection, ss []Station) (*StationModels, error) {
+	ws := make([]Wind, len(ss))
+	for i, s := range ss {
+		ws[i] = s.Wind
+	}
+	pos, err := newWindPositions(p, ws)
+	if err != nil {
+		return nil, err
+	}
+	fnt, err := vg.MakeFont(plotter.DefaultFont, plotter.DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &StationModels{
+		Radius:     DefaultStationRadius,
+		BarbLength: DefaultBarbLength,
+		LineStyle:  plotter.DefaultLineStyle,
+		TextStyle:  draw.TextStyle{Font: fnt},
+		stations:   append([]Station(nil), ss...),
+		pos:        pos,
+	}, nil
+}
+
+// Plot draws the StationModels, implementing the plot.Plotter
+// interface.
+func (m *StationModels) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for i, s := range m.stations {
+		pt, up := m.pos.canvas(i, s.Direction, trX, trY)
+		if !c.Contains(pt) {
+			continue
+		}
+		m.drawCircle(&c, pt, m.Radius, s.CloudCover)
+		if s.Speed < CalmSpeed {
+			c.StrokeLines(m.LineStyle, circle(pt, 1.6*m.Radius))
+		} else {
+			drawBarb(&c, m.LineStyle, pt.Add(up.Scale(m.Radius)), up, m.BarbLength, s.Speed, m.pos.flip(i))
+		}
+
+		gap := m.Radius / 2
+		left, right := pt.X-m.Radius-gap, pt.X+m.Radius+gap
+		if !math.IsNaN(s.Temperature) {
+			m.text(&c, vg.Point{X: left, Y: pt.Y + gap}, draw.XRight, draw.YBottom, fmt.Sprintf("%.0f", s.Temperature))
+		}
+		if !math.IsNaN(s.DewPoint) {
+			m.text(&c, vg.Point{X: left, Y: pt.Y - gap}, draw.XRight, draw.YTop, fmt.Sprintf("%.0f", s.DewPoint))
+		}
+		if !math.IsNaN(s.Pressure) {
+			coded := int(math.Floor(s.Pressure*10+0.5)) % 1000
+			m.text(&c, vg.Point{X: right, Y: pt.Y + gap}, draw.XLeft, draw.YBottom, fmt.Sprintf("%03d", coded))
+		}
+	}
+}
+
+// drawCircle draws a station circle of radius r centered at
+// pt, filled in proportion to the cloud cover.
+func (m *StationModels) drawCircle(c *draw.Canvas, pt vg.Point, r vg.Length, cover float64) {
+	switch {
+	case math.IsNaN(cover):
+		d := r / math.Sqrt2
+		c.StrokeLines(m.LineStyle,
+			[]vg.Point{{X: pt.X - d, Y: pt.Y - d}, {X: pt.X + d, Y: pt.Y + d}},
+			[]vg.Point{{X: pt.X - d, Y: pt.Y + d}, {X: pt.X + d, Y: pt.Y - d}},
+		)
+	case cover >= 1:
+		c.FillPolygon(m.Color, circle(pt, r))
+	case cover > 0:
+		wedge := []vg.Point{pt}
+		for a := 0.0; a < cover*360; a += 10 {
+			wedge = append(wedge, onCircle(pt, r, a))
+		}
+		wedge = append(wedge, onCircle(pt, r, cover*360))
+		c.FillPolygon(m.Color, wedge)
+	}
+	c.StrokeLines(m.LineStyle, circle(pt, r))
+}
+
+// text writes txt at pt with the given alignment.
+func (m *StationModels) text(c *draw.Canvas, pt vg.Point, xalign draw.XAlignment, yalign draw.YAlignment, txt string) {
+	sty := m.TextStyle
+	sty.XAlign = xalign
+	sty.YAlign = yalign
+	c.FillText(sty, pt, txt)
+}
+
+// DataRange returns the minimum and maximum projected x and
+// y values of the locations of the stations, implementing the
+// plot.DataRanger interface.
+func (m *StationModels) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return plotter.XYRange(m.pos.at)
+}
+
+// GlyphBoxes returns a glyph box at the location of each
+// station bounding its circle and wind barb in any direction,
+// implementing the plot.GlyphBoxer interface.
+func (m *StationModels) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	return windGlyphBoxes(plt, m.pos.at, m.Radius+barbReach(m.BarbLength))
+}
+
+// Thumbnail draws a half covered station circle,
+// implementing the plot.Thumbnailer interface.
+func (m *StationModels) Thumbnail(c *draw.Canvas) {
+	size := c.Size()
+	r := m.Radius
+	if max := size.Y / 2; r > max {
+		r = max
+	}
+	m.drawCircle(c, c.Center(), r, 0.5)
+}
+
+// Proportions of the features of wind barbs to the
+// lengths of their staffs.
+const (
+	// barbHeight is the length of full barbs
+	// and the width of pennants.
+	barbHeight = 0.4
+
+	// barbSpacing is the spacing of barbs along
+	// the staff and the length of pennants.
+	barbSpacing = 0.15
+)
+
+// drawBarb draws a wind barb for the speed in knots, with a
+// staff of the given length from start along the unit vector
+// up. Barbs and pennants are drawn on the right of the staff,
+// looking along it, or on its left if flip is true, as is the
+// convention in the southern hemisphere.
+func drawBarb(c *draw.Canvas, sty draw.LineStyle, start, up vg.Point, length vg.Length, speed float64, flip bool) {
+	side := vg.Point{X: up.Y, Y: -up.X}
+	if flip {
+		side = side.Scale(-1)
+	}
+	var (
+		height  = barbHeight * length
+		spacing = barbSpacing * length
+		tip     = start.Add(up.Scale(length))
+	)
+	// feather returns the end of a barb of length h from pt,
+	// leaning away from the start of the staff.
+	feather := func(pt vg.Point, h vg.Length) vg.Point {
+		return pt.Add(side.Scale(h)).Add(up.Scale(h / 2))
+	}
+
+	fives := int(math.Floor(speed/5 + 0.5))
+	pennants, fives := fives/10, fives%10
+	barbs, halves := fives/2, fives%2
+
+	lines := [][]vg.Point{{start, tip}}
+	pt := tip
+	for i := 0; i < pennants; i++ {
+		next := pt.Sub(up.Scale(spacing))
+		c.FillPolygon(sty.Color, []vg.Point{pt, pt.Add(side.Scale(height)), next})
+		pt = next
+	}
+	if pennants > 0 {
+		pt = pt.Sub(up.Scale(spacing / 2))
+	}
+	for i := 0; i < barbs; i++ {
+		lines = append(lines, []vg.Point{pt, feather(pt, height)})
+		pt = pt.Sub(up.Scale(spacing))
+	}
+	if halves > 0 {
+		if pennants == 0 && barbs == 0 {
+			// A lone half barb is set in from the
+			// tip to tell it from a full barb.
+			pt = pt.Sub(up.Scale(spacing))
+		}
+		lines = append(lines, []vg.Point{pt, feather(pt, height/2)})
+	}
+	c.StrokeLines(sty, lines...)
+}
+
+// barbReach returns the greatest distance from the start of
+// the staff of a wind barb of the given length to its barbs.
+func barbReach(length vg.Length) vg.Length {
+	return vg.Length(math.Hypot(1+barbHeight/2, barbHeight)) * length
+}
+
+// drawThumbnailBarb draws a barb of 10 knots across the
+// thumbnail canvas c.
+func drawThumbnailBarb(c *draw.Canvas, sty draw.LineStyle) {
+	size := c.Size()
+	length := size.X
+	if max := size.Y / barbHeight; length > max {
+		length = max
+	}
+	start := vg.Point{X: c.Min.X, Y: c.Center().Y - barbHeight*length/2}
+	drawBarb(c, sty, start, vg.Point{X: 1}, length, 10, true)
+}
+
+// windGlyphBoxes returns square glyph boxes with the given
+// half width at the locations xys.
+func windGlyphBoxes(plt *plot.Plot, xys plotter.XYs, half vg.Length) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(xys))
+	for i, p := range xys {
+		bs[i] = plot.GlyphBox{
+			X: plt.X.Norm(p.X),
+			Y: plt.Y.Norm(p.Y),
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{X: -half, Y: -half},
+				Max: vg.Point{X: half, Y: half},
+			},
+		}
+	}
+	return bs
+}
+
+// upwindStep is the distance in degrees from the location of
+// a wind to the point upwind of it from which the direction of
+// the wind on a map is found.
+const upwindStep = 1e-3
+
+// windPositions holds the projected locations of a set of
+// winds, and of points a short distance upwind of them, so
+// that the winds are drawn along the meridians and parallels
+// of the projection.
+type windPositions struct {
+	at, upwind plotter.XYs
+	south      []bool
+}
+
+// newWindPositions returns the positions of the winds ws
+// projected using p, or on the plot if p is nil.
+func newWindPositions(p Projection, ws []Wind) (windPositions, error) {
+	at := make(plotter.XYs, len(ws))
+	for i, w := range ws {
+		at[i].X, at[i].Y = w.Lon, w.Lat
+	}
+	var (
+		pos windPositions
+		err error
+	)
+	if p == nil {
+		pos.at, err = plotter.CopyXYs(at)
+		return pos, err
+	}
+
+	upwind := make(plotter.XYs, len(ws))
+	pos.south = make([]bool, len(ws))
+	for i, w := range ws {
+		sin, cos := math.Sincos(radians(w.Direction))
+		upwind[i].X = w.Lon + upwindStep*sin/math.Cos(radians(w.Lat))
+		upwind[i].Y = w.Lat + upwindStep*cos
+		pos.south[i] = w.Lat < 0
+	}
+	pos.at, err = plotter.CopyXYs(Projected{XYer: at, Projection: p})
+	if err != nil {
+		return pos, err
+	}
+	pos.upwind, err = plotter.CopyXYs(Projected{XYer: upwind, Projection: p})
+	return pos, err
+}
+
+// canvas returns the location on the canvas of the i'th wind,
+// blowing from the direction dir, and the unit vector pointing
+// into the wind from it.
+func (pos windPositions) canvas(i int, dir float64, trX, trY func(float64) vg.Length) (pt, up vg.Point) {
+	pt = vg.Point{X: trX(pos.at[i].X), Y: trY(pos.at[i].Y)}
+	if pos.upwind != nil {
+		d := vg.Point{X: trX(pos.upwind[i].X), Y: trY(pos.upwind[i].Y)}.Sub(pt)
+		if n := math.Hypot(float64(d.X), float64(d.Y)); n > 0 {
+			return pt, d.Scale(vg.Length(1 / n))
+		}
+	}
+	sin, cos := math.Sincos(radians(dir))
+	return pt, vg.Point{X: vg.Length(sin), Y: vg.Length(cos)}
+}
+
+// flip returns whether the barbs of the i'th wind are
+// drawn on the left of their staff, for winds in the
+// southern hemisphere.
+func (pos windPositions) flip(i int) bool {
+	return pos.south != nil && pos.south[i]
+}
+
+// circle returns a closed line approximating the circle
+// of radius r centered at ctr.
+func circle(ctr vg.Point, r vg.Length) []vg.Point {
+	pts := make([]vg.Point, 0, 37)
+	for a := 0.0; a <= 360; a += 10 {
+		pts = append(pts, onCircle(ctr, r, a))
+	}
+	return pts
+}
+
+// onCircle returns the point on the circle of radius r
+// centered at ctr at the angle deg in degrees clockwise
+// from the top.
+func onCircle(ctr vg.Point, r vg.Length, deg float64) vg.Point {
+	sin, cos := math.Sincos(radians(deg))
+	return vg.Point{X: ctr.X + r*vg.Length(sin), Y: ctr.Y + r*vg.Length(cos)}
+}
@@ -0,0 +1,230 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geo_test
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/geo"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+// ExampleWindBarbs draws the winds blowing around a low
+// pressure system on a Lambert conformal projection. The
+// barbs follow the converging meridians of the projection.
+func ExampleWindBarbs() {
+	proj := geo.LambertConformal{Lon0: -100, Lat0: 45, Lat1: 33, Lat2: 45}
+
+	var winds []geo.Wind
+	for lon := -125.0; lon <= -75; lon += 5 {
+		for lat := 30.0; lat <= 60; lat += 5 {
+			// The wind blows counterclockwise about
+			// the low, turning in toward its center,
+			// and is strongest away from it.
+			dx, dy := lon+100, lat-45
+			r := math.Hypot(dx, dy)
+			vx, vy := -dy-0.3*dx, dx-0.3*dy
+			winds = append(winds, geo.Wind{
+				Point:     geo.Point{Lon: lon, Lat: lat},
+				Speed:     3 * r,
+				Direction: math.Atan2(-vx, -vy) * 180 / math.Pi,
+			})
+		}
+	}
+	b, err := geo.NewWindBarbs(proj, winds)
+	if err != nil {
+		log.Panic(err)
+	}
+	b.LineStyle = draw.LineStyle{Color: color.RGBA{B: 160, A: 255}, Width: vg.Points(1)}
+	b.Length = vg.Points(16)
+
+	g := geo.NewGraticule(proj)
+	g.Step = geo.Point{Lon: 10, Lat: 10}
+	g.Min = geo.Point{Lon: -130, Lat: 25}
+	g.Max = geo.Point{Lon: -70, Lat: 65}
+	g.LineStyle = draw.LineStyle{Color: color.Gray{Y: 200}, Width: vg.Points(0.5)}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Winds about a low"
+	p.HideAxes()
+	p.DataAspect = 1
+	p.Add(g, b)
+
+	err = p.Save(12*vg.Centimeter, 10*vg.Centimeter, "testdata/windBarbs.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestWindBarbsPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleWindBarbs, t, "windBarbs.png")
+}
+
+// ExampleStationModels draws the observations at weather
+// stations on some islands. Barbs are drawn on the left of
+// their staffs in the southern hemisphere.
+func ExampleStationModels() {
+	features, err := geo.ReadGeoJSON(strings.NewReader(islands))
+	if err != nil {
+		log.Panic(err)
+	}
+	proj := geo.Mercator{}
+
+	m, err := geo.NewMap(proj, features...)
+	if err != nil {
+		log.Panic(err)
+	}
+	m.Color = color.RGBA{R: 196, G: 220, B: 160, A: 255}
+	m.LineStyle = draw.LineStyle{Color: color.Gray{Y: 96}, Width: vg.Points(0.5)}
+
+	stations := []geo.Station{
+		{
+			Wind:        geo.Wind{Point: geo.Point{Lon: -20, Lat: 58}, Speed: 15, Direction: 330},
+			Temperature: 12, DewPoint: 8, Pressure: 1013.2, CloudCover: 0.75,
+		},
+		{
+			Wind:        geo.Wind{Point: geo.Point{Lon: 5, Lat: 45}, Speed: 55, Direction: 200},
+			Temperature: 18, DewPoint: 16, Pressure: 996.4, CloudCover: 1,
+		},
+		{
+			Wind:        geo.Wind{Point: geo.Point{Lon: 50, Lat: 5}, Speed: 1},
+			Temperature: 29, DewPoint: 24, Pressure: 1008.9, CloudCover: 0.25,
+		},
+		{
+			Wind:        geo.Wind{Point: geo.Point{Lon: 90, Lat: -38}, Speed: 25, Direction: 150},
+			Temperature: 9, DewPoint: math.NaN(), Pressure: 1021.0, CloudCover: math.NaN(),
+		},
+	}
+	s, err := geo.NewStationModels(proj, stations)
+	if err != nil {
+		log.Panic(err)
+	}
+	s.LineStyle = draw.LineStyle{Color: color.Black, Width: vg.Points(1)}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Station observations"
+	p.HideAxes()
+	p.DataAspect = 1
+	p.BackgroundColor = color.RGBA{R: 200, G: 225, B: 255, A: 255}
+	p.Add(m, s)
+
+	err = p.Save(12*vg.Centimeter, 12*vg.Centimeter, "testdata/stationModels.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestStationModelsPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleStationModels, t, "stationModels.png")
+}
+
+func TestWindBarbs(t *testing.T) {
+	// Without a projection, a northerly wind has its
+	// staff pointing up the plot. A speed of 65 knots is
+	// drawn as a pennant, a full barb and a half barb.
+	b, err := geo.NewWindBarbs(nil, []geo.Wind{{Point: geo.Point{Lon: 1, Lat: 1}, Speed: 64, Direction: 0}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.HideAxes()
+	p.Add(b)
+	p.X.Min, p.X.Max = 0, 2
+	p.Y.Min, p.Y.Max = 0, 2
+	var rec recorder.Canvas
+	p.Draw(draw.NewCanvas(&rec, 100, 100))
+
+	var (
+		fills   int
+		strokes []vg.Path
+		width   vg.Length
+	)
+	for _, a := range rec.Actions {
+		switch a := a.(type) {
+		case *recorder.SetLineWidth:
+			width = a.Width
+		case *recorder.Fill:
+			// The first fill is the background.
+			fills++
+		case *recorder.Stroke:
+			if width > 0 {
+				strokes = append(strokes, a.Path)
+			}
+		}
+	}
+	if fills != 2 {
+		t.Errorf("unexpected number of pennants: got:%d want:1", fills-1)
+	}
+	if len(strokes) != 3 {
+		t.Fatalf("unexpected number of strokes: got:%d want:3", len(strokes))
+	}
+	staff := strokes[0][1].Pos.Sub(strokes[0][0].Pos)
+	want := vg.Point{Y: geo.DefaultBarbLength}
+	if staff != want {
+		t.Errorf("unexpected staff: got:%v want:%v", staff, want)
+	}
+
+	// On a projection, the barbs of winds in the
+	// southern hemisphere are the mirror images of
+	// those in the northern hemisphere.
+	winds := []geo.Wind{
+		{Point: geo.Point{Lon: 10, Lat: 30}, Speed: 10, Direction: 90},
+		{Point: geo.Point{Lon: 10, Lat: -30}, Speed: 10, Direction: 90},
+	}
+	b, err = geo.NewWindBarbs(geo.Equirectangular{}, winds)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p, err = plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.HideAxes()
+	p.Add(b)
+	rec = recorder.Canvas{}
+	p.Draw(draw.NewCanvas(&rec, 200, 200))
+	var barbs []vg.Point
+	width = 0
+	for _, a := range rec.Actions {
+		switch a := a.(type) {
+		case *recorder.SetLineWidth:
+			width = a.Width
+		case *recorder.Stroke:
+			// The barbs are the second of each
+			// pair of strokes.
+			if width > 0 {
+				barbs = append(barbs, a.Path[1].Pos.Sub(a.Path[0].Pos))
+			}
+		}
+	}
+	if len(barbs) != 4 {
+		t.Fatalf("unexpected number of strokes: got:%d want:4", len(barbs))
+	}
+	north, south := barbs[1], barbs[3]
+	if north.Y >= 0 || south.Y <= 0 || math.Abs(float64(north.X-south.X)) > 1e-9 {
+		t.Errorf("unexpected barbs: north:%v south:%v", north, south)
+	}
+
+	if _, err := geo.NewWindBarbs(geo.Mercator{}, []geo.Wind{{Point: geo.Point{Lon: math.NaN()}}}); err == nil {
+		t.Errorf("expected error for NaN location")
+	}
+}
@@ -8,6 +8,7 @@ require (
 	github.com/llgcode/ps v0.0.0-20150911083025-f1443b32eedb // indirect
 	golang.org/x/exp v0.0.0-20180321215751-8460e604b9de
 	golang.org/x/image v0.0.0-20180708004352-c73c2afc3b81
+	golang.org/x/text v0.3.0
 	gonum.org/v1/gonum v0.0.0-20180716103638-023b8e605abb
 	rsc.io/pdf v0.1.1
 )
@@ -0,0 +1,269 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"image"
+	imdraw "image/draw"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// Layers renders a Plot as a stack of raster layers so
+// that repeated renderings, as in interactive or live
+// plots, only redraw the layers that have changed.
+//
+// The base layer holds the background, title, axes and
+// any plotters added directly to the Plot. It is drawn
+// beneath the layers added with Add, and the legend is
+// drawn above them. The base and legend layers, and all
+// static layers, are cached between calls to Render
+// until they are invalidated. Cached layers holding
+// plotters that implement Versioner are invalidated
+// automatically when the version of any of their
+// plotters changes.
+type Layers struct {
+	// Plot provides the background, title, axes
+	// and legend of the rendering.
+	Plot *Plot
+
+	// AutoRange specifies whether the axis ranges of
+	// the Plot are extended by Render to fit the data
+	// of plotters whose version has changed. As for
+	// Plot.Add, the axis ranges are only ever grown.
+	// All layers are redrawn when the ranges change.
+	AutoRange bool
+
+	layers []*Layer
+
+	w, h vg.Length
+	dpi  int
+
+	base, legend *image.RGBA
+
+	// baseVersions holds the versions of the
+	// plotters of the Plot when the base layer
+	// was last drawn.
+	baseVersions []uint64
+}
+
+// A Layer is a group of Plotters that are drawn
+// together by a Layers.
+type Layer struct {
+	// Static specifies whether the rendering of the
+	// layer is cached between calls to Render. Static
+	// layers must be invalidated when their plotters
+	// change. Layers that are not static are redrawn
+	// on every call to Render.
+	Static bool
+
+	plot     *Plot
+	plotters []Plotter
+	img      *image.RGBA
+	valid    bool
+
+	// versions holds the versions of the plotters
+	// when they were last fitted or drawn.
+	versions []uint64
+}
+
+// NewLayers returns a Layers that renders p to an
+// image of the given size and resolution.
+func NewLayers(p *Plot, w, h vg.Length, dpi int) *Layers {
+	return &Layers{Plot: p, w: w, h: h, dpi: dpi}
+}
+
+// Add adds a new layer holding the given plotters above
+// the existing layers and returns it. The axis ranges of
+// the Plot are extended to fit the plotters as for
+// Plot.Add.
+func (ls *Layers) Add(static bool, ps ...Plotter) *Layer {
+	l := &Layer{Static: static, plot: ls.Plot}
+	l.Add(ps...)
+	ls.layers = append(ls.layers, l)
+	ls.Invalidate()
+	return l
+}
+
+// Invalidate discards all cached layers. Invalidate must
+// be called after changing the Plot, for example its
+// axis ranges, since every layer depends on the layout
+// of the Plot.
+func (ls *Layers) Invalidate() {
+	ls.base = nil
+	ls.legend = nil
+	for _, l := range ls.layers {
+		l.Invalidate()
+	}
+}
+
+// Add adds plotters to the layer, extending the axis
+// ranges of the layer's Plot to fit them, and
+// invalidates the layer.
+func (l *Layer) Add(ps ...Plotter) {
+	l.plot.fit(ps...)
+	l.plotters = append(l.plotters, ps...)
+	l.versions = versions(l.versions[:0], l.plotters)
+	l.Invalidate()
+}
+
+// Invalidate discards the cached rendering of the layer
+// so that it is redrawn by the next call to Render.
+func (l *Layer) Invalidate() {
+	l.valid = false
+}
+
+// Render returns the composited rendering of the layers.
+// The returned image is owned by the caller.
+func (ls *Layers) Render() *image.RGBA {
+	ls.update()
+
+	// Layout the plot as if all plotters had been
+	// added to it so that glyphs in any layer are
+	// accounted for when padding the data area.
+	p := *ls.Plot
+	var all glyphBoxers
+	for _, l := range ls.layers {
+		all = append(all, l.plotters...)
+	}
+	p.plotters = append(p.plotters[:len(p.plotters):len(p.plotters)], all)
+
+	if ls.base == nil {
+		base := p
+		base.Legend.entries = nil
+		c := ls.canvas(nil, true)
+		base.Draw(draw.New(c))
+		ls.base = c.Image().(*image.RGBA)
+		ls.baseVersions = versions(ls.baseVersions[:0], ls.Plot.plotters)
+	}
+
+	full := draw.New(ls.canvas(nil, false))
+	dataC := p.DataCanvas(full)
+	for _, l := range ls.layers {
+		if l.Static && l.valid {
+			continue
+		}
+		c := ls.canvas(l.img, false)
+		l.img = c.Image().(*image.RGBA)
+		dc := draw.WithOuter(draw.Canvas{Canvas: c, Rectangle: dataC.Rectangle}, full.Rectangle)
+		for _, d := range l.plotters {
+			d.Plot(dc, &p)
+		}
+		l.valid = true
+		l.versions = versions(l.versions[:0], l.plotters)
+	}
+
+	if ls.legend == nil {
+		c := ls.canvas(nil, false)
+		lc := p.legendCanvas(draw.New(c))
+		l := p.placedLegend(lc, dataC)
+		l.Draw(lc)
+		ls.legend = c.Image().(*image.RGBA)
+	}
+
+	dst := image.NewRGBA(ls.base.Bounds())
+	imdraw.Draw(dst, dst.Bounds(), ls.base, image.ZP, imdraw.Src)
+	for _, l := range ls.layers {
+		imdraw.Draw(dst, dst.Bounds(), l.img, image.ZP, imdraw.Over)
+	}
+	imdraw.Draw(dst, dst.Bounds(), ls.legend, image.ZP, imdraw.Over)
+	return dst
+}
+
+// update invalidates the cached layers holding plotters
+// whose versions have changed and, if AutoRange is set,
+// extends the axis ranges of the Plot to fit them.
+func (ls *Layers) update() {
+	var stale []Plotter
+	if ls.base != nil && changed(ls.baseVersions, ls.Plot.plotters) {
+		ls.base = nil
+		stale = append(stale, ls.Plot.plotters...)
+	}
+	for _, l := range ls.layers {
+		if changed(l.versions, l.plotters) {
+			l.Invalidate()
+			stale = append(stale, l.plotters...)
+		}
+	}
+	if !ls.AutoRange || len(stale) == 0 {
+		return
+	}
+	x, y := ls.Plot.X, ls.Plot.Y
+	ls.Plot.fit(stale...)
+	if x.Min != ls.Plot.X.Min || x.Max != ls.Plot.X.Max || y.Min != ls.Plot.Y.Min || y.Max != ls.Plot.Y.Max {
+		ls.Invalidate()
+	}
+}
+
+// versions appends the versions of the plotters to dst
+// and returns the result. Plotters that do not implement
+// Versioner have version zero.
+func versions(dst []uint64, ps []Plotter) []uint64 {
+	for _, p := range ps {
+		var v uint64
+		if vp, ok := p.(Versioner); ok {
+			v = vp.Version()
+		}
+		dst = append(dst, v)
+	}
+	return dst
+}
+
+// changed returns whether the versions of the plotters
+// differ from the recorded versions.
+func changed(recorded []uint64, ps []Plotter) bool {
+	if len(recorded) != len(ps) {
+		return true
+	}
+	for i, p := range ps {
+		var v uint64
+		if vp, ok := p.(Versioner); ok {
+			v = vp.Version()
+		}
+		if v != recorded[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// canvas returns an image canvas of the rendering size,
+// reusing img if it is not nil. Unless opaque is true
+// the canvas is cleared to transparent.
+func (ls *Layers) canvas(img *image.RGBA, opaque bool) *vgimg.Canvas {
+	var c *vgimg.Canvas
+	if img == nil {
+		c = vgimg.NewWith(vgimg.UseWH(ls.w, ls.h), vgimg.UseDPI(ls.dpi))
+	} else {
+		c = vgimg.NewWith(vgimg.UseImage(img), vgimg.UseDPI(ls.dpi))
+	}
+	if !opaque {
+		img := c.Image()
+		imdraw.Draw(img, img.Bounds(), image.Transparent, image.ZP, imdraw.Src)
+	}
+	return c
+}
+
+// glyphBoxers is a Plotter that draws nothing but
+// provides the GlyphBoxes of the plotters it holds,
+// so that they are accounted for in the layout of
+// a plot without being drawn.
+type glyphBoxers []Plotter
+
+// Plot implements the Plotter interface.
+func (glyphBoxers) Plot(draw.Canvas, *Plot) {}
+
+// GlyphBoxes implements the GlyphBoxer interface.
+func (g glyphBoxers) GlyphBoxes(p *Plot) []GlyphBox {
+	var boxes []GlyphBox
+	for _, d := range g {
+		if gb, ok := d.(GlyphBoxer); ok {
+			boxes = append(boxes, gb.GlyphBoxes(p)...)
+		}
+	}
+	return boxes
+}
@@ -0,0 +1,119 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot_test
+
+import (
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// countingPlotter counts the number of times it is drawn.
+type countingPlotter struct {
+	plot.Plotter
+	n int
+}
+
+func (c *countingPlotter) Plot(dc draw.Canvas, p *plot.Plot) {
+	c.n++
+	c.Plotter.Plot(dc, p)
+}
+
+func TestLayers(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	line, err := plotter.NewLine(plotter.XYs{{0, 0}, {1, 1}, {2, 0}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scatter, err := plotter.NewScatter(plotter.XYs{{1, 0.5}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	static := &countingPlotter{Plotter: plotter.NewGrid()}
+	dynamic := &countingPlotter{Plotter: scatter}
+
+	ls := plot.NewLayers(p, 5*vg.Centimeter, 5*vg.Centimeter, 96)
+	sl := ls.Add(true, static)
+	ls.Add(false, dynamic, line)
+	if p.X.Min != 0 || p.X.Max != 2 {
+		t.Errorf("unexpected x range: got:[%v %v] want:[0 2]", p.X.Min, p.X.Max)
+	}
+
+	const frames = 5
+	for i := 0; i < frames; i++ {
+		img := ls.Render()
+		if img.Bounds().Dx() == 0 || img.Bounds().Dy() == 0 {
+			t.Fatalf("unexpected empty image")
+		}
+	}
+	if static.n != 1 {
+		t.Errorf("unexpected number of static draws: got:%d want:1", static.n)
+	}
+	if dynamic.n != frames {
+		t.Errorf("unexpected number of dynamic draws: got:%d want:%d", dynamic.n, frames)
+	}
+
+	sl.Invalidate()
+	ls.Render()
+	if static.n != 2 {
+		t.Errorf("unexpected number of static draws after Invalidate: got:%d want:2", static.n)
+	}
+}
+
+func TestLayersVersion(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stream, err := plotter.NewStreamLine(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stream.Append(0, 0)
+	stream.Append(1, 1)
+	grid := &countingPlotter{Plotter: plotter.NewGrid()}
+	data := &countingPlotter{Plotter: stream}
+
+	ls := plot.NewLayers(p, 5*vg.Centimeter, 5*vg.Centimeter, 96)
+	ls.AutoRange = true
+	ls.Add(true, grid)
+	// The countingPlotter hides the Version method
+	// of the stream, so the stream is added as well.
+	ls.Add(true, stream, data)
+
+	ls.Render()
+	ls.Render()
+	if grid.n != 1 || data.n != 1 {
+		t.Errorf("unexpected number of draws of unchanged data: got:%d,%d want:1,1", grid.n, data.n)
+	}
+
+	// A change within the axis ranges only
+	// redraws the layer holding the data.
+	stream.Append(0.5, 0.5)
+	ls.Render()
+	if grid.n != 1 || data.n != 2 {
+		t.Errorf("unexpected number of draws after change: got:%d,%d want:1,2", grid.n, data.n)
+	}
+	if p.X.Max != 1 || p.Y.Max != 1 {
+		t.Errorf("unexpected axis maxima: got:%v,%v want:1,1", p.X.Max, p.Y.Max)
+	}
+
+	// A change outside the axis ranges extends
+	// them and redraws all layers.
+	stream.Append(2, 3)
+	ls.Render()
+	if grid.n != 2 || data.n != 3 {
+		t.Errorf("unexpected number of draws after range change: got:%d,%d want:2,3", grid.n, data.n)
+	}
+	if p.X.Max != 2 || p.Y.Max != 3 {
+		t.Errorf("unexpected axis maxima: got:%v,%v want:2,3", p.X.Max, p.Y.Max)
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package layout provides figure layouts that split a canvas into
+// named regions, into which plots or other drawings are drawn.
+//
+// A layout is a tree of Nodes. Each Node is a rectangular region,
+// which may be split into a row or a column of child regions. The
+// size of each child along the direction of the split is either
+// fixed or a weighted share of the space left over by the fixed
+// sizes and padding, so that, for example, a figure may have a
+// title strip of fixed height above a row of two plots, the first
+// twice as wide as the second:
+//
+//	root := layout.Column(layout.Weighted(1), 0,
+//		layout.Region("title", layout.Fixed(vg.Points(20))),
+//		layout.Row(layout.Weighted(1), vg.Points(5),
+//			layout.Region("left", layout.Weighted(2)),
+//			layout.Region("right", layout.Weighted(1)),
+//		),
+//	)
+package layout // import "gonum.org/v1/plot/layout"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Size is the size of a region along the direction in which
+// its parent is split. A Size with zero Fixed and Weight
+// values has a weight of one.
+type Size struct {
+	// Fixed is the fixed length of the region.
+	Fixed vg.Length
+
+	// Weight is the region's share of the space left
+	// after the fixed lengths and padding of its parent's
+	// children are removed, relative to the weights of
+	// the other children. Weight is ignored if Fixed is
+	// not zero.
+	Weight float64
+}
+
+// Fixed returns a Size with the fixed length l.
+func Fixed(l vg.Length) Size { return Size{Fixed: l} }
+
+// Weighted returns a Size with the weight w.
+func Weighted(w float64) Size { return Size{Weight: w} }
+
+// weight returns the weight of s.
+func (s Size) weight() float64 {
+	if s.Fixed != 0 {
+		return 0
+	}
+	if s.Weight == 0 {
+		return 1
+	}
+	return s.Weight
+}
+
+// Direction is the direction in which a region is split.
+type Direction int
+
+const (
+	// Horizontal splits a region into a row of children
+	// ordered from left to right.
+	Horizontal Direction = iota
+
+	// Vertical splits a region into a column of children
+	// ordered from top to bottom.
+	Vertical
+)
+
+// Node is a region of a layout.
+type Node struct {
+	// Name is the name of the region. Regions without
+	// a name are not returned by Split.
+	Name string
+
+	// Size is the size of the region within its parent.
+	Size Size
+
+	// Direction is the direction in which the region
+	// is split among its Children.
+	Direction Direction
+
+	// Children are the regions into which the region
+	// is split. A region without children is a leaf.
+	Children []*Node
+
+	// Pad is the space between adjacent children.
+	Pad vg.Length
+}
+
+// Region returns a leaf region with the given name and size.
+func Region(name string, s Size) *Node {
+	return &Node{Name: name, Size: s}
+}
+
+// Row returns an unnamed region with the given size, split into
+// a row of children separated by pad.
+func Row(s Size, pad vg.Length, children ...*Node) *Node {
+	return &Node{Size: s, Direction: Horizontal, Children: children, Pad: pad}
+}
+
+// Column returns an unnamed region with the given size, split
+// into a column of children separated by pad.
+func Column(s Size, pad vg.Length, children ...*Node) *Node {
+	return &Node{Size: s, Direction: Vertical, Children: children, Pad: pad}
+}
+
+// Split returns the canvases of the named regions of the layout
+// rooted at n when it fills c, keyed by name. The size of n itself
+// is ignored. An error is returned if two regions have the same name,
+// or the fixed sizes and padding of the children of a region exceed
+// its size.
+func (n *Node) Split(c draw.Canvas) (map[string]draw.Canvas, error) {
+	regions := make(map[string]draw.Canvas)
+	err := n.split(c, regions)
+	if err != nil {
+		return nil, err
+	}
+	return regions, nil
+}
+
+// split adds the canvases of n and its descendants, filling c,
+// to regions.
+func (n *Node) split(c draw.Canvas, regions map[string]draw.Canvas) error {
+	if n.Name != "" {
+		if _, exists := regions[n.Name]; exists {
+			return fmt.Errorf("layout: duplicate region name %q", n.Name)
+		}
+		regions[n.Name] = c
+	}
+	if len(n.Children) == 0 {
+		return nil
+	}
+
+	size := c.Max.X - c.Min.X
+	if n.Direction == Vertical {
+		size = c.Max.Y - c.Min.Y
+	}
+	free := size - vg.Length(len(n.Children)-1)*n.Pad
+	var weights float64
+	for _, ch := range n.Children {
+		free -= ch.Size.Fixed
+		weights += ch.Size.weight()
+	}
+	if free < 0 {
+		return errors.New("layout: fixed sizes exceed region")
+	}
+
+	var offset vg.Length
+	for _, ch := range n.Children {
+		l := ch.Size.Fixed
+		if w := ch.Size.weight(); w != 0 {
+			l = free * vg.Length(w/weights)
+		}
+		sub := c
+		switch n.Direction {
+		case Horizontal:
+			sub.Min.X = c.Min.X + offset
+			sub.Max.X = sub.Min.X + l
+		case Vertical:
+			sub.Max.Y = c.Max.Y - offset
+			sub.Min.Y = sub.Max.Y - l
+		default:
+			panic("layout: unknown direction")
+		}
+		if err := ch.split(sub, regions); err != nil {
+			return err
+		}
+		offset += l + n.Pad
+	}
+	return nil
+}
+
+// Drawer is the interface implemented by content that can be
+// drawn into a region, such as a *plot.Plot.
+type Drawer interface {
+	Draw(draw.Canvas)
+}
+
+// DrawerFunc is a function drawing directly to a canvas.
+// It implements the Drawer interface.
+type DrawerFunc func(draw.Canvas)
+
+// Draw implements the Draw method of the Drawer interface.
+func (f DrawerFunc) Draw(c draw.Canvas) { f(c) }
+
+// Figure draws content into the named regions of a layout.
+type Figure struct {
+	// Layout is the layout of the figure.
+	Layout *Node
+
+	// Content holds the content drawn into each
+	// region, keyed by region name.
+	Content map[string]Drawer
+}
+
+// NewFigure returns a Figure with the given layout and no
+// content.
+func NewFigure(root *Node) *Figure {
+	return &Figure{Layout: root, Content: make(map[string]Drawer)}
+}
+
+// Set sets the content of the named region to d.
+func (f *Figure) Set(name string, d Drawer) {
+	f.Content[name] = d
+}
+
+// Draw draws the content of each region of the figure, filling
+// c. Content is drawn in the order of its region's name, so that
+// drawing is deterministic. An error is returned if the layout
+// cannot be split or content is set for a region not in the
+// layout.
+func (f *Figure) Draw(c draw.Canvas) error {
+	regions, err := f.Layout.Split(c)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(f.Content))
+	for name := range f.Content {
+		if _, ok := regions[name]; !ok {
+			return fmt.Errorf("layout: no region named %q", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f.Content[name].Draw(regions[name])
+	}
+	return nil
+}
+
+// WriterTo returns an io.WriterTo that will write the figure
+// as the specified image format.
+//
+// Supported formats are:
+//
+//	eps, jpg|jpeg, pdf, png, svg, and tif|tiff.
+func (f *Figure) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
+	c, err := draw.NewFormattedCanvas(w, h, format)
+	if err != nil {
+		return nil, err
+	}
+	err = f.Draw(draw.New(c))
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Save saves the figure to an image file. The file format is
+// determined by the extension.
+//
+// Supported extensions are:
+//
+//	.eps, .jpg, .jpeg, .pdf, .png, .svg, .tif and .tiff.
+func (f *Figure) Save(w, h vg.Length, file string) (err error) {
+	format := strings.ToLower(filepath.Ext(file))
+	if len(format) != 0 {
+		format = format[1:]
+	}
+	c, err := f.WriterTo(w, h, format)
+	if err != nil {
+		return err
+	}
+
+	fd, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		e := fd.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+	_, err = c.WriteTo(fd)
+	return err
+}
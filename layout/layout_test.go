@@ -0,0 +1,178 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package layout_test
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/layout"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ExampleFigure lays out a figure with a title strip above a
+// large plot beside a column of two small plots, with a color
+// key drawn directly in a strip below.
+func ExampleFigure() {
+	root := layout.Column(layout.Weighted(1), vg.Points(4),
+		layout.Region("title", layout.Fixed(vg.Points(20))),
+		layout.Row(layout.Weighted(1), vg.Points(4),
+			layout.Region("main", layout.Weighted(2)),
+			layout.Column(layout.Weighted(1), vg.Points(4),
+				layout.Region("sin", layout.Weighted(1)),
+				layout.Region("cos", layout.Weighted(1)),
+			),
+		),
+		layout.Region("key", layout.Fixed(vg.Points(12))),
+	)
+	f := layout.NewFigure(root)
+
+	fnt, err := vg.MakeFont(plot.DefaultFont, vg.Points(14))
+	if err != nil {
+		log.Panic(err)
+	}
+	f.Set("title", layout.DrawerFunc(func(c draw.Canvas) {
+		sty := draw.TextStyle{Font: fnt, XAlign: draw.XCenter, YAlign: draw.YCenter}
+		c.FillText(sty, c.Center(), "Composed figure")
+	}))
+
+	colors := []color.Color{
+		color.NRGBA{R: 230, G: 97, B: 1, A: 255},
+		color.NRGBA{R: 94, G: 60, B: 153, A: 255},
+	}
+	f.Set("key", layout.DrawerFunc(func(c draw.Canvas) {
+		w := (c.Max.X - c.Min.X) / vg.Length(len(colors))
+		for i, col := range colors {
+			x := c.Min.X + vg.Length(i)*w
+			c.FillPolygon(col, []vg.Point{
+				{X: x, Y: c.Min.Y}, {X: x + w, Y: c.Min.Y},
+				{X: x + w, Y: c.Max.Y}, {X: x, Y: c.Max.Y},
+			})
+		}
+	}))
+
+	for _, region := range []struct {
+		name  string
+		title string
+		fn    func(float64) float64
+	}{
+		{name: "main", title: "sin × cos", fn: func(x float64) float64 { return math.Sin(x) * math.Cos(3*x) }},
+		{name: "sin", title: "sin", fn: math.Sin},
+		{name: "cos", title: "cos", fn: math.Cos},
+	} {
+		p, err := plot.New()
+		if err != nil {
+			log.Panic(err)
+		}
+		p.Title.Text = region.title
+		fn := plotter.NewFunction(region.fn)
+		fn.Samples = 200
+		fn.Color = colors[0]
+		if region.name != "main" {
+			fn.Color = colors[1]
+		}
+		p.Add(fn)
+		p.X.Min, p.X.Max = 0, 2*math.Pi
+		p.Y.Min, p.Y.Max = -1, 1
+		f.Set(region.name, p)
+	}
+
+	err = f.Save(400, 250, "testdata/figure.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestFigure(t *testing.T) {
+	cmpimg.CheckPlot(ExampleFigure, t, "figure.png")
+}
+
+func TestSplit(t *testing.T) {
+	c := draw.Canvas{Rectangle: vg.Rectangle{Max: vg.Point{X: 100, Y: 60}}}
+	root := layout.Column(layout.Size{}, 2,
+		layout.Region("top", layout.Fixed(10)),
+		&layout.Node{
+			Name: "middle",
+			Size: layout.Weighted(1),
+			Children: []*layout.Node{
+				layout.Region("a", layout.Weighted(1)),
+				layout.Region("b", layout.Weighted(3)),
+				layout.Region("c", layout.Fixed(20)),
+			},
+		},
+		layout.Region("bottom", layout.Size{}),
+	)
+	regions, err := root.Split(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rect := func(x0, y0, x1, y1 vg.Length) vg.Rectangle {
+		return vg.Rectangle{Min: vg.Point{X: x0, Y: y0}, Max: vg.Point{X: x1, Y: y1}}
+	}
+	want := map[string]vg.Rectangle{
+		"top":    rect(0, 50, 100, 60),
+		"middle": rect(0, 25, 100, 48),
+		"a":      rect(0, 25, 20, 48),
+		"b":      rect(20, 25, 80, 48),
+		"c":      rect(80, 25, 100, 48),
+		"bottom": rect(0, 0, 100, 23),
+	}
+	if len(regions) != len(want) {
+		t.Errorf("unexpected number of regions: got:%d want:%d", len(regions), len(want))
+	}
+	for name, w := range want {
+		got, ok := regions[name]
+		if !ok {
+			t.Errorf("missing region %q", name)
+			continue
+		}
+		if !closeRect(got.Rectangle, w) {
+			t.Errorf("unexpected rectangle for region %q: got:%+v want:%+v", name, got.Rectangle, w)
+		}
+	}
+
+	for _, test := range []struct {
+		name string
+		root *layout.Node
+	}{
+		{
+			name: "duplicate name",
+			root: layout.Row(layout.Size{}, 0, layout.Region("a", layout.Size{}), layout.Region("a", layout.Size{})),
+		},
+		{
+			name: "oversized",
+			root: layout.Row(layout.Size{}, 10, layout.Region("a", layout.Fixed(50)), layout.Region("b", layout.Fixed(45))),
+		},
+	} {
+		_, err := test.root.Split(c)
+		if err == nil {
+			t.Errorf("expected error for %s", test.name)
+		}
+	}
+
+	f := layout.NewFigure(root)
+	f.Set("missing", layout.DrawerFunc(func(draw.Canvas) {}))
+	if err := f.Draw(c); err == nil {
+		t.Error("expected error for content without region")
+	}
+}
+
+// closeRect returns whether the corners of a and b are
+// within a small tolerance.
+func closeRect(a, b vg.Rectangle) bool {
+	const tol = 1e-9
+	for _, d := range []vg.Length{a.Min.X - b.Min.X, a.Min.Y - b.Min.Y, a.Max.X - b.Max.X, a.Max.Y - b.Max.Y} {
+		if math.Abs(float64(d)) > tol {
+			return false
+		}
+	}
+	return true
+}
@@ -5,7 +5,9 @@
 package plot
 
 import (
+	"image/color"
 	"math"
+	"sort"
 
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
@@ -20,6 +22,10 @@ type Legend struct {
 	// entry texts.
 	draw.TextStyle
 
+	// HeadingStyle is the style given to the
+	// texts of the sub-headings of the legend.
+	HeadingStyle draw.TextStyle
+
 	// Padding is the amount of padding to add
 	// between each entry in the legend.  If Padding
 	// is zero then entries are spaced based on the
@@ -40,6 +46,30 @@ type Legend struct {
 	// final position.
 	XOffs, YOffs vg.Length
 
+	// Placement specifies how the legend is located.
+	// Legends placed at the edges of the plot are
+	// located by Top and Left, otherwise they are
+	// located by X and Y, and Left only specifies
+	// the position of the text.
+	Placement LegendPlacement
+
+	// X and Y are the position of the legend when
+	// it is not placed at the edges of the plot, as
+	// fractions of the width and height of the area
+	// it is drawn in or as data coordinates.
+	X, Y float64
+
+	// XAlign and YAlign specify the alignment of the
+	// legend relative to its position X and Y, as they
+	// specify the alignment of text.
+	XAlign draw.XAlignment
+	YAlign draw.YAlignment
+
+	// Margin is the amount of space kept between
+	// the legend and the edges of the area it is
+	// drawn in.
+	Margin vg.Length
+
 	// ThumbnailWidth is the width of legend thumbnails.
 	ThumbnailWidth vg.Length
 
@@ -48,6 +78,26 @@ type Legend struct {
 	entries []legendEntry
 }
 
+// LegendPlacement specifies how a legend is located.
+type LegendPlacement int
+
+const (
+	// LegendEdges places the legend at the edges
+	// of the plot specified by Top and Left.
+	LegendEdges LegendPlacement = iota
+
+	// LegendFraction places the legend at the
+	// fractions X and Y of the width and height of
+	// the area it is drawn in.
+	LegendFraction
+
+	// LegendData places the legend at the data
+	// coordinates X and Y of its plot. A legend so
+	// placed that is drawn other than by its plot is
+	// placed as for LegendFraction.
+	LegendData
+)
+
 // A legendEntry represents a single line of a legend, it
 // has a name and an icon.
 type legendEntry struct {
@@ -56,6 +106,10 @@ type legendEntry struct {
 
 	// thumbs is a slice of all of the thumbnails styles
 	thumbs []Thumbnailer
+
+	// heading is whether the entry is a sub-heading
+	// of the legend, drawn without thumbnails.
+	heading bool
 }
 
 // Thumbnailer wraps the Thumbnail method, which
@@ -69,6 +123,49 @@ type Thumbnailer interface {
 	Thumbnail(c *draw.Canvas)
 }
 
+// ThumbnailerFunc is a function drawing a legend
+// thumbnail, allowing custom thumbnails to be drawn
+// for legend entries.
+type ThumbnailerFunc func(c *draw.Canvas)
+
+// Thumbnail implements the Thumbnailer interface.
+func (f ThumbnailerFunc) Thumbnail(c *draw.Canvas) {
+	f(c)
+}
+
+// LineThumbnail returns a Thumbnailer drawing a
+// horizontal line across the middle of the thumbnail.
+func LineThumbnail(sty draw.LineStyle) Thumbnailer {
+	return ThumbnailerFunc(func(c *draw.Canvas) {
+		y := c.Center().Y
+		c.StrokeLine2(sty, c.Min.X, y, c.Max.X, y)
+	})
+}
+
+// GlyphThumbnail returns a Thumbnailer drawing a glyph
+// at the center of the thumbnail.
+func GlyphThumbnail(sty draw.GlyphStyle) Thumbnailer {
+	return ThumbnailerFunc(func(c *draw.Canvas) {
+		c.DrawGlyph(sty, c.Center())
+	})
+}
+
+// BandThumbnail returns a Thumbnailer filling the
+// thumbnail with the given color, as a band or area
+// is shown. Drawn before other thumbnails of an entry
+// it forms their background.
+func BandThumbnail(col color.Color) Thumbnailer {
+	return ThumbnailerFunc(func(c *draw.Canvas) {
+		pts := []vg.Point{
+			{X: c.Min.X, Y: c.Min.Y},
+			{X: c.Min.X, Y: c.Max.Y},
+			{X: c.Max.X, Y: c.Max.Y},
+			{X: c.Max.X, Y: c.Min.Y},
+		}
+		c.FillPolygon(col, c.ClipPolygonY(pts))
+	})
+}
+
 // NewLegend returns a legend with the default
 // parameter settings.
 func NewLegend() (Legend, error) {
@@ -79,28 +176,24 @@ func NewLegend() (Legend, error) {
 	return Legend{
 		ThumbnailWidth: vg.Points(20),
 		TextStyle:      draw.TextStyle{Font: font},
+		HeadingStyle:   draw.TextStyle{Font: font},
 	}, nil
 }
 
 // Draw draws the legend to the given draw.Canvas.
 func (l *Legend) Draw(c draw.Canvas) {
-	iconx := c.Min.X
+	r := l.Rectangle(c)
+	iconx := r.Min.X
 	sty := l.TextStyle
 	textx := iconx + l.ThumbnailWidth + sty.Rectangle(" ").Max.X
 	if !l.Left {
-		iconx = c.Max.X - l.ThumbnailWidth
+		iconx = r.Max.X - l.ThumbnailWidth
 		textx = iconx - l.TextStyle.Rectangle(" ").Max.X
 		sty.XAlign--
 	}
-	textx += l.XOffs
-	iconx += l.XOffs
 
 	enth := l.entryHeight()
-	y := c.Max.Y - enth
-	if !l.Top {
-		y = c.Min.Y + (enth+l.Padding)*(vg.Length(len(l.entries))-1)
-	}
-	y += l.YOffs
+	y := r.Max.Y - enth
 
 	icon := &draw.Canvas{
 		Canvas: c.Canvas,
@@ -109,7 +202,20 @@ func (l *Legend) Draw(c draw.Canvas) {
 			Max: vg.Point{X: iconx + l.ThumbnailWidth, Y: y + enth},
 		},
 	}
+	head := l.HeadingStyle
+	headx := r.Min.X
+	if !l.Left {
+		headx = r.Max.X
+		head.XAlign--
+	}
 	for _, e := range l.entries {
+		if e.heading {
+			yoffs := (enth - head.Rectangle(e.text).Max.Y) / 2
+			c.FillText(head, vg.Point{X: headx, Y: icon.Min.Y + yoffs}, e.text)
+			icon.Min.Y -= enth + l.Padding
+			icon.Max.Y -= enth + l.Padding
+			continue
+		}
 		for _, t := range e.thumbs {
 			t.Thumbnail(icon)
 		}
@@ -126,35 +232,60 @@ func (l *Legend) Rectangle(c draw.Canvas) vg.Rectangle {
 	sty := l.TextStyle
 	entryHeight := l.entryHeight()
 	for i, e := range l.entries {
-		width = vg.Length(math.Max(float64(width), float64(l.ThumbnailWidth+sty.Rectangle(" "+e.text).Max.X)))
+		w := l.ThumbnailWidth + sty.Rectangle(" "+e.text).Max.X
+		if e.heading {
+			w = l.HeadingStyle.Rectangle(e.text).Max.X
+		}
+		width = vg.Length(math.Max(float64(width), float64(w)))
 		height += entryHeight
 		if i != 0 {
 			height += l.Padding
 		}
 	}
+	c = l.area(c)
 	var r vg.Rectangle
-	if l.Left {
-		r.Max.X = c.Max.X
-		r.Min.X = c.Max.X - width
-	} else {
-		r.Max.X = c.Min.X + width
-		r.Min.X = c.Min.X
-	}
-	if l.Top {
-		r.Max.Y = c.Max.Y
-		r.Min.Y = c.Max.Y - height
-	} else {
-		r.Max.Y = c.Min.Y + height
-		r.Min.Y = c.Min.Y
+	switch l.Placement {
+	case LegendFraction, LegendData:
+		r.Min = vg.Point{
+			X: c.Min.X + vg.Length(l.X)*(c.Max.X-c.Min.X) + vg.Length(l.XAlign)*width,
+			Y: c.Min.Y + vg.Length(l.Y)*(c.Max.Y-c.Min.Y) + vg.Length(l.YAlign)*height,
+		}
+		r.Max = r.Min.Add(vg.Point{X: width, Y: height})
+	default:
+		if l.Left {
+			r.Min.X = c.Min.X
+			r.Max.X = c.Min.X + width
+		} else {
+			r.Min.X = c.Max.X - width
+			r.Max.X = c.Max.X
+		}
+		if l.Top {
+			r.Max.Y = c.Max.Y
+			r.Min.Y = c.Max.Y - height
+		} else {
+			r.Max.Y = c.Min.Y + height
+			r.Min.Y = c.Min.Y
+		}
 	}
-	return r
+	off := vg.Point{X: l.XOffs, Y: l.YOffs}
+	return vg.Rectangle{Min: r.Min.Add(off), Max: r.Max.Add(off)}
+}
+
+// area returns the area of c in which the legend
+// is located.
+func (l *Legend) area(c draw.Canvas) draw.Canvas {
+	return draw.Crop(c, l.Margin, -l.Margin, l.Margin, -l.Margin)
 }
 
 // entryHeight returns the height of the tallest legend
 // entry text.
 func (l *Legend) entryHeight() (height vg.Length) {
 	for _, e := range l.entries {
-		if h := l.TextStyle.Rectangle(e.text).Max.Y; h > height {
+		sty := l.TextStyle
+		if e.heading {
+			sty = l.HeadingStyle
+		}
+		if h := sty.Rectangle(e.text).Max.Y; h > height {
 			height = h
 		}
 	}
@@ -167,3 +298,70 @@ func (l *Legend) entryHeight() (height vg.Length) {
 func (l *Legend) Add(name string, thumbs ...Thumbnailer) {
 	l.entries = append(l.entries, legendEntry{text: name, thumbs: thumbs})
 }
+
+// AddHeading adds a sub-heading to the legend with the
+// given text. The entries added after the heading are
+// grouped under it.
+func (l *Legend) AddHeading(text string) {
+	l.entries = append(l.entries, legendEntry{text: text, heading: true})
+}
+
+// Sort sorts the entries of the legend by their names
+// using less, keeping the sub-headings in place. The
+// entries are sorted within each group under a heading,
+// and the order of entries with equal names is kept.
+func (l *Legend) Sort(less func(a, b string) bool) {
+	start := 0
+	for i := 0; i <= len(l.entries); i++ {
+		if i < len(l.entries) && !l.entries[i].heading {
+			continue
+		}
+		group := l.entries[start:i]
+		sort.SliceStable(group, func(i, j int) bool {
+			return less(group[i].text, group[j].text)
+		})
+		start = i + 1
+	}
+}
+
+// replace replaces the plotters old in the thumbnails of the
+// legend with the thumbnailers new, inserted in place of the first
+// of the old plotters of each entry. Entries left without
+// thumbnails are removed.
+func (l *Legend) replace(old []Plotter, new []Thumbnailer) {
+	isOld := func(th Thumbnailer) bool {
+		for _, pl := range old {
+			if same(th, pl) {
+				return true
+			}
+		}
+		return false
+	}
+	n := 0
+	for _, e := range l.entries {
+		var thumbs []Thumbnailer
+		replaced := false
+		for _, th := range e.thumbs {
+			if !isOld(th) {
+				thumbs = append(thumbs, th)
+				continue
+			}
+			if !replaced {
+				thumbs = append(thumbs, new...)
+				replaced = true
+			}
+		}
+		if replaced {
+			if len(thumbs) == 0 {
+				continue
+			}
+			e.thumbs = thumbs
+		}
+		l.entries[n] = e
+		n++
+	}
+	for i := n; i < len(l.entries); i++ {
+		l.entries[i] = legendEntry{}
+	}
+	l.entries = l.entries[:n]
+}
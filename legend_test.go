@@ -6,7 +6,10 @@ package plot
 
 import (
 	"image/color"
+	"math"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 
 	"gonum.org/v1/plot/internal/cmpimg"
@@ -99,3 +102,93 @@ func ExampleLegend_standalone() {
 func TestLegend_standalone(t *testing.T) {
 	cmpimg.CheckPlot(ExampleLegend_standalone, t, "legend_standalone.png")
 }
+
+func TestLegendPlacement(t *testing.T) {
+	c := draw.New(vgimg.New(vg.Points(200), vg.Points(100)))
+	l, err := NewLegend()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l.Add("red", exampleThumbnailer{Color: color.NRGBA{R: 255, A: 255}})
+	l.Add("green", exampleThumbnailer{Color: color.NRGBA{G: 255, A: 255}})
+	edges := l.Rectangle(c)
+	w, h := edges.Max.X-edges.Min.X, edges.Max.Y-edges.Min.Y
+
+	l.Placement = LegendFraction
+	l.X, l.Y = 0.5, 0.25
+	l.XAlign, l.YAlign = draw.XCenter, draw.YTop
+	l.Margin = 10
+	l.XOffs, l.YOffs = 1, 2
+	got := l.Rectangle(c)
+	min := vg.Point{X: 10 + 0.5*180 - w/2 + 1, Y: 10 + 0.25*80 - h + 2}
+	want := vg.Rectangle{Min: min, Max: min.Add(vg.Point{X: w, Y: h})}
+	if got != want {
+		t.Errorf("unexpected fraction placed legend: got:%v want:%v", got, want)
+	}
+
+	// A legend placed at data coordinates is placed at
+	// the position of the point in the data area.
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 1
+	p.Legend = l
+	p.Legend.Placement = LegendData
+	p.Legend.X, p.Legend.Y = 5, 0.25
+	lc := p.legendCanvas(c)
+	dc := p.DataCanvas(c)
+	placed := p.placedLegend(lc, dc)
+	if placed.Placement != LegendFraction {
+		t.Errorf("unexpected placement: got:%v want:%v", placed.Placement, LegendFraction)
+	}
+	got = placed.Rectangle(lc)
+	pt := vg.Point{X: dc.X(0.5) - w/2 + 1, Y: dc.Y(0.25) - h + 2}
+	if d := got.Min.Sub(pt); math.Abs(float64(d.X)) > 1e-9 || math.Abs(float64(d.Y)) > 1e-9 {
+		t.Errorf("unexpected data placed legend minimum: got:%v want:%v", got.Min, pt)
+	}
+}
+
+func TestLegendGroups(t *testing.T) {
+	l, err := NewLegend()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var drawn int
+	thumb := ThumbnailerFunc(func(*draw.Canvas) { drawn++ })
+	l.Add("b", thumb)
+	l.Add("a", thumb)
+	l.AddHeading("A much longer heading")
+	l.Add("d", thumb)
+	l.Add("c", thumb, thumb)
+	l.Sort(func(a, b string) bool { return a < b })
+
+	var got []string
+	for _, e := range l.entries {
+		got = append(got, e.text)
+	}
+	want := []string{"a", "b", "A much longer heading", "c", "d"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected sorted entries: got:%q want:%q", got, want)
+	}
+
+	c := draw.New(vgimg.New(vg.Points(200), vg.Points(100)))
+	r := l.Rectangle(c)
+	if w, hw := r.Max.X-r.Min.X, l.HeadingStyle.Width("A much longer heading"); w != hw {
+		t.Errorf("unexpected legend width: got:%v want:%v", w, hw)
+	}
+	l.Draw(c)
+	if drawn != 5 {
+		t.Errorf("unexpected number of thumbnails drawn: got:%d want:5", drawn)
+	}
+
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Legend = l
+	if s := p.Summary(); strings.Contains(s, "heading") {
+		t.Errorf("unexpected heading in summary: %q", s)
+	}
+}
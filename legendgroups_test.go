@@ -0,0 +1,89 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot_test
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ExampleLegend_groups draws measurements and models
+// of two signals, with the legend entries grouped under
+// sub-headings and the entries of the models drawn with
+// thumbnails combining a confidence band, a line and the
+// glyph of the matching measurements.
+func ExampleLegend_groups() {
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Grouped legend"
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = -1.5, 4.5
+	p.Legend.Top = true
+	p.Legend.Padding = vg.Points(1)
+
+	blue := color.RGBA{B: 255, A: 255}
+	red := color.RGBA{R: 255, A: 255}
+	signals := []struct {
+		name  string
+		color color.RGBA
+		shape draw.GlyphDrawer
+		f     func(float64) float64
+	}{
+		{name: "sine", color: blue, shape: draw.CircleGlyph{}, f: math.Sin},
+		{name: "cosine", color: red, shape: draw.TriangleGlyph{}, f: math.Cos},
+	}
+
+	p.Legend.AddHeading("Measured")
+	var models [][]plot.Thumbnailer
+	for i, s := range signals {
+		var pts plotter.XYs
+		for x := 0.5; x < 10; x++ {
+			pts = append(pts, struct{ X, Y float64 }{X: x, Y: s.f(x) + 0.2*math.Sin(7*x+float64(i))})
+		}
+		sc, err := plotter.NewScatter(pts)
+		if err != nil {
+			log.Panic(err)
+		}
+		sc.Color = s.color
+		sc.Shape = s.shape
+		p.Add(sc)
+		p.Legend.Add(s.name, sc)
+
+		fn := plotter.NewFunction(s.f)
+		fn.Color = s.color
+		p.Add(fn)
+
+		band := color.NRGBA{R: s.color.R, G: s.color.G, B: s.color.B, A: 64}
+		models = append(models, []plot.Thumbnailer{
+			plot.BandThumbnail(band),
+			fn,
+			plot.GlyphThumbnail(sc.GlyphStyle),
+		})
+	}
+	p.Legend.AddHeading("Model")
+	for i, s := range signals {
+		p.Legend.Add(s.name, models[i]...)
+	}
+	p.Legend.Sort(func(a, b string) bool { return a < b })
+
+	err = p.Save(200, 200, "testdata/legendGroups.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestLegendGroupsPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleLegend_groups, t, "legendGroups.png")
+}
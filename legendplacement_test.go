@@ -0,0 +1,66 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot_test
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ExampleLegend_placement draws a pair of curves with the
+// legend centered below the top of the plot, and the same
+// curves with the legend placed beside the peak of the first
+// of them, in data coordinates.
+func ExampleLegend_placement() {
+	gauss := func(mu float64) func(float64) float64 {
+		return func(x float64) float64 { return math.Exp(-(x - mu) * (x - mu) / 2) }
+	}
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 1.2
+	a := plotter.NewFunction(gauss(3))
+	a.Color = color.RGBA{B: 255, A: 255}
+	b := plotter.NewFunction(gauss(7))
+	b.Color = color.RGBA{R: 255, A: 255}
+	b.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+	p.Add(a, b)
+	p.Legend.Add("μ=3", a)
+	p.Legend.Add("μ=7", b)
+
+	p.Title.Text = "Fractional placement"
+	p.Legend.Placement = plot.LegendFraction
+	p.Legend.X, p.Legend.Y = 0.5, 1
+	p.Legend.XAlign, p.Legend.YAlign = draw.XCenter, draw.YTop
+	p.Legend.Margin = vg.Points(5)
+	err = p.Save(200, 200, "testdata/legendFraction.png")
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.Title.Text = "Data placement"
+	p.Legend.Placement = plot.LegendData
+	p.Legend.X, p.Legend.Y = 3, 1
+	p.Legend.XAlign, p.Legend.YAlign = draw.XLeft, draw.YTop
+	p.Legend.XOffs = vg.Points(10)
+	err = p.Save(200, 200, "testdata/legendData.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestLegendPlacementPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleLegend_placement, t, "legendFraction.png", "legendData.png")
+}
@@ -0,0 +1,73 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"image/color"
+	"testing"
+
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// ExampleAxis_padding draws a plot in a publication style,
+// with the axis lines detached from the data area, shaded
+// here, and the data inset from the ends of each axis by
+// a different amount.
+func ExampleAxis_padding() {
+	p, err := New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Detached axes"
+	p.DataBackgroundColor = color.Gray{Y: 230}
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 1
+	p.X.Padding = vg.Points(10)
+	p.Y.Padding = vg.Points(10)
+	p.X.MinPadding = vg.Points(5)
+	p.X.MaxPadding = vg.Points(20)
+	p.Y.MaxPadding = vg.Points(10)
+
+	err = p.Save(200, 150, "testdata/axisPadding.png")
+	if err != nil {
+		panic(err)
+	}
+}
+
+func TestAxisPaddingPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleAxis_padding, t, "axisPadding.png")
+}
+
+func TestAxisPadding(t *testing.T) {
+	c := draw.New(vgimg.New(vg.Points(200), vg.Points(150)))
+	for _, invert := range []bool{false, true} {
+		p, err := New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.X.Min, p.X.Max = 0, 10
+		p.Y.Min, p.Y.Max = 0, 1
+		p.X.Invert = invert
+		p.Y.Invert = invert
+		base := p.DataCanvas(c)
+
+		p.X.MinPadding, p.X.MaxPadding = 5, 20
+		p.Y.MinPadding, p.Y.MaxPadding = 10, 15
+		got := p.DataCanvas(c)
+
+		wantMin := vg.Point{X: base.Min.X + 5, Y: base.Min.Y + 10}
+		wantMax := vg.Point{X: base.Max.X - 20, Y: base.Max.Y - 15}
+		if invert {
+			wantMin = vg.Point{X: base.Min.X + 20, Y: base.Min.Y + 15}
+			wantMax = vg.Point{X: base.Max.X - 5, Y: base.Max.Y - 10}
+		}
+		if got.Min != wantMin || got.Max != wantMax {
+			t.Errorf("unexpected data area with invert=%t: got:%v want:%v", invert, got.Rectangle, vg.Rectangle{Min: wantMin, Max: wantMax})
+		}
+	}
+}
@@ -102,6 +102,15 @@ var (
 	SequentialPalettes map[string]Sequential = sequential
 )
 
+func init() {
+	for name := range all {
+		name := name
+		palette.RegisterPalette(name, func(colors int) (palette.Palette, error) {
+			return GetPalette(TypeAny, name, colors)
+		})
+	}
+}
+
 // PaletteType indicates palette type for a GetPalette request.
 type PaletteType int
 
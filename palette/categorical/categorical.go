@@ -0,0 +1,161 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package categorical generates qualitative palettes of perceptually
+// distinct colors, for plots with more series than the colors of the
+// built-in palettes.
+package categorical // import "gonum.org/v1/plot/palette/categorical"
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/palette/colorblind"
+	"gonum.org/v1/plot/palette/internal/cielab"
+)
+
+// Constraints constrain the colors of generated palettes in the
+// CIE LCh (HCL) space, the polar form of CIELAB.
+type Constraints struct {
+	// MinLightness and MaxLightness are the range of the
+	// lightness L* of the colors, within [0, 100]. A zero
+	// MaxLightness is treated as 100.
+	MinLightness, MaxLightness float64
+
+	// MinChroma and MaxChroma are the range of the chroma
+	// of the colors. A zero MaxChroma does not limit the
+	// chroma.
+	MinChroma, MaxChroma float64
+
+	// Deficiencies are the color vision deficiencies with
+	// which the colors must also be distinct. Including
+	// colorblind.Protanopia and colorblind.Deuteranopia
+	// avoids red-green confusion.
+	Deficiencies []colorblind.Deficiency
+}
+
+// Default are the default constraints of palettes, excluding
+// colors that are too dark or too light to be distinguished
+// from black text or a white background and near greys.
+var Default = Constraints{MinLightness: 30, MaxLightness: 85, MinChroma: 20}
+
+// step is the spacing of the candidate colors in CIELAB.
+const step = 4
+
+// candidate is a candidate color of a palette.
+type candidate struct {
+	color color.NRGBA
+
+	// labs holds the color as seen with normal vision
+	// and each constrained deficiency.
+	labs []cielab.Lab
+}
+
+// Generate returns a palette of n colors satisfying the constraints
+// c, chosen from a grid of candidate colors in CIELAB so that each
+// color is the candidate farthest from the colors before it. The
+// distance between colors is the least CIE76 color difference of the
+// colors as seen with normal vision and each of the deficiencies of
+// c, so the first colors of the palette are the most distinct, and
+// palettes of n colors begin with the colors of smaller palettes.
+//
+// An error is returned if n is less than one or if the constraints
+// allow fewer than n colors.
+func Generate(n int, c Constraints) (palette.Palette, error) {
+	if n < 1 {
+		return nil, errors.New("categorical: number of colors less than one")
+	}
+	cands := candidates(c)
+	if len(cands) < n {
+		return nil, errors.New("categorical: too few colors satisfy the constraints")
+	}
+
+	// Start with the most chromatic candidate and add the
+	// candidate farthest from those chosen, tracking the
+	// distance of each candidate to its nearest chosen color.
+	var first int
+	var maxChroma float64
+	for i, cand := range cands {
+		if chroma := math.Hypot(cand.labs[0].A, cand.labs[0].B); chroma > maxChroma {
+			first, maxChroma = i, chroma
+		}
+	}
+	nearest := make([]float64, len(cands))
+	for i := range nearest {
+		nearest[i] = math.Inf(1)
+	}
+	p := make(qualitative, 0, n)
+	next := first
+	for {
+		chosen := cands[next]
+		p = append(p, chosen.color)
+		if len(p) == n {
+			return p, nil
+		}
+		var farthest float64
+		for i, cand := range cands {
+			nearest[i] = math.Min(nearest[i], distance(cand, chosen))
+			if nearest[i] > farthest {
+				next, farthest = i, nearest[i]
+			}
+		}
+		if farthest == 0 {
+			return nil, errors.New("categorical: too few distinct colors satisfy the constraints")
+		}
+	}
+}
+
+// candidates returns the colors of the CIELAB grid within the
+// sRGB gamut that satisfy the constraints c.
+func candidates(c Constraints) []candidate {
+	maxL := c.MaxLightness
+	if maxL == 0 {
+		maxL = 100
+	}
+	maxC := c.MaxChroma
+	if maxC == 0 {
+		maxC = math.Inf(1)
+	}
+	sims := append([]colorblind.Deficiency{colorblind.Normal}, c.Deficiencies...)
+
+	var cands []candidate
+	for l := math.Ceil(c.MinLightness/step) * step; l <= maxL; l += step {
+		for a := -128.0; a <= 128; a += step {
+			for b := -128.0; b <= 128; b += step {
+				chroma := math.Hypot(a, b)
+				if chroma < c.MinChroma || chroma > maxC {
+					continue
+				}
+				rgb := cielab.Lab{L: l, A: a, B: b}.RGB()
+				if !rgb.InGamut() {
+					continue
+				}
+				cand := candidate{color: rgb.NRGBA(1), labs: make([]cielab.Lab, len(sims))}
+				for i, d := range sims {
+					cand.labs[i] = cielab.FromColor(colorblind.Simulate(cand.color, d))
+				}
+				cands = append(cands, cand)
+			}
+		}
+	}
+	return cands
+}
+
+// distance returns the least color difference between
+// the colors a and b as seen with each simulated vision.
+func distance(a, b candidate) float64 {
+	d := math.Inf(1)
+	for i := range a.labs {
+		d = math.Min(d, cielab.DeltaE(a.labs[i], b.labs[i]))
+	}
+	return d
+}
+
+// qualitative is a qualitative palette of colors.
+type qualitative []color.Color
+
+// Colors implements the palette.Palette interface.
+func (p qualitative) Colors() []color.Color { return p }
@@ -0,0 +1,109 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package categorical
+
+import (
+	"image/color"
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/plot/palette/colorblind"
+	"gonum.org/v1/plot/palette/internal/cielab"
+)
+
+func TestGenerate(t *testing.T) {
+	redGreen := Default
+	redGreen.Deficiencies = []colorblind.Deficiency{colorblind.Protanopia, colorblind.Deuteranopia}
+
+	for _, test := range []struct {
+		c Constraints
+		n int
+	}{
+		{c: Default, n: 1},
+		{c: Default, n: 12},
+		{c: Default, n: 30},
+		{c: redGreen, n: 12},
+		{c: Constraints{MinLightness: 60, MaxLightness: 70, MaxChroma: 40}, n: 8},
+	} {
+		p, err := Generate(test.n, test.c)
+		if err != nil {
+			t.Fatalf("unexpected error generating %d colors: %v", test.n, err)
+		}
+		cols := p.Colors()
+		if len(cols) != test.n {
+			t.Fatalf("unexpected number of colors: got:%d want:%d", len(cols), test.n)
+		}
+		maxL, maxC := test.c.MaxLightness, test.c.MaxChroma
+		if maxC == 0 {
+			maxC = math.Inf(1)
+		}
+		for _, c := range cols {
+			// Allow for the rounding of the colors to 8 bits.
+			lab := cielab.FromColor(c)
+			if lab.L < test.c.MinLightness-1 || lab.L > maxL+1 {
+				t.Errorf("lightness of %v out of range: got:%v want:[%v, %v]", c, lab.L, test.c.MinLightness, maxL)
+			}
+			if chroma := math.Hypot(lab.A, lab.B); chroma < test.c.MinChroma-1 || chroma > maxC+1 {
+				t.Errorf("chroma of %v out of range: got:%v want:[%v, %v]", c, chroma, test.c.MinChroma, maxC)
+			}
+		}
+
+		// Palettes begin with the colors of smaller palettes.
+		if test.n > 1 {
+			q, err := Generate(test.n-1, test.c)
+			if err != nil {
+				t.Fatalf("unexpected error generating %d colors: %v", test.n-1, err)
+			}
+			if !reflect.DeepEqual(q.Colors(), cols[:test.n-1]) {
+				t.Errorf("palette of %d colors is not a prefix of palette of %d", test.n-1, test.n)
+			}
+		}
+	}
+
+	// Colors chosen to be distinct with red-green deficiencies
+	// are more distinct with them than unconstrained colors.
+	minDist := func(c Constraints, d colorblind.Deficiency) float64 {
+		p, err := Generate(10, c)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return minDistance(p.Colors(), d)
+	}
+	for _, d := range redGreen.Deficiencies {
+		if got, unconstrained := minDist(redGreen, d), minDist(Default, d); got <= unconstrained {
+			t.Errorf("unexpected %v distance: got:%v want:>%v", d, got, unconstrained)
+		}
+	}
+	if got := minDist(Default, colorblind.Normal); got < 20 {
+		t.Errorf("unexpected distance: got:%v want:>=20", got)
+	}
+
+	for _, test := range []struct {
+		c Constraints
+		n int
+	}{
+		{c: Default, n: 0},
+		{c: Constraints{MinLightness: 80, MaxLightness: 60}, n: 1},
+		{c: Constraints{MinLightness: 50, MaxLightness: 50, MinChroma: 4, MaxChroma: 4}, n: 20},
+	} {
+		_, err := Generate(test.n, test.c)
+		if err == nil {
+			t.Errorf("expected error generating %d colors with %+v", test.n, test.c)
+		}
+	}
+}
+
+// minDistance returns the least distance between the
+// colors as seen with the deficiency d.
+func minDistance(cols []color.Color, d colorblind.Deficiency) float64 {
+	min := math.Inf(1)
+	for i := range cols {
+		for j := i + 1; j < len(cols); j++ {
+			min = math.Min(min, colorblind.Distance(cols[i], cols[j], d))
+		}
+	}
+	return min
+}
@@ -0,0 +1,251 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package colorblind provides palettes that remain distinguishable
+// to viewers with color vision deficiencies, and tools to simulate
+// the deficiencies and check the distinctness of palettes.
+//
+// The deficiencies are simulated with the model of Machado, Oliveira
+// and Fernandes, "A Physiologically-based Model for Simulation of
+// Color Vision Deficiency", IEEE Transactions on Visualization and
+// Computer Graphics 15(6), 2009. DOI 10.1109/TVCG.2009.113.
+package colorblind // import "gonum.org/v1/plot/palette/colorblind"
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/palette/internal/cielab"
+)
+
+// qualitative is a qualitative palette of colors.
+type qualitative []color.Color
+
+// Colors implements the palette.Palette interface.
+func (p qualitative) Colors() []color.Color { return p }
+
+// hex returns a qualitative palette of the opaque colors
+// with the given 0xRRGGBB values.
+func hex(values ...uint32) qualitative {
+	p := make(qualitative, len(values))
+	for i, v := range values {
+		p[i] = color.NRGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+	}
+	return p
+}
+
+// OkabeIto returns the eight color qualitative palette of Okabe and
+// Ito, "Color Universal Design", 2008: black, orange, sky blue, bluish
+// green, yellow, blue, vermillion and reddish purple.
+func OkabeIto() palette.Palette {
+	return hex(0x000000, 0xe69f00, 0x56b4e9, 0x009e73, 0xf0e442, 0x0072b2, 0xd55e00, 0xcc79a7)
+}
+
+// TolBright returns the seven color bright qualitative palette of
+// Paul Tol, https://personal.sron.nl/~pault/: blue, red, green,
+// yellow, cyan, purple and grey.
+func TolBright() palette.Palette {
+	return hex(0x4477aa, 0xee6677, 0x228833, 0xccbb44, 0x66ccee, 0xaa3377, 0xbbbbbb)
+}
+
+// TolVibrant returns the seven color vibrant qualitative palette of
+// Paul Tol: orange, blue, cyan, magenta, red, teal and grey.
+func TolVibrant() palette.Palette {
+	return hex(0xee7733, 0x0077bb, 0x33bbee, 0xee3377, 0xcc3311, 0x009988, 0xbbbbbb)
+}
+
+// TolMuted returns the nine color muted qualitative palette of Paul
+// Tol: rose, indigo, sand, green, cyan, wine, teal, olive and purple.
+func TolMuted() palette.Palette {
+	return hex(0xcc6677, 0x332288, 0xddcc77, 0x117733, 0x88ccee, 0x882255, 0x44aa99, 0x999933, 0xaa4499)
+}
+
+func init() {
+	for name, p := range map[string]func() palette.Palette{
+		"OkabeIto":   OkabeIto,
+		"TolBright":  TolBright,
+		"TolVibrant": TolVibrant,
+		"TolMuted":   TolMuted,
+	} {
+		name, p := name, p
+		palette.RegisterPalette(name, func(colors int) (palette.Palette, error) {
+			cols := p().Colors()
+			if colors > len(cols) {
+				return nil, fmt.Errorf("colorblind: palette %q does not support %d colors", name, colors)
+			}
+			return qualitative(cols[:colors]), nil
+		})
+	}
+}
+
+// Deficiency is a type of color vision deficiency.
+type Deficiency int
+
+const (
+	// Normal is normal color vision.
+	Normal Deficiency = iota
+
+	// Protanopia is the absence of the long
+	// wavelength sensitive, red, cones.
+	Protanopia
+
+	// Deuteranopia is the absence of the medium
+	// wavelength sensitive, green, cones.
+	Deuteranopia
+
+	// Tritanopia is the absence of the short
+	// wavelength sensitive, blue, cones.
+	Tritanopia
+)
+
+// Deficiencies is the list of the simulated deficiencies.
+var Deficiencies = []Deficiency{Protanopia, Deuteranopia, Tritanopia}
+
+// String returns the name of the deficiency.
+func (d Deficiency) String() string {
+	switch d {
+	case Normal:
+		return "normal"
+	case Protanopia:
+		return "protanopia"
+	case Deuteranopia:
+		return "deuteranopia"
+	case Tritanopia:
+		return "tritanopia"
+	}
+	return fmt.Sprintf("Deficiency(%d)", int(d))
+}
+
+// machado holds the linear RGB transforms simulating the
+// deficiencies at full severity, from Machado et al. (2009).
+var machado = map[Deficiency][3][3]float64{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// simulate returns the linear RGB color c as seen with
+// the deficiency d, clamped to the RGB gamut.
+func simulate(c cielab.RGB, d Deficiency) cielab.RGB {
+	m, ok := machado[d]
+	if !ok {
+		if d != Normal {
+			panic(fmt.Sprintf("colorblind: unknown deficiency %v", d))
+		}
+		return c
+	}
+	clamp := func(v float64) float64 { return math.Max(0, math.Min(1, v)) }
+	return cielab.RGB{
+		R: clamp(m[0][0]*c.R + m[0][1]*c.G + m[0][2]*c.B),
+		G: clamp(m[1][0]*c.R + m[1][1]*c.G + m[1][2]*c.B),
+		B: clamp(m[2][0]*c.R + m[2][1]*c.G + m[2][2]*c.B),
+	}
+}
+
+// Simulate returns the color c as seen by a viewer
+// with the deficiency d.
+func Simulate(c color.Color, d Deficiency) color.Color {
+	rgb, alpha := cielab.Linear(c)
+	return simulate(rgb, d).NRGBA(alpha)
+}
+
+// SimulatePalette returns the palette p as seen by a
+// viewer with the deficiency d.
+func SimulatePalette(p palette.Palette, d Deficiency) palette.Palette {
+	cols := p.Colors()
+	sim := make(qualitative, len(cols))
+	for i, c := range cols {
+		sim[i] = Simulate(c, d)
+	}
+	return sim
+}
+
+// SimulateImage returns the image img, such as a rendered
+// plot, as seen by a viewer with the deficiency d.
+func SimulateImage(img image.Image, d Deficiency) *image.NRGBA {
+	b := img.Bounds()
+	dst := image.NewNRGBA(b)
+	cache := make(map[color.Color]color.NRGBA)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := img.At(x, y)
+			sim, ok := cache[c]
+			if !ok {
+				sim = color.NRGBAModel.Convert(Simulate(c, d)).(color.NRGBA)
+				cache[c] = sim
+			}
+			dst.SetNRGBA(x, y, sim)
+		}
+	}
+	return dst
+}
+
+// Distance returns the perceptual distance between the colors a
+// and b as seen with the deficiency d, measured as the CIE76 color
+// difference ΔE*ab. Colors with a distance less than about 2.3 are
+// just noticeably different, and series colors should be separated
+// by a distance of at least about 10. Alpha is ignored.
+func Distance(a, b color.Color, d Deficiency) float64 {
+	ra, _ := cielab.Linear(a)
+	rb, _ := cielab.Linear(b)
+	return cielab.DeltaE(simulate(ra, d).Lab(), simulate(rb, d).Lab())
+}
+
+// Report is the result of checking the distinctness
+// of the colors of a palette.
+type Report struct {
+	// Deficiency is the color vision with
+	// which the palette was checked.
+	Deficiency Deficiency
+
+	// Distance is the minimum Distance
+	// between any two colors of the palette.
+	Distance float64
+
+	// I and J are the indices of the closest
+	// pair of colors in the palette.
+	I, J int
+}
+
+// Check returns reports of the minimum perceptual distances between
+// the colors of the palette p as seen with normal color vision and
+// each of the Deficiencies, in that order. The distances of palettes
+// with fewer than two colors are zero.
+func Check(p palette.Palette) []Report {
+	cols := p.Colors()
+	reports := make([]Report, 0, len(Deficiencies)+1)
+	for _, d := range append([]Deficiency{Normal}, Deficiencies...) {
+		labs := make([]cielab.Lab, len(cols))
+		for i, c := range cols {
+			rgb, _ := cielab.Linear(c)
+			labs[i] = simulate(rgb, d).Lab()
+		}
+		r := Report{Deficiency: d}
+		for i := range labs {
+			for j := i + 1; j < len(labs); j++ {
+				dist := cielab.DeltaE(labs[i], labs[j])
+				if (r.I == r.J) || dist < r.Distance {
+					r.Distance, r.I, r.J = dist, i, j
+				}
+			}
+		}
+		reports = append(reports, r)
+	}
+	return reports
+}
@@ -0,0 +1,132 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colorblind
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/plot/palette"
+)
+
+func ExampleCheck() {
+	for _, r := range Check(OkabeIto()) {
+		fmt.Printf("%-12s %5.1f between colors %d and %d\n", r.Deficiency, r.Distance, r.I, r.J)
+	}
+
+	// Output:
+	// normal        26.4 between colors 2 and 5
+	// protanopia    20.7 between colors 2 and 7
+	// deuteranopia  17.0 between colors 1 and 4
+	// tritanopia    16.1 between colors 2 and 3
+}
+
+func TestSimulate(t *testing.T) {
+	for _, d := range append([]Deficiency{Normal}, Deficiencies...) {
+		// Neutral colors are seen unchanged.
+		for _, c := range []color.NRGBA{
+			{A: 0xff},
+			{R: 0x80, G: 0x80, B: 0x80, A: 0xff},
+			{R: 0xff, G: 0xff, B: 0xff, A: 0x80},
+		} {
+			got := color.NRGBAModel.Convert(Simulate(c, d)).(color.NRGBA)
+			if !near(got, c, 1) {
+				t.Errorf("unexpected simulation of %v with %v: got:%v", c, d, got)
+			}
+		}
+	}
+
+	red := color.NRGBA{R: 0xd0, G: 0x30, B: 0x30, A: 0xff}
+	green := color.NRGBA{R: 0x30, G: 0xa0, B: 0x30, A: 0xff}
+	normal := Distance(red, green, Normal)
+	for _, d := range []Deficiency{Protanopia, Deuteranopia} {
+		if dist := Distance(red, green, d); dist > normal/2 {
+			t.Errorf("unexpected %v distance between red and green: got:%v want:<=%v", d, dist, normal/2)
+		}
+	}
+}
+
+func TestSimulateImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(2, 3, 6, 5))
+	c := color.RGBA{R: 0xd0, G: 0x30, B: 0x30, A: 0xff}
+	for y := 3; y < 5; y++ {
+		for x := 2; x < 6; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	sim := SimulateImage(img, Deuteranopia)
+	if sim.Bounds() != img.Bounds() {
+		t.Fatalf("unexpected bounds: got:%v want:%v", sim.Bounds(), img.Bounds())
+	}
+	want := color.NRGBAModel.Convert(Simulate(c, Deuteranopia))
+	if got := sim.At(5, 4); got != want {
+		t.Errorf("unexpected simulated pixel: got:%v want:%v", got, want)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		p    palette.Palette
+		n    int
+	}{
+		{name: "OkabeIto", p: OkabeIto(), n: 8},
+		{name: "TolBright", p: TolBright(), n: 7},
+		{name: "TolVibrant", p: TolVibrant(), n: 7},
+		{name: "TolMuted", p: TolMuted(), n: 9},
+	} {
+		if got := len(test.p.Colors()); got != test.n {
+			t.Errorf("unexpected number of colors in %s: got:%d want:%d", test.name, got, test.n)
+		}
+		reports := Check(test.p)
+		if len(reports) != 4 {
+			t.Fatalf("unexpected number of reports for %s: got:%d want:4", test.name, len(reports))
+		}
+		for i, r := range reports {
+			if i > 0 && r.Deficiency != Deficiencies[i-1] {
+				t.Errorf("unexpected deficiency of report %d: got:%v want:%v", i, r.Deficiency, Deficiencies[i-1])
+			}
+			if r.Distance < 5 {
+				t.Errorf("unexpected %v distance of %s: got:%v between %d and %d",
+					r.Deficiency, test.name, r.Distance, r.I, r.J)
+			}
+		}
+	}
+
+	// A red-green palette is indistinct for red-green deficiencies.
+	p := Check(palette.Rainbow(2, palette.Red, palette.Green, 1, 0.8, 1))
+	if p[2].Distance > p[0].Distance/2 {
+		t.Errorf("unexpected red-green distances: normal:%v deuteranopia:%v", p[0].Distance, p[2].Distance)
+	}
+
+	r := Check(hex(0xff0000))[0]
+	if r != (Report{}) {
+		t.Errorf("unexpected report for single color palette: got:%+v want:%+v", r, Report{})
+	}
+}
+
+// near returns whether the components of a and b
+// differ by no more than tol.
+func near(a, b color.NRGBA, tol int) bool {
+	d := func(x, y uint8) bool { return int(x)-int(y) <= tol && int(y)-int(x) <= tol }
+	return d(a.R, b.R) && d(a.G, b.G) && d(a.B, b.B) && d(a.A, b.A)
+}
+
+func TestLookupPalette(t *testing.T) {
+	p, err := palette.LookupPalette("okabeito", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := p.Colors(), OkabeIto().Colors()[:3]; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected colors: got:%v want:%v", got, want)
+	}
+	_, err = palette.LookupPalette("TolBright", 8)
+	if err == nil {
+		t.Error("expected error for too many colors")
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cielab provides conversions between sRGB colors, linear
+// RGB and CIELAB for the perceptual color handling of the palette
+// packages.
+package cielab // import "gonum.org/v1/plot/palette/internal/cielab"
+
+import (
+	"image/color"
+	"math"
+)
+
+// Reference white-point D65.
+const xn, yn, zn = 0.95047, 1.0, 1.08883
+
+// RGB is a physically linear RGB color with components in [0, 1].
+type RGB struct {
+	R, G, B float64
+}
+
+// Linear returns the linear RGB representation of c and its
+// alpha in [0, 1]. The color is not premultiplied by alpha.
+func Linear(c color.Color) (RGB, float64) {
+	r, g, b, a := c.RGBA()
+	if a == 0 {
+		return RGB{}, 0
+	}
+	// f converts from an sRGB component to a linear RGB component.
+	f := func(v uint32) float64 {
+		s := float64(v) / float64(a)
+		if s > 0.04045 {
+			return math.Pow((s+0.055)/1.055, 2.4)
+		}
+		return s / 12.92
+	}
+	return RGB{R: f(r), G: f(g), B: f(b)}, float64(a) / 0xffff
+}
+
+// InGamut returns whether all components of c are within [0, 1],
+// allowing for rounding error.
+func (c RGB) InGamut() bool {
+	const eps = 1e-9
+	return -eps <= c.R && c.R <= 1+eps &&
+		-eps <= c.G && c.G <= 1+eps &&
+		-eps <= c.B && c.B <= 1+eps
+}
+
+// NRGBA returns the sRGB color of c with the given alpha in [0, 1].
+// Components of c outside [0, 1] are clamped.
+func (c RGB) NRGBA(alpha float64) color.NRGBA {
+	// f converts from a linear RGB component to an 8 bit sRGB component.
+	f := func(v float64) uint8 {
+		v = math.Max(0, math.Min(1, v))
+		if v > 0.0031308 {
+			v = 1.055*math.Pow(v, 1/2.4) - 0.055
+		} else {
+			v *= 12.92
+		}
+		return uint8(math.Round(v * 0xff))
+	}
+	return color.NRGBA{R: f(c.R), G: f(c.G), B: f(c.B), A: uint8(math.Round(alpha * 0xff))}
+}
+
+// Lab returns the CIELAB representation of c.
+func (c RGB) Lab() Lab {
+	x := 0.4124*c.R + 0.3576*c.G + 0.1805*c.B
+	y := 0.2126*c.R + 0.7152*c.G + 0.0722*c.B
+	z := 0.0193*c.R + 0.1192*c.G + 0.9505*c.B
+
+	// f is an intermediate step in converting from CIE XYZ to CIELAB.
+	f := func(v float64) float64 {
+		if v > 0.008856 {
+			return math.Cbrt(v)
+		}
+		return 7.787*v + 16.0/116.0
+	}
+	fx, fy, fz := f(x/xn), f(y/yn), f(z/zn)
+	return Lab{
+		L: 116*fy - 16,
+		A: 500 * (fx - fy),
+		B: 200 * (fy - fz),
+	}
+}
+
+// Lab is a color in CIELAB space.
+type Lab struct {
+	L, A, B float64
+}
+
+// FromColor returns the CIELAB representation of c, ignoring alpha.
+func FromColor(c color.Color) Lab {
+	rgb, _ := Linear(c)
+	return rgb.Lab()
+}
+
+// RGB returns the linear RGB representation of c. The result
+// may be outside the RGB gamut.
+func (c Lab) RGB() RGB {
+	// f is an intermediate step in converting from CIELAB to CIE XYZ.
+	f := func(v float64) float64 {
+		const (
+			xlim = 0.008856
+			a    = 7.787
+			b    = 16. / 116.
+			ylim = a*xlim + b
+		)
+		if v > ylim {
+			return v * v * v
+		}
+		return (v - b) / a
+	}
+	fy := (c.L + 16) / 116
+	x := xn * f(fy+c.A/500)
+	y := yn * f(fy)
+	z := zn * f(fy-c.B/200)
+	return RGB{
+		R: x*3.2406 + y*-1.5372 + z*-0.4986,
+		G: x*-0.9689 + y*1.8758 + z*0.0415,
+		B: x*0.0557 + y*-0.204 + z*1.057,
+	}
+}
+
+// DeltaE returns the CIE76 color difference between a and b,
+// the Euclidean distance between them in CIELAB space.
+func DeltaE(a, b Lab) float64 {
+	return math.Sqrt((a.L-b.L)*(a.L-b.L) + (a.A-b.A)*(a.A-b.A) + (a.B-b.B)*(a.B-b.B))
+}
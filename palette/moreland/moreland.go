@@ -21,3 +21,26 @@
 // In Proceedings of the 5th International Symposium on Visual Computing,
 // December 2009. DOI 10.1007/978-3-642-10520-3_9.
 package moreland // import "gonum.org/v1/plot/palette/moreland"
+
+import "gonum.org/v1/plot/palette"
+
+func init() {
+	for name, new := range map[string]func() palette.ColorMap{
+		"BlackBody":         BlackBody,
+		"ExtendedBlackBody": ExtendedBlackBody,
+		"Kindlmann":         Kindlmann,
+		"ExtendedKindlmann": ExtendedKindlmann,
+	} {
+		palette.RegisterColorMap(name, new)
+	}
+	for name, new := range map[string]func() palette.DivergingColorMap{
+		"SmoothBlueRed":      SmoothBlueRed,
+		"SmoothPurpleOrange": SmoothPurpleOrange,
+		"SmoothGreenPurple":  SmoothGreenPurple,
+		"SmoothBlueTan":      SmoothBlueTan,
+		"SmoothGreenRed":     SmoothGreenRed,
+	} {
+		new := new
+		palette.RegisterColorMap(name, func() palette.ColorMap { return new() })
+	}
+}
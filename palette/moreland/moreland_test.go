@@ -0,0 +1,65 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package moreland
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/plot/palette"
+)
+
+func TestLookup(t *testing.T) {
+	for _, name := range []string{"SmoothBlueRed", "smoothbluered", "KINDLMANN"} {
+		_, err := palette.Lookup(name)
+		if err != nil {
+			t.Errorf("unexpected error looking up %q: %v", name, err)
+		}
+	}
+
+	// Each lookup returns a new color map.
+	a, _ := palette.Lookup("BlackBody")
+	a.SetMax(10)
+	b, _ := palette.Lookup("BlackBody")
+	if b.Max() == 10 {
+		t.Error("color map modified by change to earlier lookup")
+	}
+
+	// Color maps are available as palettes.
+	p, err := palette.LookupPalette("ExtendedKindlmann", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := p.Colors(), ExtendedKindlmann().Palette(5).Colors(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected palette: got:%v want:%v", got, want)
+	}
+
+	_, err = palette.Lookup("viridis")
+	if err == nil {
+		t.Error("expected error for unregistered color map")
+	}
+	_, err = palette.LookupPalette("viridis", 5)
+	if err == nil {
+		t.Error("expected error for unregistered palette")
+	}
+
+	names := palette.ColorMapNames()
+	want := []string{
+		"BlackBody", "ExtendedBlackBody", "ExtendedKindlmann", "Kindlmann",
+		"SmoothBlueRed", "SmoothBlueTan", "SmoothGreenPurple", "SmoothGreenRed", "SmoothPurpleOrange",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("unexpected color map names: got:%q want:%q", names, want)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic registering duplicate name")
+			}
+		}()
+		palette.RegisterColorMap("blackbody", BlackBody)
+	}()
+}
@@ -0,0 +1,141 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// registry holds the registered ColorMaps and Palettes,
+// keyed by their lower case names.
+var registry = struct {
+	sync.RWMutex
+	colorMaps map[string]namedColorMap
+	palettes  map[string]namedPalette
+}{
+	colorMaps: make(map[string]namedColorMap),
+	palettes:  make(map[string]namedPalette),
+}
+
+type namedColorMap struct {
+	name string
+	new  func() ColorMap
+}
+
+type namedPalette struct {
+	name string
+	new  func(colors int) (Palette, error)
+}
+
+func init() {
+	RegisterPalette("Heat", func(colors int) (Palette, error) {
+		if colors < 2 {
+			return nil, errors.New("palette: number of heat colors must be 2 or greater")
+		}
+		return Heat(colors, 1), nil
+	})
+	RegisterPalette("Rainbow", func(colors int) (Palette, error) {
+		if colors < 2 {
+			return nil, errors.New("palette: number of rainbow colors must be 2 or greater")
+		}
+		return Rainbow(colors, Red, Magenta, 1, 1, 1), nil
+	})
+}
+
+// RegisterColorMap registers a ColorMap so that it may be
+// referenced by name, for example in configuration files.
+// The function new must return a new ColorMap each time it
+// is called. Names are not case sensitive. RegisterColorMap
+// panics if the name is already registered.
+//
+// Packages providing ColorMaps register them when they are
+// imported, using the names of the functions returning them.
+func RegisterColorMap(name string, new func() ColorMap) {
+	key := strings.ToLower(name)
+	registry.Lock()
+	defer registry.Unlock()
+	if _, exists := registry.colorMaps[key]; exists {
+		panic(fmt.Sprintf("palette: color map %q already registered", name))
+	}
+	registry.colorMaps[key] = namedColorMap{name: name, new: new}
+}
+
+// Lookup returns a new ColorMap registered with the given
+// name. An error is returned if the name is not registered.
+func Lookup(name string) (ColorMap, error) {
+	registry.RLock()
+	cm, ok := registry.colorMaps[strings.ToLower(name)]
+	registry.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("palette: unknown color map %q", name)
+	}
+	return cm.new(), nil
+}
+
+// RegisterPalette registers a Palette so that it may be referenced
+// by name. The function new must return a new Palette with the given
+// number of colors, or an error if the palette does not support that
+// number of colors. Names are not case sensitive. RegisterPalette
+// panics if the name is already registered.
+func RegisterPalette(name string, new func(colors int) (Palette, error)) {
+	key := strings.ToLower(name)
+	registry.Lock()
+	defer registry.Unlock()
+	if _, exists := registry.palettes[key]; exists {
+		panic(fmt.Sprintf("palette: palette %q already registered", name))
+	}
+	registry.palettes[key] = namedPalette{name: name, new: new}
+}
+
+// LookupPalette returns the Palette with the given number of colors
+// registered with the given name. If no Palette is registered with
+// the name, the Palette of the ColorMap registered with the name is
+// returned. An error is returned if neither is registered.
+func LookupPalette(name string, colors int) (Palette, error) {
+	if colors < 1 {
+		return nil, fmt.Errorf("palette: invalid number of colors %d", colors)
+	}
+	registry.RLock()
+	p, ok := registry.palettes[strings.ToLower(name)]
+	registry.RUnlock()
+	if ok {
+		return p.new(colors)
+	}
+	cm, err := Lookup(name)
+	if err != nil {
+		return nil, fmt.Errorf("palette: unknown palette %q", name)
+	}
+	return cm.Palette(colors), nil
+}
+
+// ColorMapNames returns the sorted names of the
+// registered ColorMaps.
+func ColorMapNames() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, 0, len(registry.colorMaps))
+	for _, cm := range registry.colorMaps {
+		names = append(names, cm.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PaletteNames returns the sorted names of the
+// registered Palettes.
+func PaletteNames() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, 0, len(registry.palettes))
+	for _, p := range registry.palettes {
+		names = append(names, p.name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,69 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Pick is a data element of a Plotter found at a point
+// of a canvas.
+type Pick struct {
+	// Plotter is the Plotter holding the element.
+	Plotter Plotter
+
+	// Index is the index of the element within
+	// the data of the Plotter.
+	Index int
+
+	// X and Y are the data coordinates of the
+	// element.
+	X, Y float64
+
+	// Distance is the distance on the canvas from
+	// the point to the element. Distance is zero
+	// if the point is within the element.
+	Distance vg.Length
+}
+
+// Picker is the interface implemented by Plotters whose data
+// elements can be found from points on the canvas on which they
+// are drawn, for example to show a tooltip for the element under
+// a mouse pointer.
+type Picker interface {
+	// Pick returns the data element of the Plotter drawn
+	// on the data canvas c of plt that is nearest to the
+	// point pt, and whether there is such an element.
+	Pick(c draw.Canvas, plt *Plot, pt vg.Point) (Pick, bool)
+}
+
+// PickAt returns the data element nearest to the point pt among
+// the elements of the Plotters of the plot that implement Picker,
+// when the plot is drawn to c, and whether there is such an element.
+// If elements of several Plotters are equally near, the element of
+// the Plotter drawn last, and so on top, is returned.
+func (p *Plot) PickAt(c draw.Canvas, pt vg.Point) (Pick, bool) {
+	dc := p.DataCanvas(c)
+	var (
+		nearest Pick
+		found   bool
+	)
+	order := p.drawOrder()
+	for i := len(order) - 1; i >= 0; i-- {
+		picker, ok := order[i].(Picker)
+		if !ok {
+			continue
+		}
+		pick, ok := picker.Pick(dc, p, pt)
+		if !ok {
+			continue
+		}
+		if !found || pick.Distance < nearest.Distance {
+			nearest, found = pick, true
+		}
+	}
+	return nearest, found
+}
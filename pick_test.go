@@ -0,0 +1,63 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"testing"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// pointPicker is a Picker of a single point.
+type pointPicker struct {
+	x, y float64
+}
+
+func (pointPicker) Plot(draw.Canvas, *Plot) {}
+
+func (p *pointPicker) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return p.x, p.x, p.y, p.y
+}
+
+func (p *pointPicker) Pick(c draw.Canvas, plt *Plot, pt vg.Point) (Pick, bool) {
+	trX, trY := plt.Transforms(&c)
+	d := pt.Sub(vg.Point{X: trX(p.x), Y: trY(p.y)})
+	return Pick{Plotter: p, X: p.x, Y: p.y, Distance: vg.Length(d.X*d.X + d.Y*d.Y)}, true
+}
+
+func TestPickAt(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a := &pointPicker{x: 0, y: 0}
+	b := &pointPicker{x: 1, y: 1}
+	top := &pointPicker{x: 1, y: 1}
+	p.Add(a, b)
+	p.AddZ(1, top)
+	p.AddZ(-1, &pointPicker{x: 1, y: 1})
+
+	c := draw.New(vgimg.New(vg.Points(200), vg.Points(200)))
+	dc := p.DataCanvas(c)
+
+	pick, ok := p.PickAt(c, dc.Min)
+	if !ok || pick.Plotter != a {
+		t.Errorf("unexpected pick at origin: got:%+v", pick)
+	}
+	pick, ok = p.PickAt(c, dc.Max)
+	if !ok || pick.Plotter != top {
+		t.Errorf("expected pick of topmost plotter: got:%+v", pick)
+	}
+
+	empty, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := empty.PickAt(c, dc.Min); ok {
+		t.Error("unexpected pick from plot without pickers")
+	}
+}
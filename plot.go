@@ -5,11 +5,13 @@
 package plot
 
 import (
+	"context"
 	"image/color"
 	"io"
 	"math"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"gonum.org/v1/plot/vg"
@@ -38,19 +40,90 @@ type Plot struct {
 	}
 
 	// BackgroundColor is the background color of the plot.
-	// The default is White.
+	// The default is White. If BackgroundColor is nil the
+	// plot has no background. Images of the plot written by
+	// Save, Stream or WriterTo are drawn over the background
+	// of their canvas, which is white unless another is set
+	// with the draw.Background format option, such as
+	// draw.Background(nil) for a transparent png.
 	BackgroundColor color.Color
 
+	// Description is a description of the plot, included
+	// as accessibility metadata in the output formats that
+	// support it, such as SVG. Summary returns a generated
+	// description of the plot.
+	Description string
+
+	// DataBackgroundColor is the background color of
+	// the data area of the plot, within the axes. If
+	// DataBackgroundColor is nil, the default, the data
+	// area has the background of the plot.
+	DataBackgroundColor color.Color
+
 	// X and Y are the horizontal and vertical axes
 	// of the plot respectively.
 	X, Y Axis
 
+	// TwinX, if not nil, is an axis drawn along the top
+	// of the data area showing the values of the X axis
+	// in other units. It is usually set by NewTwinX.
+	TwinX *TwinAxis
+
 	// Legend is the plot's legend.
 	Legend Legend
 
+	// DataAspect, if positive, is the ratio of the length
+	// of one unit on the Y axis to the length of one unit
+	// on the X axis, so that a DataAspect of 1 draws a
+	// circle in data coordinates as a circle. The data area
+	// is reduced to satisfy the ratio, rather than the data
+	// being distorted. DataAspect assumes linear axis scales.
+	DataAspect float64
+
+	// AxesAspect, if positive, is the ratio of the height
+	// to the width of the data area. The data area is
+	// reduced to satisfy the ratio. AxesAspect is ignored
+	// if DataAspect is positive.
+	AxesAspect float64
+
+	// Progress, if not nil, is called as the plot is drawn
+	// with the fraction of the drawing that is complete and
+	// the name of the stage being drawn, so that long renders
+	// can report their progress. The plot reports the stages
+	// "axes", "plotter" for each plotter and "legend", after
+	// which the fraction is one, and Stream and Save report
+	// the "encode" stage when the drawing is complete. Heavy
+	// plotters report their own stages with ReportProgress.
+	Progress func(fraction float64, stage string)
+
 	// plotters are drawn by calling their Plot method
 	// after the axes are drawn.
 	plotters []Plotter
+
+	// zorders holds the z-order of each plotter.
+	zorders []int
+
+	// names holds the name of the series of each
+	// plotter added by AddNamed, and is empty for
+	// other plotters.
+	names []string
+
+	// ctx is the context of the rendering in
+	// progress, if any.
+	ctx context.Context
+
+	// progress is the span of the fraction of the
+	// rendering taken by the part being drawn.
+	progress progressSpan
+
+	// errs collects the errors of the drawing in
+	// progress if it is drawn by DrawContext.
+	errs *DrawErrors
+
+	// part and partPlotter are the name of the part
+	// of the plot being drawn, and its plotter.
+	part        string
+	partPlotter Plotter
 }
 
 // Plotter is an interface that wraps the Plot method.
@@ -69,6 +142,34 @@ type DataRanger interface {
 	DataRange() (xmin, xmax, ymin, ymax float64)
 }
 
+// Versioner wraps the Version method. It may be implemented
+// by plotters whose data can change after they have been
+// added to a plot so that renderers that cache their output,
+// such as Layers, know when the data range, ticks and cached
+// drawings must be recomputed.
+type Versioner interface {
+	// Version returns a value that changes whenever
+	// the data of the receiver changes.
+	Version() uint64
+}
+
+// ChangeCounter is a Versioner that may be embedded in
+// data containers. The zero value is ready to use.
+type ChangeCounter struct {
+	version uint64
+}
+
+// Changed marks the data as changed.
+func (c *ChangeCounter) Changed() {
+	c.version++
+}
+
+// Version returns the number of calls to Changed,
+// implementing the Versioner interface.
+func (c *ChangeCounter) Version() uint64 {
+	return c.version
+}
+
 const (
 	vertical   = true
 	horizontal = false
@@ -113,59 +214,332 @@ func New() (*Plot, error) {
 // If the plotters implements DataRanger then the
 // minimum and maximum values of the X and Y
 // axes are changed if necessary to fit the range of
-// the data.
+// the data. The fitted ranges are adjusted by the
+// AutoScale policies of the axes when the plot is
+// drawn.
+//
+// If the plotters implement DataUnits then axes
+// without a unit are given the units of the data.
 //
 // When drawing the plot, Plotters are drawn in the
 // order in which they were added to the plot.
 func (p *Plot) Add(ps ...Plotter) {
+	p.AddZ(0, ps...)
+}
+
+// AddZ adds Plotters to the plot as for Add, with
+// the z-order z. When drawing the plot, Plotters with
+// a lower z-order are drawn before, and so beneath,
+// Plotters with a higher z-order, and Plotters with
+// the same z-order are drawn in the order in which
+// they were added. Plotters added by Add have a
+// z-order of zero, so for example a grid added with
+// a negative z-order is drawn beneath all of the
+// data, wherever it was added.
+func (p *Plot) AddZ(z int, ps ...Plotter) {
+	p.addZ(z, "", ps...)
+}
+
+// addZ adds Plotters to the plot with the z-order z
+// as members of the named series.
+func (p *Plot) addZ(z int, name string, ps ...Plotter) {
+	p.fit(ps...)
+	p.align()
+	p.plotters = append(p.plotters, ps...)
+	for range ps {
+		p.zorders = append(p.zorders, z)
+		p.names = append(p.names, name)
+	}
+}
+
+// align extends the z-orders and names of the plotters
+// to the length of the plotters, which may have been
+// added without them.
+func (p *Plot) align() {
+	for len(p.zorders) < len(p.plotters) {
+		p.zorders = append(p.zorders, 0)
+	}
+	for len(p.names) < len(p.plotters) {
+		p.names = append(p.names, "")
+	}
+}
+
+// drawOrder returns the plotters in the order in
+// which they are drawn.
+func (p *Plot) drawOrder() []Plotter {
+	layered := false
+	for _, z := range p.zorders {
+		if z != 0 {
+			layered = true
+			break
+		}
+	}
+	if !layered {
+		return p.plotters
+	}
+	z := func(i int) int {
+		if i < len(p.zorders) {
+			return p.zorders[i]
+		}
+		return 0
+	}
+	idx := make([]int, len(p.plotters))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return z(idx[i]) < z(idx[j]) })
+	ps := make([]Plotter, len(idx))
+	for i, j := range idx {
+		ps[i] = p.plotters[j]
+	}
+	return ps
+}
+
+// fit extends the ranges of the X and Y axes to fit
+// the data ranges of the plotters that implement
+// DataRanger, and sets the units of axes without a
+// unit to those of plotters that implement DataUnits.
+func (p *Plot) fit(ps ...Plotter) {
 	for _, d := range ps {
 		if x, ok := d.(DataRanger); ok {
 			xmin, xmax, ymin, ymax := x.DataRange()
-			p.X.Min = math.Min(p.X.Min, xmin)
-			p.X.Max = math.Max(p.X.Max, xmax)
-			p.Y.Min = math.Min(p.Y.Min, ymin)
-			p.Y.Max = math.Max(p.Y.Max, ymax)
+			p.X.fit(xmin, xmax)
+			p.Y.fit(ymin, ymax)
+		}
+		if u, ok := d.(DataUnits); ok {
+			x, y := u.Units()
+			if p.X.Unit.Symbol == "" {
+				p.X.Unit = x
+			}
+			if p.Y.Unit.Symbol == "" {
+				p.Y.Unit = y
+			}
 		}
 	}
-
-	p.plotters = append(p.plotters, ps...)
 }
 
 // Draw draws a plot to a draw.Canvas.
 //
-// Plotters are drawn in the order in which they were
-// added to the plot.  Plotters that  implement the
+// Plotters are drawn in order of their z-order and
+// then the order in which they were added to the
+// plot.  Plotters that  implement the
 // GlyphBoxer interface will have their GlyphBoxes
 // taken into account when padding the plot so that
 // none of their glyphs are clipped.
+//
+// If the canvas is a vg.Grouper, the plot and its title,
+// axes, plotters and legend are drawn as groups marked with
+// accessibility metadata, as described by the group method.
 func (p *Plot) Draw(c draw.Canvas) {
+	p.drawContext(context.Background(), c)
+}
+
+// DrawContext draws a plot to a draw.Canvas as for Draw,
+// stopping early if ctx is done, in which case the error
+// of ctx is returned. The context is checked between the
+// parts of the plot, and by plotters with expensive loops
+// that consult the Context method of the plot. The plot
+// must not be drawn concurrently with DrawContext.
+//
+// Unlike Draw, DrawContext does not panic if a part of the
+// plot fails. The failures reported with Fail, and panics
+// other than run-time errors, of the parts of the plot are
+// collected and returned as DrawErrors, and the remaining
+// parts are drawn. A failure in the layout of the plot stops
+// the drawing.
+func (p *Plot) DrawContext(ctx context.Context, c draw.Canvas) error {
+	var errs DrawErrors
+	oldErrs := p.errs
+	p.errs = &errs
+	defer func() { p.errs = oldErrs }()
+	p.drawContext(ctx, c)
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// drawContext draws the plot to c with the context ctx.
+func (p *Plot) drawContext(ctx context.Context, c draw.Canvas) {
+	old := p.ctx
+	p.ctx = ctx
+	defer func() { p.ctx = old }()
+
+	p.part, p.partPlotter = "plot", nil
+	g, ok := c.Canvas.(vg.Grouper)
+	if !ok {
+		p.guard(func() { p.draw(c, func(string, Plotter) {}) })
+		return
+	}
+
+	g.BeginGroup(vg.Group{Role: "graphics-document", Title: p.Title.Text, Description: p.Description})
+	var open bool
+	p.guard(func() {
+		p.draw(c, func(name string, pl Plotter) {
+			if open {
+				g.EndGroup()
+			}
+			grp := p.group(name, pl)
+			open = grp != vg.Group{}
+			if open {
+				g.BeginGroup(grp)
+			}
+		})
+	})
+	if open {
+		g.EndGroup()
+	}
+	g.EndGroup()
+}
+
+// Context returns the context of the rendering of the plot
+// in progress. Plotters with expensive loops may check the
+// context so that the rendering can be canceled. Context
+// returns context.Background if the plot is not being drawn
+// by DrawContext.
+func (p *Plot) Context() context.Context {
+	if p.ctx == nil {
+		return context.Background()
+	}
+	return p.ctx
+}
+
+// draw draws the plot to c as described for Draw, calling part
+// before drawing each part of the plot. The parts are named
+// "background", "title", "x", "y", "twin" for the TwinX axis,
+// "plotter", for each of the Plotters, which is passed to part,
+// and "legend".
+func (p *Plot) draw(c draw.Canvas, part func(name string, pl Plotter)) {
+	mark := part
+	part = func(name string, pl Plotter) {
+		p.part, p.partPlotter = name, pl
+		mark(name, pl)
+	}
+
+	outer := c.Rectangle
 	if p.BackgroundColor != nil {
+		part("background", nil)
 		c.SetColor(p.BackgroundColor)
 		c.Fill(c.Rectangle.Path())
 	}
 	if p.Title.Text != "" {
+		part("title", nil)
 		c.FillText(p.Title.TextStyle, vg.Point{X: c.Center().X, Y: c.Max.Y}, p.Title.Text)
 		c.Max.Y -= p.Title.Height(p.Title.Text) - p.Title.Font.Extents().Descent
 		c.Max.Y -= p.Title.Padding
 	}
+	twin, hasTwin := p.twinX()
+	var twinHeight vg.Length
+	if hasTwin {
+		twinHeight = twin.size()
+		c.Max.Y -= twinHeight
+	}
 
-	p.X.sanitizeRange()
-	x := horizontalAxis{p.X}
-	p.Y.sanitizeRange()
-	y := verticalAxis{p.Y}
+	p.ReportProgress(0, "axes")
+	p.part, p.partPlotter = "plot", nil
+	x, y := p.axes(c)
+	c = p.constrain(c, x, y)
+	if p.X.Resolved != nil {
+		p.X.Resolved(p.X.Min, p.X.Max)
+	}
+	if p.Y.Resolved != nil {
+		p.Y.Resolved(p.Y.Min, p.Y.Max)
+	}
 
 	ywidth := y.size()
 
 	xheight := x.size()
-	x.draw(padX(p, draw.Crop(c, ywidth, 0, 0, 0)))
-	y.draw(padY(p, draw.Crop(c, 0, 0, xheight, 0)))
-
 	dataC := padY(p, padX(p, draw.Crop(c, ywidth, 0, xheight, 0)))
-	for _, data := range p.plotters {
-		data.Plot(dataC, p)
+	dataC = draw.WithOuter(dataC, outer)
+	if p.DataBackgroundColor != nil {
+		part("background", nil)
+		dataC.SetColor(p.DataBackgroundColor)
+		dataC.Fill(dataC.Rectangle.Path())
+	}
+
+	part("x", nil)
+	p.guard(func() {
+		x.draw(padX(p, draw.Crop(c, ywidth, 0, 0, 0)))
+		x.drawSpines(dataC, p.Y)
+	})
+	part("y", nil)
+	p.guard(func() {
+		y.draw(padY(p, draw.Crop(c, 0, 0, xheight, 0)))
+		y.drawSpines(dataC, p.X)
+	})
+	if hasTwin {
+		part("twin", nil)
+		p.guard(func() {
+			tc := padX(p, draw.Crop(c, ywidth, 0, 0, 0))
+			tc.Min.Y = c.Max.Y
+			tc.Max.Y = c.Max.Y + twinHeight
+			twin.draw(tc)
+		})
+	}
+
+	span := p.progress
+	order := p.drawOrder()
+	for i, data := range order {
+		if p.Context().Err() != nil {
+			return
+		}
+		part("plotter", data)
+		p.progress = span.sub(float64(i)/float64(len(order)), float64(i+1)/float64(len(order)))
+		p.ReportProgress(0, "plotter")
+		p.guard(func() { data.Plot(dataC, p) })
+	}
+	p.progress = span
+	if p.Context().Err() != nil {
+		return
+	}
+
+	p.ReportProgress(1, "legend")
+	part("legend", nil)
+	p.guard(func() {
+		lc := draw.Crop(c, ywidth, 0, xheight, 0)
+		l := p.placedLegend(lc, dataC)
+		l.Draw(lc)
+	})
+}
+
+// ReportProgress reports the progress of the stage of the
+// drawing of the plot to the Progress function of the plot,
+// if it is not nil. The fraction is the fraction of the part
+// of the plot being drawn that is complete, and is scaled to
+// the fraction of the whole drawing taken by the part. Plotters
+// that are slow to draw may call ReportProgress from their Plot
+// method to report their progress.
+func (p *Plot) ReportProgress(fraction float64, stage string) {
+	if p.Progress == nil {
+		return
+	}
+	p.Progress(p.progress.at(fraction), stage)
+}
+
+// progressSpan is the span of the fraction of a rendering
+// taken by a part of the rendering. The zero value spans
+// the whole rendering.
+type progressSpan struct {
+	lo, hi float64
+}
+
+// at returns the fraction of the rendering corresponding
+// to the fraction f of the span.
+func (s progressSpan) at(f float64) float64 {
+	if s == (progressSpan{}) {
+		return f
 	}
+	return s.lo + f*(s.hi-s.lo)
+}
 
-	p.Legend.Draw(draw.Crop(c, ywidth, 0, xheight, 0))
+// sub returns the span between the fractions lo and hi
+// of s.
+func (s progressSpan) sub(lo, hi float64) progressSpan {
+	return progressSpan{lo: s.at(lo), hi: s.at(hi)}
 }
 
 // DataCanvas returns a new draw.Canvas that
@@ -176,11 +550,103 @@ func (p *Plot) DataCanvas(da draw.Canvas) draw.Canvas {
 		da.Max.Y -= p.Title.Height(p.Title.Text) - p.Title.Font.Extents().Descent
 		da.Max.Y -= p.Title.Padding
 	}
+	if twin, ok := p.twinX(); ok {
+		da.Max.Y -= twin.size()
+	}
+	x, y := p.axes(da)
+	outer := da.Rectangle
+	da = p.constrain(da, x, y)
+	dataC := padY(p, padX(p, draw.Crop(da, y.size(), 0, x.size(), 0)))
+	return draw.WithOuter(dataC, outer)
+}
+
+// legendCanvas returns the draw.Canvas within the
+// given draw area into which the legend is drawn.
+func (p *Plot) legendCanvas(da draw.Canvas) draw.Canvas {
+	if p.Title.Text != "" {
+		da.Max.Y -= p.Title.Height(p.Title.Text) - p.Title.Font.Extents().Descent
+		da.Max.Y -= p.Title.Padding
+	}
+	if twin, ok := p.twinX(); ok {
+		da.Max.Y -= twin.size()
+	}
+	x, y := p.axes(da)
+	da = p.constrain(da, x, y)
+	return draw.Crop(da, y.size(), 0, x.size(), 0)
+}
+
+// placedLegend returns the legend of the plot to be drawn
+// in the legend canvas lc. A legend placed at data coordinates
+// is returned placed at the fractions of lc corresponding to
+// its position in the data area dataC.
+func (p *Plot) placedLegend(lc, dataC draw.Canvas) Legend {
+	l := p.Legend
+	if l.Placement != LegendData {
+		return l
+	}
+	a := l.area(lc)
+	w, h := a.Max.X-a.Min.X, a.Max.Y-a.Min.Y
+	if w <= 0 || h <= 0 {
+		return l
+	}
+	x := dataC.X(p.X.Norm(l.X))
+	y := dataC.Y(p.Y.Norm(l.Y))
+	l.Placement = LegendFraction
+	l.X = float64((x - a.Min.X) / w)
+	l.Y = float64((y - a.Min.Y) / h)
+	return l
+}
+
+// axes sanitizes the ranges of the axes of p and lays them
+// out for drawing in da, the area holding the axes and data,
+// returning the axes. The length of each axis is approximated
+// by the extent of da less the size of the other axis.
+func (p *Plot) axes(da draw.Canvas) (horizontalAxis, verticalAxis) {
 	p.X.sanitizeRange()
-	x := horizontalAxis{p.X}
 	p.Y.sanitizeRange()
-	y := verticalAxis{p.Y}
-	return padY(p, padX(p, draw.Crop(da, y.size(), 0, x.size(), 0)))
+	p.X.length = da.Max.X - da.Min.X
+	p.Y.length = da.Max.Y - da.Min.Y
+	p.X.length -= verticalAxis{p.Y}.size()
+	p.Y.length -= horizontalAxis{p.X}.size()
+	p.X.length -= p.X.MinPadding + p.X.MaxPadding
+	p.Y.length -= p.Y.MinPadding + p.Y.MaxPadding
+	return horizontalAxis{p.X}, verticalAxis{p.Y}
+}
+
+// constrain returns the area of da, the area holding the
+// axes and data, reduced and centered so that the data area
+// satisfies the DataAspect or AxesAspect constraint of the
+// plot. If the plot has no aspect constraint, da is returned
+// unaltered.
+func (p *Plot) constrain(da draw.Canvas, x horizontalAxis, y verticalAxis) draw.Canvas {
+	ratio := p.AxesAspect
+	if p.DataAspect > 0 {
+		ratio = p.DataAspect * (p.Y.Max - p.Y.Min) / (p.X.Max - p.X.Min)
+	}
+	if !(ratio > 0) || math.IsInf(ratio, 0) {
+		return da
+	}
+
+	// Padding for glyphs depends on the size of the
+	// data area, so refine the reduction once.
+	for i := 0; i < 2; i++ {
+		dc := padY(p, padX(p, draw.Crop(da, y.size(), 0, x.size(), 0)))
+		w := dc.Max.X - dc.Min.X
+		h := dc.Max.Y - dc.Min.Y
+		if w <= 0 || h <= 0 {
+			return da
+		}
+		if want := w * vg.Length(ratio); want < h {
+			d := (h - want) / 2
+			da.Min.Y += d
+			da.Max.Y -= d
+		} else {
+			d := (w - h/vg.Length(ratio)) / 2
+			da.Min.X += d
+			da.Max.X -= d
+		}
+	}
+	return da
 }
 
 // DrawGlyphBoxes draws red outlines around the plot's
@@ -195,12 +661,22 @@ func (p *Plot) DrawGlyphBoxes(c *draw.Canvas) {
 }
 
 // padX returns a draw.Canvas that is padded horizontally
-// so that glyphs will no be clipped.
+// by the end padding of the X axis, by the space for the
+// opposite line of the Y axis and so that glyphs will no
+// be clipped.
 func padX(p *Plot, c draw.Canvas) draw.Canvas {
+	lo, hi := p.X.endPadding()
+	if p.Y.OppositeLine.Width > 0 {
+		hi += p.Y.Padding + p.Y.OppositeLine.Width/2
+	}
+	c = draw.Crop(c, lo, -hi, 0, 0)
 	glyphs := p.GlyphBoxes(p)
 	l := leftMost(&c, glyphs)
 	xAxis := horizontalAxis{p.X}
 	glyphs = append(glyphs, xAxis.GlyphBoxes(p)...)
+	if twin, ok := p.twinX(); ok {
+		glyphs = append(glyphs, twin.GlyphBoxes(p)...)
+	}
 	r := rightMost(&c, glyphs)
 
 	minx := c.Min.X - l.Min.X
@@ -251,8 +727,15 @@ func leftMost(c *draw.Canvas, boxes []GlyphBox) GlyphBox {
 }
 
 // padY returns a draw.Canvas that is padded vertically
-// so that glyphs will no be clipped.
+// by the end padding of the Y axis, by the space for the
+// opposite line of the X axis and so that glyphs will no
+// be clipped.
 func padY(p *Plot, c draw.Canvas) draw.Canvas {
+	lo, hi := p.Y.endPadding()
+	if p.X.OppositeLine.Width > 0 {
+		hi += p.X.Padding + p.X.OppositeLine.Width/2
+	}
+	c = draw.Crop(c, 0, 0, lo, -hi)
 	glyphs := p.GlyphBoxes(p)
 	b := bottomMost(&c, glyphs)
 	yAxis := verticalAxis{p.Y}
@@ -436,13 +919,43 @@ func (p *Plot) NominalY(names ...string) {
 //
 // Supported formats are:
 //
-//  eps, jpg|jpeg, pdf, png, svg, and tif|tiff.
-func (p *Plot) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
-	c, err := draw.NewFormattedCanvas(w, h, format)
+//  eps, jpg|jpeg, pdf, png, svg, svgz, and tif|tiff.
+//
+// The image is encoded with the given options, as described
+// by draw.NewFormattedCanvas. The image is transparent where
+// nothing is drawn only if a transparent background is set by
+// the options, as with draw.Background(nil). The plot is drawn
+// with Draw, so WriterTo panics if a part of the plot fails.
+func (p *Plot) WriterTo(w, h vg.Length, format string, opts ...draw.FormatOption) (io.WriterTo, error) {
+	return p.writerTo(w, h, format, opts, func(c draw.Canvas) error {
+		p.Draw(c)
+		return nil
+	})
+}
+
+// WriterToContext returns an io.WriterTo as for WriterTo,
+// drawing the plot with DrawContext. The error of ctx is
+// returned if it is done before the plot is drawn, and the
+// failures of the parts of the plot are returned as DrawErrors.
+func (p *Plot) WriterToContext(ctx context.Context, w, h vg.Length, format string, opts ...draw.FormatOption) (io.WriterTo, error) {
+	return p.writerTo(w, h, format, opts, func(c draw.Canvas) error {
+		return p.DrawContext(ctx, c)
+	})
+}
+
+// writerTo returns an io.WriterTo holding the plot drawn
+// by drawFn as described for WriterTo.
+func (p *Plot) writerTo(w, h vg.Length, format string, opts []draw.FormatOption, drawFn func(draw.Canvas) error) (io.WriterTo, error) {
+	if err := validSize(w, h); err != nil {
+		return nil, err
+	}
+	c, err := draw.NewFormattedCanvas(w, h, format, opts...)
 	if err != nil {
 		return nil, err
 	}
-	p.Draw(draw.New(c))
+	if err := drawFn(draw.New(c)); err != nil {
+		return nil, err
+	}
 	return c, nil
 }
 
@@ -451,8 +964,32 @@ func (p *Plot) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
 //
 // Supported extensions are:
 //
-//  .eps, .jpg, .jpeg, .pdf, .png, .svg, .tif and .tiff.
-func (p *Plot) Save(w, h vg.Length, file string) (err error) {
+//  .eps, .jpg, .jpeg, .pdf, .png, .svg, .svgz, .tif and .tiff.
+//
+// The image is encoded with the given options, as described
+// by draw.NewFormattedCanvas. The plot is drawn with Draw, so
+// Save panics if a part of the plot fails.
+func (p *Plot) Save(w, h vg.Length, file string, opts ...draw.FormatOption) error {
+	return p.save(w, h, file, opts, func(c draw.Canvas) error {
+		p.Draw(c)
+		return nil
+	})
+}
+
+// SaveContext saves the plot to an image file as for Save,
+// drawing the plot with DrawContext. If ctx is done before
+// the plot is drawn, its error is returned and the file may
+// be incomplete. The failures of the parts of the plot are
+// returned as DrawErrors.
+func (p *Plot) SaveContext(ctx context.Context, w, h vg.Length, file string, opts ...draw.FormatOption) error {
+	return p.save(w, h, file, opts, func(c draw.Canvas) error {
+		return p.DrawContext(ctx, c)
+	})
+}
+
+// save saves the plot drawn by drawFn to an image
+// file as described for Save.
+func (p *Plot) save(w, h vg.Length, file string, opts []draw.FormatOption, drawFn func(draw.Canvas) error) (err error) {
 	f, err := os.Create(file)
 	if err != nil {
 		return err
@@ -468,11 +1005,46 @@ func (p *Plot) Save(w, h vg.Length, file string) (err error) {
 	if len(format) != 0 {
 		format = format[1:]
 	}
-	c, err := p.WriterTo(w, h, format)
+	return p.stream(f, w, h, format, opts, drawFn)
+}
+
+// Stream draws the plot to out in the given image format, with
+// the same formats as Save, encoded with the given options. Only
+// the eps, svg and svgz formats are written as the plot is drawn;
+// the pdf and raster formats are held in memory until the plot
+// has been drawn, as described by draw.NewFormattedWriter. The plot is drawn with Draw, so Stream panics
+// if a part of the plot fails.
+func (p *Plot) Stream(out io.Writer, w, h vg.Length, format string, opts ...draw.FormatOption) error {
+	return p.stream(out, w, h, format, opts, func(c draw.Canvas) error {
+		p.Draw(c)
+		return nil
+	})
+}
+
+// StreamContext draws the plot to out as for Stream, drawing
+// the plot with DrawContext. If ctx is done before the plot is
+// drawn, its error is returned and the output may be incomplete.
+// The failures of the parts of the plot are returned as DrawErrors.
+func (p *Plot) StreamContext(ctx context.Context, out io.Writer, w, h vg.Length, format string, opts ...draw.FormatOption) error {
+	return p.stream(out, w, h, format, opts, func(c draw.Canvas) error {
+		return p.DrawContext(ctx, c)
+	})
+}
+
+// stream draws the plot with drawFn to out as
+// described for Stream.
+func (p *Plot) stream(out io.Writer, w, h vg.Length, format string, opts []draw.FormatOption, drawFn func(draw.Canvas) error) error {
+	if err := validSize(w, h); err != nil {
+		return err
+	}
+	c, err := draw.NewFormattedWriter(out, w, h, format, opts...)
 	if err != nil {
 		return err
 	}
-
-	_, err = c.WriteTo(f)
-	return err
+	if err := drawFn(draw.New(c)); err != nil {
+		c.Close()
+		return err
+	}
+	p.ReportProgress(1, "encode")
+	return c.Close()
 }
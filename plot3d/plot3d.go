@@ -0,0 +1,547 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package plot3d provides a plot type for drawing three
+// dimensional data, with plotters for surfaces, wireframes
+// and scattered points.
+//
+// Plotters add polygons, lines and glyphs to a Scene, which
+// projects them through the Camera of the plot and draws them
+// from back to front, so that nearer primitives are drawn over
+// those behind them. Plots are rendered through the vg
+// backends in the same way as plot.Plot.
+package plot3d // import "gonum.org/v1/plot/plot3d"
+
+import (
+	"image/color"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Plot is a three dimensional plot.
+type Plot struct {
+	Title struct {
+		// Text is the text of the plot title. If
+		// Text is the empty string then the plot
+		// will not have a title.
+		Text string
+
+		// Padding is the amount of padding
+		// between the bottom of the title and
+		// the top of the plot.
+		Padding vg.Length
+
+		draw.TextStyle
+	}
+
+	// BackgroundColor is the background color of the plot.
+	// The default is White.
+	BackgroundColor color.Color
+
+	// X, Y and Z are the axes of the plot.
+	X, Y, Z Axis
+
+	// Camera is the view of the plot.
+	Camera Camera
+
+	// plotters are drawn by calling their Plot method.
+	plotters []Plotter
+}
+
+// Axis is an axis of a three dimensional plot.
+type Axis struct {
+	// Min and Max are the minimum and maximum data
+	// values represented by the axis.
+	Min, Max float64
+
+	Label struct {
+		// Text is the axis label string.
+		Text string
+
+		// TextStyle is the style of the axis label text.
+		draw.TextStyle
+	}
+
+	// LineStyle is the style of the axis line.
+	draw.LineStyle
+
+	Tick struct {
+		// Label is the TextStyle on the tick labels.
+		Label draw.TextStyle
+
+		// Length is the distance between the axis
+		// line and the tick labels.
+		Length vg.Length
+
+		// Marker returns the tick marks. Minor ticks
+		// and ticks not in range of the axis are not
+		// drawn.
+		Marker plot.Ticker
+	}
+}
+
+// Camera describes the view of a three dimensional plot.
+// The data are drawn within a cube centered on the origin
+// of the view.
+type Camera struct {
+	// Azimuth is the angle in radians of the viewer
+	// about the Z axis, measured counterclockwise from
+	// the negative Y axis.
+	Azimuth float64
+
+	// Elevation is the angle in radians of the viewer
+	// above the X-Y plane.
+	Elevation float64
+
+	// Distance is the distance of the viewer from the
+	// center of the cube, in units of the side length
+	// of the cube. If Distance is zero, an orthographic
+	// projection is used.
+	Distance float64
+}
+
+// DefaultCamera is the default camera of a plot, viewing
+// the plot from above the negative X and Y axes.
+var DefaultCamera = Camera{
+	Azimuth:   -math.Pi / 6,
+	Elevation: math.Pi / 6,
+}
+
+// Project returns the screen position of the point (x, y, z)
+// in the coordinates of the cube, with each coordinate in
+// [-0.5, 0.5], and its depth toward the viewer.
+func (cam Camera) Project(x, y, z float64) (sx, sy, depth float64) {
+	sin, cos := math.Sincos(cam.Azimuth)
+	x, y = x*cos+y*sin, -x*sin+y*cos
+	sin, cos = math.Sincos(cam.Elevation)
+	sx = x
+	sy = z*cos + y*sin
+	depth = z*sin - y*cos
+	if cam.Distance > 0 {
+		f := cam.Distance / (cam.Distance - depth)
+		sx *= f
+		sy *= f
+	}
+	return sx, sy, depth
+}
+
+// Plotter is an interface that wraps the Plot method.
+type Plotter interface {
+	// Plot adds the primitives of the plotter
+	// to the scene.
+	Plot(*Scene)
+}
+
+// DataRanger wraps the DataRange method.
+type DataRanger interface {
+	// DataRange returns the range of X, Y and Z values.
+	DataRange() (xmin, xmax, ymin, ymax, zmin, zmax float64)
+}
+
+// New returns a new three dimensional plot with some
+// reasonable default settings.
+func New() (*Plot, error) {
+	titleFont, err := vg.MakeFont(plot.DefaultFont, 12)
+	if err != nil {
+		return nil, err
+	}
+	p := &Plot{
+		BackgroundColor: color.White,
+		Camera:          DefaultCamera,
+	}
+	for _, a := range []*Axis{&p.X, &p.Y, &p.Z} {
+		*a, err = makeAxis()
+		if err != nil {
+			return nil, err
+		}
+	}
+	p.Title.TextStyle = draw.TextStyle{
+		Color:  color.Black,
+		Font:   titleFont,
+		XAlign: draw.XCenter,
+		YAlign: draw.YTop,
+	}
+	return p, nil
+}
+
+// makeAxis returns a default Axis.
+func makeAxis() (Axis, error) {
+	labelFont, err := vg.MakeFont(plot.DefaultFont, vg.Points(12))
+	if err != nil {
+		return Axis{}, err
+	}
+	tickFont, err := vg.MakeFont(plot.DefaultFont, vg.Points(10))
+	if err != nil {
+		return Axis{}, err
+	}
+	a := Axis{
+		Min: math.Inf(1),
+		Max: math.Inf(-1),
+		LineStyle: draw.LineStyle{
+			Color: color.Black,
+			Width: vg.Points(0.5),
+		},
+	}
+	a.Label.TextStyle = draw.TextStyle{
+		Color:  color.Black,
+		Font:   labelFont,
+		XAlign: draw.XCenter,
+		YAlign: draw.YCenter,
+	}
+	a.Tick.Label = draw.TextStyle{
+		Color:  color.Black,
+		Font:   tickFont,
+		XAlign: draw.XCenter,
+		YAlign: draw.YCenter,
+	}
+	a.Tick.Length = vg.Points(10)
+	a.Tick.Marker = plot.DefaultTicks{}
+	return a, nil
+}
+
+// sanitizeRange ensures that the range of the
+// axis makes sense.
+func (a *Axis) sanitizeRange() {
+	if math.IsInf(a.Min, 0) {
+		a.Min = 0
+	}
+	if math.IsInf(a.Max, 0) {
+		a.Max = 0
+	}
+	if a.Min > a.Max {
+		a.Min, a.Max = a.Max, a.Min
+	}
+	if a.Min == a.Max {
+		a.Min--
+		a.Max++
+	}
+}
+
+// norm returns the position of x within the cube.
+func (a *Axis) norm(x float64) float64 {
+	return (x-a.Min)/(a.Max-a.Min) - 0.5
+}
+
+// Add adds Plotters to the plot.
+//
+// If the plotters implement DataRanger then the
+// minimum and maximum values of the axes are
+// changed if necessary to fit the range of the
+// data.
+func (p *Plot) Add(ps ...Plotter) {
+	for _, d := range ps {
+		if r, ok := d.(DataRanger); ok {
+			xmin, xmax, ymin, ymax, zmin, zmax := r.DataRange()
+			p.X.Min = math.Min(p.X.Min, xmin)
+			p.X.Max = math.Max(p.X.Max, xmax)
+			p.Y.Min = math.Min(p.Y.Min, ymin)
+			p.Y.Max = math.Max(p.Y.Max, ymax)
+			p.Z.Min = math.Min(p.Z.Min, zmin)
+			p.Z.Max = math.Max(p.Z.Max, zmax)
+		}
+	}
+	p.plotters = append(p.plotters, ps...)
+}
+
+// Draw draws the plot to a draw.Canvas.
+func (p *Plot) Draw(c draw.Canvas) {
+	if p.BackgroundColor != nil {
+		c.SetColor(p.BackgroundColor)
+		c.Fill(c.Rectangle.Path())
+	}
+	if p.Title.Text != "" {
+		c.FillText(p.Title.TextStyle, vg.Point{X: c.Center().X, Y: c.Max.Y}, p.Title.Text)
+		c.Max.Y -= p.Title.Height(p.Title.Text) - p.Title.Font.Extents().Descent
+		c.Max.Y -= p.Title.Padding
+	}
+	p.X.sanitizeRange()
+	p.Y.sanitizeRange()
+	p.Z.sanitizeRange()
+
+	// Leave room for tick and axis labels.
+	var pad vg.Length
+	for _, a := range []*Axis{&p.X, &p.Y, &p.Z} {
+		pad = vg.Length(math.Max(float64(pad), float64(2*a.Tick.Length+a.Tick.Label.Font.Size+a.Label.Font.Size)))
+	}
+	s := newScene(p, draw.Crop(c, pad, -pad, pad, -pad))
+
+	s.drawAxes(c)
+	for _, d := range p.plotters {
+		d.Plot(s)
+	}
+	s.draw(c)
+}
+
+// WriterTo returns an io.WriterTo that will write the plot as
+// the specified image format.
+//
+// Supported formats are:
+//
+//  eps, jpg|jpeg, pdf, png, svg, and tif|tiff.
+func (p *Plot) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
+	c, err := draw.NewFormattedCanvas(w, h, format)
+	if err != nil {
+		return nil, err
+	}
+	p.Draw(draw.New(c))
+	return c, nil
+}
+
+// Save saves the plot to an image file. The file format is
+// determined by the extension.
+//
+// Supported extensions are:
+//
+//  .eps, .jpg, .jpeg, .pdf, .png, .svg, .tif and .tiff.
+func (p *Plot) Save(w, h vg.Length, file string) (err error) {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		e := f.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+
+	format := strings.ToLower(filepath.Ext(file))
+	if len(format) != 0 {
+		format = format[1:]
+	}
+	c, err := p.WriterTo(w, h, format)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.WriteTo(f)
+	return err
+}
+
+// Point is a location in the data coordinates
+// of a three dimensional plot.
+type Point struct {
+	X, Y, Z float64
+}
+
+// Scene collects the primitives of the plotters of a plot
+// and draws them in order of depth.
+type Scene struct {
+	plot *Plot
+
+	// scale and offset map the projected
+	// cube onto the canvas.
+	scale  vg.Length
+	offset vg.Point
+
+	prims []primitive
+}
+
+// primitive is a projected polygon, line or glyph.
+type primitive struct {
+	pts   []vg.Point
+	depth float64
+
+	fill  color.Color
+	line  draw.LineStyle
+	glyph *draw.GlyphStyle
+	close bool
+}
+
+// newScene returns a Scene for p fitting the
+// projection of the plot cube within c.
+func newScene(p *Plot, c draw.Canvas) *Scene {
+	xmin, xmax := math.Inf(1), math.Inf(-1)
+	ymin, ymax := math.Inf(1), math.Inf(-1)
+	for _, x := range []float64{-0.5, 0.5} {
+		for _, y := range []float64{-0.5, 0.5} {
+			for _, z := range []float64{-0.5, 0.5} {
+				sx, sy, _ := p.Camera.Project(x, y, z)
+				xmin = math.Min(xmin, sx)
+				xmax = math.Max(xmax, sx)
+				ymin = math.Min(ymin, sy)
+				ymax = math.Max(ymax, sy)
+			}
+		}
+	}
+	w, h := c.Max.X-c.Min.X, c.Max.Y-c.Min.Y
+	scale := vg.Length(math.Min(float64(w)/(xmax-xmin), float64(h)/(ymax-ymin)))
+	center := c.Center()
+	return &Scene{
+		plot:  p,
+		scale: scale,
+		offset: vg.Point{
+			X: center.X - scale*vg.Length(xmin+xmax)/2,
+			Y: center.Y - scale*vg.Length(ymin+ymax)/2,
+		},
+	}
+}
+
+// Plot returns the plot being drawn.
+func (s *Scene) Plot() *Plot { return s.plot }
+
+// Project returns the canvas position of the data point
+// pt and its depth toward the viewer.
+func (s *Scene) Project(pt Point) (vg.Point, float64) {
+	return s.project(s.plot.X.norm(pt.X), s.plot.Y.norm(pt.Y), s.plot.Z.norm(pt.Z))
+}
+
+// project returns the canvas position and depth of the
+// point (x, y, z) in cube coordinates.
+func (s *Scene) project(x, y, z float64) (vg.Point, float64) {
+	sx, sy, depth := s.plot.Camera.Project(x, y, z)
+	return vg.Point{
+		X: s.offset.X + s.scale*vg.Length(sx),
+		Y: s.offset.Y + s.scale*vg.Length(sy),
+	}, depth
+}
+
+// add adds a primitive with the projected points
+// of pts to the scene.
+func (s *Scene) add(prim primitive, pts []Point) {
+	prim.pts = make([]vg.Point, len(pts))
+	for i, pt := range pts {
+		var d float64
+		prim.pts[i], d = s.Project(pt)
+		prim.depth += d
+	}
+	prim.depth /= float64(len(pts))
+	s.prims = append(s.prims, prim)
+}
+
+// Polygon adds a polygon with the given vertices to
+// the scene. The polygon is filled with fill, if it is
+// not nil, and outlined with the line style.
+func (s *Scene) Polygon(fill color.Color, line draw.LineStyle, pts ...Point) {
+	if len(pts) == 0 {
+		return
+	}
+	s.add(primitive{fill: fill, line: line, close: true}, pts)
+}
+
+// Line adds a line joining the points to the scene.
+func (s *Scene) Line(sty draw.LineStyle, pts ...Point) {
+	if len(pts) == 0 {
+		return
+	}
+	s.add(primitive{line: sty}, pts)
+}
+
+// Glyph adds a glyph at pt to the scene.
+func (s *Scene) Glyph(sty draw.GlyphStyle, pt Point) {
+	s.add(primitive{glyph: &sty}, []Point{pt})
+}
+
+// draw draws the primitives of the scene to c,
+// from back to front.
+func (s *Scene) draw(c draw.Canvas) {
+	sort.SliceStable(s.prims, func(i, j int) bool {
+		return s.prims[i].depth < s.prims[j].depth
+	})
+	for _, prim := range s.prims {
+		switch {
+		case prim.glyph != nil:
+			c.DrawGlyphNoClip(*prim.glyph, prim.pts[0])
+		case prim.close:
+			if prim.fill != nil {
+				c.FillPolygon(prim.fill, prim.pts)
+			}
+			if prim.line.Color != nil && prim.line.Width > 0 {
+				c.StrokeLines(prim.line, append(prim.pts, prim.pts[0]))
+			}
+		default:
+			c.StrokeLines(prim.line, prim.pts)
+		}
+	}
+	s.prims = s.prims[:0]
+}
+
+// drawAxes draws the axes of the plot along the edges
+// of the cube nearest the viewer, with their tick labels
+// and axis labels placed outside the cube.
+func (s *Scene) drawAxes(c draw.Canvas) {
+	p := s.plot
+	center, _ := s.project(0, 0, 0)
+
+	// nearest returns the edge of the cube parallel to
+	// the axis dim, lying in the base of the cube for the
+	// X and Y axes, that is nearest the viewer. For the Z
+	// axis the left-most vertical edge is chosen.
+	nearest := func(dim int) (u, v float64) {
+		best := math.Inf(-1)
+		for _, a := range []float64{-0.5, 0.5} {
+			for _, b := range []float64{-0.5, 0.5} {
+				var score float64
+				switch dim {
+				case 0:
+					if b != -0.5 {
+						continue
+					}
+					_, score = s.project(0, a, b)
+				case 1:
+					if b != -0.5 {
+						continue
+					}
+					_, score = s.project(a, 0, b)
+				case 2:
+					pt, _ := s.project(a, b, 0)
+					score = -float64(pt.X)
+				}
+				if score > best {
+					best, u, v = score, a, b
+				}
+			}
+		}
+		return u, v
+	}
+
+	for dim, a := range []*Axis{&p.X, &p.Y, &p.Z} {
+		u, v := nearest(dim)
+		at := func(t float64) (float64, float64, float64) {
+			switch dim {
+			case 0:
+				return t, u, v
+			case 1:
+				return u, t, v
+			default:
+				return u, v, t
+			}
+		}
+
+		start, _ := s.project(at(-0.5))
+		end, _ := s.project(at(0.5))
+		c.StrokeLine2(a.LineStyle, start.X, start.Y, end.X, end.Y)
+
+		// outward returns the point dist from pt
+		// away from the center of the cube.
+		outward := func(pt vg.Point, dist vg.Length) vg.Point {
+			d := pt.Sub(center)
+			n := vg.Length(math.Hypot(float64(d.X), float64(d.Y)))
+			if n == 0 {
+				return pt
+			}
+			return pt.Add(d.Scale(dist / n))
+		}
+
+		for _, t := range a.Tick.Marker.Ticks(a.Min, a.Max) {
+			if t.IsMinor() || t.Value < a.Min || t.Value > a.Max {
+				continue
+			}
+			pt, _ := s.project(at(a.norm(t.Value)))
+			c.FillText(a.Tick.Label, outward(pt, a.Tick.Length), t.Label)
+		}
+		if a.Label.Text != "" {
+			mid, _ := s.project(at(0))
+			c.FillText(a.Label.TextStyle, outward(mid, 2*a.Tick.Length+a.Tick.Label.Font.Size), a.Label.Text)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot3d_test
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/plot3d"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// sincGrid is a plotter.GridXYZ of a radial sinc function
+// sampled on a square grid centered on the origin.
+type sincGrid struct{ mat.Matrix }
+
+func newSincGrid(n int) sincGrid {
+	m := mat.NewDense(n, n, nil)
+	g := sincGrid{m}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			r := math.Hypot(g.X(i), g.Y(j))
+			if r == 0 {
+				m.Set(j, i, 1)
+				continue
+			}
+			m.Set(j, i, math.Sin(r)/r)
+		}
+	}
+	return g
+}
+
+func (g sincGrid) Dims() (c, r int)   { r, c = g.Matrix.Dims(); return c, r }
+func (g sincGrid) Z(c, r int) float64 { return g.Matrix.At(r, c) }
+func (g sincGrid) X(c int) float64 {
+	n, _ := g.Matrix.Dims()
+	return 20*float64(c)/float64(n-1) - 10
+}
+func (g sincGrid) Y(r int) float64 { return g.X(r) }
+
+func ExampleSurface() {
+	p, err := plot3d.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Surface"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+	p.Z.Label.Text = "Z"
+
+	s := plot3d.NewSurface(newSincGrid(25), moreland.SmoothBlueRed().Palette(32))
+	p.Add(s)
+
+	peak, err := plot3d.NewScatter(plotter.XYZs{{X: 0, Y: 0, Z: 1.2}})
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Add(peak)
+
+	err = p.Save(12*vg.Centimeter, 12*vg.Centimeter, "testdata/surface.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestSurface(t *testing.T) {
+	cmpimg.CheckPlot(ExampleSurface, t, "surface.png")
+}
+
+func ExampleWireframe() {
+	p, err := plot3d.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Wireframe"
+	p.Camera.Distance = 3
+	p.Add(plot3d.NewWireframe(newSincGrid(15)))
+
+	err = p.Save(12*vg.Centimeter, 12*vg.Centimeter, "testdata/wireframe.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestWireframe(t *testing.T) {
+	cmpimg.CheckPlot(ExampleWireframe, t, "wireframe.png")
+}
+
+func TestCamera(t *testing.T) {
+	const tol = 1e-15
+	for _, test := range []struct {
+		cam                     plot3d.Camera
+		x, y, z                 float64
+		wantX, wantY, wantDepth float64
+	}{
+		{cam: plot3d.Camera{}, x: 0.5, y: 0.5, z: 0.25, wantX: 0.5, wantY: 0.25, wantDepth: -0.5},
+		{cam: plot3d.Camera{Elevation: math.Pi / 2}, x: 0.5, y: 0.25, z: 0.5, wantX: 0.5, wantY: 0.25, wantDepth: 0.5},
+		{cam: plot3d.Camera{Azimuth: math.Pi / 2}, x: 0.5, y: 0, z: 0, wantX: 0, wantY: 0, wantDepth: 0.5},
+		{cam: plot3d.Camera{Distance: 2}, x: 0.5, y: -0.5, z: 0, wantX: 2.0 / 3, wantY: 0, wantDepth: 0.5},
+	} {
+		x, y, depth := test.cam.Project(test.x, test.y, test.z)
+		if math.Abs(x-test.wantX) > tol || math.Abs(y-test.wantY) > tol || math.Abs(depth-test.wantDepth) > tol {
+			t.Errorf("unexpected projection of (%v, %v, %v) by %+v: got:(%v, %v, %v) want:(%v, %v, %v)",
+				test.x, test.y, test.z, test.cam, x, y, depth, test.wantX, test.wantY, test.wantDepth)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot3d
+
+import (
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Surface implements the Plotter interface, drawing a
+// surface over gridded data, with each cell of the grid
+// filled with a palette color representing its height.
+type Surface struct {
+	GridXYZ plotter.GridXYZ
+
+	// Palette is the color palette used to fill the
+	// cells of the surface. If Palette is nil, cells
+	// are not filled.
+	Palette palette.Palette
+
+	// Min and Max define the dynamic range of the
+	// palette. Heights outside the range are drawn
+	// with the color of the nearest limit.
+	Min, Max float64
+
+	// LineStyle is the style of the lines around
+	// each cell.
+	draw.LineStyle
+}
+
+// NewSurface returns a Surface over the grid g, filled
+// using the palette p, with the dynamic range set to the
+// range of the Z values of g.
+func NewSurface(g plotter.GridXYZ, p palette.Palette) *Surface {
+	_, _, _, _, min, max := gridRange(g)
+	return &Surface{
+		GridXYZ: g,
+		Palette: p,
+		Min:     min,
+		Max:     max,
+		LineStyle: draw.LineStyle{
+			Color: color.Gray{Y: 64},
+			Width: vg.Points(0.25),
+		},
+	}
+}
+
+// Plot implements the Plotter interface.
+func (s *Surface) Plot(sc *Scene) {
+	var pal []color.Color
+	if s.Palette != nil {
+		pal = s.Palette.Colors()
+	}
+	cols, rows := s.GridXYZ.Dims()
+	for i := 0; i < cols-1; i++ {
+		for j := 0; j < rows-1; j++ {
+			pts := cell(s.GridXYZ, i, j)
+			var fill color.Color
+			if len(pal) != 0 {
+				z := (pts[0].Z + pts[1].Z + pts[2].Z + pts[3].Z) / 4
+				if math.IsNaN(z) {
+					continue
+				}
+				fill = pal[paletteIndex(z, s.Min, s.Max, len(pal))]
+			}
+			sc.Polygon(fill, s.LineStyle, pts...)
+		}
+	}
+}
+
+// DataRange implements the DataRanger interface.
+func (s *Surface) DataRange() (xmin, xmax, ymin, ymax, zmin, zmax float64) {
+	return gridRange(s.GridXYZ)
+}
+
+// Wireframe implements the Plotter interface, drawing
+// a wireframe over gridded data.
+type Wireframe struct {
+	GridXYZ plotter.GridXYZ
+
+	// LineStyle is the style of the wireframe lines.
+	draw.LineStyle
+}
+
+// NewWireframe returns a Wireframe over the grid g using
+// the default line style.
+func NewWireframe(g plotter.GridXYZ) *Wireframe {
+	return &Wireframe{
+		GridXYZ:   g,
+		LineStyle: plotter.DefaultLineStyle,
+	}
+}
+
+// Plot implements the Plotter interface.
+func (w *Wireframe) Plot(sc *Scene) {
+	cols, rows := w.GridXYZ.Dims()
+	for i := 0; i < cols-1; i++ {
+		for j := 0; j < rows-1; j++ {
+			sc.Polygon(nil, w.LineStyle, cell(w.GridXYZ, i, j)...)
+		}
+	}
+}
+
+// DataRange implements the DataRanger interface.
+func (w *Wireframe) DataRange() (xmin, xmax, ymin, ymax, zmin, zmax float64) {
+	return gridRange(w.GridXYZ)
+}
+
+// Scatter implements the Plotter interface, drawing
+// a glyph for each of a set of points.
+type Scatter struct {
+	// XYZs is a copy of the points for this scatter.
+	plotter.XYZs
+
+	// GlyphStyle is the style of the glyphs drawn
+	// at each point.
+	draw.GlyphStyle
+}
+
+// NewScatter returns a Scatter that uses the
+// default glyph style.
+func NewScatter(xyzs plotter.XYZer) (*Scatter, error) {
+	data, err := plotter.CopyXYZs(xyzs)
+	if err != nil {
+		return nil, err
+	}
+	return &Scatter{
+		XYZs:       data,
+		GlyphStyle: plotter.DefaultGlyphStyle,
+	}, nil
+}
+
+// Plot implements the Plotter interface.
+func (s *Scatter) Plot(sc *Scene) {
+	for _, p := range s.XYZs {
+		sc.Glyph(s.GlyphStyle, Point{X: p.X, Y: p.Y, Z: p.Z})
+	}
+}
+
+// DataRange implements the DataRanger interface.
+func (s *Scatter) DataRange() (xmin, xmax, ymin, ymax, zmin, zmax float64) {
+	xmin, xmax = plotter.Range(plotter.XValues{XYer: s.XYZs})
+	ymin, ymax = plotter.Range(plotter.YValues{XYer: s.XYZs})
+	zmin, zmax = plotter.Range(zValues(s.XYZs))
+	return xmin, xmax, ymin, ymax, zmin, zmax
+}
+
+// zValues implements the plotter.Valuer interface
+// for the Z values of plotter.XYZs.
+type zValues plotter.XYZs
+
+func (z zValues) Len() int            { return len(z) }
+func (z zValues) Value(i int) float64 { return z[i].Z }
+
+// cell returns the corners of the grid cell with
+// lower left corner at column i and row j.
+func cell(g plotter.GridXYZ, i, j int) []Point {
+	return []Point{
+		{X: g.X(i), Y: g.Y(j), Z: g.Z(i, j)},
+		{X: g.X(i + 1), Y: g.Y(j), Z: g.Z(i+1, j)},
+		{X: g.X(i + 1), Y: g.Y(j + 1), Z: g.Z(i+1, j+1)},
+		{X: g.X(i), Y: g.Y(j + 1), Z: g.Z(i, j+1)},
+	}
+}
+
+// gridRange returns the range of the coordinates
+// and the non-NaN values of g.
+func gridRange(g plotter.GridXYZ) (xmin, xmax, ymin, ymax, zmin, zmax float64) {
+	c, r := g.Dims()
+	xmin, xmax = math.Min(g.X(0), g.X(c-1)), math.Max(g.X(0), g.X(c-1))
+	ymin, ymax = math.Min(g.Y(0), g.Y(r-1)), math.Max(g.Y(0), g.Y(r-1))
+	zmin, zmax = math.Inf(1), math.Inf(-1)
+	for i := 0; i < c; i++ {
+		for j := 0; j < r; j++ {
+			v := g.Z(i, j)
+			if math.IsNaN(v) {
+				continue
+			}
+			zmin = math.Min(zmin, v)
+			zmax = math.Max(zmax, v)
+		}
+	}
+	return xmin, xmax, ymin, ymax, zmin, zmax
+}
+
+// paletteIndex returns the index of the color of z in
+// a palette of n colors spanning [min, max].
+func paletteIndex(z, min, max float64, n int) int {
+	if max <= min {
+		return 0
+	}
+	i := int((z-min)/(max-min)*float64(n-1) + 0.5)
+	if i < 0 {
+		return 0
+	}
+	if i >= n {
+		return n - 1
+	}
+	return i
+}
@@ -6,8 +6,11 @@ package plot_test
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"fmt"
 	"image/color"
+	"math"
 	"reflect"
 	"testing"
 
@@ -202,6 +205,195 @@ func TestLegendAlignment(t *testing.T) {
 	}
 }
 
+func TestAspect(t *testing.T) {
+	for _, test := range []struct {
+		dataAspect, axesAspect float64
+		want                   float64
+	}{
+		{dataAspect: 1, want: 0.5},
+		{dataAspect: 4, want: 2},
+		{axesAspect: 0.75, want: 0.75},
+		{dataAspect: 1, axesAspect: 0.75, want: 0.5},
+	} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Title.Text = "Aspect"
+		p.X.Min, p.X.Max = 0, 2
+		p.Y.Min, p.Y.Max = 0, 1
+		p.DataAspect = test.dataAspect
+		p.AxesAspect = test.axesAspect
+
+		for _, size := range []vg.Point{{X: 10 * vg.Centimeter, Y: 10 * vg.Centimeter}, {X: 20 * vg.Centimeter, Y: 5 * vg.Centimeter}} {
+			c := draw.Canvas{Canvas: new(recorder.Canvas), Rectangle: vg.Rectangle{Max: size}}
+			da := p.DataCanvas(c)
+			got := float64((da.Max.Y - da.Min.Y) / (da.Max.X - da.Min.X))
+			if math.Abs(got-test.want) > 1e-9 {
+				t.Errorf("unexpected aspect ratio for data aspect %v, axes aspect %v and size %v: got:%v want:%v",
+					test.dataAspect, test.axesAspect, size, got, test.want)
+			}
+		}
+	}
+}
+
+func TestAxisInvert(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 10
+	p.X.Tick.Marker = plot.ConstantTicks{{Value: 0, Label: "left"}, {Value: 10, Label: "right"}}
+	p.Y.Tick.Marker = plot.ConstantTicks{{Value: 0, Label: "bottom"}, {Value: 10, Label: "top"}}
+	p.X.Invert = true
+	p.Y.Invert = true
+
+	if got := p.X.Norm(2); got != 0.8 {
+		t.Errorf("unexpected normalized value: got:%v want:0.8", got)
+	}
+
+	c := new(recorder.Canvas)
+	p.Draw(draw.Canvas{Canvas: c, Rectangle: vg.Rectangle{Max: vg.Point{X: 10 * vg.Centimeter, Y: 10 * vg.Centimeter}}})
+	pos := make(map[string]vg.Point)
+	for _, a := range c.Actions {
+		if s, ok := a.(*recorder.FillString); ok {
+			pos[s.String] = s.Point
+		}
+	}
+	if pos["left"].X <= pos["right"].X {
+		t.Errorf("unexpected order of inverted x tick labels: %v is not right of %v", pos["left"], pos["right"])
+	}
+	if pos["bottom"].Y <= pos["top"].Y {
+		t.Errorf("unexpected order of inverted y tick labels: %v is not above %v", pos["bottom"], pos["top"])
+	}
+}
+
+// orderPlotter records the order in which it is drawn.
+type orderPlotter struct {
+	name  string
+	order *[]string
+}
+
+func (o orderPlotter) Plot(draw.Canvas, *plot.Plot) { *o.order = append(*o.order, o.name) }
+
+func TestAddZ(t *testing.T) {
+	var got []string
+	mk := func(name string) plot.Plotter { return orderPlotter{name: name, order: &got} }
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(mk("a"), mk("b"))
+	p.AddZ(-1, mk("grid"))
+	p.AddZ(1, mk("top"))
+	p.Add(mk("c"))
+	p.AddZ(-1, mk("back"))
+
+	p.Draw(draw.Canvas{Canvas: new(recorder.Canvas), Rectangle: vg.Rectangle{Max: vg.Point{X: 10 * vg.Centimeter, Y: 10 * vg.Centimeter}}})
+	want := []string{"grid", "back", "a", "b", "c", "top"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected draw order: got:%v want:%v", got, want)
+	}
+}
+
+// marginPlotter records the area drawn by DrawUnclipped.
+type marginPlotter struct {
+	data, outer *vg.Rectangle
+}
+
+func (m marginPlotter) Plot(c draw.Canvas, _ *plot.Plot) {
+	*m.data = c.Rectangle
+	c.DrawUnclipped(func(c draw.Canvas) { *m.outer = c.Rectangle })
+}
+
+func TestDrawUnclipped(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "Unclipped"
+	var data, outer vg.Rectangle
+	p.Add(marginPlotter{data: &data, outer: &outer})
+
+	c := draw.Canvas{Canvas: new(recorder.Canvas), Rectangle: vg.Rectangle{Max: vg.Point{X: 10 * vg.Centimeter, Y: 10 * vg.Centimeter}}}
+	p.Draw(c)
+	if outer != c.Rectangle {
+		t.Errorf("unexpected unclipped area: got:%+v want:%+v", outer, c.Rectangle)
+	}
+	if data == outer {
+		t.Errorf("data area not within margins: %+v", data)
+	}
+	if da := p.DataCanvas(c); da.Rectangle != data {
+		t.Errorf("unexpected data canvas: got:%+v want:%+v", da.Rectangle, data)
+	}
+}
+
+func TestStream(t *testing.T) {
+	defer func(d bool) { vg.Deterministic = d }(vg.Deterministic)
+	vg.Deterministic = true
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Draw enough points for the streamed output
+	// to be written in several parts.
+	pts := make(plotter.XYs, 20000)
+	for i := range pts {
+		pts[i].X = float64(i)
+		pts[i].Y = math.Sin(float64(i) / 100)
+	}
+	l, err := plotter.NewLine(pts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l)
+
+	for _, format := range []string{"eps", "svg", "svgz", "png"} {
+		wt, err := p.WriterTo(10*vg.Centimeter, 10*vg.Centimeter, format)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", format, err)
+		}
+		var want bytes.Buffer
+		_, err = wt.WriteTo(&want)
+		if err != nil {
+			t.Fatalf("unexpected error writing %s: %v", format, err)
+		}
+		var got bytes.Buffer
+		err = p.Stream(&got, 10*vg.Centimeter, 10*vg.Centimeter, format)
+		if err != nil {
+			t.Fatalf("unexpected error streaming %s: %v", format, err)
+		}
+		gotData, wantData := got.Bytes(), want.Bytes()
+		if format == "svgz" {
+			gotData, wantData = gunzip(t, gotData), gunzip(t, wantData)
+		}
+		if !bytes.Equal(gotData, wantData) {
+			t.Errorf("streamed %s output does not match written output", format)
+		}
+	}
+
+	err = p.Stream(new(bytes.Buffer), 10*vg.Centimeter, 10*vg.Centimeter, "bmp")
+	if err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func gunzip(t *testing.T, data []byte) []byte {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error reading gzip data: %v", err)
+	}
+	var out bytes.Buffer
+	_, err = out.ReadFrom(r)
+	if err != nil {
+		t.Fatalf("unexpected error reading gzip data: %v", err)
+	}
+	return out.Bytes()
+}
+
 func formatActions(actions []recorder.Action) string {
 	var buf bytes.Buffer
 	for _, a := range actions {
@@ -229,3 +421,79 @@ func printFirstDiff(got, want []recorder.Action) string {
 	}
 	return buf.String()
 }
+
+// cancelPlotter cancels the rendering when it is drawn.
+type cancelPlotter struct {
+	cancel context.CancelFunc
+}
+
+func (c cancelPlotter) Plot(draw.Canvas, *plot.Plot) { c.cancel() }
+
+func TestDrawContext(t *testing.T) {
+	var got []string
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p.Add(orderPlotter{name: "before", order: &got}, cancelPlotter{cancel: cancel}, orderPlotter{name: "after", order: &got})
+
+	c := draw.Canvas{Canvas: new(recorder.Canvas), Rectangle: vg.Rectangle{Max: vg.Point{X: 10 * vg.Centimeter, Y: 10 * vg.Centimeter}}}
+	err = p.DrawContext(ctx, c)
+	if err != context.Canceled {
+		t.Errorf("unexpected error: got:%v want:%v", err, context.Canceled)
+	}
+	if want := []string{"before"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected plotters drawn: got:%v want:%v", got, want)
+	}
+	if p.Context() != context.Background() {
+		t.Error("expected background context after drawing")
+	}
+
+	_, err = p.WriterToContext(ctx, 10*vg.Centimeter, 10*vg.Centimeter, "png")
+	if err != context.Canceled {
+		t.Errorf("unexpected WriterToContext error: got:%v want:%v", err, context.Canceled)
+	}
+	err = p.StreamContext(ctx, new(bytes.Buffer), 10*vg.Centimeter, 10*vg.Centimeter, "svg")
+	if err != context.Canceled {
+		t.Errorf("unexpected StreamContext error: got:%v want:%v", err, context.Canceled)
+	}
+}
+
+// progressPlotter reports its progress when it is drawn.
+type progressPlotter struct{}
+
+func (progressPlotter) Plot(_ draw.Canvas, p *plot.Plot) { p.ReportProgress(0.5, "half") }
+
+func TestProgress(t *testing.T) {
+	type report struct {
+		fraction float64
+		stage    string
+	}
+	var got []report
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Progress = func(fraction float64, stage string) {
+		got = append(got, report{fraction, stage})
+	}
+	var order []string
+	p.Add(orderPlotter{name: "first", order: &order}, progressPlotter{})
+
+	err = p.Stream(new(bytes.Buffer), 10*vg.Centimeter, 10*vg.Centimeter, "svg")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []report{
+		{0, "axes"},
+		{0, "plotter"},
+		{0.5, "plotter"},
+		{0.75, "half"},
+		{1, "legend"},
+		{1, "encode"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected progress reports:\ngot: %v\nwant:%v", got, want)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+// The types in this file adapt data held in numeric
+// types other than float64 to the Valuer, XYer and XYZer
+// interfaces, so that such data can be passed to plotters
+// without first being converted by the caller. The adapters
+// only avoid the caller's conversion: plotter constructors
+// such as NewLine and NewScatter still copy the adapted data
+// into their own float64 storage.
+
+// Values32 implements the Valuer interface for float32 values.
+type Values32 []float32
+
+// Len implements the Len method of the Valuer interface.
+func (vs Values32) Len() int { return len(vs) }
+
+// Value implements the Value method of the Valuer interface.
+func (vs Values32) Value(i int) float64 { return float64(vs[i]) }
+
+// ValuesInt implements the Valuer interface for int values.
+type ValuesInt []int
+
+// Len implements the Len method of the Valuer interface.
+func (vs ValuesInt) Len() int { return len(vs) }
+
+// Value implements the Value method of the Valuer interface.
+func (vs ValuesInt) Value(i int) float64 { return float64(vs[i]) }
+
+// XYs32 implements the XYer interface for float32 x, y pairs.
+type XYs32 []struct{ X, Y float32 }
+
+// Len implements the Len method of the XYer interface.
+func (xys XYs32) Len() int { return len(xys) }
+
+// XY implements the XY method of the XYer interface.
+func (xys XYs32) XY(i int) (float64, float64) {
+	return float64(xys[i].X), float64(xys[i].Y)
+}
+
+// XYZs32 implements the XYZer interface for float32
+// x, y, z triples.
+type XYZs32 []struct{ X, Y, Z float32 }
+
+// Len implements the Len method of the XYZer interface.
+func (xyz XYZs32) Len() int { return len(xyz) }
+
+// XYZ implements the XYZ method of the XYZer interface.
+func (xyz XYZs32) XYZ(i int) (float64, float64, float64) {
+	return float64(xyz[i].X), float64(xyz[i].Y), float64(xyz[i].Z)
+}
+
+// XY implements the XY method of the XYZer interface.
+func (xyz XYZs32) XY(i int) (float64, float64) {
+	return float64(xyz[i].X), float64(xyz[i].Y)
+}
+
+// XYSlices32 implements the XYer interface for parallel
+// slices of float32 x and y values, as is common for data
+// produced by numerical and machine learning libraries.
+// X and Y must have the same length.
+type XYSlices32 struct {
+	X, Y []float32
+}
+
+// Len implements the Len method of the XYer interface.
+func (s XYSlices32) Len() int {
+	if len(s.X) != len(s.Y) {
+		panic("plotter: length mismatch")
+	}
+	return len(s.X)
+}
+
+// XY implements the XY method of the XYer interface.
+func (s XYSlices32) XY(i int) (float64, float64) {
+	return float64(s.X[i]), float64(s.Y[i])
+}
+
+// FuncValues implements the Valuer interface using a
+// function, allowing values of any numeric type to be
+// plotted.
+type FuncValues struct {
+	// N is the number of values.
+	N int
+
+	// F returns the ith value.
+	F func(i int) float64
+}
+
+// Len implements the Len method of the Valuer interface.
+func (f FuncValues) Len() int { return f.N }
+
+// Value implements the Value method of the Valuer interface.
+func (f FuncValues) Value(i int) float64 { return f.F(i) }
+
+// FuncXYs implements the XYer interface using a function,
+// allowing x, y pairs of any numeric type to be plotted.
+type FuncXYs struct {
+	// N is the number of x, y pairs.
+	N int
+
+	// F returns the ith x, y pair.
+	F func(i int) (x, y float64)
+}
+
+// Len implements the Len method of the XYer interface.
+func (f FuncXYs) Len() int { return f.N }
+
+// XY implements the XY method of the XYer interface.
+func (f FuncXYs) XY(i int) (float64, float64) { return f.F(i) }
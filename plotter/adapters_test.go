@@ -0,0 +1,49 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAdapters(t *testing.T) {
+	want := XYs{{1, 2}, {3, 4}, {5, 6}}
+
+	type myInt int16
+	data := []struct{ a, b myInt }{{1, 2}, {3, 4}, {5, 6}}
+
+	for _, xyer := range []XYer{
+		XYs32{{1, 2}, {3, 4}, {5, 6}},
+		XYValues{XYZs32{{1, 2, 0}, {3, 4, 0}, {5, 6, 0}}},
+		XYSlices32{X: []float32{1, 3, 5}, Y: []float32{2, 4, 6}},
+		FuncXYs{N: len(data), F: func(i int) (float64, float64) {
+			return float64(data[i].a), float64(data[i].b)
+		}},
+	} {
+		got, err := CopyXYs(xyer)
+		if err != nil {
+			t.Fatalf("unexpected error for %T: %v", xyer, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected data for %T: got:%v want:%v", xyer, got, want)
+		}
+	}
+
+	wantVals := Values{1, 2, 3}
+	for _, valuer := range []Valuer{
+		Values32{1, 2, 3},
+		ValuesInt{1, 2, 3},
+		FuncValues{N: 3, F: func(i int) float64 { return float64(i + 1) }},
+	} {
+		got, err := CopyValues(valuer)
+		if err != nil {
+			t.Fatalf("unexpected error for %T: %v", valuer, err)
+		}
+		if !reflect.DeepEqual(got, wantVals) {
+			t.Errorf("unexpected values for %T: got:%v want:%v", valuer, got, wantVals)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// nopCanvas is a vg.Canvas that discards all drawing.
+type nopCanvas struct{}
+
+func (nopCanvas) SetLineWidth(vg.Length)               {}
+func (nopCanvas) SetLineDash([]vg.Length, vg.Length)   {}
+func (nopCanvas) SetColor(color.Color)                 {}
+func (nopCanvas) Rotate(float64)                       {}
+func (nopCanvas) Translate(vg.Point)                   {}
+func (nopCanvas) Scale(float64, float64)               {}
+func (nopCanvas) Push()                                {}
+func (nopCanvas) Pop()                                 {}
+func (nopCanvas) Stroke(vg.Path)                       {}
+func (nopCanvas) Fill(vg.Path)                         {}
+func (nopCanvas) FillString(vg.Font, vg.Point, string) {}
+func (nopCanvas) DrawImage(vg.Rectangle, image.Image)  {}
+
+// allocPlot returns a plot and canvas for measuring
+// the allocations made by plotters drawn on it.
+func allocPlot() (*plot.Plot, draw.Canvas) {
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.X.Min, p.X.Max = 0, 100
+	p.Y.Min, p.Y.Max = -1, 1
+	c := draw.Canvas{
+		Canvas:    nopCanvas{},
+		Rectangle: vg.Rectangle{Max: vg.Point{X: 10 * vg.Centimeter, Y: 10 * vg.Centimeter}},
+	}
+	return p, c
+}
+
+func sinXYs(n int) XYs {
+	xys := make(XYs, n)
+	for i := range xys {
+		xys[i].X = 100 * float64(i) / float64(n)
+		xys[i].Y = math.Sin(xys[i].X)
+	}
+	return xys
+}
+
+func newAllocHeatMap() *HeatMap {
+	data := make([]float64, 100*100)
+	for i := range data {
+		data[i] = float64(i)
+	}
+	return NewHeatMap(unitGrid{mat.NewDense(100, 100, data)}, palette.Heat(12, 1))
+}
+
+func TestPlotAllocs(t *testing.T) {
+	if raceEnabled {
+		// The race detector drops sync.Pool entries at random.
+		t.Skip("skipping allocation test with the race detector on")
+	}
+	p, c := allocPlot()
+
+	l, err := NewLine(sinXYs(1000))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var shade color.Color = color.RGBA{B: 255, A: 255}
+	l.ShadeColor = &shade
+	s, err := NewScatter(sinXYs(1000))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Shape = nil
+
+	for _, test := range []struct {
+		name    string
+		plotter plot.Plotter
+		want    float64
+	}{
+		{name: "Line", plotter: l},
+		// The canvas passed to glyph drawers escapes.
+		{name: "Scatter", plotter: s, want: 1},
+		{name: "HeatMap", plotter: newAllocHeatMap()},
+	} {
+		allocs := testing.AllocsPerRun(10, func() { test.plotter.Plot(c, p) })
+		if allocs != test.want {
+			t.Errorf("unexpected allocations drawing %s: got:%v want:%v", test.name, allocs, test.want)
+		}
+	}
+}
+
+func BenchmarkLinePlot(b *testing.B) {
+	p, c := allocPlot()
+	l, err := NewLine(sinXYs(10000))
+	if err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Plot(c, p)
+	}
+}
+
+func BenchmarkHeatMapPlot(b *testing.B) {
+	p, c := allocPlot()
+	h := newAllocHeatMap()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.Plot(c, p)
+	}
+}
@@ -28,6 +28,13 @@ type BarChart struct {
 	// LineStyle is the style of the outline of the bars.
 	draw.LineStyle
 
+	// CornerRadius is the radius of the rounded corners
+	// of the bars. The radius is limited to half the
+	// width and length of each bar, so setting it to at
+	// least half of Width draws capsule shaped bars. If
+	// CornerRadius is zero the corners are square.
+	CornerRadius vg.Length
+
 	// Offset is added to the X location of each bar.
 	// When the Offset is zero, the bars are drawn
 	// centered at their X location.
@@ -129,6 +136,9 @@ func (b *BarChart) Plot(c draw.Canvas, plt *plot.Plot) {
 				{catMax, valMax},
 				{catMax, valMin},
 			}
+			if b.CornerRadius > 0 {
+				pts = roundedRect(catMin, valMin, catMax, valMax, b.CornerRadius)
+			}
 			poly = c.ClipPolygonY(pts)
 		} else {
 			pts = []vg.Point{
@@ -137,16 +147,18 @@ func (b *BarChart) Plot(c draw.Canvas, plt *plot.Plot) {
 				{valMax, catMax},
 				{valMax, catMin},
 			}
+			if b.CornerRadius > 0 {
+				pts = roundedRect(valMin, catMin, valMax, catMax, b.CornerRadius)
+			}
 			poly = c.ClipPolygonX(pts)
 		}
 		c.FillPolygon(b.Color, poly)
 
 		var outline [][]vg.Point
+		pts = append(pts, pts[0])
 		if !b.Horizontal {
-			pts = append(pts, vg.Point{X: catMin, Y: valMin})
 			outline = c.ClipLinesY(pts)
 		} else {
-			pts = append(pts, vg.Point{X: valMin, Y: catMin})
 			outline = c.ClipLinesX(pts)
 		}
 		c.StrokeLines(b.LineStyle, outline...)
@@ -202,10 +214,44 @@ func (b *BarChart) Thumbnail(c *draw.Canvas) {
 		{c.Max.X, c.Max.Y},
 		{c.Max.X, c.Min.Y},
 	}
+	if b.CornerRadius > 0 {
+		pts = roundedRect(c.Min.X, c.Min.Y, c.Max.X, c.Max.Y, b.CornerRadius)
+	}
 	poly := c.ClipPolygonY(pts)
 	c.FillPolygon(b.Color, poly)
 
-	pts = append(pts, vg.Point{X: c.Min.X, Y: c.Min.Y})
+	pts = append(pts, pts[0])
 	outline := c.ClipLinesY(pts)
 	c.StrokeLines(b.LineStyle, outline...)
 }
+
+// roundedRect returns the vertices of a polygon approximating
+// the rectangle with corners (xmin, ymin) and (xmax, ymax)
+// rounded with the given radius, as drawn by
+// vg.RoundedRectangle. A polygon, unlike a path, can be
+// clipped to the canvas.
+func roundedRect(xmin, ymin, xmax, ymax, radius vg.Length) []vg.Point {
+	// segments is the number of line segments
+	// approximating each corner.
+	const segments = 8
+
+	var pts []vg.Point
+	for _, comp := range vg.RoundedRectangle(vg.Rectangle{
+		Min: vg.Point{X: xmin, Y: ymin},
+		Max: vg.Point{X: xmax, Y: ymax},
+	}, radius) {
+		switch comp.Type {
+		case vg.MoveComp, vg.LineComp:
+			pts = append(pts, comp.Pos)
+		case vg.ArcComp:
+			for i := 0; i <= segments; i++ {
+				a := comp.Start + comp.Angle*float64(i)/segments
+				pts = append(pts, vg.Point{
+					X: comp.Pos.X + comp.Radius*vg.Length(math.Cos(a)),
+					Y: comp.Pos.Y + comp.Radius*vg.Length(math.Sin(a)),
+				})
+			}
+		}
+	}
+	return pts
+}
@@ -273,3 +273,51 @@ func ExampleBarChart_positiveNegative() {
 func TestBarChart_positiveNegative(t *testing.T) {
 	cmpimg.CheckPlot(ExampleBarChart_positiveNegative, t, "barChart_positiveNegative.png")
 }
+
+// ExampleBarChart_rounded draws bars with rounded corners
+// and capsule shaped horizontal bars.
+func ExampleBarChart_rounded() {
+	values := Values{12, 28, 15, 21, 8}
+
+	p1, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p1.Title.Text = "Rounded bars"
+	bars, err := NewBarChart(values, vg.Points(12))
+	if err != nil {
+		log.Panic(err)
+	}
+	bars.Color = color.RGBA{R: 66, G: 133, B: 244, A: 255}
+	bars.LineStyle.Width = 0
+	bars.CornerRadius = vg.Points(4)
+	p1.Add(bars)
+	p1.NominalX("A", "B", "C", "D", "E")
+	err = p1.Save(150, 150, "testdata/roundedBarChart.png")
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p2, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p2.Title.Text = "Capsule bars"
+	capsules, err := NewBarChart(values, vg.Points(8))
+	if err != nil {
+		log.Panic(err)
+	}
+	capsules.Horizontal = true
+	capsules.Color = color.RGBA{R: 219, G: 68, B: 55, A: 255}
+	capsules.CornerRadius = capsules.Width / 2
+	p2.Add(capsules)
+	p2.NominalY("A", "B", "C", "D", "E")
+	err = p2.Save(150, 150, "testdata/capsuleBarChart.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestBarChart_rounded(t *testing.T) {
+	cmpimg.CheckPlot(ExampleBarChart_rounded, t, "roundedBarChart.png", "capsuleBarChart.png")
+}
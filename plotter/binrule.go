@@ -0,0 +1,91 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+// A BinRule returns the number of histogram bins to use
+// for the values xs with the corresponding weights ws.
+// The number of samples used by a rule is the total weight.
+type BinRule func(xs, ws []float64) int
+
+// Sturges is a BinRule using Sturges' rule, which
+// chooses log₂(n)+1 bins for n samples. It assumes
+// that the data are approximately normal, and gives
+// too few bins for large samples.
+func Sturges(xs, ws []float64) int {
+	n := sum(ws)
+	if n < 1 {
+		return 1
+	}
+	return int(math.Ceil(math.Log2(n))) + 1
+}
+
+// Scott is a BinRule using Scott's normal reference
+// rule, which chooses bins of width 3.49σ/∛n for n
+// samples with standard deviation σ. If the width is
+// zero Sturges' rule is used.
+func Scott(xs, ws []float64) int {
+	_, std := stat.MeanStdDev(xs, ws)
+	w := 3.49 * std / math.Cbrt(sum(ws))
+	return binsOfWidth(xs, ws, w)
+}
+
+// FreedmanDiaconis is a BinRule using the Freedman–Diaconis
+// rule, which chooses bins of width 2×IQR/∛n for n samples
+// with interquartile range IQR. It is less sensitive to
+// outliers than Scott's rule. If the width is zero Scott's
+// rule is used.
+func FreedmanDiaconis(xs, ws []float64) int {
+	s := sortedWeighted{xs: append([]float64(nil), xs...), ws: append([]float64(nil), ws...)}
+	sort.Sort(s)
+	iqr := stat.Quantile(0.75, stat.Empirical, s.xs, s.ws) - stat.Quantile(0.25, stat.Empirical, s.xs, s.ws)
+	w := 2 * iqr / math.Cbrt(sum(ws))
+	if w == 0 {
+		return Scott(xs, ws)
+	}
+	return binsOfWidth(xs, ws, w)
+}
+
+// binsOfWidth returns the number of bins of width w that
+// span xs, or the number given by Sturges' rule if w is
+// not positive and finite.
+func binsOfWidth(xs, ws []float64, w float64) int {
+	if !(w > 0) || math.IsInf(w, 0) {
+		return Sturges(xs, ws)
+	}
+	min, max := Range(Values(xs))
+	n := int(math.Ceil((max - min) / w))
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// sum returns the sum of vs.
+func sum(vs []float64) float64 {
+	var s float64
+	for _, v := range vs {
+		s += v
+	}
+	return s
+}
+
+// sortedWeighted sorts values with their weights.
+type sortedWeighted struct {
+	xs, ws []float64
+}
+
+func (s sortedWeighted) Len() int           { return len(s.xs) }
+func (s sortedWeighted) Less(i, j int) bool { return s.xs[i] < s.xs[j] }
+func (s sortedWeighted) Swap(i, j int) {
+	s.xs[i], s.xs[j] = s.xs[j], s.xs[i]
+	s.ws[i], s.ws[j] = s.ws[j], s.ws[i]
+}
@@ -7,7 +7,6 @@ package plotter
 import (
 	"errors"
 	"math"
-	"sort"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/vg"
@@ -21,6 +20,11 @@ type fiveStatPlot struct {
 	// create this box plot.
 	Values
 
+	// Weights holds the weights of the values if the
+	// values used to create the plot implement Weighter,
+	// and is nil otherwise.
+	Weights []float64
+
 	// Location is the location of the box along its axis.
 	Location float64
 
@@ -134,28 +138,49 @@ func newFiveStat(w vg.Length, loc float64, values Valuer) (fiveStatPlot, error)
 	if b.Values, err = CopyValues(values); err != nil {
 		return fiveStatPlot{}, err
 	}
+	b.Weights, err = weightsOf(values, values.Len(), func(i int) bool {
+		return isFinite(values.Value(i))
+	})
+	if err != nil {
+		return fiveStatPlot{}, err
+	}
 
+	// The statistics of weighted values are those of the
+	// values repeated by their weights.
 	sorted := make(Values, len(b.Values))
 	copy(sorted, b.Values)
-	sort.Float64s(sorted)
+	var weights []float64
+	if b.Weights != nil {
+		weights = make([]float64, len(b.Weights))
+		copy(weights, b.Weights)
+	}
+	sample := newWeightedSample(sorted, weights)
+	n := sample.total()
+	if n == 0 {
+		return fiveStatPlot{}, ErrNoData
+	}
 
-	if len(sorted) == 1 {
-		b.Median = sorted[0]
-		b.Quartile1 = sorted[0]
-		b.Quartile3 = sorted[0]
+	if n <= 1 {
+		b.Median = sample.at(0)
+		b.Quartile1 = b.Median
+		b.Quartile3 = b.Median
 	} else {
-		b.Median = median(sorted)
-		b.Quartile1 = median(sorted[:len(sorted)/2])
-		b.Quartile3 = median(sorted[len(sorted)/2:])
+		half := math.Floor(n / 2)
+		b.Median = sample.median(0, n)
+		b.Quartile1 = sample.median(0, half)
+		b.Quartile3 = sample.median(half, n)
 	}
-	b.Min = sorted[0]
-	b.Max = sorted[len(sorted)-1]
+	b.Min = sample.at(0)
+	b.Max = sample.at(n)
 
 	low := b.Quartile1 - 1.5*(b.Quartile3-b.Quartile1)
 	high := b.Quartile3 + 1.5*(b.Quartile3-b.Quartile1)
 	b.AdjLow = math.Inf(1)
 	b.AdjHigh = math.Inf(-1)
 	for i, v := range b.Values {
+		if b.Weights != nil && b.Weights[i] == 0 {
+			continue
+		}
 		if v > high || v < low {
 			b.Outside = append(b.Outside, i)
 			continue
@@ -171,20 +196,6 @@ func newFiveStat(w vg.Length, loc float64, values Valuer) (fiveStatPlot, error)
 	return b, nil
 }
 
-// median returns the median value from a
-// sorted Values.
-func median(vs Values) float64 {
-	if len(vs) == 1 {
-		return vs[0]
-	}
-	med := vs[len(vs)/2]
-	if len(vs)%2 == 0 {
-		med += vs[len(vs)/2-1]
-		med /= 2
-	}
-	return med
-}
-
 // Plot draws the BoxPlot on Canvas c and Plot plt.
 func (b *BoxPlot) Plot(c draw.Canvas, plt *plot.Plot) {
 	if b.Horizontal {
@@ -0,0 +1,132 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"os"
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// ExampleBrush selects points in one of two plots of the same
+// observations with a lasso, and highlights the selected
+// observations in both plots.
+func ExampleBrush() {
+	rnd := rand.New(rand.NewSource(1))
+	var xy, xz XYs
+	for i := 0; i < 100; i++ {
+		x := rnd.NormFloat64()
+		xy = append(xy, struct{ X, Y float64 }{X: x, Y: x + 0.5*rnd.NormFloat64()})
+		xz = append(xz, struct{ X, Y float64 }{X: x, Y: rnd.NormFloat64()})
+	}
+
+	img := vgimg.New(400, 200)
+	c := draw.New(img)
+	tiles := draw.Tiles{Rows: 1, Cols: 2, PadX: vg.Points(10), PadLeft: vg.Points(2), PadRight: vg.Points(2)}
+
+	var (
+		plots    []*plot.Plot
+		scatters []*Scatter
+	)
+	for i, data := range []XYs{xy, xz} {
+		p, err := plot.New()
+		if err != nil {
+			log.Panic(err)
+		}
+		p.X.Label.Text = "x"
+		p.Y.Label.Text = []string{"y", "z"}[i]
+		s, err := NewScatter(data)
+		if err != nil {
+			log.Panic(err)
+		}
+		s.GlyphStyle.Radius = vg.Points(2)
+		s.GlyphStyle.Shape = draw.CircleGlyph{}
+		s.GlyphStyle.Color = color.Gray{Y: 160}
+		p.Add(s)
+		plots = append(plots, p)
+		scatters = append(scatters, s)
+	}
+
+	// Lasso the observations at the upper right of the first plot.
+	left := tiles.At(c, 0, 0)
+	dc := plots[0].DataCanvas(left)
+	at := func(fx, fy vg.Length) vg.Point {
+		return vg.Point{X: dc.Min.X + fx*(dc.Max.X-dc.Min.X), Y: dc.Min.Y + fy*(dc.Max.Y-dc.Min.Y)}
+	}
+	brush := plot.NewLassoBrush([]vg.Point{at(0.55, 0.45), at(0.95, 0.6), at(1, 1), at(0.6, 0.95), at(0.45, 0.7)})
+	selected := make(map[int]bool)
+	for _, i := range brush.Select(plots[0], left)[scatters[0]] {
+		selected[i] = true
+	}
+
+	// Highlight the selected observations in both plots.
+	for _, s := range scatters {
+		sty := s.GlyphStyle
+		s.GlyphStyleFunc = func(i int) draw.GlyphStyle {
+			if !selected[i] {
+				return sty
+			}
+			hi := sty
+			hi.Color = color.NRGBA{R: 230, G: 97, B: 1, A: 255}
+			return hi
+		}
+	}
+	for i, p := range plots {
+		p.Draw(tiles.At(c, i, 0))
+	}
+	brush.Draw(left)
+
+	w, err := os.Create("testdata/brush.png")
+	if err != nil {
+		log.Panic(err)
+	}
+	png := vgimg.PngCanvas{Canvas: img}
+	if _, err = png.WriteTo(w); err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestBrush(t *testing.T) {
+	cmpimg.CheckPlot(ExampleBrush, t, "brush.png")
+}
+
+func TestBrushSelect(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s, err := NewScatter(XYs{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 0}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l, err := NewLine(XYs{{X: 0, Y: 2}, {X: 1.5, Y: 1.5}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(s, l)
+
+	c := draw.New(vgimg.New(vg.Points(200), vg.Points(200)))
+	dc := p.DataCanvas(c)
+	trX, trY := p.Transforms(&dc)
+	brush := plot.NewRectBrush(vg.Rectangle{
+		Min: vg.Point{X: trX(0.5), Y: trY(0.5)},
+		Max: vg.Point{X: trX(2.5), Y: trY(2.5)},
+	})
+	got := brush.Select(p, c)
+	want := map[plot.Plotter][]int{s: {1, 2}, l: {1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected selection: got:%v want:%v", got, want)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+)
+
+// ConfusionMatrix implements the Plotter interface, drawing
+// the confusion matrix of a classifier as a heat map with
+// the actual classes in rows and the predicted classes in
+// columns, annotated with the value of each cell.
+type ConfusionMatrix struct {
+	LabeledHeatMap
+
+	// Counts holds the number of samples of each
+	// actual class, by row, predicted as each
+	// class, by column.
+	Counts *mat.Dense
+}
+
+// NewConfusionMatrix returns a ConfusionMatrix of the actual
+// and predicted class indices of a set of samples, using
+// the provided palette. The classes are labelled by their
+// names. If normalize is true, each row of the matrix is
+// divided by the number of samples of its actual class,
+// so that the diagonal holds the recall of each class.
+func NewConfusionMatrix(actual, predicted []int, classes []string, p palette.Palette, normalize bool) (*ConfusionMatrix, error) {
+	if len(actual) != len(predicted) {
+		return nil, errors.New("plotter: actual and predicted lengths mismatch")
+	}
+	if len(actual) == 0 {
+		return nil, ErrNoData
+	}
+	n := len(classes)
+	counts := mat.NewDense(n, n, nil)
+	for i, a := range actual {
+		q := predicted[i]
+		if a < 0 || a >= n || q < 0 || q >= n {
+			return nil, errors.New("plotter: class index out of range")
+		}
+		counts.Set(a, q, counts.At(a, q)+1)
+	}
+
+	m := counts
+	format := "%.0f"
+	if normalize {
+		m = mat.NewDense(n, n, nil)
+		for i := 0; i < n; i++ {
+			total := mat.Sum(counts.RowView(i))
+			if total == 0 {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				m.Set(i, j, counts.At(i, j)/total)
+			}
+		}
+		format = "%.2f"
+	}
+
+	h, err := NewLabeledHeatMap(m, classes, classes, p)
+	if err != nil {
+		return nil, err
+	}
+	h.Min = 0
+	h.CellText = func(v float64) string { return fmt.Sprintf(format, v) }
+	cm := &ConfusionMatrix{LabeledHeatMap: *h, Counts: counts}
+	// The grid must refer to the Mask of the copy of h
+	// held by cm for changes to the mask to take effect.
+	cm.GridXYZ = labeledGrid{m: m, mask: &cm.Mask}
+	return cm, nil
+}
+
+// NominalAxes configures the axes of p to label the
+// classes, and labels the axes as actual and predicted.
+func (cm *ConfusionMatrix) NominalAxes(p *plot.Plot) {
+	cm.LabeledHeatMap.NominalAxes(p)
+	p.X.Label.Text = "Predicted"
+	p.Y.Label.Text = "Actual"
+}
+
+// ClassifierCurve implements the Plotter interface, drawing
+// a performance curve of a binary classifier.
+type ClassifierCurve struct {
+	*Line
+
+	// AUC is the area under the curve.
+	AUC float64
+}
+
+// LegendLabel returns name followed by the area under
+// the curve, for use as the legend entry of the curve.
+func (c *ClassifierCurve) LegendLabel(name string) string {
+	return fmt.Sprintf("%s (AUC = %.3f)", name, c.AUC)
+}
+
+// NewROC returns the receiver operating characteristic curve,
+// the true positive rate against the false positive rate, of
+// a classifier giving the scores to samples of which those
+// with positive set are in the positive class. The curve is
+// drawn with the default line style.
+func NewROC(scores []float64, positive []bool) (*ClassifierCurve, error) {
+	ranks, err := rankScores(scores, positive)
+	if err != nil {
+		return nil, err
+	}
+	pos, neg := ranks[len(ranks)-1].tp, ranks[len(ranks)-1].fp
+
+	xys := make(XYs, 0, len(ranks)+1)
+	xys = append(xys, struct{ X, Y float64 }{})
+	var auc float64
+	for _, r := range ranks {
+		x, y := r.fp/neg, r.tp/pos
+		last := xys[len(xys)-1]
+		auc += (x - last.X) * (y + last.Y) / 2
+		xys = append(xys, struct{ X, Y float64 }{x, y})
+	}
+	l, err := NewLine(xys)
+	if err != nil {
+		return nil, err
+	}
+	return &ClassifierCurve{Line: l, AUC: auc}, nil
+}
+
+// NewPrecisionRecall returns the precision-recall curve of
+// a classifier giving the scores to samples of which those
+// with positive set are in the positive class. The curve is
+// drawn as steps with the default line style, and the area
+// under it is the average precision.
+func NewPrecisionRecall(scores []float64, positive []bool) (*ClassifierCurve, error) {
+	ranks, err := rankScores(scores, positive)
+	if err != nil {
+		return nil, err
+	}
+	pos := ranks[len(ranks)-1].tp
+
+	xys := make(XYs, 0, 2*len(ranks)+1)
+	xys = append(xys, struct{ X, Y float64 }{0, 1})
+	var auc float64
+	for _, r := range ranks {
+		x, y := r.tp/pos, r.tp/(r.tp+r.fp)
+		last := xys[len(xys)-1]
+		auc += (x - last.X) * y
+		xys = append(xys,
+			struct{ X, Y float64 }{last.X, y},
+			struct{ X, Y float64 }{x, y},
+		)
+	}
+	l, err := NewLine(xys)
+	if err != nil {
+		return nil, err
+	}
+	return &ClassifierCurve{Line: l, AUC: auc}, nil
+}
+
+// rank holds the cumulative numbers of true and false
+// positives at a score threshold.
+type rank struct {
+	tp, fp float64
+}
+
+// rankScores returns the cumulative numbers of true and
+// false positives at each distinct score, in order of
+// decreasing score.
+func rankScores(scores []float64, positive []bool) ([]rank, error) {
+	if len(scores) != len(positive) {
+		return nil, errors.New("plotter: scores and labels lengths mismatch")
+	}
+	if len(scores) == 0 {
+		return nil, ErrNoData
+	}
+	idx := make([]int, len(scores))
+	for i, s := range scores {
+		if err := CheckFloats(s); err != nil {
+			return nil, err
+		}
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return scores[idx[i]] > scores[idx[j]] })
+
+	var ranks []rank
+	var r rank
+	for k, i := range idx {
+		if positive[i] {
+			r.tp++
+		} else {
+			r.fp++
+		}
+		if k == len(idx)-1 || scores[idx[k+1]] != scores[i] {
+			ranks = append(ranks, r)
+		}
+	}
+	if r.tp == 0 || r.fp == 0 {
+		return nil, errors.New("plotter: scores must include both classes")
+	}
+	return ranks, nil
+}
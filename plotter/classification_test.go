@@ -0,0 +1,146 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/vg"
+)
+
+func ExampleConfusionMatrix() {
+	classes := []string{"cat", "dog", "bird"}
+	actual := []int{0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2}
+	predicted := []int{0, 0, 0, 1, 2, 1, 1, 1, 1, 0, 1, 2, 2, 1, 2}
+
+	cm, err := NewConfusionMatrix(actual, predicted, classes, moreland.SmoothBlueRed().Palette(64), true)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Confusion matrix"
+	p.Add(cm)
+	cm.NominalAxes(p)
+
+	err = p.Save(200, 200, "testdata/confusionMatrix.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestConfusionMatrix(t *testing.T) {
+	cmpimg.CheckPlot(ExampleConfusionMatrix, t, "confusionMatrix.png")
+}
+
+func TestConfusionMatrixMask(t *testing.T) {
+	cm, err := NewConfusionMatrix([]int{0, 0, 1, 1}, []int{0, 1, 0, 1}, []string{"a", "b"}, moreland.SmoothBlueRed().Palette(8), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The first row of the matrix is drawn at the top,
+	// so its cell above the diagonal is in grid row 1.
+	if z := cm.GridXYZ.Z(1, 1); z != 1 {
+		t.Errorf("unexpected unmasked value: got:%v want:1", z)
+	}
+	cm.Mask = UpperMask
+	if z := cm.GridXYZ.Z(1, 1); !math.IsNaN(z) {
+		t.Errorf("unexpected masked value: got:%v want:NaN", z)
+	}
+	if z := cm.GridXYZ.Z(0, 0); z != 1 {
+		t.Errorf("unexpected value below the diagonal: got:%v want:1", z)
+	}
+}
+
+func ExampleClassifierCurve() {
+	rnd := rand.New(rand.NewSource(1))
+	const n = 200
+	scores := make([]float64, n)
+	positive := make([]bool, n)
+	for i := range scores {
+		positive[i] = i%2 == 0
+		scores[i] = rnd.NormFloat64()
+		if positive[i] {
+			scores[i] += 1.5
+		}
+	}
+
+	roc, err := NewROC(scores, positive)
+	if err != nil {
+		log.Panic(err)
+	}
+	roc.Color = color.RGBA{R: 196, A: 255}
+	pr, err := NewPrecisionRecall(scores, positive)
+	if err != nil {
+		log.Panic(err)
+	}
+	pr.Color = color.RGBA{B: 196, A: 255}
+	chance := NewFunction(func(x float64) float64 { return x })
+	chance.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Classifier performance"
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	p.Add(chance, roc, pr)
+	p.Legend.Add(roc.LegendLabel("ROC"), roc)
+	p.Legend.Add(pr.LegendLabel("PR"), pr)
+
+	err = p.Save(200, 200, "testdata/classifierCurve.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestClassifierCurve(t *testing.T) {
+	cmpimg.CheckPlot(ExampleClassifierCurve, t, "classifierCurve.png")
+}
+
+func TestClassifierAUC(t *testing.T) {
+	scores := []float64{0.1, 0.4, 0.35, 0.8}
+	positive := []bool{false, false, true, true}
+
+	roc, err := NewROC(scores, positive)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(roc.AUC-0.75) > 1e-12 {
+		t.Errorf("unexpected ROC AUC: got:%v want:0.75", roc.AUC)
+	}
+	pr, err := NewPrecisionRecall(scores, positive)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 5.0 / 6; math.Abs(pr.AUC-want) > 1e-12 {
+		t.Errorf("unexpected average precision: got:%v want:%v", pr.AUC, want)
+	}
+
+	// Tied scores form a single threshold.
+	roc, err = NewROC([]float64{1, 1, 0}, []bool{true, false, false})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(roc.AUC-0.75) > 1e-12 {
+		t.Errorf("unexpected ROC AUC with ties: got:%v want:0.75", roc.AUC)
+	}
+
+	_, err = NewROC([]float64{1, 2}, []bool{true, true})
+	if err == nil {
+		t.Error("expected error for single class")
+	}
+}
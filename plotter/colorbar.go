@@ -5,6 +5,7 @@
 package plotter
 
 import (
+	"errors"
 	"image"
 
 	"gonum.org/v1/plot"
@@ -42,18 +43,22 @@ func (l *ColorBar) colors(c draw.Canvas) int {
 
 // check determines whether the ColorBar is
 // valid in its current configuration.
-func (l *ColorBar) check() {
+func (l *ColorBar) check() error {
 	if l.ColorMap == nil {
-		panic("plotter: nil ColorMap in ColorBar")
+		return errors.New("plotter: nil ColorMap in ColorBar")
 	}
 	if l.ColorMap.Max() == l.ColorMap.Min() {
-		panic("plotter: ColorMap Max==Min")
+		return errors.New("plotter: ColorMap Max==Min")
 	}
+	return nil
 }
 
 // Plot implements the Plot method of the plot.Plotter interface.
 func (l *ColorBar) Plot(c draw.Canvas, p *plot.Plot) {
-	l.check()
+	if err := l.check(); err != nil {
+		p.Fail(err)
+		return
+	}
 	colors := l.colors(c)
 	var pImg *Image
 	delta := (l.ColorMap.Max() - l.ColorMap.Min()) / float64(colors)
@@ -65,7 +70,8 @@ func (l *ColorBar) Plot(c draw.Canvas, p *plot.Plot) {
 		for i := 0; i < colors; i++ {
 			color, err := l.ColorMap.At(l.ColorMap.Min() + delta*float64(i))
 			if err != nil {
-				panic(err)
+				p.Fail(err)
+				return
 			}
 			img.Set(0, colors-1-i, color)
 		}
@@ -78,7 +84,8 @@ func (l *ColorBar) Plot(c draw.Canvas, p *plot.Plot) {
 		for i := 0; i < colors; i++ {
 			color, err := l.ColorMap.At(l.ColorMap.Min() + delta*float64(i))
 			if err != nil {
-				panic(err)
+				p.Fail(err)
+				return
 			}
 			img.Set(i, 0, color)
 		}
@@ -90,7 +97,9 @@ func (l *ColorBar) Plot(c draw.Canvas, p *plot.Plot) {
 // DataRange implements the DataRange method
 // of the plot.DataRanger interface.
 func (l *ColorBar) DataRange() (xmin, xmax, ymin, ymax float64) {
-	l.check()
+	if err := l.check(); err != nil {
+		panic(err)
+	}
 	if l.Vertical {
 		return 0, 1, l.ColorMap.Min(), l.ColorMap.Max()
 	}
@@ -0,0 +1,171 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// NewColorMappedScatter returns a Scatter of the x, y points of
+// xyzs with the default glyph style, and glyphs colored by the
+// z values of the points using cmap. The range of cmap is set
+// to the range of the z values.
+func NewColorMappedScatter(xyzs XYZer, cmap palette.ColorMap) (*Scatter, error) {
+	data, err := CopyXYZs(xyzs)
+	if err != nil {
+		return nil, err
+	}
+	if cmap == nil {
+		return nil, errors.New("plotter: nil ColorMap")
+	}
+	sc, err := NewScatter(data)
+	if err != nil {
+		return nil, err
+	}
+	sc.Z = zValues(data)
+	sc.ColorMap = cmap
+	setColorMapRange(cmap, sc.Z)
+	return sc, nil
+}
+
+// ColorBar returns a ColorBar showing the ColorMap
+// of the Scatter.
+func (pts *Scatter) ColorBar() *ColorBar {
+	return &ColorBar{ColorMap: pts.ColorMap}
+}
+
+// ColorMappedLine implements the Plotter interface, drawing
+// a line through a set of points with the color of each
+// segment of the line given by the z values of its ends.
+type ColorMappedLine struct {
+	// XYZs is a copy of the points for this line.
+	XYZs
+
+	// LineStyle is the style of the line. The color
+	// of the style is used only for the legend
+	// thumbnail if the ColorMap is nil.
+	draw.LineStyle
+
+	// ColorMap maps the mean of the z values of the
+	// ends of each segment to its color. Values
+	// outside the range of the ColorMap are drawn
+	// with the color of the nearest limit.
+	ColorMap palette.ColorMap
+}
+
+// NewColorMappedLine returns a ColorMappedLine through the
+// points of xyzs with the default line style, colored using
+// cmap. The range of cmap is set to the range of the z values.
+func NewColorMappedLine(xyzs XYZer, cmap palette.ColorMap) (*ColorMappedLine, error) {
+	data, err := CopyXYZs(xyzs)
+	if err != nil {
+		return nil, err
+	}
+	if cmap == nil {
+		return nil, errors.New("plotter: nil ColorMap")
+	}
+	setColorMapRange(cmap, zValues(data))
+	return &ColorMappedLine{
+		XYZs:      data,
+		LineStyle: DefaultLineStyle,
+		ColorMap:  cmap,
+	}, nil
+}
+
+// Plot draws the ColorMappedLine, implementing the
+// plot.Plotter interface.
+func (l *ColorMappedLine) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	sty := l.LineStyle
+	for i := 1; i < len(l.XYZs); i++ {
+		if canceled(plt, i) {
+			return
+		}
+		a, b := l.XYZs[i-1], l.XYZs[i]
+		if l.ColorMap != nil {
+			sty.Color = mapColor(l.ColorMap, (a.Z+b.Z)/2)
+		}
+		seg := []vg.Point{
+			{X: trX(a.X), Y: trY(a.Y)},
+			{X: trX(b.X), Y: trY(b.Y)},
+		}
+		c.StrokeLines(sty, c.ClipLinesXY(seg)...)
+	}
+}
+
+// DataRange returns the minimum and maximum
+// x and y values, implementing the plot.DataRanger
+// interface.
+func (l *ColorMappedLine) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return XYRange(XYValues{l.XYZs})
+}
+
+// Thumbnail draws a line across the thumbnail in the
+// colors of the ColorMap, implementing the
+// plot.Thumbnailer interface.
+func (l *ColorMappedLine) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	if l.ColorMap == nil {
+		c.StrokeLine2(l.LineStyle, c.Min.X, y, c.Max.X, y)
+		return
+	}
+	const n = 4
+	sty := l.LineStyle
+	min, max := l.ColorMap.Min(), l.ColorMap.Max()
+	w := (c.Max.X - c.Min.X) / n
+	for i := 0; i < n; i++ {
+		sty.Color = mapColor(l.ColorMap, min+(max-min)*(float64(i)+0.5)/n)
+		x := c.Min.X + vg.Length(i)*w
+		c.StrokeLine2(sty, x, y, x+w, y)
+	}
+}
+
+// ColorBar returns a ColorBar showing the ColorMap
+// of the ColorMappedLine.
+func (l *ColorMappedLine) ColorBar() *ColorBar {
+	return &ColorBar{ColorMap: l.ColorMap}
+}
+
+// zValues returns the z values of data.
+func zValues(data XYZs) Values {
+	zs := make(Values, len(data))
+	for i, p := range data {
+		zs[i] = p.Z
+	}
+	return zs
+}
+
+// setColorMapRange sets the range of cmap to the range
+// of zs, widened about a single value.
+func setColorMapRange(cmap palette.ColorMap, zs Values) {
+	if len(zs) == 0 {
+		return
+	}
+	min, max := Range(zs)
+	if min == max {
+		min--
+		max++
+	}
+	cmap.SetMin(min)
+	cmap.SetMax(max)
+}
+
+// mapColor returns the color of v in cmap, with values
+// outside the range of cmap clamped to its limits.
+func mapColor(cmap palette.ColorMap, v float64) color.Color {
+	v = math.Max(cmap.Min(), math.Min(v, cmap.Max()))
+	col, err := cmap.At(v)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
@@ -0,0 +1,152 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"os"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// ExampleColorMappedLine draws a spiral colored by the
+// time along it, with markers at whole times colored by
+// the same color map, and a color bar beside the plot
+// aligned with its data area.
+func ExampleColorMappedLine() {
+	var spiral, marks XYZs
+	for t := 0.0; t <= 4*math.Pi; t += 0.05 {
+		spiral = append(spiral, struct{ X, Y, Z float64 }{X: t * math.Cos(t), Y: t * math.Sin(t), Z: t})
+	}
+	for t := 1.0; t <= 4*math.Pi; t++ {
+		marks = append(marks, struct{ X, Y, Z float64 }{X: t * math.Cos(t), Y: t * math.Sin(t), Z: t})
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Color mapped spiral"
+	l, err := NewColorMappedLine(spiral, moreland.SmoothBlueRed())
+	if err != nil {
+		log.Panic(err)
+	}
+	l.Width = vg.Points(2)
+	sc, err := NewColorMappedScatter(marks, moreland.SmoothBlueRed())
+	if err != nil {
+		log.Panic(err)
+	}
+	sc.Shape = draw.CircleGlyph{}
+	sc.Radius = vg.Points(3)
+	// Color the markers with the color map of the line,
+	// so they share the range of the color bar.
+	sc.ColorMap = l.ColorMap
+	p.Add(l, sc)
+
+	bar, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	cb := l.ColorBar()
+	cb.Vertical = true
+	bar.Add(cb)
+	bar.HideX()
+	bar.Y.Padding = 0
+
+	img := vgimg.New(250, 200)
+	dc := draw.New(img)
+	const barWidth = 50
+	pc := draw.Crop(dc, 0, -barWidth, 0, 0)
+	p.Draw(pc)
+	data := p.DataCanvas(pc)
+	bc := draw.Crop(dc, 250-barWidth+5, 0, 0, 0)
+	bc.Min.Y, bc.Max.Y = data.Min.Y, data.Max.Y
+	bar.Draw(bc)
+
+	w, err := os.Create("testdata/colorMappedLine.png")
+	if err != nil {
+		log.Panic(err)
+	}
+	defer w.Close()
+	png := vgimg.PngCanvas{Canvas: img}
+	if _, err = png.WriteTo(w); err != nil {
+		log.Panic(err)
+	}
+	if err = w.Close(); err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestColorMappedLinePlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleColorMappedLine, t, "colorMappedLine.png")
+}
+
+func TestColorMapped(t *testing.T) {
+	data := XYZs{{X: 0, Y: 0, Z: 2}, {X: 1, Y: 1, Z: 4}, {X: 2, Y: 0, Z: 6}}
+	if _, err := NewColorMappedLine(data, nil); err == nil {
+		t.Errorf("expected error for nil color map")
+	}
+
+	l, err := NewColorMappedLine(data, moreland.SmoothBlueRed())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if min, max := l.ColorMap.Min(), l.ColorMap.Max(); min != 2 || max != 6 {
+		t.Errorf("unexpected color map range: got:[%v, %v] want:[2, 6]", min, max)
+	}
+	if cb := l.ColorBar(); cb.ColorMap != l.ColorMap {
+		t.Errorf("color bar does not share the color map of the line")
+	}
+	xmin, xmax, ymin, ymax := l.DataRange()
+	if xmin != 0 || xmax != 2 || ymin != 0 || ymax != 1 {
+		t.Errorf("unexpected data range: got:%v %v %v %v", xmin, xmax, ymin, ymax)
+	}
+
+	sc, err := NewColorMappedScatter(data, moreland.SmoothBlueRed())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sc.Z) != len(data) || sc.Z[2] != 6 {
+		t.Errorf("unexpected scatter values: %v", sc.Z)
+	}
+
+	// Each segment of the line is colored by the
+	// mean of the values of its ends, and each
+	// glyph of the scatter by its value.
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l, sc)
+	var rec recorder.Canvas
+	p.Draw(draw.NewCanvas(&rec, 100, 100))
+	got := make(map[color.Color]bool)
+	for _, a := range rec.Actions {
+		if c, ok := a.(*recorder.SetColor); ok && c.Color != nil {
+			got[color.RGBAModel.Convert(c.Color)] = true
+		}
+	}
+	var want []color.Color
+	for _, v := range []float64{3, 5} {
+		want = append(want, mapColor(l.ColorMap, v))
+	}
+	for _, v := range []float64{2, 4, 6} {
+		want = append(want, mapColor(sc.ColorMap, v))
+	}
+	for _, w := range want {
+		if !got[color.RGBAModel.Convert(w)] {
+			t.Errorf("color %v not drawn", w)
+		}
+	}
+}
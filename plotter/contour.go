@@ -5,6 +5,9 @@
 package plotter
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"image/color"
 	"math"
 	"sort"
@@ -43,6 +46,25 @@ type Contour struct {
 	// Min and Max define the dynamic range of the
 	// heat map.
 	Min, Max float64
+
+	// Mask reports whether the grid cell spanning
+	// columns c to c+1 and rows r to r+1 is masked.
+	// Masked cells are excluded from contouring, so
+	// contour lines end at the edges of masked
+	// regions. If Mask is nil no cells are masked.
+	Mask func(c, r int) bool
+
+	// Filled specifies that the regions enclosed by
+	// the closed contours of each level are filled
+	// with the color of the level before the contour
+	// lines are drawn. Levels are filled from lowest
+	// to highest, so that the regions above higher
+	// levels are drawn over those of lower levels.
+	// Contours that end at the edges of the grid
+	// enclose no region, so Filled is best suited to
+	// grids whose values are below the lowest level
+	// at their edges, such as density estimates.
+	Filled bool
 }
 
 // NewContour creates as new contour plotter for the given data, using
@@ -95,25 +117,35 @@ var defaultQuantiles = []float64{0.01, 0.05, 0.25, 0.5, 0.75, 0.95, 0.99}
 
 // quantilesR7 returns the pth quantiles of the data in g according the the R-7 method.
 // http://en.wikipedia.org/wiki/Quantile#Estimating_the_quantiles_of_a_population
+//
+// If g implements GridWeighter, the quantiles are those of the data with each
+// value repeated by its weight. Values with weights that are not positive and
+// finite are ignored.
 func quantilesR7(g GridXYZ, p []float64) []float64 {
 	c, r := g.Dims()
+	gw, weighted := g.(GridWeighter)
 	data := make([]float64, 0, c*r)
+	var weights []float64
 	for i := 0; i < c; i++ {
 		for j := 0; j < r; j++ {
-			if v := g.Z(i, j); !math.IsNaN(v) {
-				data = append(data, v)
+			v := g.Z(i, j)
+			if math.IsNaN(v) {
+				continue
 			}
+			if weighted {
+				w := gw.Weight(i, j)
+				if !(w > 0) || math.IsInf(w, 1) {
+					continue
+				}
+				weights = append(weights, w)
+			}
+			data = append(data, v)
 		}
 	}
-	sort.Float64s(data)
+	sample := newWeightedSample(data, weights)
 	v := make([]float64, len(p))
 	for j, q := range p {
-		if q == 1 {
-			v[j] = data[len(data)-1]
-		}
-		h := float64(len(data)-1) * q
-		i := int(h)
-		v[j] = data[i] + (h-math.Floor(h))*(data[i+1]-data[i])
+		v[j] = sample.quantileR7(q)
 	}
 	return v
 }
@@ -122,10 +154,26 @@ func quantilesR7(g GridXYZ, p []float64) []float64 {
 // reconstruction, instead rendering each path segment individually.
 const naive = false
 
+// Validate returns an error if the Contour has no levels
+// or line styles, or an invalid Z range, implementing the
+// plot.Validator interface.
+func (h *Contour) Validate() error {
+	switch {
+	case len(h.Levels) == 0:
+		return errors.New("plotter: contour has no levels; set Levels, or pass nil levels to NewContour for levels at the data quantiles")
+	case len(h.LineStyles) == 0:
+		return errors.New("plotter: contour has no line styles; set LineStyles")
+	case h.Min > h.Max:
+		return fmt.Errorf("plotter: contour Z range [%v, %v] has its minimum greater than its maximum", h.Min, h.Max)
+	}
+	return nil
+}
+
 // Plot implements the Plot method of the plot.Plotter interface.
 func (h *Contour) Plot(c draw.Canvas, plt *plot.Plot) {
 	if h.Min > h.Max {
-		panic("contour: invalid Z range: min greater than max")
+		plt.Fail(errors.New("contour: invalid Z range: min greater than max"))
+		return
 	}
 
 	if naive {
@@ -145,7 +193,15 @@ func (h *Contour) Plot(c draw.Canvas, plt *plot.Plot) {
 	// The alternative naive approach is to draw each line segment as
 	// conrec returns it. The integrated path approach allows graphical
 	// optimisations and is necessary for contour fill shading.
-	cp := contourPaths(h.GridXYZ, h.Levels, trX, trY)
+	//
+	// The reconstruction of the paths dominates the time
+	// taken, so it is reported as the first half of the
+	// progress of the contour plot.
+	plt.ReportProgress(0, "contour paths")
+	cp := contourPaths(h.GridXYZ, h.Levels, cancelMask(plt.Context(), h.Mask), trX, trY)
+	if plt.Context().Err() != nil {
+		return
+	}
 
 	// ps is a palette scaling factor to scale the palette uniformly
 	// across the given levels. This enables a discordance between the
@@ -156,7 +212,32 @@ func (h *Contour) Plot(c draw.Canvas, plt *plot.Plot) {
 		ps = 0
 	}
 
+	plt.ReportProgress(0.5, "contour levels")
+	if h.Filled {
+		c.Push()
+		c.SetFillRule(vg.EvenOdd)
+		for i, z := range h.Levels {
+			if math.IsNaN(z) {
+				continue
+			}
+			var region vg.Path
+			for _, pa := range cp[z] {
+				if isLoop(pa) {
+					region = append(region, pa...)
+					region.Close()
+				}
+			}
+			col := h.levelColor(z, h.LineStyles[i%len(h.LineStyles)], pal, ps)
+			if col != nil && len(region) != 0 {
+				c.SetColor(col)
+				c.Fill(region)
+			}
+		}
+		c.Pop()
+	}
+
 	for i, z := range h.Levels {
+		plt.ReportProgress(0.5+0.5*float64(i)/float64(len(h.Levels)), "contour levels")
 		if math.IsNaN(z) {
 			continue
 		}
@@ -166,17 +247,7 @@ func (h *Contour) Plot(c draw.Canvas, plt *plot.Plot) {
 			}
 
 			style := h.LineStyles[i%len(h.LineStyles)]
-			var col color.Color
-			switch {
-			case z < h.Min:
-				col = h.Underflow
-			case z > h.Max:
-				col = h.Overflow
-			case len(pal) == 0:
-				col = style.Color
-			default:
-				col = pal[int((z-h.Levels[0])*ps+0.5)] // Apply palette scaling.
-			}
+			col := h.levelColor(z, style, pal, ps)
 			if col != nil && style.Width != 0 {
 				c.SetLineStyle(style)
 				c.SetColor(col)
@@ -186,6 +257,22 @@ func (h *Contour) Plot(c draw.Canvas, plt *plot.Plot) {
 	}
 }
 
+// levelColor returns the color of the contours at level z
+// drawn with the given style, using the palette colors pal
+// scaled by ps.
+func (h *Contour) levelColor(z float64, style draw.LineStyle, pal []color.Color, ps float64) color.Color {
+	switch {
+	case z < h.Min:
+		return h.Underflow
+	case z > h.Max:
+		return h.Overflow
+	case len(pal) == 0:
+		return style.Color
+	default:
+		return pal[int((z-h.Levels[0])*ps+0.5)] // Apply palette scaling.
+	}
+}
+
 // naivePlot implements the a naive rendering approach for contours.
 // It is here as a debugging mode since it simply draws line segments
 // generated by conrec without further computation.
@@ -215,13 +302,17 @@ func (h *Contour) naivePlot(c draw.Canvas, plt *plot.Plot) {
 
 	// Draw each line segment as conrec generates it.
 	var pa vg.Path
-	conrec(h.GridXYZ, h.Levels, func(_, _ int, l line, z float64) {
-		if math.IsNaN(z) {
+	conrec(h.GridXYZ, h.Levels, func(i, j int, l line, z float64) {
+		if math.IsNaN(z) || (h.Mask != nil && h.Mask(i, j)) {
 			return
 		}
 
 		pa = pa[:0]
 
+		if w, ok := h.GridXYZ.(warpedGrid); ok {
+			l.p1.X, l.p1.Y = w.warp(l.p1.X, l.p1.Y)
+			l.p2.X, l.p2.Y = w.warp(l.p2.X, l.p2.Y)
+		}
 		x1, y1 := trX(l.p1.X), trY(l.p1.Y)
 		x2, y2 := trX(l.p2.X), trY(l.p2.Y)
 
@@ -258,6 +349,9 @@ func (h *Contour) naivePlot(c draw.Canvas, plt *plot.Plot) {
 // DataRange implements the DataRange method
 // of the plot.DataRanger interface.
 func (h *Contour) DataRange() (xmin, xmax, ymin, ymax float64) {
+	if w, ok := h.GridXYZ.(warpedGrid); ok {
+		return w.dataRange()
+	}
 	c, r := h.GridXYZ.Dims()
 	return h.GridXYZ.X(0), h.GridXYZ.X(c - 1), h.GridXYZ.Y(0), h.GridXYZ.Y(r - 1)
 }
@@ -265,21 +359,15 @@ func (h *Contour) DataRange() (xmin, xmax, ymin, ymax float64) {
 // GlyphBoxes implements the GlyphBoxes method
 // of the plot.GlyphBoxer interface.
 func (h *Contour) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
-	c, r := h.GridXYZ.Dims()
-	b := make([]plot.GlyphBox, 0, r*c)
-	for i := 0; i < c; i++ {
-		for j := 0; j < r; j++ {
-			b = append(b, plot.GlyphBox{
-				X: plt.X.Norm(h.GridXYZ.X(i)),
-				Y: plt.Y.Norm(h.GridXYZ.Y(j)),
-				Rectangle: vg.Rectangle{
-					Min: vg.Point{X: -2.5, Y: -2.5},
-					Max: vg.Point{X: +2.5, Y: +2.5},
-				},
-			})
-		}
-	}
-	return b
+	if _, ok := h.GridXYZ.(warpedGrid); ok {
+		// The grid coordinates of a warped grid are
+		// not data coordinates.
+		return nil
+	}
+	return gridGlyphBoxes(plt, h.GridXYZ, vg.Rectangle{
+		Min: vg.Point{X: -2.5, Y: -2.5},
+		Max: vg.Point{X: +2.5, Y: +2.5},
+	})
 }
 
 // isLoop returns true iff a vg.Path is a closed loop.
@@ -289,17 +377,66 @@ func isLoop(p vg.Path) bool {
 	return s.Pos == e.Pos
 }
 
+// Isolines returns the contour lines of the grid at each of
+// the contour levels, in data coordinates, keyed by level.
+// The points of a closed contour line begin and end at the
+// same point. The contour lines are those drawn by Plot, so
+// they may be post-processed, for example to export them or
+// to compute the areas they enclose.
+func (h *Contour) Isolines() map[float64][]XYs {
+	levels := append([]float64(nil), h.Levels...)
+	lines := make(map[float64][]XYs)
+	for _, c := range contours(h.GridXYZ, levels, h.Mask) {
+		lines[c.z] = append(lines[c.z], c.xys())
+	}
+	return lines
+}
+
 // contourPaths returns a collection of vg.Paths describing contour lines based
-// on the input data in m cut at the given levels. The trX and trY function
-// are coordinate transforms. The returned map contains slices of paths keyed
-// on the value of the contour level. contouPaths sorts levels ascending as a
+// on the input data in m cut at the given levels, excluding the cells masked
+// by mask if it is not nil. The trX and trY function are coordinate
+// transforms. The returned map contains slices of paths keyed on the value
+// of the contour level. contouPaths sorts levels ascending as a side effect.
+func contourPaths(m GridXYZ, levels []float64, mask func(c, r int) bool, trX, trY func(float64) vg.Length) map[float64][]vg.Path {
+	paths := make(map[float64][]vg.Path)
+	for _, c := range contours(m, levels, mask) {
+		paths[c.z] = append(paths[c.z], c.path(trX, trY))
+	}
+	return paths
+}
+
+// cancelMask returns a mask for contours that excludes the
+// cells excluded by mask, and all cells once ctx is done, so
+// that the reconstruction of contours is cut short when the
+// rendering is canceled.
+func cancelMask(ctx context.Context, mask func(c, r int) bool) func(c, r int) bool {
+	done := ctx.Done()
+	if done == nil {
+		return mask
+	}
+	return func(c, r int) bool {
+		select {
+		case <-done:
+			return true
+		default:
+		}
+		return mask != nil && mask(c, r)
+	}
+}
+
+// contours returns the contours of the input data in m cut at
+// the given levels, ordered by level, excluding the cells masked
+// by mask if it is not nil. contours sorts levels ascending as a
 // side effect.
-func contourPaths(m GridXYZ, levels []float64, trX, trY func(float64) vg.Length) map[float64][]vg.Path {
+func contours(m GridXYZ, levels []float64, mask func(c, r int) bool) []*contour {
 	sort.Float64s(levels)
 
 	ends := make(map[float64]endMap)
 	conts := make(contourSet)
-	conrec(m, levels, func(_, _ int, l line, z float64) {
+	conrec(m, levels, func(i, j int, l line, z float64) {
+		if mask != nil && mask(i, j) {
+			return
+		}
 		paths(l, z, ends, conts)
 	})
 	ends = nil
@@ -315,24 +452,53 @@ func contourPaths(m GridXYZ, levels []float64, trX, trY func(float64) vg.Length)
 	// furcation so it may be that the path ends at middle node
 	// of another path. This needs to be investigated.
 
-	// Excise loops from crossed paths.
-	for c := range conts {
+	// Excise loops from crossed paths. The contours are
+	// visited in a fixed order, and returned ordered, so
+	// that rendering is deterministic.
+	for _, c := range conts.sorted() {
 		// Always try to do quick excision in production if possible.
 		c.exciseLoops(conts, true)
 	}
 
-	// Build vg.Paths.
-	paths := make(map[float64][]vg.Path)
-	for c := range conts {
-		paths[c.z] = append(paths[c.z], c.path(trX, trY))
+	sorted := conts.sorted()
+	if w, ok := m.(warpedGrid); ok {
+		for _, c := range sorted {
+			c.warp(w)
+		}
 	}
-
-	return paths
+	return sorted
 }
 
 // contourSet hold a working collection of contours.
 type contourSet map[*contour]struct{}
 
+// sorted returns the contours in s ordered by height, then by
+// their points.
+func (s contourSet) sorted() []*contour {
+	cs := make([]*contour, 0, len(s))
+	for c := range s {
+		cs = append(cs, c)
+	}
+	sort.Slice(cs, func(i, j int) bool {
+		a, b := cs[i], cs[j]
+		if a.z != b.z {
+			return a.z < b.z
+		}
+		na, nb := len(a.backward)+len(a.forward), len(b.backward)+len(b.forward)
+		for k := 0; k < na && k < nb; k++ {
+			pa, pb := a.at(k), b.at(k)
+			if pa.X != pb.X {
+				return pa.X < pb.X
+			}
+			if pa.Y != pb.Y {
+				return pa.Y < pb.Y
+			}
+		}
+		return na < nb
+	})
+	return cs
+}
+
 // endMap holds a working collection of available ends.
 type endMap map[point]*contour
 
@@ -424,9 +590,38 @@ func (c *contour) path(trX, trY func(float64) vg.Length) vg.Path {
 	return pa
 }
 
+// xys returns the points of the contour in order.
+func (c *contour) xys() XYs {
+	n := len(c.backward) + len(c.forward)
+	xys := make(XYs, n)
+	for i := range xys {
+		p := c.at(i)
+		xys[i].X, xys[i].Y = p.X, p.Y
+	}
+	return xys
+}
+
+// warp maps the points of the contour from grid coordinates
+// to data coordinates using the warp method of g.
+func (c *contour) warp(g warpedGrid) {
+	for _, p := range [...]path{c.backward, c.forward} {
+		for i := range p {
+			p[i].X, p[i].Y = g.warp(p[i].X, p[i].Y)
+		}
+	}
+}
+
 // front returns the first point in the contour.
 func (c *contour) front() point { return c.backward[len(c.backward)-1] }
 
+// at returns the ith point in the contour.
+func (c *contour) at(i int) point {
+	if i < len(c.backward) {
+		return c.backward[len(c.backward)-1-i]
+	}
+	return c.forward[i-len(c.backward)]
+}
+
 // back returns the last point in the contour
 func (c *contour) back() point { return c.forward[len(c.forward)-1] }
 
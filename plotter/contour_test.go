@@ -5,8 +5,10 @@
 package plotter
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"log"
 	"math"
 	"reflect"
 	"sort"
@@ -16,6 +18,7 @@ import (
 
 	"gonum.org/v1/gonum/mat"
 	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
 	"gonum.org/v1/plot/palette"
 	"gonum.org/v1/plot/vg"
 )
@@ -100,6 +103,181 @@ func TestComplexContours(t *testing.T) {
 	}
 }
 
+func TestContourPathsDeterministic(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	data := make([]float64, 1600)
+	for i := range data {
+		r := float64(i/40) - 20
+		c := float64(i%40) - 20
+		data[i] = 4*rnd.NormFloat64() + math.Hypot(r, c)
+	}
+	m := unitGrid{mat.NewDense(40, 40, data)}
+	levels := []float64{3, 7, 11, 15}
+
+	want := contourPaths(m, levels, nil, unity, unity)
+	for i := 0; i < 10; i++ {
+		got := contourPaths(m, levels, nil, unity, unity)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("contour paths differ between runs on run %d", i)
+		}
+	}
+}
+
+func TestContourIsolines(t *testing.T) {
+	// The grid holds the distance from its center, so
+	// the contour lines are close to circles.
+	const n = 41
+	data := make([]float64, n*n)
+	for i := range data {
+		r := float64(i/n) - n/2
+		c := float64(i%n) - n/2
+		data[i] = math.Hypot(r, c)
+	}
+	m := unitGrid{mat.NewDense(n, n, data)}
+	levels := []float64{15, 5, 10}
+	c := NewContour(m, levels, nil)
+
+	lines := c.Isolines()
+	if !reflect.DeepEqual(c.Levels, []float64{15, 5, 10}) {
+		t.Errorf("unexpected change to levels: got:%v", c.Levels)
+	}
+	for _, z := range levels {
+		if len(lines[z]) != 1 {
+			t.Errorf("unexpected number of isolines at %v: got:%d want:1", z, len(lines[z]))
+			continue
+		}
+		line := lines[z][0]
+		if line[0] != line[len(line)-1] {
+			t.Errorf("isoline at %v is not closed", z)
+		}
+		for _, p := range line {
+			if r := math.Hypot(p.X-n/2, p.Y-n/2); math.Abs(r-z) > 0.1 {
+				t.Errorf("isoline point at %v off circle: got radius %v", z, r)
+				break
+			}
+		}
+
+		// The enclosed area is close to that of the circle.
+		var area float64
+		for i := 1; i < len(line); i++ {
+			area += line[i-1].X*line[i].Y - line[i].X*line[i-1].Y
+		}
+		area = math.Abs(area) / 2
+		if want := math.Pi * z * z; math.Abs(area-want) > 0.01*want {
+			t.Errorf("unexpected area enclosed by isoline at %v: got:%v want:%v", z, area, want)
+		}
+	}
+}
+
+// ExampleContour_masked draws the contours of a field
+// with a circular region masked out, as for a lake in
+// a terrain model.
+func ExampleContour_masked() {
+	const n = 60
+	data := make([]float64, n*n)
+	for i := range data {
+		r := float64(i / n)
+		c := float64(i % n)
+		data[i] = math.Sin(c/6) * math.Cos(r/8)
+	}
+	m := unitGrid{mat.NewDense(n, n, data)}
+
+	c := NewContour(m, []float64{-0.75, -0.25, 0.25, 0.75}, palette.Rainbow(4, palette.Blue, palette.Red, 1, 1, 1))
+	c.Mask = func(col, row int) bool {
+		// Mask cells whose centres are within the lake.
+		return math.Hypot(float64(col)+0.5-35, float64(row)+0.5-30) < 12
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Masked contours"
+	p.Add(c)
+	p.X.Padding = 0
+	p.Y.Padding = 0
+
+	err = p.Save(200, 200, "testdata/maskedContour.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestContour_masked(t *testing.T) {
+	cmpimg.CheckPlot(ExampleContour_masked, t, "maskedContour.png")
+}
+
+func TestContourMask(t *testing.T) {
+	const n = 41
+	data := make([]float64, n*n)
+	for i := range data {
+		r := float64(i/n) - n/2
+		c := float64(i%n) - n/2
+		data[i] = math.Hypot(r, c)
+	}
+	m := unitGrid{mat.NewDense(n, n, data)}
+	c := NewContour(m, []float64{10}, nil)
+	// Mask the left half of the grid.
+	c.Mask = func(col, _ int) bool { return col < n/2 }
+
+	lines := c.Isolines()[10]
+	if len(lines) != 1 {
+		t.Fatalf("unexpected number of isolines: got:%d want:1", len(lines))
+	}
+	line := lines[0]
+	if line[0] == line[len(line)-1] {
+		t.Error("masked isoline is closed")
+	}
+	for _, p := range line {
+		if p.X < n/2 {
+			t.Errorf("isoline point in masked region: %v", p)
+		}
+	}
+	for _, p := range []struct{ X, Y float64 }{line[0], line[len(line)-1]} {
+		if p.X != n/2 {
+			t.Errorf("isoline does not end at mask boundary: got end %v", p)
+		}
+	}
+}
+
+func TestContourCancelMask(t *testing.T) {
+	if mask := cancelMask(context.Background(), nil); mask != nil {
+		t.Error("unexpected mask for uncancelable context")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	left := func(col, _ int) bool { return col < 2 }
+	mask := cancelMask(ctx, left)
+	if !mask(1, 0) || mask(3, 0) {
+		t.Error("unexpected cells masked before cancellation")
+	}
+	cancel()
+	if !mask(3, 0) {
+		t.Error("expected all cells masked after cancellation")
+	}
+
+	m := unitGrid{mat.NewDense(3, 3, []float64{0, 0, 0, 0, 1, 0, 0, 0, 0})}
+	if got := contourPaths(m, []float64{0.5}, mask, unity, unity); len(got) != 0 {
+		t.Errorf("unexpected contours after cancellation: %v", got)
+	}
+}
+
+func TestContourValidate(t *testing.T) {
+	m := unitGrid{mat.NewDense(3, 3, []float64{0, 0, 0, 0, 1, 0, 0, 0, 0})}
+	c := NewContour(m, []float64{0.5}, palette.Heat(4, 1))
+	if err := c.Validate(); err != nil {
+		t.Errorf("unexpected error for valid contour: %v", err)
+	}
+	c.Min, c.Max = 1, 0
+	if err := c.Validate(); err == nil {
+		t.Error("expected error for invalid Z range")
+	}
+	c.Levels = nil
+	if err := c.Validate(); err == nil {
+		t.Error("expected error for contour without levels")
+	}
+}
+
 func unity(f float64) vg.Length { return vg.Length(f) }
 
 func BenchmarkComplexContour0(b *testing.B)  { complexContourBench(0, b) }
@@ -131,7 +309,7 @@ func complexContourBench(noise float64, b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		p = contourPaths(m, levels, unity, unity)
+		p = contourPaths(m, levels, nil, unity, unity)
 	}
 
 	cp = p
@@ -151,7 +329,7 @@ func TestContourPaths(t *testing.T) {
 		gotClosed  int
 	)
 
-	got := contourPaths(m, levels, unity, unity)
+	got := contourPaths(m, levels, nil, unity, unity)
 	for l, p := range got {
 		sort.Sort(byLength(p))
 		for i, c := range p {
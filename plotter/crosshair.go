@@ -0,0 +1,134 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"fmt"
+	"image/color"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Crosshair implements the Plotter interface, drawing tracking
+// lines through a point across the data area of a plot, with a box
+// holding a readout of the coordinates of the point beside it.
+//
+// The point may be moved between renderings of a plot, for example
+// to follow a mouse pointer in an interactive frontend or to
+// trace the data of a recorded animation. A Crosshair does not
+// implement plot.DataRanger, so moving it does not change the
+// axis ranges of the plot.
+type Crosshair struct {
+	// X and Y are the data coordinates of the point.
+	X, Y float64
+
+	// Hidden specifies that the Crosshair is not drawn.
+	Hidden bool
+
+	// LineStyle is the style of the tracking lines.
+	LineStyle draw.LineStyle
+
+	// TextStyle is the style of the readout text.
+	TextStyle draw.TextStyle
+
+	// Format returns the readout text of the point
+	// at x and y.
+	Format func(x, y float64) string
+
+	// BoxColor is the fill color of the readout box.
+	// If BoxColor is nil the box is not filled.
+	BoxColor color.Color
+
+	// BoxStyle is the style of the outline of the
+	// readout box.
+	BoxStyle draw.LineStyle
+
+	// Padding is the space between the readout text
+	// and the edges of its box, and between the box
+	// and the point.
+	Padding vg.Length
+}
+
+// NewCrosshair returns a Crosshair at the point x, y, using
+// dashed gray tracking lines and a readout of the coordinates
+// in the DefaultFont and DefaultFontSize.
+func NewCrosshair(x, y float64) (*Crosshair, error) {
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Crosshair{
+		X: x,
+		Y: y,
+		LineStyle: draw.LineStyle{
+			Color:  color.Gray{Y: 96},
+			Width:  vg.Points(0.5),
+			Dashes: []vg.Length{vg.Points(3), vg.Points(2)},
+		},
+		TextStyle: draw.TextStyle{Color: color.Black, Font: fnt},
+		Format: func(x, y float64) string {
+			return fmt.Sprintf("x = %.4g\ny = %.4g", x, y)
+		},
+		BoxColor: color.NRGBA{R: 255, G: 255, B: 255, A: 224},
+		BoxStyle: draw.LineStyle{Color: color.Gray{Y: 96}, Width: vg.Points(0.5)},
+		Padding:  vg.Points(3),
+	}, nil
+}
+
+// MoveTo moves the Crosshair to the data element of a pick,
+// such as that returned by plot.PickAt.
+func (ch *Crosshair) MoveTo(p plot.Pick) {
+	ch.X, ch.Y = p.X, p.Y
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+// Nothing is drawn if the point is hidden or outside the axis
+// ranges of the plot.
+func (ch *Crosshair) Plot(c draw.Canvas, plt *plot.Plot) {
+	if ch.Hidden || !inRange(plt, ch.X, ch.Y) {
+		return
+	}
+	trX, trY := plt.Transforms(&c)
+	pt := vg.Point{X: trX(ch.X), Y: trY(ch.Y)}
+	c.StrokeLine2(ch.LineStyle, pt.X, c.Min.Y, pt.X, c.Max.Y)
+	c.StrokeLine2(ch.LineStyle, c.Min.X, pt.Y, c.Max.X, pt.Y)
+
+	txt := ch.Format(ch.X, ch.Y)
+	if txt == "" {
+		return
+	}
+	sty := ch.TextStyle
+	sty.XAlign, sty.YAlign = draw.XLeft, draw.YBottom
+	descent := -sty.Font.Extents().Descent
+	size := vg.Point{
+		X: sty.Width(txt) + 2*ch.Padding,
+		Y: sty.Height(txt) + descent + 2*ch.Padding,
+	}
+
+	// Place the box above and to the right of the point,
+	// flipping it to the other side of the point on either
+	// axis if it would otherwise extend beyond the canvas.
+	box := vg.Rectangle{Min: pt.Add(vg.Point{X: ch.Padding, Y: ch.Padding})}
+	if box.Min.X+size.X > c.Max.X {
+		box.Min.X = pt.X - ch.Padding - size.X
+	}
+	if box.Min.Y+size.Y > c.Max.Y {
+		box.Min.Y = pt.Y - ch.Padding - size.Y
+	}
+	box.Max = box.Min.Add(size)
+	corners := []vg.Point{
+		box.Min, {X: box.Max.X, Y: box.Min.Y},
+		box.Max, {X: box.Min.X, Y: box.Max.Y},
+	}
+	if ch.BoxColor != nil {
+		c.FillPolygon(ch.BoxColor, corners)
+	}
+	if ch.BoxStyle.Color != nil && ch.BoxStyle.Width != 0 {
+		c.StrokeLines(ch.BoxStyle, append(corners, box.Min))
+	}
+	c.FillText(sty, box.Min.Add(vg.Point{X: ch.Padding, Y: ch.Padding + descent}), txt)
+}
@@ -0,0 +1,77 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"os"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// ExampleCrosshair draws crosshairs at the points of a line
+// nearest to two positions on the canvas, as an interactive
+// frontend would when tracking a mouse pointer. The readout
+// of the crosshair near the top right corner of the plot is
+// moved to stay within the data area.
+func ExampleCrosshair() {
+	var data XYs
+	for i := 0; i <= 40; i++ {
+		x := float64(i) / 4
+		data = append(data, struct{ X, Y float64 }{X: x, Y: x * (1 + math.Sin(x))})
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Crosshair"
+	l, err := NewLine(data)
+	if err != nil {
+		log.Panic(err)
+	}
+	l.Width = vg.Points(1)
+	p.Add(l)
+
+	img := vgimg.New(250, 200)
+	c := draw.New(img)
+	dc := p.DataCanvas(c)
+	for _, frac := range []vg.Point{{X: 0.3, Y: 0.2}, {X: 0.95, Y: 0.9}} {
+		pt := vg.Point{
+			X: dc.Min.X + frac.X*(dc.Max.X-dc.Min.X),
+			Y: dc.Min.Y + frac.Y*(dc.Max.Y-dc.Min.Y),
+		}
+		pick, ok := p.PickAt(c, pt)
+		if !ok {
+			log.Panic("no data at point")
+		}
+		ch, err := NewCrosshair(0, 0)
+		if err != nil {
+			log.Panic(err)
+		}
+		ch.MoveTo(pick)
+		p.Add(ch)
+	}
+	p.Draw(c)
+
+	w, err := os.Create("testdata/crosshair.png")
+	if err != nil {
+		log.Panic(err)
+	}
+	png := vgimg.PngCanvas{Canvas: img}
+	if _, err = png.WriteTo(w); err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestCrosshair(t *testing.T) {
+	cmpimg.CheckPlot(ExampleCrosshair, t, "crosshair.png")
+}
@@ -0,0 +1,88 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import "gonum.org/v1/plot"
+
+// Outcode bits describing the position of a point
+// relative to the axis ranges of a plot.
+const (
+	outLeft = 1 << iota
+	outRight
+	outBelow
+	outAbove
+
+	// outNonFinite marks points with
+	// non-finite coordinates.
+	outNonFinite
+)
+
+// outcode returns the Cohen-Sutherland outcode of the
+// point (x, y) relative to the axis ranges of plt.
+// Points with non-finite coordinates have the code
+// outNonFinite.
+func outcode(plt *plot.Plot, x, y float64) int {
+	if !isFinite(x, y) {
+		return outNonFinite
+	}
+	var code int
+	switch {
+	case x < plt.X.Min:
+		code |= outLeft
+	case x > plt.X.Max:
+		code |= outRight
+	}
+	switch {
+	case y < plt.Y.Min:
+		code |= outBelow
+	case y > plt.Y.Max:
+		code |= outAbove
+	}
+	return code
+}
+
+// inRange returns whether the point (x, y) lies within
+// the axis ranges of plt.
+func inRange(plt *plot.Plot, x, y float64) bool {
+	return outcode(plt, x, y) == 0
+}
+
+// cullXYs appends to runs the index ranges, [from, to),
+// of the runs of points in xys that may contribute to a
+// line drawn within the axis ranges of plt, and returns
+// the extended slice. Points that only join segments
+// lying entirely to one side of the axis ranges are
+// omitted, splitting the line into runs. Points with
+// non-finite coordinates are omitted, breaking the line.
+func cullXYs(runs [][2]int, xys XYs, plt *plot.Plot) [][2]int {
+	if len(xys) == 0 {
+		return runs
+	}
+	if len(xys) == 1 {
+		if inRange(plt, xys[0].X, xys[0].Y) {
+			runs = append(runs, [2]int{0, 1})
+		}
+		return runs
+	}
+
+	start := -1
+	prev := outcode(plt, xys[0].X, xys[0].Y)
+	for i := 1; i < len(xys); i++ {
+		cur := outcode(plt, xys[i].X, xys[i].Y)
+		visible := prev&cur == 0 && (prev|cur)&outNonFinite == 0
+		switch {
+		case visible && start < 0:
+			start = i - 1
+		case !visible && start >= 0:
+			runs = append(runs, [2]int{start, i})
+			start = -1
+		}
+		prev = cur
+	}
+	if start >= 0 {
+		runs = append(runs, [2]int{start, len(xys)})
+	}
+	return runs
+}
@@ -0,0 +1,54 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/plot"
+)
+
+func TestCullXYs(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 10
+
+	for i, test := range []struct {
+		xys  XYs
+		want [][2]int
+	}{
+		{
+			xys:  XYs{{1, 1}, {2, 2}, {3, 3}},
+			want: [][2]int{{0, 3}},
+		},
+		{
+			xys:  XYs{{-3, 1}, {-2, 2}, {-1, 1}, {1, 1}, {2, 2}},
+			want: [][2]int{{2, 5}},
+		},
+		{
+			xys:  XYs{{1, 1}, {5, 20}, {6, 30}, {7, 20}, {8, 1}},
+			want: [][2]int{{0, 2}, {3, 5}},
+		},
+		{
+			// A segment crossing the data area with both
+			// end points outside is retained.
+			xys:  XYs{{-1, 5}, {11, 5}},
+			want: [][2]int{{0, 2}},
+		},
+		{
+			xys:  XYs{{-1, -1}, {-2, -2}},
+			want: nil,
+		},
+	} {
+		got := cullXYs(nil, test.xys, p)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected runs for test %d: got:%v want:%v", i, got, test.want)
+		}
+	}
+}
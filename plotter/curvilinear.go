@@ -0,0 +1,262 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// CurvilinearGridXYZ describes three dimensional data where the
+// X and Y coordinates are arranged on a curvilinear grid, so that
+// each grid point has its own X and Y coordinates. Such grids are
+// common in the output of ocean and atmosphere models.
+//
+// The cells of a curvilinear grid are the quadrilaterals formed by
+// neighbouring grid points, which must not overlap.
+type CurvilinearGridXYZ interface {
+	// Dims returns the dimensions of the grid.
+	Dims() (c, r int)
+
+	// Z returns the value of a grid value at (c, r).
+	// It will panic if c or r are out of bounds for the grid.
+	Z(c, r int) float64
+
+	// X returns the X coordinate of the grid point at (c, r).
+	// It will panic if c or r are out of bounds for the grid.
+	X(c, r int) float64
+
+	// Y returns the Y coordinate of the grid point at (c, r).
+	// It will panic if c or r are out of bounds for the grid.
+	Y(c, r int) float64
+}
+
+// Mesh implements the Plotter interface, drawing a
+// pseudocolor plot of the values in a curvilinear grid.
+// Each grid value is drawn as a quadrilateral cell
+// centred on its grid point, with corners midway between
+// the point and its neighbours, in the manner that a
+// HeatMap draws a rectilinear grid.
+type Mesh struct {
+	CurvilinearGridXYZ CurvilinearGridXYZ
+
+	// Palette is the color palette used to render
+	// the mesh. Palette must not be nil or return a
+	// zero length []color.Color.
+	Palette palette.Palette
+
+	// Underflow and Overflow are colors used to fill
+	// mesh cells outside the dynamic range defined
+	// by Min and Max.
+	Underflow color.Color
+	Overflow  color.Color
+
+	// NaN is the color used to fill mesh cells that
+	// are NaN or do not map to a unique palette color.
+	NaN color.Color
+
+	// Min and Max define the dynamic range of the
+	// mesh.
+	Min, Max float64
+}
+
+// NewMesh creates as new mesh plotter for the given data, using the
+// provided palette. If g has Min and Max methods that return a float,
+// those returned values are used to set the respective Mesh fields.
+// If the returned Mesh is used when Min is greater than Max, the Plot
+// method will panic.
+func NewMesh(g CurvilinearGridXYZ, p palette.Palette) *Mesh {
+	min, max := curvilinearGrid{g}.zRange()
+	return &Mesh{
+		CurvilinearGridXYZ: g,
+		Palette:            p,
+		Min:                min,
+		Max:                max,
+	}
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (m *Mesh) Plot(c draw.Canvas, plt *plot.Plot) {
+	if m.Min > m.Max {
+		plt.Fail(errors.New("mesh: invalid Z range: min greater than max"))
+		return
+	}
+	pal := m.Palette.Colors()
+	if len(pal) == 0 {
+		plt.Fail(errors.New("mesh: empty palette"))
+		return
+	}
+	// ps scales the palette uniformly across the data range.
+	ps := float64(len(pal)-1) / (m.Max - m.Min)
+
+	trX, trY := plt.Transforms(&c)
+
+	g := curvilinearGrid{m.CurvilinearGridXYZ}
+	cols, rows := g.Dims()
+	corners := g.corners()
+	corner := func(i, j int) vg.Point {
+		p := corners[i*(rows+1)+j]
+		return vg.Point{X: trX(p.X), Y: trY(p.Y)}
+	}
+	for i := 0; i < cols; i++ {
+		for j := 0; j < rows; j++ {
+			var col color.Color
+			switch v := g.Z(i, j); {
+			case v < m.Min:
+				col = m.Underflow
+			case v > m.Max:
+				col = m.Overflow
+			case math.IsNaN(v), math.IsInf(ps, 0):
+				col = m.NaN
+			default:
+				col = pal[int((v-m.Min)*ps+0.5)] // Apply palette scaling.
+			}
+			if col == nil {
+				continue
+			}
+			pts := []vg.Point{corner(i, j), corner(i+1, j), corner(i+1, j+1), corner(i, j+1)}
+			c.FillPolygon(col, c.ClipPolygonXY(pts))
+		}
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (m *Mesh) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	for _, p := range (curvilinearGrid{m.CurvilinearGridXYZ}).corners() {
+		xmin, xmax = math.Min(xmin, p.X), math.Max(xmax, p.X)
+		ymin, ymax = math.Min(ymin, p.Y), math.Max(ymax, p.Y)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// NewCurvilinearContour creates as new contour plotter for the given
+// curvilinear grid, using the provided palette, as NewContour does for
+// a rectilinear grid. The contours are found in the index space of the
+// grid and the points of the contour lines are then mapped into data
+// coordinates by bilinear interpolation of the coordinates of the grid
+// points, so the contour lines follow the warp of the grid.
+//
+// The GridXYZ field of the returned Contour holds the grid in index
+// space, with the X and Y coordinates of the grid point at (c, r)
+// being c and r, and must not be replaced by a rectilinear grid.
+func NewCurvilinearContour(g CurvilinearGridXYZ, levels []float64, p palette.Palette) *Contour {
+	return NewContour(curvilinearGrid{g}, levels, p)
+}
+
+// warpedGrid is a GridXYZ whose X and Y coordinates are
+// mapped to data coordinates by warp before being drawn.
+type warpedGrid interface {
+	GridXYZ
+
+	// warp returns the data coordinates of the point
+	// at (x, y) in the coordinates of the grid.
+	warp(x, y float64) (float64, float64)
+
+	// dataRange returns the range of the data
+	// coordinates of the grid points.
+	dataRange() (xmin, xmax, ymin, ymax float64)
+}
+
+// curvilinearGrid is the GridXYZ holding a curvilinear grid
+// in index space.
+type curvilinearGrid struct {
+	CurvilinearGridXYZ
+}
+
+func (g curvilinearGrid) X(c int) float64 { return float64(c) }
+func (g curvilinearGrid) Y(r int) float64 { return float64(r) }
+
+// zRange returns the range of the non-NaN values of the grid.
+func (g curvilinearGrid) zRange() (min, max float64) {
+	min, max = math.Inf(1), math.Inf(-1)
+	c, r := g.Dims()
+	for i := 0; i < c; i++ {
+		for j := 0; j < r; j++ {
+			v := g.Z(i, j)
+			if math.IsNaN(v) {
+				continue
+			}
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+	}
+	return min, max
+}
+
+// warp returns the data coordinates of the point at the
+// fractional column x and row y of the grid, interpolating
+// the coordinates of the surrounding grid points bilinearly.
+// Points outside the grid are extrapolated from the cells
+// at its edges.
+func (g curvilinearGrid) warp(x, y float64) (float64, float64) {
+	cols, rows := g.Dims()
+	i0, i1, s := cell(x, cols)
+	j0, j1, t := cell(y, rows)
+	bilinear := func(f func(c, r int) float64) float64 {
+		return (1-s)*(1-t)*f(i0, j0) + s*(1-t)*f(i1, j0) +
+			(1-s)*t*f(i0, j1) + s*t*f(i1, j1)
+	}
+	return bilinear(g.CurvilinearGridXYZ.X), bilinear(g.CurvilinearGridXYZ.Y)
+}
+
+// cell returns the indices of the grid points either side of
+// the fractional index v along a dimension of n points, and the
+// fraction of the distance from the first to the second at which
+// v lies. For v outside the grid, the points at the nearest edge
+// are returned.
+func cell(v float64, n int) (i0, i1 int, f float64) {
+	if n < 2 {
+		return 0, 0, 0
+	}
+	i0 = int(math.Floor(v))
+	if i0 < 0 {
+		i0 = 0
+	}
+	if i0 > n-2 {
+		i0 = n - 2
+	}
+	return i0, i0 + 1, v - float64(i0)
+}
+
+// dataRange returns the range of the data coordinates of the
+// grid points.
+func (g curvilinearGrid) dataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	c, r := g.Dims()
+	for i := 0; i < c; i++ {
+		for j := 0; j < r; j++ {
+			x, y := g.CurvilinearGridXYZ.X(i, j), g.CurvilinearGridXYZ.Y(i, j)
+			xmin, xmax = math.Min(xmin, x), math.Max(xmax, x)
+			ymin, ymax = math.Min(ymin, y), math.Max(ymax, y)
+		}
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// corners returns the data coordinates of the corners of the
+// cells centred on the grid points. The corner at the fractional
+// index (i-0.5, j-0.5) is held at i*(r+1)+j, where r is the number
+// of rows of the grid.
+func (g curvilinearGrid) corners() []point {
+	c, r := g.Dims()
+	corners := make([]point, 0, (c+1)*(r+1))
+	for i := 0; i <= c; i++ {
+		for j := 0; j <= r; j++ {
+			x, y := g.warp(float64(i)-0.5, float64(j)-0.5)
+			corners = append(corners, point{X: x, Y: y})
+		}
+	}
+	return corners
+}
@@ -0,0 +1,125 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/palette"
+)
+
+// annulus is a curvilinear grid on a half annulus, with columns
+// running around the annulus and rows running outwards.
+type annulus struct {
+	cols, rows int
+	z          func(x, y float64) float64
+}
+
+func (a annulus) Dims() (c, r int) { return a.cols, a.rows }
+func (a annulus) Z(c, r int) float64 {
+	return a.z(a.X(c, r), a.Y(c, r))
+}
+func (a annulus) X(c, r int) float64 {
+	return a.radius(r) * math.Cos(a.angle(c))
+}
+func (a annulus) Y(c, r int) float64 {
+	return a.radius(r) * math.Sin(a.angle(c))
+}
+func (a annulus) radius(r int) float64 {
+	return 1 + float64(r)/float64(a.rows-1)
+}
+func (a annulus) angle(c int) float64 {
+	return math.Pi * float64(c) / float64(a.cols-1)
+}
+
+// ExampleMesh draws a pseudocolor plot and contours of a
+// field given on a curvilinear grid.
+func ExampleMesh() {
+	g := annulus{
+		cols: 24, rows: 8,
+		z: func(x, y float64) float64 {
+			return math.Sin(2*x) * math.Cos(2*y)
+		},
+	}
+
+	m := NewMesh(g, palette.Heat(12, 1))
+	c := NewCurvilinearContour(g, []float64{-0.5, 0, 0.5}, nil)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Curvilinear grid"
+	p.Add(m, c)
+
+	err = p.Save(300, 180, "testdata/mesh.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestMesh(t *testing.T) {
+	cmpimg.CheckPlot(ExampleMesh, t, "mesh.png")
+}
+
+// stretchedGrid is a rectilinear grid with unequal spacing in
+// X and Y.
+type stretchedGrid struct{ mat.Matrix }
+
+func (g stretchedGrid) Dims() (c, r int)   { r, c = g.Matrix.Dims(); return c, r }
+func (g stretchedGrid) Z(c, r int) float64 { return g.Matrix.At(r, c) }
+func (g stretchedGrid) X(c int) float64    { return 2*float64(c) + 1 }
+func (g stretchedGrid) Y(r int) float64    { return 3 * float64(r) }
+
+// stretched is a rectilinear grid seen as a curvilinear grid.
+type stretched struct{ GridXYZ }
+
+func (s stretched) X(c, r int) float64 { return s.GridXYZ.X(c) }
+func (s stretched) Y(c, r int) float64 { return s.GridXYZ.Y(r) }
+
+func TestCurvilinearContour(t *testing.T) {
+	data := mat.NewDense(9, 11, nil)
+	for r := 0; r < 9; r++ {
+		for c := 0; c < 11; c++ {
+			data.Set(r, c, math.Hypot(float64(c-5), float64(r-4)))
+		}
+	}
+	g := stretchedGrid{data}
+
+	want := NewContour(g, []float64{2, 4}, nil)
+	got := NewCurvilinearContour(stretched{g}, []float64{2, 4}, nil)
+
+	xmin, xmax, ymin, ymax := got.DataRange()
+	wxmin, wxmax, wymin, wymax := want.DataRange()
+	if xmin != wxmin || xmax != wxmax || ymin != wymin || ymax != wymax {
+		t.Errorf("unexpected data range: got:[%v %v]×[%v %v] want:[%v %v]×[%v %v]",
+			xmin, xmax, ymin, ymax, wxmin, wxmax, wymin, wymax)
+	}
+
+	gotLines, wantLines := got.Isolines(), want.Isolines()
+	for _, z := range []float64{2, 4} {
+		if len(gotLines[z]) != len(wantLines[z]) {
+			t.Errorf("unexpected number of lines at %v: got:%d want:%d", z, len(gotLines[z]), len(wantLines[z]))
+			continue
+		}
+		for i, l := range gotLines[z] {
+			if len(l) != len(wantLines[z][i]) {
+				t.Errorf("unexpected length of line %d at %v: got:%d want:%d", i, z, len(l), len(wantLines[z][i]))
+				continue
+			}
+			for k, p := range l {
+				w := wantLines[z][i][k]
+				if math.Abs(p.X-w.X) > 1e-12 || math.Abs(p.Y-w.Y) > 1e-12 {
+					t.Errorf("unexpected point %d of line %d at %v: got:%v want:%v", k, i, z, p, w)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot/palette"
+)
+
+// Density2D is a GridXYZ holding a Gaussian kernel density
+// estimate of a set of two dimensional samples. The estimate is
+// computed by binning the samples into a two dimensional histogram
+// on the grid and smoothing the histogram with the kernel, so it
+// is fast for large numbers of samples.
+type Density2D struct {
+	xs, ys  []float64
+	density []float64
+}
+
+// NewDensity2D returns the density estimate of the samples in xys
+// on a grid of cols×rows nodes extending three bandwidths beyond
+// the samples, using a Gaussian kernel with the bandwidths bx and by
+// in X and Y. A bandwidth of zero is chosen by Scott's rule. An
+// error is returned if the grid has fewer than two columns or rows,
+// or a bandwidth is zero because the samples do not vary.
+//
+// If xys implements Weighter, each sample contributes to the
+// estimate in proportion to its weight.
+func NewDensity2D(xys XYer, cols, rows int, bx, by float64) (*Density2D, error) {
+	if cols < 2 || rows < 2 {
+		return nil, errors.New("plotter: density grid too small")
+	}
+	finite, err := finiteXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	data := finite.(XYs)
+	weights, err := weightsOf(xys, xys.Len(), func(i int) bool {
+		return isFinite(xys.XY(i))
+	})
+	if err != nil {
+		return nil, err
+	}
+	weight := func(i int) float64 {
+		if weights == nil {
+			return 1
+		}
+		return weights[i]
+	}
+	var total float64
+	for i := range data {
+		total += weight(i)
+	}
+	if total == 0 {
+		return nil, ErrNoData
+	}
+	if bx == 0 {
+		bx = scottBandwidth(data, weight, func(p struct{ X, Y float64 }) float64 { return p.X })
+	}
+	if by == 0 {
+		by = scottBandwidth(data, weight, func(p struct{ X, Y float64 }) float64 { return p.Y })
+	}
+	if !(bx > 0) || !(by > 0) {
+		return nil, errors.New("plotter: invalid density bandwidth")
+	}
+
+	xmin, xmax, ymin, ymax := XYRange(data)
+	d := &Density2D{
+		xs:      nodes(xmin-3*bx, xmax+3*bx, cols),
+		ys:      nodes(ymin-3*by, ymax+3*by, rows),
+		density: make([]float64, cols*rows),
+	}
+	dx := d.xs[1] - d.xs[0]
+	dy := d.ys[1] - d.ys[0]
+
+	// Bin the samples, sharing each between the four
+	// nodes around it in proportion to its proximity.
+	for i, p := range data {
+		w := weight(i)
+		fx := (p.X - d.xs[0]) / dx
+		fy := (p.Y - d.ys[0]) / dy
+		c := int(math.Min(fx, float64(cols-2)))
+		r := int(math.Min(fy, float64(rows-2)))
+		wx := fx - float64(c)
+		wy := fy - float64(r)
+		d.density[r*cols+c] += w * (1 - wx) * (1 - wy)
+		d.density[r*cols+c+1] += w * wx * (1 - wy)
+		d.density[(r+1)*cols+c] += w * (1 - wx) * wy
+		d.density[(r+1)*cols+c+1] += w * wx * wy
+	}
+
+	// Smooth the histogram by separable convolution
+	// with the kernel, and normalize it to a density.
+	smooth(d.density, cols, rows, 1, cols, gaussianKernel(bx/dx))
+	smooth(d.density, rows, cols, cols, 1, gaussianKernel(by/dy))
+	norm := 1 / (total * dx * dy)
+	for i := range d.density {
+		d.density[i] *= norm
+	}
+	return d, nil
+}
+
+// scottBandwidth returns the bandwidth of a two dimensional
+// Gaussian kernel density estimate along the dimension of the
+// data returned by v, according to Scott's rule, with the ith
+// sample counted weight(i) times.
+func scottBandwidth(data XYs, weight func(int) float64, v func(struct{ X, Y float64 }) float64) float64 {
+	var n, mean float64
+	for i, p := range data {
+		n += weight(i)
+		mean += weight(i) * v(p)
+	}
+	if n < 2 {
+		return 0
+	}
+	mean /= n
+	var ss float64
+	for i, p := range data {
+		d := v(p) - mean
+		ss += weight(i) * d * d
+	}
+	return math.Sqrt(ss/(n-1)) * math.Pow(n, -1.0/6)
+}
+
+// nodes returns n evenly spaced values from min to max.
+func nodes(min, max float64, n int) []float64 {
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = min + (max-min)*float64(i)/float64(n-1)
+	}
+	return v
+}
+
+// gaussianKernel returns the weights of a discrete Gaussian
+// kernel with the standard deviation sigma, in nodes, truncated
+// at four standard deviations and normalized to sum to one.
+func gaussianKernel(sigma float64) []float64 {
+	n := int(math.Ceil(4 * sigma))
+	k := make([]float64, 2*n+1)
+	var sum float64
+	for i := range k {
+		x := float64(i-n) / sigma
+		k[i] = math.Exp(-x * x / 2)
+		sum += k[i]
+	}
+	for i := range k {
+		k[i] /= sum
+	}
+	return k
+}
+
+// smooth convolves the lines of the row major grid data with the
+// kernel k. There are m lines of n values each. The ith value of
+// line j is held at data[j*lineStride+i*stride].
+func smooth(data []float64, n, m, stride, lineStride int, k []float64) {
+	half := len(k) / 2
+	line := make([]float64, n)
+	for j := 0; j < m; j++ {
+		for i := range line {
+			line[i] = data[j*lineStride+i*stride]
+		}
+		for i := 0; i < n; i++ {
+			var v float64
+			for o, w := range k {
+				if s := i + o - half; 0 <= s && s < n {
+					v += w * line[s]
+				}
+			}
+			data[j*lineStride+i*stride] = v
+		}
+	}
+}
+
+// Dims implements the Dims method of the GridXYZ interface.
+func (d *Density2D) Dims() (c, r int) { return len(d.xs), len(d.ys) }
+
+// Z implements the Z method of the GridXYZ interface.
+func (d *Density2D) Z(c, r int) float64 { return d.density[r*len(d.xs)+c] }
+
+// X implements the X method of the GridXYZ interface.
+func (d *Density2D) X(c int) float64 { return d.xs[c] }
+
+// Y implements the Y method of the GridXYZ interface.
+func (d *Density2D) Y(r int) float64 { return d.ys[r] }
+
+// MassLevels returns the density levels of the highest density
+// regions enclosing each of the given probability masses, so that
+// the contour of the density at the level of a mass of 0.5 encloses
+// the smallest region holding half of the probability. Masses must
+// be in the interval (0, 1].
+func (d *Density2D) MassLevels(mass ...float64) []float64 {
+	sorted := append([]float64(nil), d.density...)
+	sort.Sort(sort.Reverse(sort.Float64Slice(sorted)))
+	var total float64
+	for _, v := range sorted {
+		total += v
+	}
+
+	levels := make([]float64, len(mass))
+	for i, m := range mass {
+		if !(0 < m && m <= 1) {
+			panic("plotter: probability mass out of range")
+		}
+		var cum float64
+		levels[i] = sorted[len(sorted)-1]
+		for _, v := range sorted {
+			cum += v
+			if cum >= m*total {
+				levels[i] = v
+				break
+			}
+		}
+	}
+	return levels
+}
+
+// defaultMasses are the probability masses of the highest
+// density regions drawn by NewDensityContour by default.
+var defaultMasses = []float64{0.5, 0.9}
+
+// NewDensityContour returns a Contour of a kernel density estimate
+// of the samples in xys, with contours bounding the highest density
+// regions holding each of the given probability masses and colored
+// using the provided palette. If mass is nil, the regions holding 50%
+// and 90% of the probability are bounded. The density is estimated on
+// a 100×100 grid using bandwidths chosen by Scott's rule. The regions
+// may be filled by setting the Filled field of the returned Contour.
+func NewDensityContour(xys XYer, mass []float64, p palette.Palette) (*Contour, error) {
+	d, err := NewDensity2D(xys, 100, 100, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if mass == nil {
+		mass = defaultMasses
+	}
+	return NewContour(d, d.MassLevels(mass...), p), nil
+}
@@ -0,0 +1,125 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ExampleNewDensityContour draws the filled highest density
+// regions holding 25%, 50%, 75% and 95% of the probability of
+// a kernel density estimate of a two component mixture, over
+// the samples.
+func ExampleNewDensityContour() {
+	rnd := rand.New(rand.NewSource(1))
+	var data XYs
+	for i := 0; i < 500; i++ {
+		x, y := rnd.NormFloat64(), rnd.NormFloat64()
+		if i%3 == 0 {
+			x, y = 0.5*x+3, 0.5*y+2
+		}
+		data = append(data, struct{ X, Y float64 }{X: x, Y: y})
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Highest density regions"
+
+	pal := moreland.SmoothBlueRed().Palette(4)
+	c, err := NewDensityContour(data, []float64{0.25, 0.5, 0.75, 0.95}, pal)
+	if err != nil {
+		log.Panic(err)
+	}
+	c.Filled = true
+	c.LineStyles = []draw.LineStyle{{Color: color.Black, Width: vg.Points(0.5)}}
+	p.Add(c)
+
+	s, err := NewScatter(data)
+	if err != nil {
+		log.Panic(err)
+	}
+	s.GlyphStyle.Radius = vg.Points(1)
+	s.GlyphStyle.Color = color.NRGBA{A: 128}
+	p.Add(s)
+
+	err = p.Save(250, 250, "testdata/densityContour.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestDensityContour(t *testing.T) {
+	cmpimg.CheckPlot(ExampleNewDensityContour, t, "densityContour.png")
+}
+
+func TestDensity2D(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	const n = 10000
+	data := make(XYs, n)
+	for i := range data {
+		data[i].X = rnd.NormFloat64()
+		data[i].Y = rnd.NormFloat64()
+	}
+	d, err := NewDensity2D(data, 120, 120, 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cols, rows := d.Dims()
+	dx := d.X(1) - d.X(0)
+	dy := d.Y(1) - d.Y(0)
+	var total float64
+	for c := 0; c < cols; c++ {
+		for r := 0; r < rows; r++ {
+			total += d.Z(c, r) * dx * dy
+		}
+	}
+	if math.Abs(total-1) > 1e-3 {
+		t.Errorf("unexpected total probability: got:%v want:1", total)
+	}
+
+	// The estimate of a standard bivariate normal density is
+	// a normal density with variance inflated by the square of
+	// the bandwidth, whose highest density region holding mass
+	// m is bounded at the density (1-m)/(2πσ²).
+	h := math.Pow(n, -1.0/6)
+	variance := 1 + h*h
+	masses := []float64{0.5, 0.9}
+	for i, level := range d.MassLevels(masses...) {
+		want := (1 - masses[i]) / (2 * math.Pi * variance)
+		if math.Abs(level-want) > 0.05*want {
+			t.Errorf("unexpected level for mass %v: got:%v want:%v", masses[i], level, want)
+		}
+
+		// The region should hold about the mass of the samples.
+		var in int
+		for _, p := range data {
+			if p.X*p.X+p.Y*p.Y < -2*variance*math.Log(2*math.Pi*variance*level) {
+				in++
+			}
+		}
+		if frac := float64(in) / n; math.Abs(frac-masses[i]) > 0.03 {
+			t.Errorf("unexpected fraction of samples within region of mass %v: got:%v", masses[i], frac)
+		}
+	}
+
+	_, err = NewDensity2D(XYs{{X: 1, Y: 1}, {X: 1, Y: 2}}, 10, 10, 0, 0)
+	if err == nil {
+		t.Error("expected error for samples without variation")
+	}
+}
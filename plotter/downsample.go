@@ -0,0 +1,182 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// downsampleFactor is the ratio of the number of points to
+// the horizontal extent of the canvas, in dots at the resolution
+// of the canvas, above which Line and Scatter downsample.
+const downsampleFactor = 4
+
+// Downsampler wraps the Downsample method.
+type Downsampler interface {
+	// Downsample returns the indices, in increasing
+	// order, of approximately n of the given points that
+	// visually represent the complete set of points.
+	Downsample(pts []vg.Point, n int) []int
+}
+
+// DefaultDownsampler is the Downsampler used by a Line
+// with a nil Downsampler.
+var DefaultDownsampler Downsampler = MinMax{}
+
+// NoDownsampling is a Downsampler that keeps all of the
+// points. Setting the Downsampler of a Line to NoDownsampling
+// disables its automatic downsampling.
+var NoDownsampling Downsampler = noDownsampling{}
+
+type noDownsampling struct{}
+
+// Downsample implements the Downsampler interface.
+func (noDownsampling) Downsample(pts []vg.Point, _ int) []int { return allIndices(len(pts)) }
+
+// allIndices returns the indices of n points.
+func allIndices(n int) []int {
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	return idx
+}
+
+// downsample returns the indices of the points in pts to
+// draw on a canvas the given number of dots wide using d.
+// If d is nil or NoDownsampling, or the number of points does
+// not greatly exceed the width of the canvas, downsample
+// returns nil.
+func downsample(d Downsampler, pts []vg.Point, dots float64) []int {
+	if d == nil || d == NoDownsampling {
+		return nil
+	}
+	budget := int(math.Ceil(dots))
+	if budget < 1 || len(pts) <= downsampleFactor*budget {
+		return nil
+	}
+	return d.Downsample(pts, 2*budget)
+}
+
+// widthDots returns the width of c in dots at the
+// resolution of its vg.Canvas, or in points if the
+// canvas does not report its resolution.
+func widthDots(c draw.Canvas) float64 {
+	dpi := float64(vg.Inch / vg.Points(1))
+	if r, ok := c.Canvas.(interface{ DPI() float64 }); ok {
+		dpi = r.DPI()
+	}
+	return c.Size().X.Dots(dpi)
+}
+
+// LTTB is a Downsampler that uses the Largest-Triangle-Three-Buckets
+// algorithm described by Steinarsson in "Downsampling Time Series
+// for Visual Representation", 2013.
+//
+// LTTB assumes that the points are ordered by increasing X.
+type LTTB struct{}
+
+var _ Downsampler = LTTB{}
+
+// Downsample implements the Downsampler interface.
+func (LTTB) Downsample(pts []vg.Point, n int) []int {
+	if n >= len(pts) || n < 3 {
+		return allIndices(len(pts))
+	}
+
+	idx := make([]int, 0, n)
+	idx = append(idx, 0)
+
+	// Bucket size, excluding the first and last points.
+	every := float64(len(pts)-2) / float64(n-2)
+	a := 0
+	for i := 0; i < n-2; i++ {
+		// Average of the next bucket.
+		start := int(math.Floor(float64(i+1)*every)) + 1
+		end := int(math.Floor(float64(i+2)*every)) + 1
+		if end > len(pts) {
+			end = len(pts)
+		}
+		var avg vg.Point
+		for _, p := range pts[start:end] {
+			avg = avg.Add(p)
+		}
+		avg = avg.Scale(1 / vg.Length(end-start))
+
+		// Point in the current bucket forming the largest
+		// triangle with the previously selected point and
+		// the average of the next bucket.
+		from := int(math.Floor(float64(i)*every)) + 1
+		to := int(math.Floor(float64(i+1)*every)) + 1
+		pa := pts[a]
+		maxArea := vg.Length(-1)
+		next := from
+		for j := from; j < to; j++ {
+			p := pts[j]
+			area := (pa.X-avg.X)*(p.Y-pa.Y) - (pa.X-p.X)*(avg.Y-pa.Y)
+			if area < 0 {
+				area = -area
+			}
+			if area > maxArea {
+				maxArea = area
+				next = j
+			}
+		}
+		idx = append(idx, next)
+		a = next
+	}
+
+	return append(idx, len(pts)-1)
+}
+
+// MinMax is a Downsampler that divides the points into
+// buckets and keeps the first, last, minimum and maximum
+// Y valued points of each bucket. MinMax preserves the
+// visual envelope of dense data such as signals.
+type MinMax struct{}
+
+var _ Downsampler = MinMax{}
+
+// Downsample implements the Downsampler interface.
+func (MinMax) Downsample(pts []vg.Point, n int) []int {
+	buckets := n / 4
+	if buckets < 1 {
+		buckets = 1
+	}
+	if len(pts) <= 4*buckets {
+		return allIndices(len(pts))
+	}
+
+	idx := make([]int, 0, 4*buckets)
+	size := float64(len(pts)) / float64(buckets)
+	for b := 0; b < buckets; b++ {
+		from := int(float64(b) * size)
+		to := int(float64(b+1) * size)
+		if b == buckets-1 {
+			to = len(pts)
+		}
+		min, max := from, from
+		for j := from + 1; j < to; j++ {
+			if pts[j].Y < pts[min].Y {
+				min = j
+			}
+			if pts[j].Y > pts[max].Y {
+				max = j
+			}
+		}
+		sel := []int{from, min, max, to - 1}
+		sort.Ints(sel)
+		for i, j := range sel {
+			if i == 0 || j != sel[i-1] {
+				idx = append(idx, j)
+			}
+		}
+	}
+	return idx
+}
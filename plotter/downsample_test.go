@@ -0,0 +1,152 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+func sinePoints(n int) []vg.Point {
+	pts := make([]vg.Point, n)
+	for i := range pts {
+		pts[i] = vg.Point{X: vg.Length(i), Y: vg.Length(math.Sin(float64(i) / 50))}
+	}
+	return pts
+}
+
+func TestLTTB(t *testing.T) {
+	pts := sinePoints(10000)
+	idx := LTTB{}.Downsample(pts, 100)
+	if len(idx) != 100 {
+		t.Fatalf("unexpected number of points: got:%d want:100", len(idx))
+	}
+	if idx[0] != 0 || idx[len(idx)-1] != len(pts)-1 {
+		t.Errorf("end points not retained: first:%d last:%d", idx[0], idx[len(idx)-1])
+	}
+	if !sort.IntsAreSorted(idx) {
+		t.Error("indices not sorted")
+	}
+
+	short := LTTB{}.Downsample(pts[:10], 100)
+	if len(short) != 10 {
+		t.Errorf("unexpected number of points for short input: got:%d want:10", len(short))
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	pts := sinePoints(10000)
+	pts[5000].Y = 10
+	pts[7000].Y = -10
+	idx := MinMax{}.Downsample(pts, 100)
+	if len(idx) > 100 {
+		t.Errorf("too many points: got:%d want:<=100", len(idx))
+	}
+	if !sort.IntsAreSorted(idx) {
+		t.Error("indices not sorted")
+	}
+	var sawMax, sawMin bool
+	for _, i := range idx {
+		sawMax = sawMax || i == 5000
+		sawMin = sawMin || i == 7000
+	}
+	if !sawMax || !sawMin {
+		t.Errorf("extreme points not retained: max:%t min:%t", sawMax, sawMin)
+	}
+}
+
+func TestLineDownsample(t *testing.T) {
+	xys := make(XYs, 100000)
+	for i := range xys {
+		xys[i].X = float64(i)
+		xys[i].Y = math.Sin(float64(i) / 1000)
+	}
+	for _, d := range []Downsampler{LTTB{}, MinMax{}} {
+		l, err := NewLine(xys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		l.Downsampler = d
+		s, err := NewScatter(xys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		s.Downsampler = d
+
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Add(l, s)
+		_, err = p.WriterTo(4*vg.Centimeter, 4*vg.Centimeter, "png")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+// dpiRecorder is a recorder.Canvas reporting a resolution.
+type dpiRecorder struct {
+	recorder.Canvas
+	dpi float64
+}
+
+func (c *dpiRecorder) DPI() float64 { return c.dpi }
+
+func TestLineDownsampleDefault(t *testing.T) {
+	xys := make(XYs, 100000)
+	for i := range xys {
+		xys[i].X = float64(i)
+		xys[i].Y = math.Sin(float64(i) / 1000)
+	}
+	for _, test := range []struct {
+		d        Downsampler
+		dpi      float64
+		min, max int
+	}{
+		// 10cm is 284 dots at 72 dpi and 1182 dots at 300 dpi.
+		{d: nil, dpi: 0, min: 2, max: 2 * 284},
+		{d: nil, dpi: 300, min: 2 * 284, max: 2 * 1182},
+		{d: NoDownsampling, dpi: 0, min: len(xys), max: len(xys)},
+		{d: NoDownsampling, dpi: 300, min: len(xys), max: len(xys)},
+	} {
+		l, err := NewLine(xys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		l.Downsampler = test.d
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.HideAxes()
+		p.Add(l)
+
+		rec := &recorder.Canvas{}
+		var c vg.Canvas = rec
+		if test.dpi != 0 {
+			r := &dpiRecorder{dpi: test.dpi}
+			rec, c = &r.Canvas, r
+		}
+		dc := draw.Canvas{Canvas: c, Rectangle: vg.Rectangle{Max: vg.Point{X: 10 * vg.Centimeter, Y: 10 * vg.Centimeter}}}
+		l.Plot(dc, p)
+
+		var n int
+		for _, a := range rec.Actions {
+			if s, ok := a.(*recorder.Stroke); ok && len(s.Path) > n {
+				n = len(s.Path)
+			}
+		}
+		if n < test.min || n > test.max {
+			t.Errorf("unexpected number of points drawn for %T at %v dpi: got:%d want:[%d,%d]", test.d, test.dpi, n, test.min, test.max)
+		}
+	}
+}
@@ -5,6 +5,9 @@
 package plotter
 
 import (
+	"errors"
+	"fmt"
+	"image/color"
 	"math"
 
 	"gonum.org/v1/plot"
@@ -62,6 +65,16 @@ func NewYErrorBars(yerrs interface {
 	}, nil
 }
 
+// Validate returns an error if the YErrorBars do not have
+// an error for each point, implementing the plot.Validator
+// interface.
+func (e *YErrorBars) Validate() error {
+	if len(e.XYs) != len(e.YErrors) {
+		return fmt.Errorf("plotter: y error bars have %d points but %d errors", len(e.XYs), len(e.YErrors))
+	}
+	return nil
+}
+
 // Plot implements the Plotter interface, drawing labels.
 func (e *YErrorBars) Plot(c draw.Canvas, p *plot.Plot) {
 	trX, trY := p.Transforms(&c)
@@ -170,6 +183,16 @@ func NewXErrorBars(xerrs interface {
 	}, nil
 }
 
+// Validate returns an error if the XErrorBars do not have
+// an error for each point, implementing the plot.Validator
+// interface.
+func (e *XErrorBars) Validate() error {
+	if len(e.XYs) != len(e.XErrors) {
+		return fmt.Errorf("plotter: x error bars have %d points but %d errors", len(e.XYs), len(e.XErrors))
+	}
+	return nil
+}
+
 // Plot implements the Plotter interface, drawing labels.
 func (e *XErrorBars) Plot(c draw.Canvas, p *plot.Plot) {
 	trX, trY := p.Transforms(&c)
@@ -230,3 +253,214 @@ func (e *XErrorBars) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 	}
 	return bs
 }
+
+// ErrorBars implements the plot.Plotter, plot.DataRanger,
+// and plot.GlyphBoxer interfaces, drawing horizontal and
+// vertical error bars, or error boxes, denoting errors in
+// X and Y values.
+type ErrorBars struct {
+	XYs
+
+	// XErrors and YErrors are copies of the X and Y
+	// errors for each point. Either may be nil, in
+	// which case no bars are drawn for that axis.
+	XErrors
+	YErrors
+
+	// LineStyle is the style used to draw the error bars.
+	draw.LineStyle
+
+	// LineStyleFunc, if not nil, specifies the LineStyle
+	// of the error bars or box outline of individual
+	// points.
+	LineStyleFunc func(int) draw.LineStyle
+
+	// CapWidth is the width of the caps drawn at the
+	// ends of each error bar.
+	CapWidth vg.Length
+
+	// CapStyle is the style used to draw the caps. If
+	// the Color of CapStyle is nil, the caps are drawn
+	// in the style of the error bar.
+	CapStyle draw.LineStyle
+
+	// Boxes specifies whether each point is drawn as a
+	// box spanning its X and Y errors rather than as a
+	// pair of error bars.
+	Boxes bool
+
+	// BoxColor is the fill color of error boxes. If
+	// BoxColor is nil the boxes are not filled.
+	BoxColor color.Color
+}
+
+// NewErrorBars returns a new ErrorBars plotter, or an error on
+// failure. The data must implement XErrorer, YErrorer or both.
+// As for NewXErrorBars and NewYErrorBars the error values are
+// interpreted as the absolute distances below and above each
+// point, so they may differ to denote asymmetric errors.
+func NewErrorBars(data XYer) (*ErrorBars, error) {
+	xerr, isX := data.(XErrorer)
+	yerr, isY := data.(YErrorer)
+	if !isX && !isY {
+		return nil, errors.New("plotter: data is neither an XErrorer nor a YErrorer")
+	}
+	xys, err := CopyXYs(data)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &ErrorBars{
+		XYs:       xys,
+		LineStyle: DefaultLineStyle,
+		CapWidth:  DefaultCapWidth,
+	}
+	if isX {
+		e.XErrors = make(XErrors, len(xys))
+		for i := range e.XErrors {
+			e.XErrors[i].Low, e.XErrors[i].High = xerr.XError(i)
+			if err := CheckFloats(e.XErrors[i].Low, e.XErrors[i].High); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if isY {
+		e.YErrors = make(YErrors, len(xys))
+		for i := range e.YErrors {
+			e.YErrors[i].Low, e.YErrors[i].High = yerr.YError(i)
+			if err := CheckFloats(e.YErrors[i].Low, e.YErrors[i].High); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return e, nil
+}
+
+// extent returns the low and high X and Y values spanned by
+// the errors of the ith point.
+func (e *ErrorBars) extent(i int) (xlow, xhigh, ylow, yhigh float64) {
+	p := e.XYs[i]
+	xlow, xhigh = p.X, p.X
+	if e.XErrors != nil {
+		xlow -= math.Abs(e.XErrors[i].Low)
+		xhigh += math.Abs(e.XErrors[i].High)
+	}
+	ylow, yhigh = p.Y, p.Y
+	if e.YErrors != nil {
+		ylow -= math.Abs(e.YErrors[i].Low)
+		yhigh += math.Abs(e.YErrors[i].High)
+	}
+	return xlow, xhigh, ylow, yhigh
+}
+
+// Plot implements the Plotter interface, drawing the error
+// bars or boxes.
+func (e *ErrorBars) Plot(c draw.Canvas, p *plot.Plot) {
+	trX, trY := p.Transforms(&c)
+	for i, pt := range e.XYs {
+		sty := e.LineStyle
+		if e.LineStyleFunc != nil {
+			sty = e.LineStyleFunc(i)
+		}
+		capSty := e.CapStyle
+		if capSty.Color == nil {
+			capSty = sty
+		}
+
+		x, y := trX(pt.X), trY(pt.Y)
+		xl, xh, yl, yh := e.extent(i)
+		xlow, xhigh := trX(xl), trX(xh)
+		ylow, yhigh := trY(yl), trY(yh)
+
+		if e.Boxes {
+			box := []vg.Point{{X: xlow, Y: ylow}, {X: xhigh, Y: ylow}, {X: xhigh, Y: yhigh}, {X: xlow, Y: yhigh}}
+			if e.BoxColor != nil {
+				c.FillPolygon(e.BoxColor, c.ClipPolygonXY(box))
+			}
+			c.StrokeLines(sty, c.ClipLinesXY(append(box, box[0]))...)
+			continue
+		}
+
+		if e.XErrors != nil {
+			c.StrokeLines(sty, c.ClipLinesX([]vg.Point{{X: xlow, Y: y}, {X: xhigh, Y: y}})...)
+			for _, x := range []vg.Length{xlow, xhigh} {
+				if c.Contains(vg.Point{X: x, Y: y}) {
+					c.StrokeLine2(capSty, x, y-e.CapWidth/2, x, y+e.CapWidth/2)
+				}
+			}
+		}
+		if e.YErrors != nil {
+			c.StrokeLines(sty, c.ClipLinesY([]vg.Point{{X: x, Y: ylow}, {X: x, Y: yhigh}})...)
+			for _, y := range []vg.Length{ylow, yhigh} {
+				if c.Contains(vg.Point{X: x, Y: y}) {
+					c.StrokeLine2(capSty, x-e.CapWidth/2, y, x+e.CapWidth/2, y)
+				}
+			}
+		}
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (e *ErrorBars) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	for i := range e.XYs {
+		xl, xh, yl, yh := e.extent(i)
+		xmin = math.Min(xmin, xl)
+		xmax = math.Max(xmax, xh)
+		ymin = math.Min(ymin, yl)
+		ymax = math.Max(ymax, yh)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface.
+func (e *ErrorBars) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	w := e.LineStyle.Width / 2
+	c := w
+	if !e.Boxes && e.CapWidth/2 > c {
+		c = e.CapWidth / 2
+	}
+	xcap := vg.Rectangle{Min: vg.Point{X: -w, Y: -c}, Max: vg.Point{X: w, Y: c}}
+	ycap := vg.Rectangle{Min: vg.Point{X: -c, Y: -w}, Max: vg.Point{X: c, Y: w}}
+	var bs []plot.GlyphBox
+	for i, p := range e.XYs {
+		xl, xh, yl, yh := e.extent(i)
+		x, y := plt.X.Norm(p.X), plt.Y.Norm(p.Y)
+		if e.XErrors != nil || e.Boxes {
+			bs = append(bs,
+				plot.GlyphBox{X: plt.X.Norm(xl), Y: y, Rectangle: xcap},
+				plot.GlyphBox{X: plt.X.Norm(xh), Y: y, Rectangle: xcap})
+		}
+		if e.YErrors != nil || e.Boxes {
+			bs = append(bs,
+				plot.GlyphBox{X: x, Y: plt.Y.Norm(yl), Rectangle: ycap},
+				plot.GlyphBox{X: x, Y: plt.Y.Norm(yh), Rectangle: ycap})
+		}
+	}
+	return bs
+}
+
+// Thumbnail draws a cross of error bars in the style of the
+// ErrorBars, or a box if Boxes is true, implementing the
+// plot.Thumbnailer interface.
+func (e *ErrorBars) Thumbnail(c *draw.Canvas) {
+	if e.Boxes {
+		pts := []vg.Point{
+			{X: c.Min.X, Y: c.Min.Y}, {X: c.Max.X, Y: c.Min.Y},
+			{X: c.Max.X, Y: c.Max.Y}, {X: c.Min.X, Y: c.Max.Y},
+		}
+		if e.BoxColor != nil {
+			c.FillPolygon(e.BoxColor, c.ClipPolygonXY(pts))
+		}
+		c.StrokeLines(e.LineStyle, append(pts, pts[0]))
+		return
+	}
+	ctr := c.Center()
+	if e.XErrors != nil {
+		c.StrokeLine2(e.LineStyle, c.Min.X, ctr.Y, c.Max.X, ctr.Y)
+	}
+	if e.YErrors != nil {
+		c.StrokeLine2(e.LineStyle, ctr.X, c.Min.Y, ctr.X, c.Max.Y)
+	}
+}
@@ -5,13 +5,16 @@
 package plotter
 
 import (
+	"image/color"
 	"log"
+	"math"
 	"testing"
 
 	"golang.org/x/exp/rand"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 )
 
@@ -83,3 +86,125 @@ func ExampleErrors() {
 func TestErrors(t *testing.T) {
 	cmpimg.CheckPlot(ExampleErrors, t, "errorBars.png")
 }
+
+// ExampleErrorBars draws asymmetric error bars with styled
+// caps and per-point colors, and error boxes.
+func ExampleErrorBars() {
+	type yErrPoints struct {
+		XYs
+		YErrors
+	}
+	type errPoints struct {
+		XYs
+		XErrors
+		YErrors
+	}
+	bars := yErrPoints{
+		XYs:     XYs{{X: 1, Y: 2}, {X: 2, Y: 3.5}, {X: 3, Y: 3}, {X: 4, Y: 5}},
+		YErrors: YErrors{{Low: 0.5, High: 1}, {Low: 0.2, High: 0.4}, {Low: 1, High: 0.3}, {Low: 0.6, High: 0.6}},
+	}
+	boxes := errPoints{
+		XYs:     XYs{{X: 1.5, Y: 0.5}, {X: 3, Y: 1}, {X: 4.2, Y: 1.8}},
+		XErrors: XErrors{{Low: 0.3, High: 0.2}, {Low: 0.5, High: 0.5}, {Low: 0.2, High: 0.4}},
+		YErrors: YErrors{{Low: 0.2, High: 0.3}, {Low: 0.4, High: 0.2}, {Low: 0.3, High: 0.3}},
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	e, err := NewErrorBars(bars)
+	if err != nil {
+		log.Panic(err)
+	}
+	e.CapWidth = vg.Points(8)
+	e.CapStyle = draw.LineStyle{Color: color.Black, Width: vg.Points(2)}
+	e.LineStyleFunc = func(i int) draw.LineStyle {
+		sty := e.LineStyle
+		if bars.YErrors[i].Low > bars.YErrors[i].High {
+			sty.Color = color.RGBA{R: 196, A: 255}
+		}
+		return sty
+	}
+
+	b, err := NewErrorBars(boxes)
+	if err != nil {
+		log.Panic(err)
+	}
+	b.Boxes = true
+	b.Color = color.RGBA{B: 196, A: 255}
+	b.BoxColor = color.NRGBA{B: 196, A: 64}
+
+	s, err := NewScatter(bars.XYs)
+	if err != nil {
+		log.Panic(err)
+	}
+	s.Shape = draw.CircleGlyph{}
+	c, err := NewScatter(boxes.XYs)
+	if err != nil {
+		log.Panic(err)
+	}
+	c.Shape = draw.CrossGlyph{}
+
+	p.Add(b, e, s, c)
+	p.Legend.Add("bars", e, s)
+	p.Legend.Add("boxes", b)
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	err = p.Save(200, 200, "testdata/errorBarsUnified.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestErrorBars(t *testing.T) {
+	cmpimg.CheckPlot(ExampleErrorBars, t, "errorBarsUnified.png")
+}
+
+func TestErrorBarsDataRange(t *testing.T) {
+	type errPoints struct {
+		XYs
+		XErrors
+		YErrors
+	}
+	data := errPoints{
+		XYs:     XYs{{X: 0, Y: 0}, {X: 2, Y: 1}},
+		XErrors: XErrors{{Low: 1, High: 0.5}, {Low: 0.5, High: 2}},
+		YErrors: YErrors{{Low: -3, High: 1}, {Low: 0, High: 0.5}},
+	}
+	e, err := NewErrorBars(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xmin, xmax, ymin, ymax := e.DataRange()
+	if xmin != -1 || xmax != 4 || ymin != -3 || ymax != 1.5 {
+		t.Errorf("unexpected data range: got:[%v, %v]×[%v, %v] want:[-1, 4]×[-3, 1.5]", xmin, xmax, ymin, ymax)
+	}
+
+	y, err := NewErrorBars(struct {
+		XYs
+		YErrors
+	}{data.XYs, data.YErrors})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if y.XErrors != nil {
+		t.Error("unexpected X errors for YErrorer")
+	}
+	xmin, xmax, _, _ = y.DataRange()
+	if xmin != 0 || xmax != 2 {
+		t.Errorf("unexpected x range: got:[%v, %v] want:[0, 2]", xmin, xmax)
+	}
+
+	_, err = NewErrorBars(data.XYs)
+	if err == nil {
+		t.Error("expected error for data without errors")
+	}
+	data.YErrors[0].High = math.NaN()
+	_, err = NewErrorBars(data)
+	if err == nil {
+		t.Error("expected error for NaN error")
+	}
+}
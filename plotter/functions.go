@@ -5,6 +5,8 @@
 package plotter
 
 import (
+	"math"
+
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
@@ -21,6 +23,13 @@ type Function struct {
 
 	Samples int
 
+	// Adaptive specifies whether the sampling is refined
+	// where the curve bends sharply on the canvas. When
+	// sampling is adaptive, the line is also broken where
+	// F is not finite or jumps discontinuously, as at the
+	// asymptotes of tan(x).
+	Adaptive bool
+
 	draw.LineStyle
 }
 
@@ -44,6 +53,19 @@ func (f *Function) Plot(c draw.Canvas, p *plot.Plot) {
 		min = p.X.Min
 		max = p.X.Max
 	}
+	if f.Adaptive {
+		s := sampler{
+			point: func(x float64) (vg.Point, bool) {
+				y := f.F(x)
+				return vg.Point{X: trX(x), Y: trY(y)}, isFinite(y)
+			},
+			deviation: chordDistance,
+			jump:      (c.Max.Y - c.Min.Y) / 8,
+		}
+		c.StrokeLines(f.LineStyle, c.ClipLinesXY(s.sample(min, max, f.Samples)...)...)
+		return
+	}
+
 	d := (max - min) / float64(f.Samples-1)
 	line := make([]vg.Point, f.Samples)
 	for i := range line {
@@ -61,3 +83,127 @@ func (f Function) Thumbnail(c *draw.Canvas) {
 	y := c.Center().Y
 	c.StrokeLine2(f.LineStyle, c.Min.X, y, c.Max.X, y)
 }
+
+const (
+	// maxRefinement is the maximum number of times an
+	// interval between uniform samples is bisected by
+	// adaptive sampling.
+	maxRefinement = 10
+
+	// refineTolerance is the deviation of the middle of
+	// an interval beyond which the curve is refined.
+	refineTolerance = vg.Length(0.25)
+)
+
+// sampler adaptively samples a curve, breaking the
+// sampled line at discontinuities.
+type sampler struct {
+	// point returns the canvas point of the curve at
+	// the parameter t, and whether it is finite.
+	point func(t float64) (vg.Point, bool)
+
+	// deviation returns the deviation of the point m
+	// at the middle of an interval from the chord of
+	// the interval from a to b.
+	deviation func(a, m, b vg.Point) vg.Length
+
+	// jump is the distance moved over an interval at
+	// the maximum refinement that is taken to be a
+	// discontinuity.
+	jump vg.Length
+
+	lines [][]vg.Point
+	cur   []vg.Point
+}
+
+// sample returns the lines of the sampled curve over the
+// parameter range [min, max], starting from n uniform
+// samples.
+func (s *sampler) sample(min, max float64, n int) [][]vg.Point {
+	if n < 2 {
+		n = 2
+	}
+	d := (max - min) / float64(n-1)
+	x0 := min
+	p0, ok0 := s.point(x0)
+	if ok0 {
+		s.cur = append(s.cur, p0)
+	}
+	for i := 1; i < n; i++ {
+		x1 := min + float64(i)*d
+		p1, ok1 := s.point(x1)
+		s.refine(x0, x1, p0, p1, ok0, ok1, 0)
+		x0, p0, ok0 = x1, p1, ok1
+	}
+	s.end()
+	return s.lines
+}
+
+// refine adds the curve over the parameter interval
+// [x0, x1] to the current line, bisecting the interval
+// while the curve deviates from its chord.
+func (s *sampler) refine(x0, x1 float64, p0, p1 vg.Point, ok0, ok1 bool, depth int) {
+	if depth < maxRefinement {
+		xm := (x0 + x1) / 2
+		pm, okm := s.point(xm)
+		if !ok0 || !ok1 || !okm || s.deviation(p0, pm, p1) > refineTolerance {
+			s.refine(x0, xm, p0, pm, ok0, okm, depth+1)
+			s.refine(xm, x1, pm, p1, okm, ok1, depth+1)
+			return
+		}
+	}
+	switch {
+	case !ok1:
+		s.end()
+	case !ok0:
+		s.cur = append(s.cur, p1)
+	case depth == maxRefinement && distance(p0, p1) > s.jump:
+		s.end()
+		s.cur = append(s.cur, p1)
+	default:
+		s.cur = append(s.cur, p1)
+	}
+}
+
+// end ends the current line.
+func (s *sampler) end() {
+	if len(s.cur) > 1 {
+		s.lines = append(s.lines, s.cur)
+	}
+	s.cur = nil
+}
+
+// chordDistance returns the vertical distance of m from
+// the chord from a to b. The vertical distance is used
+// rather than the perpendicular distance so that jumps,
+// which have nearly vertical chords, are refined.
+func chordDistance(a, m, b vg.Point) vg.Length {
+	if b.X == a.X {
+		return vg.Length(math.Min(math.Abs(float64(m.Y-a.Y)), math.Abs(float64(m.Y-b.Y))))
+	}
+	y := a.Y + (b.Y-a.Y)*(m.X-a.X)/(b.X-a.X)
+	return vg.Length(math.Abs(float64(m.Y - y)))
+}
+
+// midpointDistance returns the distance of m from the
+// middle of the chord from a to b.
+func midpointDistance(a, m, b vg.Point) vg.Length {
+	return distance(m, a.Add(b).Scale(0.5))
+}
+
+// distance returns the distance between a and b.
+func distance(a, b vg.Point) vg.Length {
+	d := b.Sub(a)
+	return vg.Length(math.Hypot(float64(d.X), float64(d.Y)))
+}
+
+// isFinite returns whether all the values are neither
+// NaN nor infinite.
+func isFinite(vs ...float64) bool {
+	for _, v := range vs {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return true
+}
@@ -59,3 +59,87 @@ func ExampleFunction() {
 func TestFunction(t *testing.T) {
 	cmpimg.CheckPlot(ExampleFunction, t, "functions.png")
 }
+
+// ExampleFunction_adaptive draws functions with asymptotes
+// and steep regions using adaptive sampling.
+func ExampleFunction_adaptive() {
+	tan := NewFunction(math.Tan)
+	tan.Adaptive = true
+	tan.Samples = 20
+	tan.Color = color.RGBA{B: 255, A: 255}
+
+	sinInv := NewFunction(func(x float64) float64 { return 2 * math.Sin(4/x) })
+	sinInv.Adaptive = true
+	sinInv.Samples = 20
+	sinInv.Color = color.RGBA{R: 255, A: 255}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.Title.Text = "Adaptive sampling"
+	p.Add(NewGrid(), tan, sinInv)
+	p.Legend.Add("tan(x)", tan)
+	p.Legend.Add("2sin(4/x)", sinInv)
+
+	p.X.Min = -5
+	p.X.Max = 5
+	p.Y.Min = -4
+	p.Y.Max = 4
+
+	err = p.Save(200, 200, "testdata/functionAdaptive.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestFunctionAdaptive(t *testing.T) {
+	cmpimg.CheckPlot(ExampleFunction_adaptive, t, "functionAdaptive.png")
+}
+
+// testSampler returns a sampler of f on an identity
+// transformed canvas.
+func testSampler(f func(float64) float64) sampler {
+	return sampler{
+		point: func(x float64) (vg.Point, bool) {
+			y := f(x)
+			return vg.Point{X: vg.Length(x), Y: vg.Length(y)}, isFinite(y)
+		},
+		deviation: chordDistance,
+		jump:      10,
+	}
+}
+
+func TestSamplerBreaks(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		f     func(float64) float64
+		lines int
+	}{
+		{name: "line", f: func(x float64) float64 { return x }, lines: 1},
+		{name: "step", f: func(x float64) float64 { return math.Floor(x/40) * 40 }, lines: 6},
+		{name: "sqrt", f: math.Sqrt, lines: 1},
+		{name: "reciprocal", f: func(x float64) float64 { return 1 / (x - 0.3) }, lines: 2},
+	} {
+		s := testSampler(test.f)
+		lines := s.sample(-100, 100, 11)
+		if len(lines) != test.lines {
+			t.Errorf("unexpected number of lines for %s: got:%d want:%d", test.name, len(lines), test.lines)
+		}
+		for _, l := range lines {
+			for i := 1; i < len(l); i++ {
+				if l[i].X <= l[i-1].X {
+					t.Errorf("points out of order for %s: %v", test.name, l)
+					break
+				}
+			}
+		}
+	}
+
+	// Straight lines need no refinement.
+	s := testSampler(func(x float64) float64 { return 2 * x })
+	if lines := s.sample(0, 10, 5); len(lines[0]) != 5 {
+		t.Errorf("unexpected refinement of straight line: got %d points, want 5", len(lines[0]))
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ExampleScatter_glyphs draws each of the glyphs registered with
+// the draw package, labelled with the names of the glyphs.
+func ExampleScatter_glyphs() {
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Glyphs"
+	p.HideAxes()
+
+	names := draw.GlyphNames()
+	const cols = 4
+	for i, name := range names {
+		g, err := draw.LookupGlyph(name)
+		if err != nil {
+			log.Panic(err)
+		}
+		x, y := float64(i%cols), -float64(i/cols)
+		s, err := NewScatter(XYs{{X: x, Y: y}})
+		if err != nil {
+			log.Panic(err)
+		}
+		s.GlyphStyle = draw.GlyphStyle{Color: color.RGBA{B: 160, A: 255}, Radius: vg.Points(6), Shape: g}
+		l, err := NewLabels(XYLabels{XYs: XYs{{X: x, Y: y}}, Labels: []string{name}})
+		if err != nil {
+			log.Panic(err)
+		}
+		l.XOffset, l.YOffset = vg.Points(10), -vg.Points(4)
+		p.Add(s, l)
+	}
+	p.X.Max = cols
+
+	err = p.Save(400, 300, "testdata/glyphs.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestScatterGlyphs(t *testing.T) {
+	cmpimg.CheckPlot(ExampleScatter_glyphs, t, "glyphs.png")
+}
@@ -0,0 +1,377 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+
+	gonumgraph "gonum.org/v1/gonum/graph"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Layout computes the positions of the nodes of a graph.
+type Layout interface {
+	// Layout returns the positions of the given
+	// nodes of g, in the same order as nodes.
+	Layout(g gonumgraph.Graph, nodes []gonumgraph.Node) XYs
+}
+
+// CircularLayout is a Layout that places nodes at equal
+// intervals around the unit circle, counterclockwise in
+// order of node ID starting from the top.
+type CircularLayout struct{}
+
+var _ Layout = CircularLayout{}
+
+// Layout implements the Layout interface.
+func (CircularLayout) Layout(_ gonumgraph.Graph, nodes []gonumgraph.Node) XYs {
+	xys := make(XYs, len(nodes))
+	for i := range nodes {
+		sin, cos := math.Sincos(math.Pi/2 + 2*math.Pi*float64(i)/float64(len(nodes)))
+		xys[i].X = cos
+		xys[i].Y = sin
+	}
+	return xys
+}
+
+// BipartiteLayout is a Layout that places the nodes of
+// the two parts of a bipartite graph in two columns, at
+// x = 0 and x = 1, in order of node ID from the top.
+type BipartiteLayout struct {
+	// Left reports whether a node belongs in the
+	// left column.
+	Left func(gonumgraph.Node) bool
+}
+
+var _ Layout = BipartiteLayout{}
+
+// Layout implements the Layout interface.
+func (l BipartiteLayout) Layout(_ gonumgraph.Graph, nodes []gonumgraph.Node) XYs {
+	xys := make(XYs, len(nodes))
+	var left, right float64
+	for i, n := range nodes {
+		if l.Left(n) {
+			xys[i].Y = -left
+			left++
+		} else {
+			xys[i].X = 1
+			xys[i].Y = -right
+			right++
+		}
+	}
+	return xys
+}
+
+// ForceLayout is a Layout that positions nodes using the
+// Fruchterman-Reingold force-directed algorithm, in which
+// all nodes repel each other and connected nodes attract.
+// Positions lie within the unit square centred on the
+// origin.
+type ForceLayout struct {
+	// Iterations is the number of iterations of the
+	// algorithm. If Iterations is zero, 100
+	// iterations are performed.
+	Iterations int
+
+	// Seed is the seed of the random source used to
+	// choose the initial positions, so that layouts
+	// are reproducible.
+	Seed int64
+}
+
+var _ Layout = ForceLayout{}
+
+// Layout implements the Layout interface.
+func (l ForceLayout) Layout(g gonumgraph.Graph, nodes []gonumgraph.Node) XYs {
+	n := len(nodes)
+	xys := make(XYs, n)
+	if n == 0 {
+		return xys
+	}
+	iter := l.Iterations
+	if iter == 0 {
+		iter = 100
+	}
+
+	rnd := rand.New(rand.NewSource(l.Seed))
+	for i := range xys {
+		xys[i].X = rnd.Float64() - 0.5
+		xys[i].Y = rnd.Float64() - 0.5
+	}
+	index := nodeIndex(nodes)
+	edges := graphEdges(g, nodes)
+
+	// k is the ideal distance between nodes for
+	// n nodes in a unit area.
+	k := math.Sqrt(1 / float64(n))
+	disp := make(XYs, n)
+	for it := 0; it < iter; it++ {
+		for i := range disp {
+			disp[i].X, disp[i].Y = 0, 0
+		}
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				dx := xys[i].X - xys[j].X
+				dy := xys[i].Y - xys[j].Y
+				d := math.Max(math.Hypot(dx, dy), 1e-3)
+				f := k * k / d / d
+				disp[i].X += dx * f
+				disp[i].Y += dy * f
+				disp[j].X -= dx * f
+				disp[j].Y -= dy * f
+			}
+		}
+		for _, e := range edges {
+			i, j := index[e.From().ID()], index[e.To().ID()]
+			dx := xys[i].X - xys[j].X
+			dy := xys[i].Y - xys[j].Y
+			f := math.Hypot(dx, dy) / k
+			disp[i].X -= dx * f
+			disp[i].Y -= dy * f
+			disp[j].X += dx * f
+			disp[j].Y += dy * f
+		}
+
+		// The temperature limits the displacement of
+		// each node and cools linearly.
+		t := 0.1 * (1 - float64(it)/float64(iter))
+		for i := range xys {
+			d := math.Hypot(disp[i].X, disp[i].Y)
+			if d == 0 {
+				continue
+			}
+			s := math.Min(d, t) / d
+			xys[i].X = math.Max(-0.5, math.Min(xys[i].X+disp[i].X*s, 0.5))
+			xys[i].Y = math.Max(-0.5, math.Min(xys[i].Y+disp[i].Y*s, 0.5))
+		}
+	}
+	return xys
+}
+
+// GraphPlot implements the Plotter interface, drawing
+// the nodes of a graph as glyphs and its edges as lines.
+type GraphPlot struct {
+	// Nodes holds the nodes of the graph in
+	// order of ID.
+	Nodes []gonumgraph.Node
+
+	// XYs holds the positions of Nodes.
+	XYs
+
+	// Edges holds the edges of the graph. Each edge
+	// of an undirected graph is held once.
+	Edges []gonumgraph.Edge
+
+	// NodeStyle is the style of the node glyphs.
+	NodeStyle draw.GlyphStyle
+
+	// NodeStyleFunc, if not nil, specifies the
+	// style of individual nodes.
+	NodeStyleFunc func(gonumgraph.Node) draw.GlyphStyle
+
+	// EdgeStyle is the style of the edge lines.
+	EdgeStyle draw.LineStyle
+
+	// EdgeStyleFunc, if not nil, specifies the
+	// style of individual edges.
+	EdgeStyleFunc func(gonumgraph.Edge) draw.LineStyle
+
+	// Curvature bends edges into quadratic curves
+	// whose control point is offset from the middle
+	// of the edge, to the left of its direction, by
+	// Curvature times the length of the edge. Edges
+	// are drawn as straight lines when Curvature
+	// is zero.
+	Curvature float64
+
+	// Label, if not nil, returns the label drawn
+	// above each node.
+	Label func(gonumgraph.Node) string
+
+	// TextStyle is the style of the node labels.
+	TextStyle draw.TextStyle
+
+	index map[int64]int
+}
+
+// NewGraphPlot returns a GraphPlot drawing g with nodes
+// positioned by the layout l, using the default glyph,
+// line and label styles.
+func NewGraphPlot(g gonumgraph.Graph, l Layout) (*GraphPlot, error) {
+	nodes := g.Nodes()
+	if len(nodes) == 0 {
+		return nil, errors.New("plotter: graph has no nodes")
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
+
+	xys := l.Layout(g, nodes)
+	if len(xys) != len(nodes) {
+		return nil, errors.New("plotter: layout does not match number of nodes")
+	}
+	for _, p := range xys {
+		if err := CheckFloats(p.X, p.Y); err != nil {
+			return nil, err
+		}
+	}
+
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &GraphPlot{
+		Nodes:     nodes,
+		XYs:       xys,
+		Edges:     graphEdges(g, nodes),
+		NodeStyle: DefaultGlyphStyle,
+		EdgeStyle: DefaultLineStyle,
+		TextStyle: draw.TextStyle{Font: fnt},
+		index:     nodeIndex(nodes),
+	}, nil
+}
+
+// nodeIndex returns the index of each node keyed by ID.
+func nodeIndex(nodes []gonumgraph.Node) map[int64]int {
+	index := make(map[int64]int, len(nodes))
+	for i, n := range nodes {
+		index[n.ID()] = i
+	}
+	return index
+}
+
+// graphEdges returns the edges of g between the given
+// nodes, holding each edge of an undirected graph once.
+// Self loops are omitted.
+func graphEdges(g gonumgraph.Graph, nodes []gonumgraph.Node) []gonumgraph.Edge {
+	_, undirected := g.(gonumgraph.Undirected)
+	var edges []gonumgraph.Edge
+	for _, u := range nodes {
+		to := g.From(u.ID())
+		sort.Slice(to, func(i, j int) bool { return to[i].ID() < to[j].ID() })
+		for _, v := range to {
+			if u.ID() == v.ID() || (undirected && v.ID() < u.ID()) {
+				continue
+			}
+			edges = append(edges, g.Edge(u.ID(), v.ID()))
+		}
+	}
+	return edges
+}
+
+// Plot draws the GraphPlot, implementing the plot.Plotter
+// interface. Edges are drawn first, then the nodes and
+// finally their labels.
+func (gp *GraphPlot) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	pt := func(i int) vg.Point {
+		return vg.Point{X: trX(gp.XYs[i].X), Y: trY(gp.XYs[i].Y)}
+	}
+
+	for _, e := range gp.Edges {
+		sty := gp.EdgeStyle
+		if gp.EdgeStyleFunc != nil {
+			sty = gp.EdgeStyleFunc(e)
+		}
+		p, q := pt(gp.index[e.From().ID()]), pt(gp.index[e.To().ID()])
+		c.StrokeLines(sty, c.ClipLinesXY(curve(p, q, gp.Curvature))...)
+	}
+
+	for i, n := range gp.Nodes {
+		p := pt(i)
+		if !c.Contains(p) {
+			continue
+		}
+		c.DrawGlyph(gp.glyph(n), p)
+	}
+
+	if gp.Label == nil {
+		return
+	}
+	sty := gp.TextStyle
+	sty.XAlign = draw.XCenter
+	for i, n := range gp.Nodes {
+		p := pt(i)
+		if !c.Contains(p) {
+			continue
+		}
+		p.Y += gp.glyph(n).Radius
+		c.FillText(sty, p, gp.Label(n))
+	}
+}
+
+// glyph returns the glyph style of the node n.
+func (gp *GraphPlot) glyph(n gonumgraph.Node) draw.GlyphStyle {
+	if gp.NodeStyleFunc != nil {
+		return gp.NodeStyleFunc(n)
+	}
+	return gp.NodeStyle
+}
+
+// curveSegments is the number of line segments
+// approximating a curved edge.
+const curveSegments = 16
+
+// curve returns the points of an edge from p to q, bent
+// to the left by the given curvature.
+func curve(p, q vg.Point, curvature float64) []vg.Point {
+	if curvature == 0 {
+		return []vg.Point{p, q}
+	}
+	d := q.Sub(p)
+	ctl := vg.Point{
+		X: (p.X+q.X)/2 - d.Y*vg.Length(curvature),
+		Y: (p.Y+q.Y)/2 + d.X*vg.Length(curvature),
+	}
+	pts := make([]vg.Point, curveSegments+1)
+	for i := range pts {
+		t := vg.Length(i) / curveSegments
+		pts[i] = p.Scale((1 - t) * (1 - t)).Add(ctl.Scale(2 * t * (1 - t))).Add(q.Scale(t * t))
+	}
+	return pts
+}
+
+// DataRange returns the minimum and maximum x and y
+// values of the node positions, implementing the
+// plot.DataRanger interface.
+func (gp *GraphPlot) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return XYRange(gp.XYs)
+}
+
+// GlyphBoxes returns a slice of plot.GlyphBoxes, one
+// for each node, implementing the plot.GlyphBoxer
+// interface. The boxes of labelled nodes include
+// their labels.
+func (gp *GraphPlot) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(gp.Nodes))
+	for i, n := range gp.Nodes {
+		bs[i].X = plt.X.Norm(gp.XYs[i].X)
+		bs[i].Y = plt.Y.Norm(gp.XYs[i].Y)
+		r := gp.glyph(n).Radius
+		bs[i].Rectangle = vg.Rectangle{
+			Min: vg.Point{X: -r, Y: -r},
+			Max: vg.Point{X: +r, Y: +r},
+		}
+		if gp.Label == nil {
+			continue
+		}
+		label := gp.Label(n)
+		w := gp.TextStyle.Width(label)
+		h := gp.TextStyle.Height(label)
+		bs[i].Rectangle.Min.X = vg.Length(math.Min(float64(-r), float64(-w/2)))
+		bs[i].Rectangle.Max.X = vg.Length(math.Max(float64(r), float64(w/2)))
+		bs[i].Rectangle.Max.Y = r + h
+	}
+	return bs
+}
+
+// Thumbnail draws a node glyph, implementing the
+// plot.Thumbnailer interface.
+func (gp *GraphPlot) Thumbnail(c *draw.Canvas) {
+	c.DrawGlyph(gp.NodeStyle, c.Center())
+}
@@ -0,0 +1,111 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	gonumgraph "gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+func ExampleGraphPlot() {
+	// The Petersen graph.
+	g := simple.NewUndirectedGraph()
+	for i := 0; i < 5; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node((i + 1) % 5)})
+		g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(i + 5)})
+		g.SetEdge(simple.Edge{F: simple.Node(i + 5), T: simple.Node((i+2)%5 + 5)})
+	}
+
+	gp, err := NewGraphPlot(g, ForceLayout{Seed: 1})
+	if err != nil {
+		log.Panic(err)
+	}
+	gp.NodeStyleFunc = func(n gonumgraph.Node) draw.GlyphStyle {
+		sty := draw.GlyphStyle{Shape: draw.CircleGlyph{}, Radius: vg.Points(5)}
+		sty.Color = color.RGBA{R: 196, B: 128, A: 255}
+		if n.ID() >= 5 {
+			sty.Color = color.RGBA{G: 128, B: 196, A: 255}
+		}
+		return sty
+	}
+	gp.EdgeStyle.Color = color.Gray{Y: 128}
+	gp.Curvature = 0.1
+	gp.Label = func(n gonumgraph.Node) string { return fmt.Sprint(n.ID()) }
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Petersen graph"
+	p.HideAxes()
+	p.DataAspect = 1
+	p.Add(gp)
+
+	err = p.Save(250, 250, "testdata/graph.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestGraphPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleGraphPlot, t, "graph.png")
+}
+
+func TestGraphLayouts(t *testing.T) {
+	g := simple.NewUndirectedGraph()
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+	g.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(3)})
+
+	gp, err := NewGraphPlot(g, CircularLayout{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(gp.Edges) != 3 {
+		t.Errorf("unexpected number of edges: got:%d want:3", len(gp.Edges))
+	}
+	want := XYs{{X: 0, Y: 1}, {X: -1, Y: 0}, {X: 0, Y: -1}, {X: 1, Y: 0}}
+	for i, p := range gp.XYs {
+		if math.Abs(p.X-want[i].X) > 1e-12 || math.Abs(p.Y-want[i].Y) > 1e-12 {
+			t.Errorf("unexpected circular position of node %d: got:%v want:%v", i, p, want[i])
+		}
+	}
+
+	gp, err = NewGraphPlot(g, BipartiteLayout{Left: func(n gonumgraph.Node) bool { return n.ID()%2 == 0 }})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = XYs{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: -1}, {X: 1, Y: -1}}
+	for i, p := range gp.XYs {
+		if p != want[i] {
+			t.Errorf("unexpected bipartite position of node %d: got:%v want:%v", i, p, want[i])
+		}
+	}
+
+	a := ForceLayout{Seed: 1}.Layout(g, gp.Nodes)
+	b := ForceLayout{Seed: 1}.Layout(g, gp.Nodes)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("force layout is not reproducible: %v != %v", a, b)
+		}
+		if math.Abs(a[i].X) > 0.5 || math.Abs(a[i].Y) > 0.5 {
+			t.Errorf("force layout position outside unit square: %v", a[i])
+		}
+	}
+	// Adjacent nodes should be closer than the ends of the path.
+	if d01, d03 := math.Hypot(a[0].X-a[1].X, a[0].Y-a[1].Y), math.Hypot(a[0].X-a[3].X, a[0].Y-a[3].Y); d01 >= d03 {
+		t.Errorf("unexpected force layout: adjacent distance %v >= path end distance %v", d01, d03)
+	}
+}
@@ -18,20 +18,38 @@ var (
 		Color: color.Gray{128},
 		Width: vg.Points(0.25),
 	}
+
+	// DefaultMinorGridLineStyle is a style for grid lines
+	// at the minor tick marks, fainter than the default
+	// grid line style.
+	DefaultMinorGridLineStyle = draw.LineStyle{
+		Color: color.Gray{192},
+		Width: vg.Points(0.25),
+	}
 )
 
 // Grid implements the plot.Plotter interface, drawing
-// a set of grid lines at the major tick marks.
+// a set of grid lines at the tick marks of the axes of
+// the plot, as they are laid out when the plot is drawn.
 type Grid struct {
-	// Vertical is the style of the vertical lines.
+	// Vertical is the style of the vertical lines
+	// at the major tick marks of the X axis.
 	Vertical draw.LineStyle
 
-	// Horizontal is the style of the horizontal lines.
+	// Horizontal is the style of the horizontal lines
+	// at the major tick marks of the Y axis.
 	Horizontal draw.LineStyle
+
+	// VerticalMinor and HorizontalMinor are the
+	// styles of the lines at the minor tick marks of
+	// the X and Y axes. The lines of a style with a
+	// nil color, the default, are not drawn.
+	VerticalMinor, HorizontalMinor draw.LineStyle
 }
 
 // NewGrid returns a new grid with both vertical and
-// horizontal lines using the default grid line style.
+// horizontal lines at the major tick marks using the
+// default grid line style.
 func NewGrid() *Grid {
 	return &Grid{
 		Vertical:   DefaultGridLineStyle,
@@ -43,39 +61,38 @@ func NewGrid() *Grid {
 func (g *Grid) Plot(c draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&c)
 
-	var (
-		ymin = c.Min.Y
-		ymax = c.Max.Y
-		xmin = c.Min.X
-		xmax = c.Max.X
-	)
-
-	if g.Vertical.Color == nil {
-		goto horiz
-	}
-	for _, tk := range plt.X.Tick.Marker.Ticks(plt.X.Min, plt.X.Max) {
-		if tk.IsMinor() {
-			continue
-		}
-		x := trX(tk.Value)
-		if x > xmax || x < xmin {
-			continue
+	// The minor lines are drawn first so that
+	// the major lines are drawn over them.
+	xticks := plt.X.Ticks()
+	yticks := plt.Y.Ticks()
+	for _, minor := range []bool{true, false} {
+		vert, horiz := g.Vertical, g.Horizontal
+		if minor {
+			vert, horiz = g.VerticalMinor, g.HorizontalMinor
 		}
-		c.StrokeLine2(g.Vertical, x, ymin, x, ymax)
-	}
-
-horiz:
-	if g.Horizontal.Color == nil {
-		return
-	}
-	for _, tk := range plt.Y.Tick.Marker.Ticks(plt.Y.Min, plt.Y.Max) {
-		if tk.IsMinor() {
-			continue
+		if vert.Color != nil {
+			for _, tk := range xticks {
+				if tk.IsMinor() != minor {
+					continue
+				}
+				x := trX(tk.Value)
+				if x > c.Max.X || x < c.Min.X {
+					continue
+				}
+				c.StrokeLine2(vert, x, c.Min.Y, x, c.Max.Y)
+			}
 		}
-		y := trY(tk.Value)
-		if y > ymax || y < ymin {
-			continue
+		if horiz.Color != nil {
+			for _, tk := range yticks {
+				if tk.IsMinor() != minor {
+					continue
+				}
+				y := trY(tk.Value)
+				if y > c.Max.Y || y < c.Min.Y {
+					continue
+				}
+				c.StrokeLine2(horiz, c.Min.X, y, c.Max.X, y)
+			}
 		}
-		c.StrokeLine2(g.Horizontal, xmin, y, xmax, y)
 	}
 }
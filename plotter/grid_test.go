@@ -0,0 +1,51 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+)
+
+// ExampleGrid_minor draws a grid with lines at both the
+// major and the minor tick marks of a linear X axis and a
+// log scale Y axis, with dashed vertical lines.
+func ExampleGrid_minor() {
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Minor grid lines"
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 1, 1000
+	p.Y.Scale = plot.LogScale{}
+	p.Y.Tick.Marker = plot.LogTicks{}
+
+	g := NewGrid()
+	g.Vertical.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
+	g.VerticalMinor = DefaultMinorGridLineStyle
+	g.HorizontalMinor = DefaultMinorGridLineStyle
+	p.Add(g)
+
+	exp := NewFunction(func(x float64) float64 { return math.Pow(2, x) })
+	exp.Width = vg.Points(1)
+	exp.Color = color.RGBA{B: 255, A: 255}
+	p.Add(exp)
+
+	err = p.Save(200, 200, "testdata/gridMinor.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestGridMinor(t *testing.T) {
+	cmpimg.CheckPlot(ExampleGrid_minor, t, "gridMinor.png")
+}
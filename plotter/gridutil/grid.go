@@ -0,0 +1,110 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gridutil provides functions for preparing gridded
+// data for the HeatMap and Contour plotters.
+//
+// Gridded data may be resampled onto another grid with Regrid,
+// smoothed with Smooth, and scattered data may be interpolated
+// onto a grid with IDW or NaturalNeighbor. Each returns a Grid,
+// which implements plotter.GridXYZ. The X and Y coordinates of
+// the columns and rows of all grids must be increasing.
+package gridutil // import "gonum.org/v1/plot/plotter/gridutil"
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// Grid is a rectilinear grid of values. It implements the
+// plotter.GridXYZ interface.
+type Grid struct {
+	// Xs and Ys are the coordinates of the columns
+	// and rows of the grid.
+	Xs, Ys []float64
+
+	// Data holds the values of the grid in row major
+	// order, the value at (c, r) being held at
+	// Data[r*len(Xs)+c].
+	Data []float64
+}
+
+// NewGrid returns a Grid with the given column and row
+// coordinates, holding zero values. The coordinates must
+// be increasing.
+func NewGrid(xs, ys []float64) (*Grid, error) {
+	if len(xs) == 0 || len(ys) == 0 {
+		return nil, errors.New("gridutil: empty grid")
+	}
+	if !increasing(len(xs), func(i int) float64 { return xs[i] }) ||
+		!increasing(len(ys), func(i int) float64 { return ys[i] }) {
+		return nil, errors.New("gridutil: grid coordinates not increasing")
+	}
+	return &Grid{Xs: xs, Ys: ys, Data: make([]float64, len(xs)*len(ys))}, nil
+}
+
+// Dims implements the Dims method of the plotter.GridXYZ interface.
+func (g *Grid) Dims() (c, r int) { return len(g.Xs), len(g.Ys) }
+
+// Z implements the Z method of the plotter.GridXYZ interface.
+func (g *Grid) Z(c, r int) float64 { return g.Data[g.index(c, r)] }
+
+// X implements the X method of the plotter.GridXYZ interface.
+func (g *Grid) X(c int) float64 { return g.Xs[c] }
+
+// Y implements the Y method of the plotter.GridXYZ interface.
+func (g *Grid) Y(r int) float64 { return g.Ys[r] }
+
+// Set sets the value at (c, r) to v.
+func (g *Grid) Set(c, r int, v float64) { g.Data[g.index(c, r)] = v }
+
+// index returns the index into Data of the value at (c, r).
+func (g *Grid) index(c, r int) int {
+	if c < 0 || c >= len(g.Xs) || r < 0 || r >= len(g.Ys) {
+		panic("gridutil: index out of range")
+	}
+	return r*len(g.Xs) + c
+}
+
+// increasing returns whether the n values returned by v are
+// increasing.
+func increasing(n int, v func(int) float64) bool {
+	for i := 1; i < n; i++ {
+		if !(v(i) > v(i-1)) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkGrid returns an error if g has fewer than two columns
+// or rows, or its coordinates are not increasing.
+func checkGrid(g plotter.GridXYZ) error {
+	c, r := g.Dims()
+	if c < 2 || r < 2 {
+		return errors.New("gridutil: grid has fewer than two columns or rows")
+	}
+	if !increasing(c, g.X) || !increasing(r, g.Y) {
+		return errors.New("gridutil: grid coordinates not increasing")
+	}
+	return nil
+}
+
+// fractionalIndex returns the fractional index of v among the
+// n increasing values returned by at, interpolating linearly
+// between them. It returns NaN if v is outside their range.
+func fractionalIndex(v float64, n int, at func(int) float64) float64 {
+	if !(v >= at(0) && v <= at(n-1)) {
+		return math.NaN()
+	}
+	i := sort.Search(n, func(i int) bool { return at(i) >= v })
+	if i == 0 {
+		return 0
+	}
+	lo, hi := at(i-1), at(i)
+	return float64(i-1) + (v-lo)/(hi-lo)
+}
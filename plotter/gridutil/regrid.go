@@ -0,0 +1,222 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridutil
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// Method is a method of interpolating between grid values.
+type Method int
+
+const (
+	// Bilinear interpolates linearly between the four
+	// grid values surrounding a point.
+	Bilinear Method = iota
+
+	// Bicubic interpolates between the sixteen grid values
+	// surrounding a point with Catmull-Rom splines, giving
+	// a smooth surface through the grid values. The splines
+	// are computed in the index space of the grid, so they
+	// reproduce linear surfaces exactly only on uniformly
+	// spaced grids. Grid values beyond the edges of the grid
+	// are extrapolated linearly.
+	Bicubic
+)
+
+// Regrid returns a grid with columns and rows at xs and ys
+// holding the values of g interpolated at those coordinates
+// using the method m. Values at coordinates outside the range
+// of the columns or rows of g are NaN. The grid g must have at
+// least two columns and rows.
+func Regrid(g plotter.GridXYZ, xs, ys []float64, m Method) (*Grid, error) {
+	if err := checkGrid(g); err != nil {
+		return nil, err
+	}
+	if m != Bilinear && m != Bicubic {
+		return nil, errors.New("gridutil: unknown interpolation method")
+	}
+	dst, err := NewGrid(xs, ys)
+	if err != nil {
+		return nil, err
+	}
+
+	c, r := g.Dims()
+	for j, y := range ys {
+		fy := fractionalIndex(y, r, g.Y)
+		for i, x := range xs {
+			fx := fractionalIndex(x, c, g.X)
+			if math.IsNaN(fx) || math.IsNaN(fy) {
+				dst.Set(i, j, math.NaN())
+				continue
+			}
+			switch m {
+			case Bilinear:
+				dst.Set(i, j, bilinear(g, fx, fy))
+			case Bicubic:
+				dst.Set(i, j, bicubic(g, fx, fy))
+			}
+		}
+	}
+	return dst, nil
+}
+
+// cellOf returns the index of the first of the two grid values
+// along a dimension of n values either side of the fractional
+// index f, and the fraction of the distance between them at
+// which f lies.
+func cellOf(f float64, n int) (i int, t float64) {
+	i = int(f)
+	if i > n-2 {
+		i = n - 2
+	}
+	return i, f - float64(i)
+}
+
+// bilinear returns the value of g at the fractional column
+// and row (fx, fy) interpolated bilinearly.
+func bilinear(g plotter.GridXYZ, fx, fy float64) float64 {
+	c, r := g.Dims()
+	i, s := cellOf(fx, c)
+	j, t := cellOf(fy, r)
+	return (1-s)*(1-t)*g.Z(i, j) + s*(1-t)*g.Z(i+1, j) +
+		(1-s)*t*g.Z(i, j+1) + s*t*g.Z(i+1, j+1)
+}
+
+// bicubic returns the value of g at the fractional column
+// and row (fx, fy) interpolated with Catmull-Rom splines.
+func bicubic(g plotter.GridXYZ, fx, fy float64) float64 {
+	c, r := g.Dims()
+	i, s := cellOf(fx, c)
+	j, t := cellOf(fy, r)
+
+	// z returns the grid value at (i, j), extrapolating
+	// linearly for indices one beyond the edges of g.
+	var z func(i, j int) float64
+	z = func(i, j int) float64 {
+		switch {
+		case i < 0:
+			return 2*z(0, j) - z(1, j)
+		case i >= c:
+			return 2*z(c-1, j) - z(c-2, j)
+		case j < 0:
+			return 2*z(i, 0) - z(i, 1)
+		case j >= r:
+			return 2*z(i, r-1) - z(i, r-2)
+		}
+		return g.Z(i, j)
+	}
+
+	var col [4]float64
+	for k := range col {
+		col[k] = catmullRom(z(i-1, j-1+k), z(i, j-1+k), z(i+1, j-1+k), z(i+2, j-1+k), s)
+	}
+	return catmullRom(col[0], col[1], col[2], col[3], t)
+}
+
+// catmullRom returns the value at t in [0, 1] of the Catmull-Rom
+// spline through p1 and p2, with neighbouring points p0 and p3.
+func catmullRom(p0, p1, p2, p3, t float64) float64 {
+	return 0.5 * (2*p1 +
+		(p2-p0)*t +
+		(2*p0-5*p1+4*p2-p3)*t*t +
+		(3*p1-p0-3*p2+p3)*t*t*t)
+}
+
+// Smooth returns a grid with the columns and rows of g holding
+// the values of g smoothed by a gaussian kernel with a standard
+// deviation of sigma grid cells. NaN values of g are excluded
+// from smoothing and remain NaN, so that masked regions of the
+// grid are preserved. The kernel is truncated at three standard
+// deviations.
+func Smooth(g plotter.GridXYZ, sigma float64) (*Grid, error) {
+	if !(sigma >= 0) || math.IsInf(sigma, 0) {
+		return nil, errors.New("gridutil: invalid smoothing width")
+	}
+	c, r := g.Dims()
+	xs := make([]float64, c)
+	for i := range xs {
+		xs[i] = g.X(i)
+	}
+	ys := make([]float64, r)
+	for j := range ys {
+		ys[j] = g.Y(j)
+	}
+	dst, err := NewGrid(xs, ys)
+	if err != nil {
+		return nil, err
+	}
+
+	// Smooth by normalized convolution: the values, with
+	// NaNs replaced by zero, and a mask of the non-NaN values
+	// are convolved with the kernel, and the smoothed values
+	// are their ratio. The kernel is separable, so the rows
+	// and then the columns are convolved.
+	kernel := gaussian(sigma)
+	n := len(kernel) / 2
+	vals := make([]float64, c*r)
+	mask := make([]float64, c*r)
+	for j := 0; j < r; j++ {
+		for i := 0; i < c; i++ {
+			if v := g.Z(i, j); !math.IsNaN(v) {
+				vals[j*c+i] = v
+				mask[j*c+i] = 1
+			}
+		}
+	}
+	for _, buf := range [][]float64{vals, mask} {
+		tmp := make([]float64, c*r)
+		for j := 0; j < r; j++ {
+			for i := 0; i < c; i++ {
+				var sum float64
+				for k, w := range kernel {
+					if ii := i + k - n; 0 <= ii && ii < c {
+						sum += w * buf[j*c+ii]
+					}
+				}
+				tmp[j*c+i] = sum
+			}
+		}
+		for j := 0; j < r; j++ {
+			for i := 0; i < c; i++ {
+				var sum float64
+				for k, w := range kernel {
+					if jj := j + k - n; 0 <= jj && jj < r {
+						sum += w * tmp[jj*c+i]
+					}
+				}
+				buf[j*c+i] = sum
+			}
+		}
+	}
+	for j := 0; j < r; j++ {
+		for i := 0; i < c; i++ {
+			if math.IsNaN(g.Z(i, j)) {
+				dst.Set(i, j, math.NaN())
+				continue
+			}
+			dst.Set(i, j, vals[j*c+i]/mask[j*c+i])
+		}
+	}
+	return dst, nil
+}
+
+// gaussian returns a gaussian kernel with standard deviation
+// sigma, truncated at three standard deviations.
+func gaussian(sigma float64) []float64 {
+	if sigma == 0 {
+		return []float64{1}
+	}
+	n := int(math.Ceil(3 * sigma))
+	k := make([]float64, 2*n+1)
+	for i := range k {
+		d := float64(i-n) / sigma
+		k[i] = math.Exp(-d * d / 2)
+	}
+	return k
+}
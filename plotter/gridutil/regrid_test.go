@@ -0,0 +1,134 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridutil
+
+import (
+	"math"
+	"testing"
+)
+
+// gridOf returns a grid with columns and rows at xs and ys
+// holding the values of f.
+func gridOf(t *testing.T, xs, ys []float64, f func(x, y float64) float64) *Grid {
+	g, err := NewGrid(xs, ys)
+	if err != nil {
+		t.Fatalf("unexpected error making grid: %v", err)
+	}
+	for j, y := range ys {
+		for i, x := range xs {
+			g.Set(i, j, f(x, y))
+		}
+	}
+	return g
+}
+
+func TestRegrid(t *testing.T) {
+	linear := func(x, y float64) float64 { return 2*x - y + 1 }
+	xs := []float64{-1, 0, 0.5, 2, 3.75}
+	ys := []float64{0, 1, 3.5, 5}
+	for _, test := range []struct {
+		m     Method
+		srcXs []float64
+		srcYs []float64
+	}{
+		// Bilinear interpolation reproduces a linear
+		// surface on any grid, and bicubic interpolation
+		// on a uniformly spaced grid.
+		{m: Bilinear, srcXs: []float64{0, 1, 3, 4}, srcYs: []float64{0, 2, 4}},
+		{m: Bicubic, srcXs: []float64{0, 1, 2, 3, 4}, srcYs: []float64{0, 2, 4}},
+	} {
+		m := test.m
+		src := gridOf(t, test.srcXs, test.srcYs, linear)
+		dst, err := Regrid(src, xs, ys, m)
+		if err != nil {
+			t.Fatalf("unexpected error for method %d: %v", m, err)
+		}
+		for j, y := range ys {
+			for i, x := range xs {
+				got := dst.Z(i, j)
+				if x < 0 || y > 4 {
+					if !math.IsNaN(got) {
+						t.Errorf("expected NaN outside grid for method %d at (%v, %v): got:%v", m, x, y, got)
+					}
+					continue
+				}
+				want := linear(x, y)
+				if math.Abs(got-want) > 1e-12 {
+					t.Errorf("unexpected value for method %d at (%v, %v): got:%v want:%v", m, x, y, got, want)
+				}
+			}
+		}
+	}
+
+	src := gridOf(t, []float64{0, 1, 2}, ys, linear)
+	_, err := Regrid(src, []float64{1, 0}, ys, Bilinear)
+	if err == nil {
+		t.Error("expected error for decreasing coordinates")
+	}
+	_, err = Regrid(gridOf(t, []float64{0}, ys, func(x, y float64) float64 { return 0 }), xs, ys, Bilinear)
+	if err == nil {
+		t.Error("expected error for single column grid")
+	}
+}
+
+func TestRegridBicubic(t *testing.T) {
+	xs := []float64{0, 1, 2, 3, 4, 5, 6}
+	src := gridOf(t, xs, xs, func(x, y float64) float64 { return x * x })
+	dst, err := Regrid(src, []float64{2.5}, []float64{3}, Bicubic)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Catmull-Rom splines reproduce quadratics
+	// away from the edges of the grid.
+	if got, want := dst.Z(0, 0), 6.25; math.Abs(got-want) > 1e-12 {
+		t.Errorf("unexpected value: got:%v want:%v", got, want)
+	}
+}
+
+func TestSmooth(t *testing.T) {
+	xs := []float64{0, 1, 2, 3, 4, 5, 6}
+	src := gridOf(t, xs, xs, func(x, y float64) float64 { return 3 })
+	src.Set(2, 2, math.NaN())
+	dst, err := Smooth(src, 1.5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for j := range xs {
+		for i := range xs {
+			got := dst.Z(i, j)
+			if i == 2 && j == 2 {
+				if !math.IsNaN(got) {
+					t.Errorf("expected NaN to be preserved: got:%v", got)
+				}
+				continue
+			}
+			if math.Abs(got-3) > 1e-12 {
+				t.Errorf("unexpected smoothed constant at (%d, %d): got:%v want:3", i, j, got)
+			}
+		}
+	}
+
+	spike := gridOf(t, xs, xs, func(x, y float64) float64 {
+		if x == 3 && y == 3 {
+			return 1
+		}
+		return 0
+	})
+	dst, err = Smooth(spike, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !(dst.Z(3, 3) > dst.Z(4, 3) && dst.Z(4, 3) > dst.Z(5, 3) && dst.Z(5, 3) > 0) {
+		t.Errorf("expected smoothed spike to decay from its centre: got:%v", dst.Data[3*7+3:3*7+6])
+	}
+	if dst.Z(4, 3) != dst.Z(3, 4) {
+		t.Errorf("expected smoothed spike to be symmetric: got:%v and %v", dst.Z(4, 3), dst.Z(3, 4))
+	}
+
+	_, err = Smooth(src, -1)
+	if err == nil {
+		t.Error("expected error for negative smoothing width")
+	}
+}
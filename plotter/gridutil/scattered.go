@@ -0,0 +1,121 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridutil
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// IDW returns a grid with columns and rows at xs and ys holding
+// the values of the scattered data interpolated by inverse distance
+// weighting, with weights of the inverse of the distance raised to
+// the given power. A power of 2 is commonly used; larger powers give
+// more weight to nearby data. Grid points that coincide with a data
+// point take its value. An error is returned if there is no data,
+// the power is not positive or any data value is NaN or infinite.
+func IDW(data plotter.XYZer, xs, ys []float64, power float64) (*Grid, error) {
+	if !(power > 0) || math.IsInf(power, 0) {
+		return nil, errors.New("gridutil: invalid power")
+	}
+	pts, err := scattered(data)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := NewGrid(xs, ys)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, y := range ys {
+	cells:
+		for i, x := range xs {
+			var sum, weights float64
+			for _, p := range pts {
+				d := math.Hypot(p.X-x, p.Y-y)
+				if d == 0 {
+					dst.Set(i, j, p.Z)
+					continue cells
+				}
+				w := math.Pow(d, -power)
+				sum += w * p.Z
+				weights += w
+			}
+			dst.Set(i, j, sum/weights)
+		}
+	}
+	return dst, nil
+}
+
+// NaturalNeighbor returns a grid with columns and rows at xs and ys
+// holding the values of the scattered data interpolated by natural
+// neighbor (Sibson) interpolation. The interpolation is computed with
+// the discrete approximation of Park et al. (2006), in which each grid
+// point contributes the value of its nearest data point to the grid
+// points lying within the distance to that data point, and each grid
+// value is the mean of the contributions it receives. The result is
+// smooth between data points and passes through them, and is not
+// limited to the convex hull of the data. An error is returned if
+// there is no data or any data value is NaN or infinite.
+//
+// See https://doi.org/10.1109/TVCG.2006.27 for details.
+func NaturalNeighbor(data plotter.XYZer, xs, ys []float64) (*Grid, error) {
+	pts, err := scattered(data)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := NewGrid(xs, ys)
+	if err != nil {
+		return nil, err
+	}
+
+	sums := make([]float64, len(xs)*len(ys))
+	counts := make([]int, len(xs)*len(ys))
+	for _, y := range ys {
+		for _, x := range xs {
+			// Find the data point nearest (x, y).
+			z, d := math.NaN(), math.Inf(1)
+			for _, p := range pts {
+				if pd := math.Hypot(p.X-x, p.Y-y); pd < d {
+					z, d = p.Z, pd
+				}
+			}
+
+			// Contribute its value to (x, y) and the grid
+			// points within the circle of radius d around
+			// it. Points on the circle are excluded so that
+			// the interpolation passes through the data.
+			for l := sort.SearchFloat64s(ys, y-d); l < len(ys) && ys[l] <= y+d; l++ {
+				for k := sort.SearchFloat64s(xs, x-d); k < len(xs) && xs[k] <= x+d; k++ {
+					if xs[k] != x || ys[l] != y {
+						if math.Hypot(xs[k]-x, ys[l]-y) >= d {
+							continue
+						}
+					}
+					sums[l*len(xs)+k] += z
+					counts[l*len(xs)+k]++
+				}
+			}
+		}
+	}
+	for k, sum := range sums {
+		// Every grid point contributes to itself,
+		// so its count is not zero.
+		dst.Data[k] = sum / float64(counts[k])
+	}
+	return dst, nil
+}
+
+// scattered returns a copy of data, or an error if data is
+// empty or holds a NaN or infinite value.
+func scattered(data plotter.XYZer) (plotter.XYZs, error) {
+	if data.Len() == 0 {
+		return nil, errors.New("gridutil: no data")
+	}
+	return plotter.CopyXYZs(data)
+}
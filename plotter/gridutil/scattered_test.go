@@ -0,0 +1,115 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridutil_test
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotter/gridutil"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ExampleNaturalNeighbor interpolates scattered measurements
+// onto a grid, smooths the grid and draws it as a heat map with
+// contours, with the measurement locations overlaid.
+func ExampleNaturalNeighbor() {
+	rnd := rand.New(rand.NewSource(1))
+	data := make(plotter.XYZs, 60)
+	for i := range data {
+		x, y := 4*rnd.Float64()-2, 4*rnd.Float64()-2
+		data[i].X, data[i].Y = x, y
+		data[i].Z = x * math.Exp(-x*x-y*y)
+	}
+
+	xs := floats.Span(make([]float64, 41), -2, 2)
+	ys := floats.Span(make([]float64, 41), -2, 2)
+	g, err := gridutil.NaturalNeighbor(data, xs, ys)
+	if err != nil {
+		log.Panic(err)
+	}
+	g, err = gridutil.Smooth(g, 1)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Natural neighbor interpolation"
+	p.Add(plotter.NewHeatMap(g, palette.Heat(12, 1)))
+	p.Add(plotter.NewContour(g, []float64{-0.3, -0.15, 0, 0.15, 0.3}, nil))
+
+	s, err := plotter.NewScatter(plotter.XYValues{XYZer: data})
+	if err != nil {
+		log.Panic(err)
+	}
+	s.Shape = draw.CrossGlyph{}
+	p.Add(s)
+
+	err = p.Save(250, 250, "testdata/naturalNeighbor.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestNaturalNeighbor(t *testing.T) {
+	cmpimg.CheckPlot(ExampleNaturalNeighbor, t, "naturalNeighbor.png")
+}
+
+func TestScattered(t *testing.T) {
+	data := plotter.XYZs{
+		{X: 0, Y: 0, Z: 1},
+		{X: 4, Y: 0, Z: 2},
+		{X: 0, Y: 4, Z: 3},
+		{X: 4, Y: 4, Z: 4},
+	}
+	xs := []float64{0, 1, 2, 3, 4}
+	for _, test := range []struct {
+		name   string
+		interp func(data plotter.XYZer, xs, ys []float64) (*gridutil.Grid, error)
+	}{
+		{
+			name: "IDW",
+			interp: func(data plotter.XYZer, xs, ys []float64) (*gridutil.Grid, error) {
+				return gridutil.IDW(data, xs, ys, 2)
+			},
+		},
+		{name: "NaturalNeighbor", interp: gridutil.NaturalNeighbor},
+	} {
+		g, err := test.interp(data, xs, xs)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", test.name, err)
+		}
+		for _, p := range data {
+			c, r := int(p.X), int(p.Y)
+			if got := g.Z(c, r); got != p.Z {
+				t.Errorf("unexpected value for %s at data point (%v, %v): got:%v want:%v", test.name, p.X, p.Y, got, p.Z)
+			}
+		}
+		for i, v := range g.Data {
+			if v < 1 || v > 4 {
+				t.Errorf("value %d for %s outside range of data: %v", i, test.name, v)
+			}
+		}
+
+		_, err = test.interp(plotter.XYZs{}, xs, xs)
+		if err == nil {
+			t.Errorf("expected error for %s with no data", test.name)
+		}
+		_, err = test.interp(plotter.XYZs{{Z: math.NaN()}}, xs, xs)
+		if err == nil {
+			t.Errorf("expected error for %s with NaN data", test.name)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Group implements the Plotter interface, drawing a set of
+// plotters that represent a single series, such as a Line, the
+// Scatter of its points and their YErrorBars, as one unit. A
+// Group is added to a plot, given a z-order, hidden and added
+// to a legend as a single plotter.
+type Group struct {
+	// Plotters are the plotters of the group,
+	// drawn in order.
+	Plotters []plot.Plotter
+
+	// Hidden specifies that the plotters of the
+	// group are not drawn. The axis ranges of the
+	// plot still include the data of the group,
+	// and its legend entry is still drawn.
+	Hidden bool
+}
+
+// NewGroup returns a Group of the given plotters.
+func NewGroup(ps ...plot.Plotter) *Group {
+	return &Group{Plotters: ps}
+}
+
+// SetColor sets the color of the lines and glyphs of the
+// Line, Scatter, Function, XErrorBars and YErrorBars plotters
+// of the group, so that the group is styled as a unit. Other
+// plotters are not changed.
+func (g *Group) SetColor(c color.Color) {
+	for _, p := range g.Plotters {
+		switch p := p.(type) {
+		case *Line:
+			p.LineStyle.Color = c
+		case *Scatter:
+			p.GlyphStyle.Color = c
+		case *Function:
+			p.LineStyle.Color = c
+		case *XErrorBars:
+			p.LineStyle.Color = c
+		case *YErrorBars:
+			p.LineStyle.Color = c
+		case *Group:
+			p.SetColor(c)
+		}
+	}
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (g *Group) Plot(c draw.Canvas, plt *plot.Plot) {
+	if g.Hidden {
+		return
+	}
+	for _, p := range g.Plotters {
+		p.Plot(c, plt)
+	}
+}
+
+// DataRange returns the union of the data ranges of the
+// plotters of the group that implement plot.DataRanger,
+// implementing the plot.DataRanger interface.
+func (g *Group) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for _, p := range g.Plotters {
+		dr, ok := p.(plot.DataRanger)
+		if !ok {
+			continue
+		}
+		pxmin, pxmax, pymin, pymax := dr.DataRange()
+		xmin, xmax = math.Min(xmin, pxmin), math.Max(xmax, pxmax)
+		ymin, ymax = math.Min(ymin, pymin), math.Max(ymax, pymax)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// Version returns the sum of the versions of the plotters
+// of the group that implement plot.Versioner, implementing
+// the plot.Versioner interface. Since versions only grow,
+// the sum changes whenever the data of any plotter of the
+// group changes.
+func (g *Group) Version() uint64 {
+	var v uint64
+	for _, p := range g.Plotters {
+		if vp, ok := p.(plot.Versioner); ok {
+			v += vp.Version()
+		}
+	}
+	return v
+}
+
+// GlyphBoxes returns the glyph boxes of the plotters of the
+// group that implement plot.GlyphBoxer, implementing the
+// plot.GlyphBoxer interface. A hidden group has no glyph
+// boxes.
+func (g *Group) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	if g.Hidden {
+		return nil
+	}
+	var boxes []plot.GlyphBox
+	for _, p := range g.Plotters {
+		if gb, ok := p.(plot.GlyphBoxer); ok {
+			boxes = append(boxes, gb.GlyphBoxes(plt)...)
+		}
+	}
+	return boxes
+}
+
+// Thumbnail draws the composite of the thumbnails of the
+// plotters of the group that implement plot.Thumbnailer,
+// implementing the plot.Thumbnailer interface.
+func (g *Group) Thumbnail(c *draw.Canvas) {
+	for _, p := range g.Plotters {
+		if th, ok := p.(plot.Thumbnailer); ok {
+			th.Thumbnail(c)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+// ExampleGroup draws two series of measurements, each as a group
+// of a line, its points and their error bars, with the first series
+// drawn over the second.
+func ExampleGroup() {
+	rnd := rand.New(rand.NewSource(1))
+
+	// series returns a group of a line through noisy
+	// measurements of f and the error bars of the
+	// measurements.
+	series := func(f func(float64) float64, shape draw.GlyphDrawer) *Group {
+		type errPoints struct {
+			XYs
+			YErrors
+		}
+		const n = 10
+		data := errPoints{XYs: make(XYs, n), YErrors: make(YErrors, n)}
+		for i := range data.XYs {
+			x := float64(i)
+			data.XYs[i].X = x
+			data.XYs[i].Y = f(x) + 0.3*rnd.NormFloat64()
+			data.YErrors[i].Low = 0.3 + 0.2*rnd.Float64()
+			data.YErrors[i].High = data.YErrors[i].Low
+		}
+		l, s, err := NewLinePoints(data)
+		if err != nil {
+			log.Panic(err)
+		}
+		l.Width = vg.Points(1)
+		s.Shape = shape
+		s.Radius = vg.Points(2.5)
+		e, err := NewYErrorBars(data)
+		if err != nil {
+			log.Panic(err)
+		}
+		e.Width = vg.Points(1)
+		return NewGroup(l, s, e)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Grouped series"
+	p.Legend.Top = true
+
+	a := series(func(x float64) float64 { return math.Sin(x / 2) }, draw.CircleGlyph{})
+	a.SetColor(color.RGBA{R: 200, A: 255})
+	b := series(func(x float64) float64 { return math.Cos(x / 2) }, draw.BoxGlyph{})
+	b.SetColor(color.RGBA{B: 200, A: 255})
+
+	p.AddZ(1, a)
+	p.Add(b)
+	p.Legend.Add("sin", a)
+	p.Legend.Add("cos", b)
+
+	err = p.Save(250, 200, "testdata/group.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestGroupPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleGroup, t, "group.png")
+}
+
+func TestGroup(t *testing.T) {
+	l, s, err := NewLinePoints(XYs{{X: 0, Y: 1}, {X: 2, Y: 3}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f := NewFunction(math.Sin)
+	inner := NewGroup(f)
+	g := NewGroup(l, s, inner, NewGrid())
+
+	xmin, xmax, ymin, ymax := g.DataRange()
+	if xmin != 0 || xmax != 2 || ymin != 1 || ymax != 3 {
+		t.Errorf("unexpected data range: got:[%v, %v]x[%v, %v] want:[0, 2]x[1, 3]", xmin, xmax, ymin, ymax)
+	}
+
+	stream, err := NewStreamLine(2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	inner.Plotters = append(inner.Plotters, stream)
+	v := g.Version()
+	stream.Append(1, 2)
+	if g.Version() == v {
+		t.Error("expected group version to change with the data of its plotters")
+	}
+
+	red := color.RGBA{R: 255, A: 255}
+	g.SetColor(red)
+	if l.Color != red || s.Color != red || f.Color != red {
+		t.Errorf("unexpected colors after SetColor: line:%v scatter:%v function:%v", l.Color, s.Color, f.Color)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(g)
+	if got := len(g.GlyphBoxes(p)); got != 2 {
+		t.Errorf("unexpected number of glyph boxes: got:%d want:2", got)
+	}
+
+	var rec recorder.Canvas
+	c := draw.NewCanvas(&rec, 100, 100)
+	g.Hidden = true
+	g.Plot(c, p)
+	if len(g.GlyphBoxes(p)) != 0 || len(rec.Actions) != 0 {
+		t.Errorf("hidden group drew %d actions and has %d glyph boxes", len(rec.Actions), len(g.GlyphBoxes(p)))
+	}
+	g.Hidden = false
+	g.Plot(c, p)
+	if len(rec.Actions) == 0 {
+		t.Error("expected group to draw")
+	}
+}
@@ -5,8 +5,11 @@
 package plotter
 
 import (
+	"errors"
+	"fmt"
 	"image/color"
 	"math"
+	"sync"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/palette"
@@ -57,6 +60,31 @@ type HeatMap struct {
 	// Min and Max define the dynamic range of the
 	// heat map.
 	Min, Max float64
+
+	// CellText, if not nil, returns the text written
+	// in the center of each cell with the value v, such
+	// as the count of a cell of a confusion matrix.
+	// Cells with NaN values are not annotated.
+	CellText func(v float64) string
+
+	// CellTextStyle is the style of the cell text. If
+	// its Color is nil, text is written in black or
+	// white, whichever contrasts most with the color
+	// of the cell. If it has no font, the default font
+	// is used.
+	CellTextStyle draw.TextStyle
+
+	// MinCellSize is the smallest width and height of
+	// a cell in which text is written. If MinCellSize
+	// is zero, text is written only in the cells that
+	// can hold it.
+	MinCellSize vg.Length
+}
+
+// heatMapPathPool holds the cell paths of calls to HeatMap.Plot,
+// so that a HeatMap may be drawn concurrently.
+var heatMapPathPool = sync.Pool{
+	New: func() interface{} { return new(vg.Path) },
 }
 
 // NewHeatMap creates as new heat map plotter for the given data,
@@ -96,23 +124,51 @@ func NewHeatMap(g GridXYZ, p palette.Palette) *HeatMap {
 	}
 }
 
+// Validate returns an error if the HeatMap has an empty
+// palette or an invalid Z range, implementing the
+// plot.Validator interface.
+func (h *HeatMap) Validate() error {
+	switch {
+	case h.Palette == nil || len(h.Palette.Colors()) == 0:
+		return errors.New("plotter: heat map has an empty palette; set Palette")
+	case h.Min > h.Max:
+		return fmt.Errorf("plotter: heat map Z range [%v, %v] has its minimum greater than its maximum", h.Min, h.Max)
+	}
+	return nil
+}
+
 // Plot implements the Plot method of the plot.Plotter interface.
 func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
 	if h.Min > h.Max {
-		panic("contour: invalid Z range: min greater than max")
+		plt.Fail(errors.New("contour: invalid Z range: min greater than max"))
+		return
 	}
 	pal := h.Palette.Colors()
 	if len(pal) == 0 {
-		panic("heatmap: empty palette")
+		plt.Fail(errors.New("heatmap: empty palette"))
+		return
 	}
 	// ps scales the palette uniformly across the data range.
 	ps := float64(len(pal)-1) / (h.Max - h.Min)
 
+	h.plot(c, plt, func(i, j int) color.Color {
+		return h.color(pal, ps, h.GridXYZ.Z(i, j))
+	})
+}
+
+// plot draws the cells of the heat map filled with the colors
+// returned by fill for each column and row. Cells for which
+// fill returns nil are not drawn.
+func (h *HeatMap) plot(c draw.Canvas, plt *plot.Plot, fill func(i, j int) color.Color) {
 	trX, trY := plt.Transforms(&c)
+	sty, ok := h.cellTextStyle(plt)
 
-	var pa vg.Path
+	buf := heatMapPathPool.Get().(*vg.Path)
+	defer heatMapPathPool.Put(buf)
+	pa := (*buf)[:0]
 	cols, rows := h.GridXYZ.Dims()
 	for i := 0; i < cols; i++ {
+		plt.ReportProgress(float64(i)/float64(cols), "heatmap")
 		var right, left float64
 		switch i {
 		case 0:
@@ -154,23 +210,77 @@ func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
 			pa.Line(vg.Point{X: x, Y: dy})
 			pa.Close()
 
-			var col color.Color
-			switch v := h.GridXYZ.Z(i, j); {
-			case v < h.Min:
-				col = h.Underflow
-			case v > h.Max:
-				col = h.Overflow
-			case math.IsNaN(v), math.IsInf(ps, 0):
-				col = h.NaN
-			default:
-				col = pal[int((v-h.Min)*ps+0.5)] // Apply palette scaling.
-			}
-			if col != nil {
+			if col := fill(i, j); col != nil {
 				c.SetColor(col)
 				c.Fill(pa)
+				if ok {
+					h.cellText(c, sty, i, j, col, vg.Rectangle{
+						Min: vg.Point{X: x, Y: y},
+						Max: vg.Point{X: dx, Y: dy},
+					})
+				}
 			}
 		}
 	}
+	*buf = pa
+}
+
+// cellTextStyle returns the style of the cell text of the
+// heat map, and whether cell text is written.
+func (h *HeatMap) cellTextStyle(plt *plot.Plot) (draw.TextStyle, bool) {
+	if h.CellText == nil {
+		return draw.TextStyle{}, false
+	}
+	sty := h.CellTextStyle
+	if sty.Font.Size == 0 {
+		fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+		if err != nil {
+			plt.Fail(err)
+			return draw.TextStyle{}, false
+		}
+		sty.Font = fnt
+	}
+	sty.XAlign = draw.XCenter
+	sty.YAlign = draw.YCenter
+	return sty, true
+}
+
+// cellText writes the cell text of the value of the cell in
+// column i and row j, filled with col, in the center of the
+// cell r if the cell is large enough.
+func (h *HeatMap) cellText(c draw.Canvas, sty draw.TextStyle, i, j int, col color.Color, r vg.Rectangle) {
+	v := h.GridXYZ.Z(i, j)
+	if math.IsNaN(v) {
+		return
+	}
+	w := vg.Length(math.Abs(float64(r.Max.X - r.Min.X)))
+	ht := vg.Length(math.Abs(float64(r.Max.Y - r.Min.Y)))
+	if w < h.MinCellSize || ht < h.MinCellSize {
+		return
+	}
+	txt := h.CellText(v)
+	if h.MinCellSize == 0 && (sty.Width(txt) > w || sty.Height(txt) > ht) {
+		return
+	}
+	if sty.Color == nil {
+		sty.Color = draw.ContrastColor(col)
+	}
+	c.FillText(sty, vg.Point{X: (r.Min.X + r.Max.X) / 2, Y: (r.Min.Y + r.Max.Y) / 2}, txt)
+}
+
+// color returns the fill color of the value v using the
+// palette colors pal, scaled by ps across the dynamic range.
+func (h *HeatMap) color(pal []color.Color, ps, v float64) color.Color {
+	switch {
+	case v < h.Min:
+		return h.Underflow
+	case v > h.Max:
+		return h.Overflow
+	case math.IsNaN(v), math.IsInf(ps, 0):
+		return h.NaN
+	default:
+		return pal[int((v-h.Min)*ps+0.5)] // Apply palette scaling.
+	}
 }
 
 // DataRange implements the DataRange method
@@ -199,19 +309,64 @@ func (h *HeatMap) DataRange() (xmin, xmax, ymin, ymax float64) {
 // GlyphBoxes implements the GlyphBoxes method
 // of the plot.GlyphBoxer interface.
 func (h *HeatMap) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
-	c, r := h.GridXYZ.Dims()
-	b := make([]plot.GlyphBox, 0, r*c)
-	for i := 0; i < c; i++ {
-		for j := 0; j < r; j++ {
-			b = append(b, plot.GlyphBox{
-				X: plt.X.Norm(h.GridXYZ.X(i)),
-				Y: plt.Y.Norm(h.GridXYZ.Y(j)),
-				Rectangle: vg.Rectangle{
-					Min: vg.Point{X: -5, Y: -5},
-					Max: vg.Point{X: +5, Y: +5},
-				},
-			})
+	return gridGlyphBoxes(plt, h.GridXYZ, vg.Rectangle{
+		Min: vg.Point{X: -5, Y: -5},
+		Max: vg.Point{X: +5, Y: +5},
+	})
+}
+
+// gridGlyphBoxes returns glyph boxes of the given size for
+// padding a plot holding the grid g. Only the glyphs nearest
+// the edges of the data area affect padding, so rather than
+// returning a box for each grid point, gridGlyphBoxes returns
+// at most two boxes for each axis, with sizes of zero in the
+// other direction.
+func gridGlyphBoxes(plt *plot.Plot, g GridXYZ, r vg.Rectangle) []plot.GlyphBox {
+	c, rows := g.Dims()
+	xmin, xmax := normRange(plt.X, c, g.X)
+	ymin, ymax := normRange(plt.Y, rows, g.Y)
+
+	var b []plot.GlyphBox
+	for _, x := range [...]float64{xmin, xmax} {
+		if math.IsInf(x, 0) {
+			continue
+		}
+		b = append(b, plot.GlyphBox{
+			X: x,
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{X: r.Min.X},
+				Max: vg.Point{X: r.Max.X},
+			},
+		})
+	}
+	for _, y := range [...]float64{ymin, ymax} {
+		if math.IsInf(y, 0) {
+			continue
 		}
+		b = append(b, plot.GlyphBox{
+			Y: y,
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{Y: r.Min.Y},
+				Max: vg.Point{Y: r.Max.Y},
+			},
+		})
 	}
 	return b
 }
+
+// normRange returns the smallest and largest normalized
+// coordinates of the n values returned by v that lie within
+// the range of the axis. If no value is in range the returned
+// values are infinite.
+func normRange(a plot.Axis, n int, v func(int) float64) (min, max float64) {
+	min, max = math.Inf(1), math.Inf(-1)
+	for i := 0; i < n; i++ {
+		f := a.Norm(v(i))
+		if f < 0 || f > 1 {
+			continue
+		}
+		min = math.Min(min, f)
+		max = math.Max(max, f)
+	}
+	return min, max
+}
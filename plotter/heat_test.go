@@ -9,6 +9,7 @@ import (
 	"log"
 	"math"
 	"os"
+	"reflect"
 	"testing"
 
 	"gonum.org/v1/gonum/mat"
@@ -17,6 +18,7 @@ import (
 	"gonum.org/v1/plot/palette"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
 	"gonum.org/v1/plot/vg/vgimg"
 )
 
@@ -128,3 +130,121 @@ func ExampleHeatMap() {
 func TestHeatMap(t *testing.T) {
 	cmpimg.CheckPlot(ExampleHeatMap, t, "heatMap.png")
 }
+
+func TestHeatMapGlyphBoxes(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 10, 20.5
+	p.Y.Min, p.Y.Max = -5, 3
+
+	h := NewHeatMap(unitGrid{mat.NewDense(500, 1000, nil)}, palette.Heat(12, 1))
+	got := h.GlyphBoxes(p)
+	want := []plot.GlyphBox{
+		{X: 0, Rectangle: vg.Rectangle{Min: vg.Point{X: -5}, Max: vg.Point{X: 5}}},
+		{X: 10 / 10.5, Rectangle: vg.Rectangle{Min: vg.Point{X: -5}, Max: vg.Point{X: 5}}},
+		{Y: 5.0 / 8, Rectangle: vg.Rectangle{Min: vg.Point{Y: -5}, Max: vg.Point{Y: 5}}},
+		{Y: 1, Rectangle: vg.Rectangle{Min: vg.Point{Y: -5}, Max: vg.Point{Y: 5}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected glyph boxes:\ngot: %v\nwant:%v", got, want)
+	}
+}
+
+func TestHeatMapProgress(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var fractions []float64
+	p.Progress = func(fraction float64, stage string) {
+		if stage == "heatmap" {
+			fractions = append(fractions, fraction)
+		}
+	}
+	p.Add(NewHeatMap(unitGrid{mat.NewDense(4, 4, nil)}, palette.Heat(12, 1)))
+	p.Draw(draw.New(vgimg.New(5*vg.Centimeter, 5*vg.Centimeter)))
+
+	want := []float64{0, 0.25, 0.5, 0.75}
+	if !reflect.DeepEqual(fractions, want) {
+		t.Errorf("unexpected heat map progress: got:%v want:%v", fractions, want)
+	}
+}
+
+// ExampleHeatMap_cellText draws a confusion matrix with
+// the count of each cell written in it.
+func ExampleHeatMap_cellText() {
+	classes := []string{"cat", "dog", "bird"}
+	// The rows of m are the true classes, bottom up, and
+	// the columns the predicted classes.
+	m := unitGrid{mat.NewDense(3, 3, []float64{
+		50, 3, 1,
+		4, 41, 7,
+		2, 9, 38,
+	})}
+	h := NewHeatMap(m, palette.Heat(12, 1))
+	h.CellText = func(v float64) string { return fmt.Sprintf("%.0f", v) }
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Confusion matrix"
+	p.X.Label.Text = "Predicted"
+	p.Y.Label.Text = "True"
+	p.NominalX(classes...)
+	p.NominalY(classes...)
+	p.X.Padding = 0
+	p.Y.Padding = 0
+	p.Add(h)
+
+	err = p.Save(200, 200, "testdata/heatMapCellText.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestHeatMapCellText(t *testing.T) {
+	cmpimg.CheckPlot(ExampleHeatMap_cellText, t, "heatMapCellText.png")
+}
+
+func TestHeatMapCellTextThreshold(t *testing.T) {
+	m := unitGrid{mat.NewDense(2, 2, []float64{1, math.NaN(), 3, 4})}
+	for _, test := range []struct {
+		size    vg.Length
+		minCell vg.Length
+		want    int
+	}{
+		// Three of the four cells have values.
+		{size: 100, want: 3},
+		{size: 100, minCell: 40, want: 3},
+		{size: 100, minCell: 60, want: 0},
+		// The text does not fit in cells of 2pt.
+		{size: 4, want: 0},
+	} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.HideAxes()
+		p.X.Padding = 0
+		p.Y.Padding = 0
+		h := NewHeatMap(m, palette.Heat(12, 1))
+		h.CellText = func(v float64) string { return fmt.Sprint(v) }
+		h.MinCellSize = test.minCell
+		p.Add(h)
+		var rec recorder.Canvas
+		p.Draw(draw.NewCanvas(&rec, test.size, test.size))
+		var got int
+		for _, a := range rec.Actions {
+			if _, ok := a.(*recorder.FillString); ok {
+				got++
+			}
+		}
+		if got != test.want {
+			t.Errorf("unexpected number of cell texts for size=%v min=%v: got:%d want:%d",
+				test.size, test.minCell, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Hillshade implements the Plotter interface, drawing a
+// shaded relief of the height field in the GridXYZ field.
+// Each cell is lit by a distant light source according to
+// the slope of the surface at the cell, and the illumination
+// is blended with the cell's palette color, so that the
+// shape of terrain or of a microscope's height map is shown
+// along with its values.
+type Hillshade struct {
+	HeatMap
+
+	// Azimuth is the direction of the light source in
+	// degrees clockwise from the positive Y direction.
+	Azimuth float64
+
+	// Altitude is the angle of the light source above
+	// the horizon in degrees.
+	Altitude float64
+
+	// ZFactor is the vertical exaggeration of the height
+	// field. Heights are multiplied by ZFactor when the
+	// slope is computed, so ZFactor also converts between
+	// the units of Z and those of X and Y.
+	ZFactor float64
+
+	// Intensity is the strength of the shading in [0, 1].
+	// Each palette color is multiplied by 1-Intensity*(1-s),
+	// where s in [0, 1] is the illumination of the cell.
+	// If Palette is nil, cells are drawn in gray with a
+	// lightness of s.
+	Intensity float64
+}
+
+// NewHillshade creates as new hillshade plotter for the given height
+// field, using the provided palette, which may be nil. The light source
+// is placed to the north west at an altitude of 45°, and the returned
+// Hillshade has no vertical exaggeration and full intensity shading.
+// The dynamic range of the palette is set as for NewHeatMap.
+func NewHillshade(g GridXYZ, p palette.Palette) *Hillshade {
+	return &Hillshade{
+		HeatMap:   *NewHeatMap(g, p),
+		Azimuth:   315,
+		Altitude:  45,
+		ZFactor:   1,
+		Intensity: 1,
+	}
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (h *Hillshade) Plot(c draw.Canvas, plt *plot.Plot) {
+	if h.Min > h.Max {
+		plt.Fail(errors.New("hillshade: invalid Z range: min greater than max"))
+		return
+	}
+	var (
+		pal []color.Color
+		ps  float64
+	)
+	if h.Palette != nil {
+		pal = h.Palette.Colors()
+		if len(pal) == 0 {
+			plt.Fail(errors.New("hillshade: empty palette"))
+			return
+		}
+		// ps scales the palette uniformly across the data range.
+		ps = float64(len(pal)-1) / (h.Max - h.Min)
+	}
+
+	h.plot(c, plt, func(i, j int) color.Color {
+		z := h.GridXYZ.Z(i, j)
+		s := h.Illumination(i, j)
+		if pal == nil {
+			if math.IsNaN(s) {
+				return h.NaN
+			}
+			v := uint8(255*s + 0.5)
+			return color.Gray{Y: v}
+		}
+		col := h.color(pal, ps, z)
+		if col == nil || math.IsNaN(z) || math.IsNaN(s) {
+			return col
+		}
+		return shade(col, 1-h.Intensity*(1-s))
+	})
+}
+
+// Illumination returns the illumination of the cell at
+// (c, r) by the light source, in [0, 1]. The surface normal
+// at the cell is computed from the central differences of
+// the heights of its neighbours, or from one-sided differences
+// at the edges of the grid. Illumination returns NaN if the
+// normal cannot be computed because of NaN heights.
+func (h *Hillshade) Illumination(c, r int) float64 {
+	cols, rows := h.GridXYZ.Dims()
+	dzdx := h.slope(c, cols, func(i int) (float64, float64) {
+		return h.GridXYZ.X(i), h.GridXYZ.Z(i, r)
+	})
+	dzdy := h.slope(r, rows, func(j int) (float64, float64) {
+		return h.GridXYZ.Y(j), h.GridXYZ.Z(c, j)
+	})
+	if math.IsNaN(dzdx) || math.IsNaN(dzdy) {
+		return math.NaN()
+	}
+
+	az := h.Azimuth * math.Pi / 180
+	alt := h.Altitude * math.Pi / 180
+	lx, ly, lz := math.Cos(alt)*math.Sin(az), math.Cos(alt)*math.Cos(az), math.Sin(alt)
+
+	// The surface normal is (-dz/dx, -dz/dy, 1).
+	nx, ny := -dzdx, -dzdy
+	s := (nx*lx + ny*ly + lz) / math.Sqrt(nx*nx+ny*ny+1)
+	return math.Max(0, s)
+}
+
+// slope returns the exaggerated slope of the height along a
+// dimension of n grid points at index i, where at returns the
+// coordinate and height at an index.
+func (h *Hillshade) slope(i, n int, at func(int) (float64, float64)) float64 {
+	if n < 2 {
+		return 0
+	}
+	lo, hi := i-1, i+1
+	if lo < 0 {
+		lo = 0
+	}
+	if hi > n-1 {
+		hi = n - 1
+	}
+	x0, z0 := at(lo)
+	x1, z1 := at(hi)
+	return h.ZFactor * (z1 - z0) / (x1 - x0)
+}
+
+// shade returns col with its red, green and blue components
+// multiplied by f.
+func shade(col color.Color, f float64) color.Color {
+	c := color.NRGBAModel.Convert(col).(color.NRGBA)
+	return color.NRGBA{
+		R: uint8(float64(c.R)*f + 0.5),
+		G: uint8(float64(c.G)*f + 0.5),
+		B: uint8(float64(c.B)*f + 0.5),
+		A: c.A,
+	}
+}
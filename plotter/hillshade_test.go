@@ -0,0 +1,106 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/palette/moreland"
+)
+
+// ExampleHillshade draws a shaded relief of a terrain of
+// two hills and a valley, colored by height.
+func ExampleHillshade() {
+	const n = 60
+	data := mat.NewDense(n, n, nil)
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			x, y := float64(c)/(n-1)*6-3, float64(r)/(n-1)*6-3
+			z := 3*math.Exp(-(x-1)*(x-1)-(y-1)*(y-1)) +
+				2*math.Exp(-(x+1.5)*(x+1.5)/2-(y+1)*(y+1)) -
+				math.Exp(-x*x/4-(y-2)*(y-2)*4) +
+				0.1*math.Sin(3*x)*math.Cos(2*y)
+			data.Set(r, c, z)
+		}
+	}
+	g := unitGrid{data}
+
+	pal := moreland.ExtendedBlackBody().Palette(255)
+	h := NewHillshade(g, pal)
+	h.ZFactor = 20
+	h.Intensity = 0.8
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Hillshade"
+	p.Add(h)
+	p.X.Padding = 0
+	p.Y.Padding = 0
+
+	err = p.Save(250, 250, "testdata/hillshade.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestHillshade(t *testing.T) {
+	cmpimg.CheckPlot(ExampleHillshade, t, "hillshade.png")
+}
+
+func TestHillshadeIllumination(t *testing.T) {
+	const n = 5
+	for _, test := range []struct {
+		name    string
+		z       func(c, r int) float64
+		azimuth float64
+		want    float64
+	}{
+		{
+			name:    "flat",
+			z:       func(c, r int) float64 { return 2 },
+			azimuth: 315,
+			want:    math.Sin(math.Pi / 4),
+		},
+		{
+			// A slope of 45° facing west is lit
+			// head on by a light in the west.
+			name:    "facing light",
+			z:       func(c, r int) float64 { return float64(c) },
+			azimuth: 270,
+			want:    1,
+		},
+		{
+			// A slope of 45° facing east is edge
+			// on to a light in the west.
+			name:    "facing away",
+			z:       func(c, r int) float64 { return -float64(c) },
+			azimuth: 270,
+			want:    0,
+		},
+	} {
+		data := mat.NewDense(n, n, nil)
+		for r := 0; r < n; r++ {
+			for c := 0; c < n; c++ {
+				data.Set(r, c, test.z(c, r))
+			}
+		}
+		h := NewHillshade(unitGrid{data}, nil)
+		h.Azimuth = test.azimuth
+		for r := 0; r < n; r++ {
+			for c := 0; c < n; c++ {
+				if got := h.Illumination(c, r); math.Abs(got-test.want) > 1e-12 {
+					t.Errorf("unexpected illumination for %s at (%d, %d): got:%v want:%v", test.name, c, r, got, test.want)
+				}
+			}
+		}
+	}
+}
@@ -21,7 +21,8 @@ type Histogram struct {
 	// Bins is the set of bins for this histogram.
 	Bins []HistogramBin
 
-	// Width is the width of each bin.
+	// Width is the width of each bin. Width is
+	// zero if the bins have different widths.
 	Width float64
 
 	// FillColor is the color used to fill each
@@ -32,6 +33,35 @@ type Histogram struct {
 	// LineStyle is the style of the outline of each
 	// bar of the histogram.
 	draw.LineStyle
+
+	// CornerRadius is the radius of the rounded corners
+	// of the bars. If CornerRadius is zero the corners
+	// are square.
+	CornerRadius vg.Length
+
+	// Horizontal dictates whether the bars are drawn
+	// in the vertical (default) or horizontal direction.
+	// If Horizontal is true the bins are on the Y axis
+	// and the weights on the X axis.
+	Horizontal bool
+
+	// Step dictates whether the histogram is drawn as a
+	// single stepped outline around the bins instead of
+	// as separate bars, which is clearer when several
+	// histograms are overlaid. The area under the
+	// outline is filled with FillColor if it is not nil.
+	Step bool
+
+	// LogScale dictates whether the histogram is drawn
+	// on a log scale weight axis. If LogScale is true
+	// the bars are drawn up from the minimum of the axis
+	// instead of from zero, and the data range of the
+	// weights excludes zero.
+	LogScale bool
+
+	// stackedOn is the histogram upon which
+	// this histogram is stacked.
+	stackedOn *Histogram
 }
 
 // NewHistogram returns a new histogram
@@ -43,10 +73,21 @@ type Histogram struct {
 //
 // If the number of bins is non-positive than
 // a reasonable default is used.
+//
+// If xy implements Weighter, each y value is
+// scaled by the weight of its point.
+//
+// An error is returned if the data holds NaN or
+// infinite values, unless the data has a NonFinite
+// policy, such as NonFiniteXYs, that drops them.
 func NewHistogram(xy XYer, n int) (*Histogram, error) {
 	if n <= 0 {
 		return nil, errors.New("Histogram with non-positive number of bins")
 	}
+	xy, err := histXYs(xy)
+	if err != nil {
+		return nil, err
+	}
 	bins, width := binPoints(xy, n)
 	return &Histogram{
 		Bins:      bins,
@@ -56,11 +97,140 @@ func NewHistogram(xy XYer, n int) (*Histogram, error) {
 	}, nil
 }
 
+// NewLogHistogram returns a new histogram as in
+// NewHistogram, except that the n bins are of equal
+// width in the logarithm of the x values, which must
+// all be positive. The histogram is suitable for
+// drawing on a log scale X axis.
+func NewLogHistogram(xy XYer, n int) (*Histogram, error) {
+	if n <= 0 {
+		return nil, errors.New("Histogram with non-positive number of bins")
+	}
+	xy, err := histXYs(xy)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < xy.Len(); i++ {
+		if x, _ := xy.XY(i); !(x > 0) {
+			return nil, errors.New("plotter: non-positive value in log histogram")
+		}
+	}
+	bins, _ := binPoints(logXs{xy}, n)
+	for i := range bins {
+		bins[i].Min = math.Exp(bins[i].Min)
+		bins[i].Max = math.Exp(bins[i].Max)
+	}
+	return &Histogram{
+		Bins:      bins,
+		FillColor: color.Gray{128},
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// histXYs returns the finite points of xy, weighted
+// if xy implements Weighter.
+func histXYs(xy XYer) (XYer, error) {
+	xy, err := weightXYs(xy, xy)
+	if err != nil {
+		return nil, err
+	}
+	return finiteXYs(xy)
+}
+
+// logXs is an XYer of the logarithms of the x values
+// of an XYer.
+type logXs struct{ XYer }
+
+func (l logXs) XY(i int) (float64, float64) {
+	x, y := l.XYer.XY(i)
+	return math.Log(x), y
+}
+
+// NewHistogramRule returns a new histogram as in
+// NewHistogram, except that the number of bins is
+// chosen by the given rule.
+func NewHistogramRule(xy XYer, rule BinRule) (*Histogram, error) {
+	xy, err := histXYs(xy)
+	if err != nil {
+		return nil, err
+	}
+	xs := make([]float64, xy.Len())
+	ws := make([]float64, xy.Len())
+	for i := range xs {
+		xs[i], ws[i] = xy.XY(i)
+	}
+	return NewHistogram(xy, rule(xs, ws))
+}
+
+// NewWeightedHist returns a new histogram of the
+// values in vs, as in NewHist, except that each value
+// contributes its weight in weights to its bin instead
+// of one.
+func NewWeightedHist(vs, weights Valuer, n int) (*Histogram, error) {
+	if vs.Len() != weights.Len() {
+		return nil, errors.New("plotter: values and weights lengths mismatch")
+	}
+	return NewHistogram(NonFiniteXYs{XYer: weightedValues{vs, weights}, Policy: policyOf(vs)}, n)
+}
+
+type weightedValues struct {
+	Valuer
+	weights Valuer
+}
+
+func (w weightedValues) XY(i int) (float64, float64) {
+	return w.Value(i), w.weights.Value(i)
+}
+
+// NewHistograms returns a histogram of each of xys, as in
+// NewHistogram, binned with the same n bins spanning the
+// values of all of them so that the histograms can be
+// compared, overlaid or stacked.
+func NewHistograms(xys []XYer, n int) ([]*Histogram, error) {
+	if n <= 0 {
+		return nil, errors.New("Histogram with non-positive number of bins")
+	}
+	if len(xys) == 0 {
+		return nil, ErrNoData
+	}
+	finite := make([]XYer, len(xys))
+	for i, xy := range xys {
+		var err error
+		finite[i], err = histXYs(xy)
+		if err != nil {
+			return nil, err
+		}
+	}
+	xys = finite
+	xmin, xmax := math.Inf(1), math.Inf(-1)
+	for _, xy := range xys {
+		min, max := Range(XValues{xy})
+		xmin, xmax = math.Min(xmin, min), math.Max(xmax, max)
+	}
+	hs := make([]*Histogram, len(xys))
+	for i, xy := range xys {
+		bins, width := binRange(xy, n, xmin, xmax)
+		hs[i] = &Histogram{
+			Bins:      bins,
+			Width:     width,
+			FillColor: color.Gray{128},
+			LineStyle: DefaultLineStyle,
+		}
+	}
+	return hs, nil
+}
+
 // NewHist returns a new histogram, as in
 // NewHistogram, except that it accepts a Valuer
-// instead of an XYer.
+// instead of an XYer. If vs implements Weighter,
+// such as WeightedValues, each value contributes
+// its weight to its bin instead of one.
 func NewHist(vs Valuer, n int) (*Histogram, error) {
-	return NewHistogram(unitYs{vs}, n)
+	xy, err := weightXYs(vs, unitYs{vs})
+	if err != nil {
+		return nil, err
+	}
+	return NewHistogram(NonFiniteXYs{XYer: xy, Policy: policyOf(vs)}, n)
 }
 
 type unitYs struct {
@@ -75,37 +245,135 @@ func (u unitYs) XY(i int) (float64, float64) {
 // that connects each point in the Line.
 func (h *Histogram) Plot(c draw.Canvas, p *plot.Plot) {
 	trX, trY := p.Transforms(&c)
+	// tr returns the point of the given position
+	// along the bins with the given weight.
+	tr := func(pos, weight float64) vg.Point {
+		if h.Horizontal {
+			return vg.Point{X: trX(weight), Y: trY(pos)}
+		}
+		return vg.Point{X: trX(pos), Y: trY(weight)}
+	}
+	if h.LogScale {
+		// Weights below the minimum of the axis,
+		// including zero, are drawn at the minimum.
+		min := p.Y.Min
+		if h.Horizontal {
+			min = p.X.Min
+		}
+		linear := tr
+		tr = func(pos, weight float64) vg.Point {
+			return linear(pos, math.Max(weight, min))
+		}
+	}
 
-	for _, bin := range h.Bins {
+	if h.Step {
+		h.plotStep(c, tr)
+		return
+	}
+	for i, bin := range h.Bins {
+		base := h.stackedOn.binHeight(i)
 		pts := []vg.Point{
-			{trX(bin.Min), trY(0)},
-			{trX(bin.Max), trY(0)},
-			{trX(bin.Max), trY(bin.Weight)},
-			{trX(bin.Min), trY(bin.Weight)},
+			tr(bin.Min, base),
+			tr(bin.Max, base),
+			tr(bin.Max, base+bin.Weight),
+			tr(bin.Min, base+bin.Weight),
+		}
+		if h.CornerRadius > 0 {
+			pts = roundedRect(pts[0].X, pts[0].Y, pts[2].X, pts[2].Y, h.CornerRadius)
 		}
 		if h.FillColor != nil {
 			c.FillPolygon(h.FillColor, c.ClipPolygonXY(pts))
 		}
-		pts = append(pts, vg.Point{X: trX(bin.Min), Y: trY(0)})
+		pts = append(pts, pts[0])
 		c.StrokeLines(h.LineStyle, c.ClipLinesXY(pts)...)
 	}
 }
 
+// plotStep draws the histogram as a stepped outline.
+func (h *Histogram) plotStep(c draw.Canvas, tr func(pos, weight float64) vg.Point) {
+	if len(h.Bins) == 0 {
+		return
+	}
+	last := len(h.Bins) - 1
+	pts := []vg.Point{tr(h.Bins[0].Min, h.stackedOn.binHeight(0))}
+	for i, bin := range h.Bins {
+		top := h.stackedOn.binHeight(i) + bin.Weight
+		pts = append(pts, tr(bin.Min, top), tr(bin.Max, top))
+	}
+	pts = append(pts, tr(h.Bins[last].Max, h.stackedOn.binHeight(last)))
+	if h.FillColor != nil {
+		poly := pts
+		if h.stackedOn != nil {
+			// Close the area along the top of the
+			// histogram below.
+			poly = append([]vg.Point(nil), pts...)
+			for i := last; i >= 0; i-- {
+				base := h.stackedOn.binHeight(i)
+				poly = append(poly, tr(h.Bins[i].Max, base), tr(h.Bins[i].Min, base))
+			}
+		}
+		c.FillPolygon(h.FillColor, c.ClipPolygonXY(poly))
+	}
+	c.StrokeLines(h.LineStyle, c.ClipLinesXY(pts)...)
+}
+
+// binHeight returns the top of the ith bin, taking
+// into account any histograms upon which it is stacked.
+func (h *Histogram) binHeight(i int) float64 {
+	if h == nil {
+		return 0
+	}
+	var ht float64
+	if i >= 0 && i < len(h.Bins) {
+		ht = h.Bins[i].Weight
+	}
+	return ht + h.stackedOn.binHeight(i)
+}
+
+// StackOn stacks the histogram on top of another,
+// which must have the same bins.
+func (h *Histogram) StackOn(on *Histogram) {
+	h.stackedOn = on
+}
+
 // DataRange returns the minimum and maximum X and Y values
 func (h *Histogram) DataRange() (xmin, xmax, ymin, ymax float64) {
+	if h.Horizontal {
+		ymin, ymax, xmin, xmax = h.dataRange()
+		return
+	}
+	return h.dataRange()
+}
+
+// dataRange returns the range of the bins and of their
+// weights, including zero.
+func (h *Histogram) dataRange() (xmin, xmax, ymin, ymax float64) {
 	xmin = math.Inf(1)
 	xmax = math.Inf(-1)
 	ymax = math.Inf(-1)
-	for _, bin := range h.Bins {
+	if h.LogScale {
+		ymin = math.Inf(1)
+	}
+	for i, bin := range h.Bins {
 		if bin.Max > xmax {
 			xmax = bin.Max
 		}
 		if bin.Min < xmin {
 			xmin = bin.Min
 		}
-		if bin.Weight > ymax {
-			ymax = bin.Weight
+		base := h.stackedOn.binHeight(i)
+		if h.LogScale {
+			// Include the smallest positive weight
+			// with room to show its bar.
+			top := base + bin.Weight
+			if top > 0 {
+				ymin = math.Min(ymin, top/2)
+				ymax = math.Max(ymax, top)
+			}
+			continue
 		}
+		ymax = math.Max(ymax, math.Max(base, base+bin.Weight))
+		ymin = math.Min(ymin, math.Min(base, base+bin.Weight))
 	}
 	return
 }
@@ -117,8 +385,39 @@ func (h *Histogram) Normalize(sum float64) {
 	for _, b := range h.Bins {
 		mass += b.Weight
 	}
+	for i, b := range h.Bins {
+		w := h.Width
+		if w == 0 {
+			w = b.Max - b.Min
+		}
+		h.Bins[i].Weight *= sum / (w * mass)
+	}
+}
+
+// Density normalizes the histogram so that it
+// estimates the probability density of the data,
+// with a total area of one beneath it.
+func (h *Histogram) Density() {
+	h.Normalize(1)
+}
+
+// Cumulative replaces the weight of each bin with the
+// total weight of that bin and all the bins before it,
+// so that the histogram shows the cumulative distribution
+// of the data. If normalize is true the weights are
+// divided by the total weight, so that the last bin has
+// a weight of one.
+func (h *Histogram) Cumulative(normalize bool) {
+	var sum float64
 	for i := range h.Bins {
-		h.Bins[i].Weight *= sum / (h.Width * mass)
+		sum += h.Bins[i].Weight
+		h.Bins[i].Weight = sum
+	}
+	if !normalize || sum == 0 {
+		return
+	}
+	for i := range h.Bins {
+		h.Bins[i].Weight /= sum
 	}
 }
 
@@ -135,10 +434,13 @@ func (h *Histogram) Thumbnail(c *draw.Canvas) {
 		{xmax, ymax},
 		{xmin, ymax},
 	}
+	if h.CornerRadius > 0 {
+		pts = roundedRect(xmin, ymin, xmax, ymax, h.CornerRadius)
+	}
 	if h.FillColor != nil {
 		c.FillPolygon(h.FillColor, c.ClipPolygonXY(pts))
 	}
-	pts = append(pts, vg.Point{X: xmin, Y: ymin})
+	pts = append(pts, pts[0])
 	c.StrokeLines(h.LineStyle, c.ClipLinesXY(pts)...)
 }
 
@@ -152,6 +454,13 @@ func (h *Histogram) Thumbnail(c *draw.Canvas) {
 // the y values.
 func binPoints(xys XYer, n int) (bins []HistogramBin, width float64) {
 	xmin, xmax := Range(XValues{xys})
+	return binRange(xys, n, xmin, xmax)
+}
+
+// binRange returns bins as for binPoints, except that
+// the bins span [xmin, xmax], which must contain all
+// of the x values.
+func binRange(xys XYer, n int, xmin, xmax float64) (bins []HistogramBin, width float64) {
 	if n <= 0 {
 		m := 0.0
 		for i := 0; i < xys.Len(); i++ {
@@ -94,3 +94,245 @@ func TestSingletonHistogram(t *testing.T) {
 	case <-done:
 	}
 }
+
+// ExampleHistogram_step overlays stepped histograms of two
+// samples binned by the Freedman–Diaconis rule, and draws
+// the weighted cumulative distribution of a sample as a
+// horizontal histogram.
+func ExampleHistogram_step() {
+	rnd := rand.New(rand.NewSource(1))
+
+	a := make(XYs, 1000)
+	b := make(XYs, 1000)
+	for i := range a {
+		a[i].X, a[i].Y = rnd.NormFloat64(), 1
+		b[i].X, b[i].Y = 1.5+0.5*rnd.NormFloat64(), 1
+	}
+
+	p1, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p1.Title.Text = "Stepped histograms"
+	for i, xys := range []XYs{a, b} {
+		h, err := NewHistogramRule(xys, FreedmanDiaconis)
+		if err != nil {
+			log.Panic(err)
+		}
+		h.Density()
+		h.Step = true
+		h.FillColor = nil
+		h.LineStyle.Width = vg.Points(1.5)
+		h.LineStyle.Color = []color.Color{
+			color.RGBA{B: 255, A: 255},
+			color.RGBA{R: 255, A: 255},
+		}[i]
+		p1.Add(h)
+	}
+	err = p1.Save(200, 200, "testdata/histogramStep.png")
+	if err != nil {
+		log.Panic(err)
+	}
+
+	vs := make(Values, 500)
+	ws := make(Values, 500)
+	for i := range vs {
+		vs[i] = rnd.ExpFloat64()
+		ws[i] = vs[i]
+	}
+	p2, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p2.Title.Text = "Weighted cumulative histogram"
+	h, err := NewWeightedHist(vs, ws, 20)
+	if err != nil {
+		log.Panic(err)
+	}
+	h.Cumulative(true)
+	h.Horizontal = true
+	h.FillColor = color.NRGBA{G: 128, A: 96}
+	p2.Add(h)
+	err = p2.Save(200, 200, "testdata/histogramCumulative.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestHistogram_step(t *testing.T) {
+	cmpimg.CheckPlot(ExampleHistogram_step, t, "histogramStep.png", "histogramCumulative.png")
+}
+
+func TestBinRules(t *testing.T) {
+	xs := make([]float64, 100)
+	ws := make([]float64, 100)
+	for i := range xs {
+		xs[i] = float64(i)
+		ws[i] = 1
+	}
+	for _, test := range []struct {
+		name string
+		rule BinRule
+		want int
+	}{
+		// ⌈log₂ 100⌉+1.
+		{name: "Sturges", rule: Sturges, want: 8},
+		// 99/(3.49×29.01/∛100) = 4.54.
+		{name: "Scott", rule: Scott, want: 5},
+		// 99/(2×50/∛100) = 4.60.
+		{name: "FreedmanDiaconis", rule: FreedmanDiaconis, want: 5},
+	} {
+		got := test.rule(xs, ws)
+		if got != test.want {
+			t.Errorf("unexpected number of bins for %s: got:%d want:%d", test.name, got, test.want)
+		}
+	}
+
+	// Constant data have zero width, and fall
+	// back to Sturges' rule.
+	for i := range xs {
+		xs[i] = 1
+	}
+	if got := FreedmanDiaconis(xs, ws); got != 8 {
+		t.Errorf("unexpected number of bins for constant data: got:%d want:8", got)
+	}
+}
+
+func TestHistogramCumulative(t *testing.T) {
+	h, err := NewWeightedHist(Values{0, 1, 2, 3}, Values{1, 2, 3, 4}, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h.Cumulative(false)
+	for i, want := range []float64{1, 3, 6, 10} {
+		if h.Bins[i].Weight != want {
+			t.Errorf("unexpected weight of bin %d: got:%v want:%v", i, h.Bins[i].Weight, want)
+		}
+	}
+	h.Cumulative(true)
+	if w := h.Bins[3].Weight; w != 1 {
+		t.Errorf("unexpected weight of last normalized bin: got:%v want:1", w)
+	}
+
+	h.Horizontal = true
+	xmin, xmax, ymin, ymax := h.DataRange()
+	if xmin != 0 || xmax != 1 || ymin != 0 || ymax != 3 {
+		t.Errorf("unexpected horizontal data range: got:[%v, %v]×[%v, %v] want:[0, 1]×[0, 3]", xmin, xmax, ymin, ymax)
+	}
+
+	_, err = NewWeightedHist(Values{0, 1}, Values{1}, 2)
+	if err == nil {
+		t.Error("expected error for mismatched weights")
+	}
+}
+
+// ExampleHistogram_stacked stacks histograms of two
+// samples binned with the same bins.
+func ExampleHistogram_stacked() {
+	rnd := rand.New(rand.NewSource(1))
+
+	// The Y values of the samples are the
+	// weights of the X values.
+	a := make(XYs, 500)
+	b := make(XYs, 300)
+	for i := range a {
+		a[i].X, a[i].Y = rnd.NormFloat64(), 1
+	}
+	for i := range b {
+		b[i].X, b[i].Y = 1+0.5*rnd.NormFloat64(), 1
+	}
+	hs, err := NewHistograms([]XYer{a, b}, 20)
+	if err != nil {
+		log.Panic(err)
+	}
+	hs[0].FillColor = color.RGBA{R: 66, G: 133, B: 244, A: 255}
+	hs[1].FillColor = color.RGBA{R: 244, G: 160, B: 0, A: 255}
+	hs[1].StackOn(hs[0])
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Stacked histograms"
+	p.Add(hs[0], hs[1])
+	p.Legend.Add("a", hs[0])
+	p.Legend.Add("b", hs[1])
+	p.Legend.Top, p.Legend.Left = true, true
+
+	err = p.Save(200, 200, "testdata/histogramStacked.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestHistogram_stacked(t *testing.T) {
+	cmpimg.CheckPlot(ExampleHistogram_stacked, t, "histogramStacked.png")
+}
+
+// ExampleNewLogHistogram draws a histogram of a heavy
+// tailed sample with logarithmic bins on log scale axes.
+func ExampleNewLogHistogram() {
+	rnd := rand.New(rand.NewSource(1))
+
+	// Draw a sample from a Pareto distribution.
+	const alpha = 1.5
+	vs := make(XYs, 1000)
+	for i := range vs {
+		vs[i].X = math.Pow(1-rnd.Float64(), -1/alpha)
+		vs[i].Y = 1
+	}
+
+	h, err := NewLogHistogram(vs, 20)
+	if err != nil {
+		log.Panic(err)
+	}
+	h.Density()
+	h.LogScale = true
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Log-binned histogram"
+	p.X.Scale = plot.LogScale{}
+	p.X.Tick.Marker = plot.LogTicks{}
+	p.Y.Scale = plot.LogScale{}
+	p.Y.Tick.Marker = plot.LogTicks{}
+	p.Add(h)
+
+	err = p.Save(200, 200, "testdata/histogramLog.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestNewLogHistogram(t *testing.T) {
+	cmpimg.CheckPlot(ExampleNewLogHistogram, t, "histogramLog.png")
+
+	h, err := NewLogHistogram(XYs{{X: 1, Y: 1}, {X: 10, Y: 2}, {X: 100, Y: 1}}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, want := range []HistogramBin{{Min: 1, Max: 10, Weight: 1}, {Min: 10, Max: 100, Weight: 3}} {
+		got := h.Bins[i]
+		if math.Abs(got.Min-want.Min) > 1e-12 || math.Abs(got.Max-want.Max) > 1e-12 || got.Weight != want.Weight {
+			t.Errorf("unexpected bin %d: got:%+v want:%+v", i, got, want)
+		}
+	}
+	if h.Width != 0 {
+		t.Errorf("unexpected width of log bins: got:%v want:0", h.Width)
+	}
+	h.Normalize(1)
+	var area float64
+	for _, b := range h.Bins {
+		area += b.Weight * (b.Max - b.Min)
+	}
+	if math.Abs(area-1) > 1e-12 {
+		t.Errorf("unexpected normalized area: got:%v want:1", area)
+	}
+
+	_, err = NewLogHistogram(XYs{{X: 0, Y: 1}}, 2)
+	if err == nil {
+		t.Error("expected error for non-positive value")
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ImplicitFunction implements the Plotter interface, drawing
+// the curve on which a function of x and y equals a level.
+//
+// The function is evaluated on a grid of cells spanning the
+// range of the plot, and each cell that the curve may cross is
+// subdivided to refine the curve, which is traced through the
+// refined cells using the contouring algorithm of Contour.
+// Features of the curve smaller than a cell of the initial
+// grid may be missed.
+type ImplicitFunction struct {
+	F func(x, y float64) float64
+
+	// Level is the value of F on the drawn curve.
+	Level float64
+
+	// XMin, XMax, YMin and YMax specify the range of
+	// x and y values to pass to F. If both the minimum
+	// and maximum of a range are zero, the range of
+	// the corresponding axis is used.
+	XMin, XMax float64
+	YMin, YMax float64
+
+	// Resolution is the number of cells of the initial
+	// grid along each axis.
+	Resolution int
+
+	// Refinement is the number of times that cells
+	// the curve may cross are bisected along each
+	// axis.
+	Refinement int
+
+	draw.LineStyle
+}
+
+// NewImplicitFunction returns an ImplicitFunction that plots
+// the zero level of f using the default line style, on a
+// grid of 64×64 cells refined 2 times.
+func NewImplicitFunction(f func(x, y float64) float64) *ImplicitFunction {
+	return &ImplicitFunction{
+		F:          f,
+		Resolution: 64,
+		Refinement: 2,
+		LineStyle:  DefaultLineStyle,
+	}
+}
+
+// Plot implements the Plotter interface, drawing the
+// level curve of the function.
+func (f *ImplicitFunction) Plot(c draw.Canvas, p *plot.Plot) {
+	trX, trY := p.Transforms(&c)
+	for _, pa := range f.paths(p, trX, trY) {
+		if isLoop(pa) {
+			pa.Close()
+		}
+		c.SetLineStyle(f.LineStyle)
+		c.Stroke(pa)
+	}
+}
+
+// paths returns the paths of the level curve within the
+// range of p transformed by trX and trY.
+func (f *ImplicitFunction) paths(p *plot.Plot, trX, trY func(float64) vg.Length) []vg.Path {
+	xmin, xmax := f.XMin, f.XMax
+	if xmin == 0 && xmax == 0 {
+		xmin, xmax = p.X.Min, p.X.Max
+	}
+	ymin, ymax := f.YMin, f.YMax
+	if ymin == 0 && ymax == 0 {
+		ymin, ymax = p.Y.Min, p.Y.Max
+	}
+	n := f.Resolution
+	if n < 1 {
+		n = 1
+	}
+	sub := 1 << uint(f.Refinement)
+	g := &refinedGrid{
+		f:     f.F,
+		level: f.Level,
+		n:     n,
+		sub:   sub,
+		x0:    xmin,
+		dx:    (xmax - xmin) / float64(n*sub),
+		y0:    ymin,
+		dy:    (ymax - ymin) / float64(n*sub),
+	}
+	g.init()
+
+	// Sort the paths so that they are drawn in
+	// the same order on each call.
+	paths := contourPaths(g, []float64{0}, nil, trX, trY)[0]
+	sort.Slice(paths, func(i, j int) bool {
+		a, b := paths[i][0].Pos, paths[j][0].Pos
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		return len(paths[i]) < len(paths[j])
+	})
+	return paths
+}
+
+// refinedGrid is a GridXYZ of the values of a function less
+// a level on a fine grid. The function is only evaluated at
+// the points of the cells of a coarse grid that the level
+// curve may cross. The values at other points have the sign
+// of the corners of their coarse cell, so that the level
+// curve does not cross them.
+type refinedGrid struct {
+	f     func(x, y float64) float64
+	level float64
+
+	// n is the number of coarse cells along each axis
+	// and sub is the number of fine cells along each
+	// axis of a coarse cell.
+	n, sub int
+
+	x0, dx float64
+	y0, dy float64
+
+	// coarse holds the values at the corners of the
+	// coarse cells and refined holds whether each
+	// coarse cell is refined.
+	coarse  []float64
+	refined []bool
+
+	// z holds the values at the fine grid points
+	// and done holds whether each has been
+	// evaluated.
+	z    []float64
+	done []bool
+}
+
+// init evaluates the coarse grid and marks the coarse
+// cells that the level curve may cross.
+func (g *refinedGrid) init() {
+	n, sub := g.n, g.sub
+	g.coarse = make([]float64, (n+1)*(n+1))
+	for i := 0; i <= n; i++ {
+		for j := 0; j <= n; j++ {
+			g.coarse[i*(n+1)+j] = g.eval(i*sub, j*sub)
+		}
+	}
+	g.refined = make([]bool, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			g.refined[i*n+j] = mayCross(
+				g.coarse[i*(n+1)+j], g.coarse[(i+1)*(n+1)+j],
+				g.coarse[i*(n+1)+j+1], g.coarse[(i+1)*(n+1)+j+1],
+			)
+		}
+	}
+	m := n*sub + 1
+	g.z = make([]float64, m*m)
+	g.done = make([]bool, m*m)
+}
+
+// eval returns the value of the function less the level
+// at the fine grid point in column c and row r.
+func (g *refinedGrid) eval(c, r int) float64 {
+	return g.f(g.X(c), g.Y(r)) - g.level
+}
+
+func (g *refinedGrid) Dims() (c, r int) { return g.n*g.sub + 1, g.n*g.sub + 1 }
+func (g *refinedGrid) X(c int) float64  { return g.x0 + float64(c)*g.dx }
+func (g *refinedGrid) Y(r int) float64  { return g.y0 + float64(r)*g.dy }
+func (g *refinedGrid) Z(c, r int) float64 {
+	m := g.n*g.sub + 1
+	k := c*m + r
+	if g.done[k] {
+		return g.z[k]
+	}
+
+	// Find the coarse cells holding the point, which
+	// is on their shared boundary if it is in more
+	// than one.
+	ci, cj := c/g.sub, r/g.sub
+	is := []int{ci}
+	if c%g.sub == 0 && ci > 0 {
+		is = append(is, ci-1)
+	}
+	js := []int{cj}
+	if r%g.sub == 0 && cj > 0 {
+		js = append(js, cj-1)
+	}
+	var z float64
+	refined := false
+	for _, i := range is {
+		for _, j := range js {
+			if i < g.n && j < g.n && g.refined[i*g.n+j] {
+				refined = true
+			}
+		}
+	}
+	if refined {
+		z = g.eval(c, r)
+	} else {
+		// Take the sign of the cells from the value at
+		// the lower left corner of one of them.
+		z = 1
+	search:
+		for _, i := range is {
+			for _, j := range js {
+				if i == g.n || j == g.n {
+					continue
+				}
+				if v := g.coarse[i*(g.n+1)+j]; !math.IsNaN(v) {
+					z = v
+					break search
+				}
+			}
+		}
+	}
+	g.z[k] = z
+	g.done[k] = true
+	return z
+}
+
+// mayCross returns whether the zero level may cross a cell
+// with the given corner values.
+func mayCross(vs ...float64) bool {
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range vs {
+		if math.IsNaN(v) {
+			return false
+		}
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	return min <= 0 && 0 <= max
+}
+
+// Thumbnail draws a line in the given style down the
+// center of a DrawArea as a thumbnail representation
+// of the LineStyle of the function.
+func (f *ImplicitFunction) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(f.LineStyle, c.Min.X, y, c.Max.X, y)
+}
@@ -0,0 +1,90 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+)
+
+// ExampleImplicitFunction draws level curves of
+// functions of x and y.
+func ExampleImplicitFunction() {
+	folium := NewImplicitFunction(func(x, y float64) float64 { return x*x*x + y*y*y - 3*x*y })
+	folium.Color = color.RGBA{B: 255, A: 255}
+
+	circle := NewImplicitFunction(func(x, y float64) float64 { return x*x + y*y })
+	circle.Level = 4
+	circle.Color = color.RGBA{R: 255, A: 255}
+
+	heart := NewImplicitFunction(func(x, y float64) float64 {
+		a := x*x + y*y - 1
+		return a*a*a - x*x*y*y*y
+	})
+	heart.Color = color.RGBA{R: 196, B: 128, A: 255}
+	heart.Width = vg.Points(2)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Implicit functions"
+	p.Add(NewGrid(), folium, circle, heart)
+	p.Legend.Add("x³+y³=3xy", folium)
+	p.Legend.Add("x²+y²=4", circle)
+	p.X.Min, p.X.Max = -3, 3
+	p.Y.Min, p.Y.Max = -3, 3
+	p.DataAspect = 1
+
+	err = p.Save(200, 200, "testdata/implicitFunction.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestImplicitFunction(t *testing.T) {
+	cmpimg.CheckPlot(ExampleImplicitFunction, t, "implicitFunction.png")
+}
+
+func TestImplicitFunctionPaths(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = -2, 2
+	p.Y.Min, p.Y.Max = -2, 2
+	id := func(v float64) vg.Length { return vg.Length(v) }
+
+	evals := 0
+	f := NewImplicitFunction(func(x, y float64) float64 {
+		evals++
+		return x*x + y*y
+	})
+	f.Level = 1
+	f.Resolution = 16
+	f.Refinement = 3
+	paths := f.paths(p, id, id)
+	if len(paths) != 1 {
+		t.Fatalf("unexpected number of paths: got:%d want:1", len(paths))
+	}
+	if !isLoop(paths[0]) {
+		t.Error("expected closed circle")
+	}
+	for _, c := range paths[0] {
+		r := math.Hypot(float64(c.Pos.X), float64(c.Pos.Y))
+		if math.Abs(r-1) > 0.01 {
+			t.Errorf("point %v not on unit circle: radius %v", c.Pos, r)
+		}
+	}
+	if full := (16*8 + 1) * (16*8 + 1); evals >= full/2 {
+		t.Errorf("refinement is not adaptive: %d evaluations for %d grid points", evals, full)
+	}
+}
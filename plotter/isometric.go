@@ -0,0 +1,207 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Isometric implements the Plotter interface, drawing a
+// GridXYZ as a heightfield in an oblique projection. The
+// cells of the grid are drawn from back to front, so that
+// nearer cells hide those behind them.
+//
+// Isometric is drawn in projected coordinates, so the
+// axes of the plot do not correspond to the X and Y values
+// of the grid and are normally hidden.
+type Isometric struct {
+	GridXYZ GridXYZ
+
+	// Azimuth is the angle in radians by which the
+	// grid is rotated counterclockwise about its
+	// vertical axis.
+	Azimuth float64
+
+	// Elevation is the angle in radians of the viewer
+	// above the plane of the grid. An Elevation of π/2
+	// views the grid from directly above.
+	Elevation float64
+
+	// Exaggeration is the height of the Z range of the
+	// grid as a fraction of the larger of its X and Y
+	// extents.
+	Exaggeration float64
+
+	// Palette, if not nil, is the color palette used to
+	// fill the cells according to their mean height.
+	Palette palette.Palette
+
+	// Min and Max define the dynamic range of the
+	// Palette and the range of heights scaled by
+	// Exaggeration.
+	Min, Max float64
+
+	// Color is the fill color of the cells if Palette
+	// is nil. If both are nil, cells are not filled.
+	Color color.Color
+
+	// LineStyle is the style of the lines around
+	// each cell.
+	draw.LineStyle
+}
+
+// NewIsometric returns an Isometric heightfield of the grid g,
+// filled using the palette p, viewed from 30° above the grid
+// after rotating it by 30°. The dynamic range is set to the
+// range of the Z values of g.
+func NewIsometric(g GridXYZ, p palette.Palette) *Isometric {
+	min, max := math.Inf(1), math.Inf(-1)
+	c, r := g.Dims()
+	for i := 0; i < c; i++ {
+		for j := 0; j < r; j++ {
+			v := g.Z(i, j)
+			if math.IsNaN(v) {
+				continue
+			}
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+	}
+	return &Isometric{
+		GridXYZ:      g,
+		Azimuth:      math.Pi / 6,
+		Elevation:    math.Pi / 6,
+		Exaggeration: 0.25,
+		Palette:      p,
+		Min:          min,
+		Max:          max,
+		LineStyle: draw.LineStyle{
+			Color: color.Gray{Y: 64},
+			Width: vg.Points(0.25),
+		},
+	}
+}
+
+// project returns the projected coordinates of the grid
+// point (x, y, z) and its depth away from the viewer.
+func (iso *Isometric) project(x, y, z float64) (px, py, depth float64) {
+	sinA, cosA := math.Sincos(iso.Azimuth)
+	u := x*cosA - y*sinA
+	v := x*sinA + y*cosA
+	sinE, cosE := math.Sincos(iso.Elevation)
+	return u, v*sinE + iso.height(z)*cosE, v
+}
+
+// height returns the height in grid units of z.
+func (iso *Isometric) height(z float64) float64 {
+	if iso.Max <= iso.Min {
+		return 0
+	}
+	c, r := iso.GridXYZ.Dims()
+	extent := math.Max(
+		math.Abs(iso.GridXYZ.X(c-1)-iso.GridXYZ.X(0)),
+		math.Abs(iso.GridXYZ.Y(r-1)-iso.GridXYZ.Y(0)),
+	)
+	return (z - iso.Min) / (iso.Max - iso.Min) * iso.Exaggeration * extent
+}
+
+// isoCell is a projected cell of an Isometric.
+type isoCell struct {
+	pts   [4]vg.Point
+	z     float64
+	depth float64
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (iso *Isometric) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	g := iso.GridXYZ
+	cols, rows := g.Dims()
+
+	cells := make([]isoCell, 0, (cols-1)*(rows-1))
+	for i := 0; i < cols-1; i++ {
+		for j := 0; j < rows-1; j++ {
+			var cell isoCell
+			for k, off := range [4][2]int{{0, 0}, {1, 0}, {1, 1}, {0, 1}} {
+				x, y, z := g.X(i+off[0]), g.Y(j+off[1]), g.Z(i+off[0], j+off[1])
+				px, py, depth := iso.project(x, y, z)
+				cell.pts[k] = vg.Point{X: trX(px), Y: trY(py)}
+				cell.z += z / 4
+				cell.depth += depth / 4
+			}
+			if math.IsNaN(cell.z) {
+				continue
+			}
+			cells = append(cells, cell)
+		}
+	}
+	sort.SliceStable(cells, func(i, j int) bool {
+		return cells[i].depth > cells[j].depth
+	})
+
+	var pal []color.Color
+	if iso.Palette != nil {
+		pal = iso.Palette.Colors()
+	}
+	stroke := iso.LineStyle.Color != nil && iso.LineStyle.Width > 0
+	for _, cell := range cells {
+		pts := cell.pts[:]
+		fill := iso.Color
+		if len(pal) != 0 {
+			fill = pal[paletteIndex(cell.z, iso.Min, iso.Max, len(pal))]
+		}
+		if fill != nil {
+			c.FillPolygon(fill, c.ClipPolygonXY(pts))
+		}
+		if stroke {
+			c.StrokeLines(iso.LineStyle, c.ClipLinesXY(append(pts, pts[0]))...)
+		}
+	}
+}
+
+// paletteIndex returns the index of the color of z in
+// a palette of n colors spanning [min, max].
+func paletteIndex(z, min, max float64, n int) int {
+	if max <= min {
+		return 0
+	}
+	i := int((z-min)/(max-min)*float64(n-1) + 0.5)
+	if i < 0 {
+		return 0
+	}
+	if i >= n {
+		return n - 1
+	}
+	return i
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (iso *Isometric) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	c, r := iso.GridXYZ.Dims()
+	for i := 0; i < c; i++ {
+		for j := 0; j < r; j++ {
+			z := iso.GridXYZ.Z(i, j)
+			if math.IsNaN(z) {
+				continue
+			}
+			x, y, _ := iso.project(iso.GridXYZ.X(i), iso.GridXYZ.Y(j), z)
+			xmin = math.Min(xmin, x)
+			xmax = math.Max(xmax, x)
+			ymin = math.Min(ymin, y)
+			ymax = math.Max(ymax, y)
+		}
+	}
+	return xmin, xmax, ymin, ymax
+}
@@ -0,0 +1,48 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/palette/moreland"
+)
+
+func ExampleIsometric() {
+	const n = 30
+	m := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			x, y := float64(j)/n-0.5, float64(i)/n-0.5
+			m.Set(i, j, math.Exp(-20*(x*x+y*y))+0.5*math.Exp(-40*((x-0.3)*(x-0.3)+(y+0.25)*(y+0.25))))
+		}
+	}
+
+	iso := NewIsometric(unitGrid{m}, moreland.SmoothBlueRed().Palette(32))
+	iso.Exaggeration = 0.4
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Isometric"
+	p.HideAxes()
+	p.DataAspect = 1
+	p.Add(iso)
+
+	err = p.Save(300, 250, "testdata/isometric.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestIsometric(t *testing.T) {
+	cmpimg.CheckPlot(ExampleIsometric, t, "isometric.png")
+}
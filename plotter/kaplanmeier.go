@@ -0,0 +1,253 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// KaplanMeier implements the Plotter interface, drawing
+// the Kaplan-Meier estimate of a survival function as a
+// step function, with a pointwise confidence band and a
+// mark at the time of each censored observation.
+type KaplanMeier struct {
+	// Times holds the distinct times of the observed
+	// events in increasing order.
+	Times []float64
+
+	// Survival holds the estimated probability of
+	// survival just after each of Times.
+	Survival []float64
+
+	// Lower and Upper hold the bounds of the confidence
+	// interval of Survival, using Greenwood's formula
+	// for its variance.
+	Lower, Upper []float64
+
+	// Censored holds the times of the censored
+	// observations and the estimated survival at
+	// those times.
+	Censored XYs
+
+	// LineStyle is the style of the survival curve.
+	draw.LineStyle
+
+	// BandColor is the fill color of the confidence
+	// band. If BandColor is nil the band is not drawn.
+	BandColor color.Color
+
+	// CensorStyle is the style of the glyphs marking
+	// censored observations.
+	CensorStyle draw.GlyphStyle
+
+	// durations holds the durations of all
+	// observations in increasing order.
+	durations []float64
+}
+
+// NewKaplanMeier returns a KaplanMeier estimate from the
+// durations of a set of observations, where events reports
+// whether each observation ended with the event, rather than
+// being censored. The confidence band is computed at the
+// given level, which must be in (0, 1), and is filled with
+// a translucent gray.
+func NewKaplanMeier(durations []float64, events []bool, level float64) (*KaplanMeier, error) {
+	if len(durations) != len(events) {
+		return nil, errors.New("plotter: durations and events lengths mismatch")
+	}
+	if len(durations) == 0 {
+		return nil, ErrNoData
+	}
+	if !(0 < level && level < 1) {
+		return nil, errors.New("plotter: confidence level out of range")
+	}
+	idx := make([]int, len(durations))
+	for i, d := range durations {
+		if err := CheckFloats(d); err != nil {
+			return nil, err
+		}
+		if d < 0 {
+			return nil, errors.New("plotter: negative duration")
+		}
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return durations[idx[i]] < durations[idx[j]] })
+
+	km := &KaplanMeier{
+		LineStyle:   DefaultLineStyle,
+		BandColor:   color.NRGBA{R: 128, G: 128, B: 128, A: 64},
+		CensorStyle: draw.GlyphStyle{Shape: draw.PlusGlyph{}, Radius: vg.Points(3)},
+		durations:   make([]float64, len(idx)),
+	}
+	z := distuv.UnitNormal.Quantile(0.5 + level/2)
+
+	s, v := 1.0, 0.0
+	for k := 0; k < len(idx); {
+		t := durations[idx[k]]
+		atRisk := float64(len(idx) - k)
+		var died float64
+		start := k
+		for ; k < len(idx) && durations[idx[k]] == t; k++ {
+			km.durations[k] = t
+			if events[idx[k]] {
+				died++
+			}
+		}
+		if died > 0 {
+			s *= 1 - died/atRisk
+			if died < atRisk {
+				v += died / (atRisk * (atRisk - died))
+			}
+			se := s * math.Sqrt(v)
+			km.Times = append(km.Times, t)
+			km.Survival = append(km.Survival, s)
+			km.Lower = append(km.Lower, math.Max(0, s-z*se))
+			km.Upper = append(km.Upper, math.Min(1, s+z*se))
+		}
+		for _, i := range idx[start:k] {
+			if !events[i] {
+				km.Censored = append(km.Censored, struct{ X, Y float64 }{t, s})
+			}
+		}
+	}
+	return km, nil
+}
+
+// NumberAtRisk returns the number of observations that
+// have neither had the event nor been censored before t.
+func (km *KaplanMeier) NumberAtRisk(t float64) int {
+	return len(km.durations) - sort.SearchFloat64s(km.durations, t)
+}
+
+// steps returns the points of the step function with the
+// value 1 before the first event and the given values
+// after each event, ending at the last duration.
+func (km *KaplanMeier) steps(vs []float64, trX, trY func(float64) vg.Length) []vg.Point {
+	pts := make([]vg.Point, 0, 2*len(vs)+2)
+	pts = append(pts, vg.Point{X: trX(0), Y: trY(1)})
+	last := 1.0
+	for i, t := range km.Times {
+		pts = append(pts,
+			vg.Point{X: trX(t), Y: trY(last)},
+			vg.Point{X: trX(t), Y: trY(vs[i])},
+		)
+		last = vs[i]
+	}
+	return append(pts, vg.Point{X: trX(km.durations[len(km.durations)-1]), Y: trY(last)})
+}
+
+// Plot draws the KaplanMeier, implementing the plot.Plotter
+// interface.
+func (km *KaplanMeier) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	if km.BandColor != nil {
+		band := km.steps(km.Upper, trX, trY)
+		lower := km.steps(km.Lower, trX, trY)
+		for i := len(lower) - 1; i >= 0; i-- {
+			band = append(band, lower[i])
+		}
+		c.FillPolygon(km.BandColor, c.ClipPolygonXY(band))
+	}
+
+	c.StrokeLines(km.LineStyle, c.ClipLinesXY(km.steps(km.Survival, trX, trY))...)
+
+	for _, p := range km.Censored {
+		pt := vg.Point{X: trX(p.X), Y: trY(p.Y)}
+		if !c.Contains(pt) {
+			continue
+		}
+		sty := km.CensorStyle
+		if sty.Color == nil {
+			sty.Color = km.LineStyle.Color
+		}
+		c.DrawGlyph(sty, pt)
+	}
+}
+
+// DataRange returns the minimum and maximum time and
+// survival, including the confidence band, implementing
+// the plot.DataRanger interface.
+func (km *KaplanMeier) DataRange() (xmin, xmax, ymin, ymax float64) {
+	ymin = 1
+	for i, s := range km.Survival {
+		ymin = math.Min(ymin, s)
+		if km.BandColor != nil {
+			ymin = math.Min(ymin, km.Lower[i])
+		}
+	}
+	return 0, km.durations[len(km.durations)-1], ymin, 1
+}
+
+// GlyphBoxes returns a slice of plot.GlyphBoxes, one for
+// each censor mark, implementing the plot.GlyphBoxer
+// interface.
+func (km *KaplanMeier) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(km.Censored))
+	r := km.CensorStyle.Radius
+	for i, p := range km.Censored {
+		bs[i].X = plt.X.Norm(p.X)
+		bs[i].Y = plt.Y.Norm(p.Y)
+		bs[i].Rectangle = vg.Rectangle{
+			Min: vg.Point{X: -r, Y: -r},
+			Max: vg.Point{X: +r, Y: +r},
+		}
+	}
+	return bs
+}
+
+// Thumbnail draws a line in the style of the survival
+// curve, implementing the plot.Thumbnailer interface.
+func (km *KaplanMeier) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(km.LineStyle, c.Min.X, y, c.Max.X, y)
+}
+
+// RiskTable implements the plot.Ticker interface, adding
+// the number of observations at risk in each of a set of
+// groups below the labels of the major ticks of an axis,
+// so that a table of the numbers at risk is drawn below
+// the X axis of a survival plot. The numbers are written
+// in the order of Groups.
+type RiskTable struct {
+	// Ticker returns the ticks of the axis. If Ticker
+	// is nil, plot.DefaultTicks is used.
+	Ticker plot.Ticker
+
+	// Groups holds the survival estimates of the
+	// groups in the table.
+	Groups []*KaplanMeier
+}
+
+var _ plot.Ticker = RiskTable{}
+
+// Ticks implements the plot.Ticker interface.
+func (r RiskTable) Ticks(min, max float64) []plot.Tick {
+	ticker := r.Ticker
+	if ticker == nil {
+		ticker = plot.DefaultTicks{}
+	}
+	// The ticks are copied since a Ticker may return
+	// the same slice on each call.
+	ticks := append([]plot.Tick(nil), ticker.Ticks(min, max)...)
+	for i, t := range ticks {
+		if t.IsMinor() {
+			continue
+		}
+		for _, g := range r.Groups {
+			ticks[i].Label += fmt.Sprintf("\n%d", g.NumberAtRisk(t.Value))
+		}
+	}
+	return ticks
+}
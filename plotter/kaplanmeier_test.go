@@ -0,0 +1,118 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+)
+
+func ExampleKaplanMeier() {
+	rnd := rand.New(rand.NewSource(1))
+	group := func(scale float64) *KaplanMeier {
+		const n = 40
+		durations := make([]float64, n)
+		events := make([]bool, n)
+		for i := range durations {
+			t := math.Ceil(rnd.ExpFloat64() * scale)
+			censor := math.Ceil(rnd.Float64() * 30)
+			durations[i] = math.Min(t, censor)
+			events[i] = t <= censor
+		}
+		km, err := NewKaplanMeier(durations, events, 0.95)
+		if err != nil {
+			log.Panic(err)
+		}
+		return km
+	}
+	control := group(8)
+	control.Color = color.RGBA{R: 196, A: 255}
+	control.BandColor = color.NRGBA{R: 196, A: 48}
+	treated := group(16)
+	treated.Color = color.RGBA{B: 196, A: 255}
+	treated.BandColor = color.NRGBA{B: 196, A: 48}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Survival"
+	p.X.Label.Text = "Months"
+	p.Y.Label.Text = "Probability"
+	p.Add(control, treated)
+	p.Legend.Add("control", control)
+	p.Legend.Add("treated", treated)
+	p.Legend.Top = true
+	var ticks plot.ConstantTicks
+	for t := 0; t <= 30; t += 10 {
+		ticks = append(ticks, plot.Tick{Value: float64(t), Label: fmt.Sprint(t)})
+	}
+	p.X.Tick.Marker = RiskTable{Ticker: ticks, Groups: []*KaplanMeier{control, treated}}
+
+	err = p.Save(250, 200, "testdata/kaplanMeier.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestKaplanMeier(t *testing.T) {
+	cmpimg.CheckPlot(ExampleKaplanMeier, t, "kaplanMeier.png")
+}
+
+func TestKaplanMeierEstimate(t *testing.T) {
+	durations := []float64{2, 1, 2, 3, 4, 5}
+	events := []bool{true, true, false, true, false, true}
+	km, err := NewKaplanMeier(durations, events, 0.95)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []float64{1, 2, 3, 5}; !reflect.DeepEqual(km.Times, want) {
+		t.Errorf("unexpected times: got:%v want:%v", km.Times, want)
+	}
+	want := []float64{5.0 / 6, 2.0 / 3, 4.0 / 9, 0}
+	for i, s := range km.Survival {
+		if math.Abs(s-want[i]) > 1e-12 {
+			t.Errorf("unexpected survival at %v: got:%v want:%v", km.Times[i], s, want[i])
+		}
+	}
+	se := 5.0 / 6 * math.Sqrt(1.0/30)
+	if got, want := km.Survival[0]-km.Lower[0], 1.959963984540054*se; math.Abs(got-want) > 1e-9 {
+		t.Errorf("unexpected confidence half width: got:%v want:%v", got, want)
+	}
+	wantCensored := XYs{{X: 2, Y: want[1]}, {X: 4, Y: want[2]}}
+	if len(km.Censored) != len(wantCensored) {
+		t.Fatalf("unexpected number of censored points: got:%d want:%d", len(km.Censored), len(wantCensored))
+	}
+	for i, p := range km.Censored {
+		if p.X != wantCensored[i].X || math.Abs(p.Y-wantCensored[i].Y) > 1e-12 {
+			t.Errorf("unexpected censored point: got:%v want:%v", p, wantCensored[i])
+		}
+	}
+
+	for _, test := range []struct {
+		t    float64
+		want int
+	}{
+		{t: 0, want: 6}, {t: 2, want: 5}, {t: 2.5, want: 3}, {t: 5, want: 1}, {t: 6, want: 0},
+	} {
+		if got := km.NumberAtRisk(test.t); got != test.want {
+			t.Errorf("unexpected number at risk at %v: got:%d want:%d", test.t, got, test.want)
+		}
+	}
+
+	ticks := RiskTable{Ticker: plot.ConstantTicks{{Value: 2, Label: "2"}}, Groups: []*KaplanMeier{km, km}}.Ticks(0, 5)
+	if got, want := ticks[0].Label, "2\n5\n5"; got != want {
+		t.Errorf("unexpected risk table label: got:%q want:%q", got, want)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+)
+
+// TriangleMask specifies the cells of a matrix that are
+// hidden by a LabeledHeatMap.
+type TriangleMask int
+
+const (
+	// NoMask shows all cells.
+	NoMask TriangleMask = iota
+
+	// UpperMask hides the cells above the diagonal.
+	UpperMask
+
+	// LowerMask hides the cells below the diagonal.
+	LowerMask
+)
+
+// LabeledHeatMap implements the Plotter interface, drawing
+// a heat map of a matrix with labelled rows and columns, such
+// as a correlation matrix. The first row of the matrix is
+// drawn at the top of the plot. Cells hidden by the Mask are
+// drawn as NaN values. The values of the cells are written
+// in them by the CellText of the embedded HeatMap.
+type LabeledHeatMap struct {
+	HeatMap
+
+	// Rows and Cols are the labels of the rows and
+	// columns of the matrix.
+	Rows, Cols []string
+
+	// Mask specifies the cells to hide.
+	Mask TriangleMask
+}
+
+// NewLabeledHeatMap returns a LabeledHeatMap of m with the
+// given row and column labels, using the provided palette.
+// The dynamic range is set to the range of the values of m.
+func NewLabeledHeatMap(m mat.Matrix, rows, cols []string, p palette.Palette) (*LabeledHeatMap, error) {
+	r, c := m.Dims()
+	if len(rows) != r || len(cols) != c {
+		return nil, errors.New("plotter: number of labels does not match matrix dimensions")
+	}
+	h := &LabeledHeatMap{Rows: rows, Cols: cols}
+	h.HeatMap = *NewHeatMap(labeledGrid{m: m, mask: &h.Mask}, p)
+	return h, nil
+}
+
+// labeledGrid is a GridXYZ holding a matrix with its
+// first row at the top and masked cells set to NaN.
+type labeledGrid struct {
+	m    mat.Matrix
+	mask *TriangleMask
+}
+
+func (g labeledGrid) Dims() (c, r int) { r, c = g.m.Dims(); return c, r }
+func (g labeledGrid) X(c int) float64  { return float64(c) }
+func (g labeledGrid) Y(r int) float64  { return float64(r) }
+func (g labeledGrid) Z(c, r int) float64 {
+	rows, _ := g.m.Dims()
+	i := rows - 1 - r
+	if g.masked(i, c) {
+		return math.NaN()
+	}
+	return g.m.At(i, c)
+}
+
+// masked returns whether the cell in row i and
+// column j of the matrix is hidden.
+func (g labeledGrid) masked(i, j int) bool {
+	switch *g.mask {
+	case UpperMask:
+		return j > i
+	case LowerMask:
+		return j < i
+	default:
+		return false
+	}
+}
+
+// NominalAxes configures the axes of p to label the rows
+// and columns of the matrix.
+func (h *LabeledHeatMap) NominalAxes(p *plot.Plot) {
+	p.NominalX(h.Cols...)
+	rows := make([]string, len(h.Rows))
+	for i, r := range h.Rows {
+		rows[len(rows)-1-i] = r
+	}
+	p.NominalY(rows...)
+}
@@ -0,0 +1,84 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/palette/moreland"
+)
+
+func ExampleLabeledHeatMap() {
+	names := []string{"height", "weight", "age", "income"}
+	corr := mat.NewSymDense(4, []float64{
+		1, 0.82, 0.12, 0.31,
+		0.82, 1, 0.27, 0.22,
+		0.12, 0.27, 1, 0.54,
+		0.31, 0.22, 0.54, 1,
+	})
+
+	h, err := NewLabeledHeatMap(corr, names, names, moreland.SmoothBlueRed().Palette(64))
+	if err != nil {
+		log.Panic(err)
+	}
+	h.Min, h.Max = -1, 1
+	h.Mask = UpperMask
+	h.CellText = func(v float64) string { return fmt.Sprintf("%.2f", v) }
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Correlation matrix"
+	p.Add(h)
+	h.NominalAxes(p)
+
+	err = p.Save(250, 200, "testdata/labeledHeatMap.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestLabeledHeatMap(t *testing.T) {
+	cmpimg.CheckPlot(ExampleLabeledHeatMap, t, "labeledHeatMap.png")
+}
+
+func TestLabeledHeatMapMask(t *testing.T) {
+	m := mat.NewDense(2, 2, []float64{1, 2, 3, 4})
+	h, err := NewLabeledHeatMap(m, []string{"a", "b"}, []string{"c", "d"}, moreland.SmoothBlueRed().Palette(8))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Grid rows are ordered from the bottom of the plot.
+	for _, test := range []struct {
+		mask TriangleMask
+		want [2][2]float64 // Indexed by grid column and row.
+	}{
+		{mask: NoMask, want: [2][2]float64{{3, 1}, {4, 2}}},
+		{mask: UpperMask, want: [2][2]float64{{3, 1}, {4, math.NaN()}}},
+		{mask: LowerMask, want: [2][2]float64{{math.NaN(), 1}, {4, 2}}},
+	} {
+		h.Mask = test.mask
+		for c := 0; c < 2; c++ {
+			for r := 0; r < 2; r++ {
+				got, want := h.GridXYZ.Z(c, r), test.want[c][r]
+				if got != want && !(math.IsNaN(got) && math.IsNaN(want)) {
+					t.Errorf("unexpected value for mask %d at (%d, %d): got:%v want:%v", test.mask, c, r, got, want)
+				}
+			}
+		}
+	}
+
+	_, err = NewLabeledHeatMap(m, []string{"a"}, []string{"c", "d"}, moreland.SmoothBlueRed().Palette(8))
+	if err == nil {
+		t.Error("expected error for mismatched labels")
+	}
+}
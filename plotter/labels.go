@@ -6,6 +6,8 @@ package plotter
 
 import (
 	"errors"
+	"fmt"
+	"math"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/vg"
@@ -36,8 +38,46 @@ type Labels struct {
 	// XOffset and YOffset are added directly to the final
 	// label X and Y location respectively.
 	XOffset, YOffset vg.Length
+
+	// Placement specifies how labels are moved from
+	// their offset locations to avoid overlapping one
+	// another and the glyphs at the labelled points.
+	Placement LabelPlacement
+
+	// GlyphRadius is the radius of the glyphs drawn at
+	// the labelled points, which moved labels avoid.
+	GlyphRadius vg.Length
+
+	// LeaderStyle is the style of the leader lines
+	// drawn from the labelled points to labels that
+	// have been moved away from them. Leader lines are
+	// not drawn if LeaderStyle.Width is zero.
+	LeaderStyle draw.LineStyle
 }
 
+// LabelPlacement specifies how Labels are placed.
+type LabelPlacement int
+
+const (
+	// FixedPlacement draws each label at its point,
+	// offset by XOffset and YOffset.
+	FixedPlacement LabelPlacement = iota
+
+	// GreedyPlacement places the labels in order, moving
+	// each to the nearest of a set of candidate locations
+	// around its point at which it overlaps no label that
+	// has already been placed and no glyph. If there is no
+	// such location, the label is placed where it overlaps
+	// least.
+	GreedyPlacement
+
+	// ForcePlacement moves overlapping labels apart, and
+	// labels away from the glyphs they overlap, while
+	// pulling each label toward its point, until the
+	// labels settle.
+	ForcePlacement
+)
+
 // NewLabels returns a new Labels using the DefaultFont and
 // the DefaultFontSize.
 func NewLabels(d XYLabeller) (*Labels, error) {
@@ -66,26 +106,277 @@ func NewLabels(d XYLabeller) (*Labels, error) {
 	}
 
 	return &Labels{
-		XYs:       xys,
-		Labels:    strs,
-		TextStyle: styles,
+		XYs:         xys,
+		Labels:      strs,
+		TextStyle:   styles,
+		GlyphRadius: DefaultGlyphStyle.Radius,
+		LeaderStyle: draw.LineStyle{
+			Color: DefaultLineStyle.Color,
+			Width: vg.Points(0.5),
+		},
 	}, nil
 }
 
+// Validate returns an error if the Labels do not have
+// a point and a text style for each label, implementing
+// the plot.Validator interface.
+func (l *Labels) Validate() error {
+	switch {
+	case len(l.XYs) != len(l.Labels):
+		return fmt.Errorf("plotter: labels have %d points but %d labels", len(l.XYs), len(l.Labels))
+	case len(l.TextStyle) != len(l.Labels):
+		return fmt.Errorf("plotter: labels have %d text styles but %d labels", len(l.TextStyle), len(l.Labels))
+	}
+	return nil
+}
+
 // Plot implements the Plotter interface, drawing labels.
 func (l *Labels) Plot(c draw.Canvas, p *plot.Plot) {
 	trX, trY := p.Transforms(&c)
+	var (
+		idx    []int
+		points []vg.Point
+		rects  []vg.Rectangle
+	)
 	for i, label := range l.Labels {
 		pt := vg.Point{X: trX(l.XYs[i].X), Y: trY(l.XYs[i].Y)}
 		if !c.Contains(pt) {
 			continue
 		}
-		pt.X += l.XOffset
-		pt.Y += l.YOffset
-		c.FillText(l.TextStyle[i], pt, label)
+		idx = append(idx, i)
+		points = append(points, pt)
+		r := l.TextStyle[i].Rectangle(label)
+		off := vg.Point{X: pt.X + l.XOffset, Y: pt.Y + l.YOffset}
+		rects = append(rects, vg.Rectangle{Min: r.Min.Add(off), Max: r.Max.Add(off)})
+	}
+
+	var moves []vg.Point
+	switch l.Placement {
+	case FixedPlacement:
+		moves = make([]vg.Point, len(rects))
+	case GreedyPlacement:
+		moves = placeGreedy(c.Rectangle, points, rects, l.GlyphRadius)
+	case ForcePlacement:
+		moves = placeForce(c.Rectangle, points, rects, l.GlyphRadius)
+	default:
+		panic("plotter: unknown label placement")
+	}
+
+	for k, i := range idx {
+		pt := points[k]
+		d := moves[k]
+		if d != (vg.Point{}) && l.LeaderStyle.Width != 0 {
+			r := translate(rects[k], d)
+			end := nearest(r, pt)
+			if dist(end, pt) > l.GlyphRadius {
+				c.StrokeLine2(l.LeaderStyle, pt.X, pt.Y, end.X, end.Y)
+			}
+		}
+		pt.X += l.XOffset + d.X
+		pt.Y += l.YOffset + d.Y
+		c.FillText(l.TextStyle[i], pt, l.Labels[i])
+	}
+}
+
+// placeGreedy returns the moves of the label rectangles rects,
+// labelling points, that place the labels within the bounds
+// without overlap, as described for GreedyPlacement. The glyphs
+// at the points have radius rad.
+func placeGreedy(bounds vg.Rectangle, points []vg.Point, rects []vg.Rectangle, rad vg.Length) []vg.Point {
+	const rings = 6
+	dirs := [...]vg.Point{
+		{X: 1, Y: 0}, {X: -1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: -1},
+		{X: 1, Y: 1}, {X: -1, Y: 1}, {X: 1, Y: -1}, {X: -1, Y: -1},
+	}
+
+	moves := make([]vg.Point, len(rects))
+	placed := make([]vg.Rectangle, 0, len(rects))
+	for i, r := range rects {
+		size := r.Size()
+		step := size.Y
+		if size.X < step {
+			step = size.X
+		}
+		step = step/2 + rad
+
+		best, bestCost := vg.Point{}, vg.Length(math.Inf(1))
+	search:
+		for ring := 0; ring <= rings; ring++ {
+			for k, dir := range dirs {
+				if ring == 0 && k > 0 {
+					break
+				}
+				d := vg.Point{X: dir.X * vg.Length(ring) * step, Y: dir.Y * vg.Length(ring) * step}
+				cost := overlapCost(bounds, translate(r, d), placed, points, rad)
+				if cost < bestCost {
+					best, bestCost = d, cost
+				}
+				if cost == 0 {
+					break search
+				}
+			}
+		}
+		moves[i] = best
+		placed = append(placed, translate(r, best))
+	}
+	return moves
+}
+
+// overlapCost returns the total area of the overlap of r with
+// the placed rectangles and the squares bounding the glyphs of
+// radius rad at the points, and of r lying outside bounds.
+func overlapCost(bounds, r vg.Rectangle, placed []vg.Rectangle, points []vg.Point, rad vg.Length) vg.Length {
+	size := r.Size()
+	cost := size.X*size.Y - overlap(r, bounds)
+	for _, p := range placed {
+		cost += overlap(r, p)
+	}
+	for _, pt := range points {
+		cost += overlap(r, glyphRect(pt, rad))
+	}
+	return cost
+}
+
+// placeForce returns the moves of the label rectangles rects,
+// labelling points, that separate the labels within the bounds,
+// as described for ForcePlacement. The glyphs at the points have
+// radius rad.
+func placeForce(bounds vg.Rectangle, points []vg.Point, rects []vg.Rectangle, rad vg.Length) []vg.Point {
+	const (
+		iterations = 400
+
+		// pull is the largest fraction of a label's
+		// displacement from its point that is recovered
+		// each iteration. The pull weakens over the first
+		// half of the iterations and is then released so
+		// that the remaining overlaps are resolved.
+		pull = 0.05
+	)
+	moves := make([]vg.Point, len(rects))
+	for it := 0; it < iterations; it++ {
+		var moved bool
+		push := make([]vg.Point, len(rects))
+		for i := range rects {
+			ri := translate(rects[i], moves[i])
+			for j := i + 1; j < len(rects); j++ {
+				d := separation(ri, translate(rects[j], moves[j]))
+				if d == (vg.Point{}) {
+					continue
+				}
+				moved = true
+				push[i] = push[i].Add(d.Scale(0.5))
+				push[j] = push[j].Sub(d.Scale(0.5))
+			}
+			for _, pt := range points {
+				d := separation(ri, glyphRect(pt, rad))
+				if d == (vg.Point{}) {
+					continue
+				}
+				moved = true
+				push[i] = push[i].Add(d.Scale(0.5))
+			}
+		}
+		f := pull * (1 - 2*vg.Length(it)/iterations)
+		if f < 0 {
+			f = 0
+			if !moved {
+				break
+			}
+		}
+		for i := range moves {
+			m := moves[i].Add(push[i]).Sub(moves[i].Scale(f))
+			moves[i] = clampMove(bounds, rects[i], m)
+		}
+	}
+	return moves
+}
+
+// separation returns a move of a that removes its overlap with
+// b, leaving a small margin between them, or the zero point if
+// they do not overlap. The move is the smallest that separates
+// the rectangles along either axis.
+func separation(a, b vg.Rectangle) vg.Point {
+	const margin = 0.5
+	if overlap(a, b) == 0 {
+		return vg.Point{}
+	}
+	left := b.Min.X - a.Max.X
+	right := b.Max.X - a.Min.X
+	down := b.Min.Y - a.Max.Y
+	up := b.Max.Y - a.Min.Y
+	dx := left
+	if -left > right {
+		dx = right
+	}
+	dy := down
+	if -down > up {
+		dy = up
+	}
+	if math.Abs(float64(dx)) < math.Abs(float64(dy)) {
+		if dx < 0 {
+			return vg.Point{X: dx - margin}
+		}
+		return vg.Point{X: dx + margin}
+	}
+	if dy < 0 {
+		return vg.Point{Y: dy - margin}
+	}
+	return vg.Point{Y: dy + margin}
+}
+
+// clampMove returns the move m of r, limited so that the moved
+// rectangle lies within bounds where possible.
+func clampMove(bounds, r vg.Rectangle, m vg.Point) vg.Point {
+	if d := bounds.Min.X - (r.Min.X + m.X); d > 0 {
+		m.X += d
+	} else if d := bounds.Max.X - (r.Max.X + m.X); d < 0 {
+		m.X += d
+	}
+	if d := bounds.Min.Y - (r.Min.Y + m.Y); d > 0 {
+		m.Y += d
+	} else if d := bounds.Max.Y - (r.Max.Y + m.Y); d < 0 {
+		m.Y += d
+	}
+	return m
+}
+
+// overlap returns the area of the intersection of a and b.
+func overlap(a, b vg.Rectangle) vg.Length {
+	w := vg.Length(math.Min(float64(a.Max.X), float64(b.Max.X)) - math.Max(float64(a.Min.X), float64(b.Min.X)))
+	h := vg.Length(math.Min(float64(a.Max.Y), float64(b.Max.Y)) - math.Max(float64(a.Min.Y), float64(b.Min.Y)))
+	if w <= 0 || h <= 0 {
+		return 0
+	}
+	return w * h
+}
+
+// glyphRect returns the square bounding a glyph of radius
+// rad at pt.
+func glyphRect(pt vg.Point, rad vg.Length) vg.Rectangle {
+	return vg.Rectangle{
+		Min: vg.Point{X: pt.X - rad, Y: pt.Y - rad},
+		Max: vg.Point{X: pt.X + rad, Y: pt.Y + rad},
 	}
 }
 
+// translate returns r moved by d.
+func translate(r vg.Rectangle, d vg.Point) vg.Rectangle {
+	return vg.Rectangle{Min: r.Min.Add(d), Max: r.Max.Add(d)}
+}
+
+// nearest returns the point of r nearest pt.
+func nearest(r vg.Rectangle, pt vg.Point) vg.Point {
+	return vg.Point{
+		X: vg.Length(math.Max(float64(r.Min.X), math.Min(float64(r.Max.X), float64(pt.X)))),
+		Y: vg.Length(math.Max(float64(r.Min.Y), math.Min(float64(r.Max.Y), float64(pt.Y)))),
+	}
+}
+
+// dist returns the distance between a and b.
+func dist(a, b vg.Point) vg.Length {
+	return vg.Length(math.Hypot(float64(a.X-b.X), float64(a.Y-b.Y)))
+}
+
 // DataRange returns the minimum and maximum X and Y values
 func (l *Labels) DataRange() (xmin, xmax, ymin, ymax float64) {
 	return XYRange(l)
@@ -0,0 +1,123 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+)
+
+// ExampleLabels_placement labels a dense cluster of points,
+// moving the labels so that they do not overlap one another
+// or the points, with leader lines to labels that have moved.
+func ExampleLabels_placement() {
+	rnd := rand.New(rand.NewSource(1))
+	var data XYLabels
+	for i := 0; i < 20; i++ {
+		data.XYs = append(data.XYs, struct{ X, Y float64 }{
+			X: rnd.NormFloat64(),
+			Y: rnd.NormFloat64(),
+		})
+		data.Labels = append(data.Labels, fmt.Sprintf("gene%d", i+1))
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Label placement"
+
+	s, err := NewScatter(data)
+	if err != nil {
+		log.Panic(err)
+	}
+	l, err := NewLabels(data)
+	if err != nil {
+		log.Panic(err)
+	}
+	s.GlyphStyle.Radius = vg.Points(3)
+	l.Placement = GreedyPlacement
+	l.GlyphRadius = s.GlyphStyle.Radius
+	p.Add(s, l)
+
+	err = p.Save(250, 250, "testdata/labelPlacement.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestLabelsPlacement(t *testing.T) {
+	cmpimg.CheckPlot(ExampleLabels_placement, t, "labelPlacement.png")
+}
+
+func TestPlaceLabels(t *testing.T) {
+	bounds := vg.Rectangle{Max: vg.Point{X: 200, Y: 200}}
+	const rad = 2
+
+	// Ten labels at nearly the same point.
+	var (
+		points []vg.Point
+		rects  []vg.Rectangle
+	)
+	for i := 0; i < 10; i++ {
+		pt := vg.Point{X: 100 + vg.Length(i), Y: 100}
+		points = append(points, pt)
+		rects = append(rects, vg.Rectangle{Min: pt, Max: pt.Add(vg.Point{X: 30, Y: 10})})
+	}
+
+	for _, test := range []struct {
+		name  string
+		place func(bounds vg.Rectangle, points []vg.Point, rects []vg.Rectangle, rad vg.Length) []vg.Point
+	}{
+		{name: "greedy", place: placeGreedy},
+		{name: "force", place: placeForce},
+	} {
+		moves := test.place(bounds, points, rects, rad)
+		for i := range rects {
+			ri := translate(rects[i], moves[i])
+			if a := overlap(ri, bounds); a < 30*10-1e-9 {
+				t.Errorf("%s placed label %d outside bounds: %+v", test.name, i, ri)
+			}
+			for j := i + 1; j < len(rects); j++ {
+				if a := overlap(ri, translate(rects[j], moves[j])); a != 0 {
+					t.Errorf("%s placed labels %d and %d overlapping by %v", test.name, i, j, a)
+				}
+			}
+			if test.name != "greedy" {
+				continue
+			}
+			for _, pt := range points {
+				if a := overlap(ri, glyphRect(pt, rad)); a != 0 {
+					t.Errorf("%s placed label %d over glyph at %v", test.name, i, pt)
+				}
+			}
+		}
+	}
+}
+
+func TestLabelsValidate(t *testing.T) {
+	l, err := NewLabels(XYLabels{XYs: XYs{{X: 0, Y: 0}, {X: 1, Y: 1}}, Labels: []string{"a", "b"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := l.Validate(); err != nil {
+		t.Errorf("unexpected error for valid labels: %v", err)
+	}
+	l.Labels = append(l.Labels, "c")
+	if err := l.Validate(); err == nil {
+		t.Error("expected error for labels without points")
+	}
+	l.XYs = append(l.XYs, l.XYs[0])
+	if err := l.Validate(); err == nil {
+		t.Error("expected error for labels without text styles")
+	}
+}
@@ -6,6 +6,7 @@ package plotter
 
 import (
 	"image/color"
+	"sync"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/vg"
@@ -23,10 +24,19 @@ type Line struct {
 
 	// ShadeColor is the color of the shaded area.
 	ShadeColor *color.Color
+
+	// Downsampler is used to reduce the number of
+	// points drawn when the number of points greatly
+	// exceeds the width of the canvas in dots at its
+	// resolution. If Downsampler is nil, DefaultDownsampler
+	// is used. Setting Downsampler to NoDownsampling draws
+	// all of the points.
+	Downsampler Downsampler
 }
 
 // NewLine returns a Line that uses the default line style and
-// does not draw glyphs.
+// does not draw glyphs. The points of xys are copied into the
+// XYs of the Line.
 func NewLine(xys XYer) (*Line, error) {
 	data, err := CopyXYs(xys)
 	if err != nil {
@@ -40,29 +50,154 @@ func NewLine(xys XYer) (*Line, error) {
 
 // Plot draws the Line, implementing the plot.Plotter
 // interface.
+//
+// Segments of the line lying entirely outside the
+// axis ranges are culled before the line is
+// transformed to the canvas, and the shaded area is
+// clipped to the data area.
 func (pts *Line) Plot(c draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&c)
-	ps := make([]vg.Point, len(pts.XYs))
+	width := widthDots(c)
 
-	for i, p := range pts.XYs {
-		ps[i].X = trX(p.X)
-		ps[i].Y = trY(p.Y)
-	}
+	buf := lineBufferPool.Get().(*lineBuffers)
+	defer lineBufferPool.Put(buf)
 
-	if pts.ShadeColor != nil && len(ps) > 0 {
-		c.SetColor(*pts.ShadeColor)
+	if pts.ShadeColor != nil {
+		// Shade each run of finite points separately so
+		// that the shaded area is broken where the line is.
 		minY := trY(plt.Y.Min)
-		var pa vg.Path
-		pa.Move(vg.Point{X: ps[0].X, Y: minY})
-		for i := range pts.XYs {
-			pa.Line(ps[i])
+		c.SetColor(*pts.ShadeColor)
+		xys := pts.XYs
+		for len(xys) > 0 {
+			n := 0
+			for n < len(xys) && isFinite(xys[n].X, xys[n].Y) {
+				n++
+			}
+			if n > 1 {
+				pts.shade(&c, buf, xys[:n], minY, trX, trY, width)
+			}
+			for n < len(xys) && !isFinite(xys[n].X, xys[n].Y) {
+				n++
+			}
+			xys = xys[n:]
+		}
+	}
+
+	buf.runs = cullXYs(buf.runs[:0], pts.XYs, plt)
+	ps := buf.pts[:0]
+	lines := buf.lines[:0]
+	for _, r := range buf.runs {
+		n := len(ps)
+		ps = pts.appendPoints(ps, pts.XYs[r[0]:r[1]], trX, trY, width)
+		lines = append(lines, ps[n:len(ps):len(ps)])
+	}
+	buf.pts = ps
+	buf.lines = lines
+	if len(lines) == 0 {
+		return
+	}
+	if !containsAll(&c, ps) {
+		lines = c.ClipLinesXY(lines...)
+	}
+	c.SetLineStyle(pts.LineStyle)
+	for _, l := range lines {
+		if len(l) == 0 {
+			continue
 		}
-		pa.Line(vg.Point{X: ps[len(pts.XYs)-1].X, Y: minY})
-		pa.Close()
-		c.Fill(pa)
+		buf.path = appendPath(buf.path[:0], l)
+		c.Stroke(buf.path)
 	}
+}
 
-	c.StrokeLines(pts.LineStyle, c.ClipLinesXY(ps)...)
+// shade fills the area between the finite points of xys
+// and the canvas y coordinate minY with the current color.
+func (pts *Line) shade(c *draw.Canvas, buf *lineBuffers, xys XYs, minY vg.Length, trX, trY func(float64) vg.Length, width float64) {
+	poly := append(buf.poly[:0], vg.Point{})
+	poly = pts.appendPoints(poly, xys, trX, trY, width)
+	buf.poly = poly
+	if len(poly) < 3 {
+		return
+	}
+	poly[0] = vg.Point{X: poly[1].X, Y: minY}
+	poly = append(poly, vg.Point{X: poly[len(poly)-1].X, Y: minY})
+	buf.poly = poly
+	if !containsAll(c, poly) {
+		poly = c.ClipPolygonXY(poly)
+	}
+	if len(poly) == 0 {
+		return
+	}
+	buf.path = appendPath(buf.path[:0], poly)
+	buf.path.Close()
+	c.Fill(buf.path)
+}
+
+// lineBuffers holds storage reused between calls
+// to Line.Plot and StreamLine.Plot.
+type lineBuffers struct {
+	pts   []vg.Point
+	poly  []vg.Point
+	lines [][]vg.Point
+	runs  [][2]int
+	path  vg.Path
+}
+
+// lineBufferPool holds the lineBuffers of calls to
+// Line.Plot and StreamLine.Plot, so that a line may be
+// drawn concurrently.
+var lineBufferPool = sync.Pool{
+	New: func() interface{} { return new(lineBuffers) },
+}
+
+// appendPath appends a path joining the points in
+// pts to p and returns the extended path.
+func appendPath(p vg.Path, pts []vg.Point) vg.Path {
+	p.Move(pts[0])
+	for _, pt := range pts[1:] {
+		p.Line(pt)
+	}
+	return p
+}
+
+// downsampler returns the Downsampler used to draw the Line.
+func (pts *Line) downsampler() Downsampler {
+	if pts.Downsampler == nil {
+		return DefaultDownsampler
+	}
+	return pts.Downsampler
+}
+
+// appendPoints appends the canvas coordinates of the finite
+// points of xys, downsampled by the Line's Downsampler for a
+// canvas of the given width in dots, to dst and returns the extended
+// slice.
+func (pts *Line) appendPoints(dst []vg.Point, xys XYs, trX, trY func(float64) vg.Length, width float64) []vg.Point {
+	n := len(dst)
+	for _, p := range xys {
+		if !isFinite(p.X, p.Y) {
+			continue
+		}
+		dst = append(dst, vg.Point{X: trX(p.X), Y: trY(p.Y)})
+	}
+	ps := dst[n:]
+	if idx := downsample(pts.downsampler(), ps, width); idx != nil {
+		for i, j := range idx {
+			ps[i] = ps[j]
+		}
+		dst = dst[:n+len(idx)]
+	}
+	return dst
+}
+
+// containsAll returns whether all of the points
+// are within the canvas.
+func containsAll(c *draw.Canvas, pts []vg.Point) bool {
+	for _, p := range pts {
+		if !c.Contains(p) {
+			return false
+		}
+	}
+	return true
 }
 
 // DataRange returns the minimum and maximum
@@ -0,0 +1,303 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Lollipop implements the Plotter interface, drawing a
+// stem from a baseline to each of a set of values with a
+// glyph at the value, at consecutive category locations.
+type Lollipop struct {
+	Values
+
+	// Baseline is the value from which the stems
+	// are drawn.
+	Baseline float64
+
+	// Stem is the style of the stems.
+	Stem draw.LineStyle
+
+	// GlyphStyle is the style of the glyphs drawn
+	// at the values.
+	draw.GlyphStyle
+
+	// Offset is added to the X location of each
+	// lollipop.
+	Offset vg.Length
+
+	// XMin is the X location of the first lollipop.
+	XMin float64
+
+	// Horizontal dictates whether the stems are drawn
+	// in the vertical (default) or horizontal direction.
+	// If Horizontal is true, all X locations and
+	// distances referred to here will actually be Y
+	// locations and distances.
+	Horizontal bool
+}
+
+// NewLollipop returns a Lollipop with a stem from zero to each
+// value, using the default line and glyph styles. The category
+// location of each value is its index.
+func NewLollipop(vs Valuer) (*Lollipop, error) {
+	values, err := CopyValues(vs)
+	if err != nil {
+		return nil, err
+	}
+	return &Lollipop{
+		Values:     values,
+		Stem:       DefaultLineStyle,
+		GlyphStyle: DefaultGlyphStyle,
+	}, nil
+}
+
+// Plot implements the plot.Plotter interface.
+func (l *Lollipop) Plot(c draw.Canvas, plt *plot.Plot) {
+	cc := newCategoryCanvas(plt, &c, l.Horizontal)
+	for i, v := range l.Values {
+		cat, ok := cc.cat(l.XMin+float64(i), l.Offset)
+		if !ok {
+			continue
+		}
+		base, tip := cc.point(cat, l.Baseline), cc.point(cat, v)
+		c.StrokeLines(l.Stem, cc.clip(base, tip)...)
+		if cc.contains(tip) {
+			c.DrawGlyphNoClip(l.GlyphStyle, tip)
+		}
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (l *Lollipop) DataRange() (xmin, xmax, ymin, ymax float64) {
+	catMin := l.XMin
+	catMax := catMin + float64(len(l.Values)-1)
+	valMin, valMax := l.Baseline, l.Baseline
+	for _, v := range l.Values {
+		valMin = math.Min(valMin, v)
+		valMax = math.Max(valMax, v)
+	}
+	if !l.Horizontal {
+		return catMin, catMax, valMin, valMax
+	}
+	return valMin, valMax, catMin, catMax
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface.
+func (l *Lollipop) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	boxes := make([]plot.GlyphBox, len(l.Values))
+	for i, v := range l.Values {
+		boxes[i] = categoryGlyphBox(plt, l.Horizontal, l.Offset, l.XMin+float64(i), v, l.GlyphStyle)
+	}
+	return boxes
+}
+
+// Thumbnail draws a stem rising to a glyph,
+// implementing the plot.Thumbnailer interface.
+func (l *Lollipop) Thumbnail(c *draw.Canvas) {
+	x := c.Center().X
+	c.StrokeLine2(l.Stem, x, c.Min.Y, x, c.Center().Y)
+	c.DrawGlyph(l.GlyphStyle, c.Center())
+}
+
+// Dumbbell implements the Plotter interface, drawing a
+// pair of glyphs connected by a bar for each pair of a
+// set of low and high values, at consecutive category
+// locations.
+type Dumbbell struct {
+	// Low and High are copies of the values
+	// at the ends of the dumbbells.
+	Low, High Values
+
+	// Bar is the style of the bars connecting
+	// the ends.
+	Bar draw.LineStyle
+
+	// LowGlyph and HighGlyph are the styles of
+	// the glyphs drawn at the Low and High values.
+	LowGlyph, HighGlyph draw.GlyphStyle
+
+	// Offset is added to the X location of each
+	// dumbbell.
+	Offset vg.Length
+
+	// XMin is the X location of the first dumbbell.
+	XMin float64
+
+	// Horizontal dictates whether the bars are drawn
+	// in the vertical (default) or horizontal direction.
+	// If Horizontal is true, all X locations and
+	// distances referred to here will actually be Y
+	// locations and distances.
+	Horizontal bool
+}
+
+// NewDumbbell returns a Dumbbell connecting each value in low
+// to the corresponding value in high, using the default line and
+// glyph styles. The category location of each pair is its index.
+func NewDumbbell(low, high Valuer) (*Dumbbell, error) {
+	if low.Len() != high.Len() {
+		return nil, errors.New("plotter: dumbbell low and high lengths mismatch")
+	}
+	lo, err := CopyValues(low)
+	if err != nil {
+		return nil, err
+	}
+	hi, err := CopyValues(high)
+	if err != nil {
+		return nil, err
+	}
+	return &Dumbbell{
+		Low:       lo,
+		High:      hi,
+		Bar:       DefaultLineStyle,
+		LowGlyph:  DefaultGlyphStyle,
+		HighGlyph: DefaultGlyphStyle,
+	}, nil
+}
+
+// Plot implements the plot.Plotter interface.
+func (d *Dumbbell) Plot(c draw.Canvas, plt *plot.Plot) {
+	cc := newCategoryCanvas(plt, &c, d.Horizontal)
+	for i, lo := range d.Low {
+		cat, ok := cc.cat(d.XMin+float64(i), d.Offset)
+		if !ok {
+			continue
+		}
+		a, b := cc.point(cat, lo), cc.point(cat, d.High[i])
+		c.StrokeLines(d.Bar, cc.clip(a, b)...)
+		if cc.contains(a) {
+			c.DrawGlyphNoClip(d.LowGlyph, a)
+		}
+		if cc.contains(b) {
+			c.DrawGlyphNoClip(d.HighGlyph, b)
+		}
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (d *Dumbbell) DataRange() (xmin, xmax, ymin, ymax float64) {
+	catMin := d.XMin
+	catMax := catMin + float64(len(d.Low)-1)
+	valMin, valMax := math.Inf(1), math.Inf(-1)
+	for i, lo := range d.Low {
+		hi := d.High[i]
+		valMin = math.Min(valMin, math.Min(lo, hi))
+		valMax = math.Max(valMax, math.Max(lo, hi))
+	}
+	if !d.Horizontal {
+		return catMin, catMax, valMin, valMax
+	}
+	return valMin, valMax, catMin, catMax
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface.
+func (d *Dumbbell) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	boxes := make([]plot.GlyphBox, 0, 2*len(d.Low))
+	for i, lo := range d.Low {
+		cat := d.XMin + float64(i)
+		boxes = append(boxes,
+			categoryGlyphBox(plt, d.Horizontal, d.Offset, cat, lo, d.LowGlyph),
+			categoryGlyphBox(plt, d.Horizontal, d.Offset, cat, d.High[i], d.HighGlyph),
+		)
+	}
+	return boxes
+}
+
+// Thumbnail draws a bar across the thumbnail with
+// the glyphs at its ends, implementing the
+// plot.Thumbnailer interface.
+func (d *Dumbbell) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	lo := c.Min.X + d.LowGlyph.Radius
+	hi := c.Max.X - d.HighGlyph.Radius
+	c.StrokeLine2(d.Bar, lo, y, hi, y)
+	c.DrawGlyph(d.LowGlyph, vg.Point{X: lo, Y: y})
+	c.DrawGlyph(d.HighGlyph, vg.Point{X: hi, Y: y})
+}
+
+// categoryCanvas transforms the category locations and
+// values of a categorical plotter to a canvas.
+type categoryCanvas struct {
+	c            *draw.Canvas
+	trCat, trVal func(float64) vg.Length
+	horizontal   bool
+}
+
+// newCategoryCanvas returns a categoryCanvas for the canvas c
+// of the plot plt, with the categories along the X axis, or
+// the Y axis if horizontal is true.
+func newCategoryCanvas(plt *plot.Plot, c *draw.Canvas, horizontal bool) categoryCanvas {
+	trCat, trVal := plt.Transforms(c)
+	if horizontal {
+		trCat, trVal = trVal, trCat
+	}
+	return categoryCanvas{c: c, trCat: trCat, trVal: trVal, horizontal: horizontal}
+}
+
+// cat returns the canvas position of the category location
+// v, with the offset added, and whether the location is
+// within the canvas. As for the bars of a BarChart, the
+// offset may place a category outside the canvas.
+func (cc categoryCanvas) cat(v float64, offset vg.Length) (vg.Length, bool) {
+	pos := cc.trCat(v)
+	if cc.horizontal {
+		return pos + offset, cc.c.ContainsY(pos)
+	}
+	return pos + offset, cc.c.ContainsX(pos)
+}
+
+// point returns the point at the canvas category position
+// cat and the value v.
+func (cc categoryCanvas) point(cat vg.Length, v float64) vg.Point {
+	if cc.horizontal {
+		return vg.Point{X: cc.trVal(v), Y: cat}
+	}
+	return vg.Point{X: cat, Y: cc.trVal(v)}
+}
+
+// contains returns whether the value of the point pt
+// is within the canvas.
+func (cc categoryCanvas) contains(pt vg.Point) bool {
+	if cc.horizontal {
+		return cc.c.ContainsX(pt.X)
+	}
+	return cc.c.ContainsY(pt.Y)
+}
+
+// clip returns the line from a to b clipped to the
+// canvas along the value axis.
+func (cc categoryCanvas) clip(a, b vg.Point) [][]vg.Point {
+	if cc.horizontal {
+		return cc.c.ClipLinesX([]vg.Point{a, b})
+	}
+	return cc.c.ClipLinesY([]vg.Point{a, b})
+}
+
+// categoryGlyphBox returns the glyph box of a glyph drawn at the
+// category location cat and value v of a categorical plotter.
+func categoryGlyphBox(plt *plot.Plot, horizontal bool, offset vg.Length, cat, v float64, sty draw.GlyphStyle) plot.GlyphBox {
+	r := sty.Rectangle()
+	if horizontal {
+		off := vg.Point{Y: offset}
+		return plot.GlyphBox{
+			X:         plt.X.Norm(v),
+			Y:         plt.Y.Norm(cat),
+			Rectangle: vg.Rectangle{Min: r.Min.Add(off), Max: r.Max.Add(off)},
+		}
+	}
+	off := vg.Point{X: offset}
+	return plot.GlyphBox{
+		X:         plt.X.Norm(cat),
+		Y:         plt.Y.Norm(v),
+		Rectangle: vg.Rectangle{Min: r.Min.Add(off), Max: r.Max.Add(off)},
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ExampleLollipop draws the monthly change of a value as
+// stems from zero, with two years side by side.
+func ExampleLollipop() {
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Monthly change"
+	p.Legend.Top = true
+	p.Y.Max = 9
+	p.NominalX("Jan", "Feb", "Mar", "Apr", "May", "Jun")
+	p.Add(NewGrid())
+
+	years := []struct {
+		name   string
+		values Values
+		color  color.Color
+		offset vg.Length
+	}{
+		{name: "2017", values: Values{3, -2, 5, 1, -4, 2}, color: color.RGBA{B: 200, A: 255}, offset: -vg.Points(4)},
+		{name: "2018", values: Values{4, 1, -1, 3, -2, 6}, color: color.RGBA{R: 200, A: 255}, offset: vg.Points(4)},
+	}
+	for _, y := range years {
+		l, err := NewLollipop(y.values)
+		if err != nil {
+			log.Panic(err)
+		}
+		l.Stem = draw.LineStyle{Color: y.color, Width: vg.Points(1)}
+		l.GlyphStyle = draw.GlyphStyle{Color: y.color, Radius: vg.Points(3), Shape: draw.CircleGlyph{}}
+		l.Offset = y.offset
+		p.Add(l)
+		p.Legend.Add(y.name, l)
+	}
+
+	err = p.Save(250, 200, "testdata/lollipop.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestLollipopPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleLollipop, t, "lollipop.png")
+}
+
+// ExampleDumbbell draws horizontal dumbbells connecting
+// the values of categories before and after a change.
+func ExampleDumbbell() {
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Before and after"
+	p.NominalY("A", "B", "C", "D")
+	p.X.Min = 0
+
+	d, err := NewDumbbell(Values{20, 35, 42, 15}, Values{32, 30, 55, 28})
+	if err != nil {
+		log.Panic(err)
+	}
+	d.Horizontal = true
+	d.Bar = draw.LineStyle{Color: color.Gray{Y: 160}, Width: vg.Points(3)}
+	d.LowGlyph = draw.GlyphStyle{Color: color.RGBA{B: 200, A: 255}, Radius: vg.Points(4), Shape: draw.CircleGlyph{}}
+	d.HighGlyph = draw.GlyphStyle{Color: color.RGBA{R: 200, A: 255}, Radius: vg.Points(4), Shape: draw.CircleGlyph{}}
+	p.Add(d)
+	p.Legend.Add("before", plot.GlyphThumbnail(d.LowGlyph))
+	p.Legend.Add("after", plot.GlyphThumbnail(d.HighGlyph))
+	p.Legend.Add("change", d)
+
+	err = p.Save(250, 200, "testdata/dumbbell.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestDumbbellPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleDumbbell, t, "dumbbell.png")
+}
+
+func TestLollipopDumbbell(t *testing.T) {
+	l, err := NewLollipop(Values{2, -1, 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l.XMin = 1
+	l.Baseline = 1
+	xmin, xmax, ymin, ymax := l.DataRange()
+	if xmin != 1 || xmax != 3 || ymin != -1 || ymax != 4 {
+		t.Errorf("unexpected lollipop data range: got:%v %v %v %v want:1 3 -1 4", xmin, xmax, ymin, ymax)
+	}
+	l.Horizontal = true
+	xmin, xmax, ymin, ymax = l.DataRange()
+	if xmin != -1 || xmax != 4 || ymin != 1 || ymax != 3 {
+		t.Errorf("unexpected horizontal lollipop data range: got:%v %v %v %v want:-1 4 1 3", xmin, xmax, ymin, ymax)
+	}
+
+	if _, err := NewDumbbell(Values{1, 2}, Values{3}); err == nil {
+		t.Errorf("expected error for mismatched lengths")
+	}
+	d, err := NewDumbbell(Values{1, 5}, Values{3, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xmin, xmax, ymin, ymax = d.DataRange()
+	if xmin != 0 || xmax != 1 || ymin != 1 || ymax != 5 {
+		t.Errorf("unexpected dumbbell data range: got:%v %v %v %v want:0 1 1 5", xmin, xmax, ymin, ymax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(d)
+	boxes := d.GlyphBoxes(p)
+	if len(boxes) != 4 {
+		t.Fatalf("unexpected number of glyph boxes: got:%d want:4", len(boxes))
+	}
+	if boxes[1].X != p.X.Norm(0) || boxes[1].Y != p.Y.Norm(3) {
+		t.Errorf("unexpected glyph box position: got:(%v, %v) want:(%v, %v)", boxes[1].X, boxes[1].Y, p.X.Norm(0), p.Y.Norm(3))
+	}
+}
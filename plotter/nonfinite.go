@@ -0,0 +1,72 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+// NonFinite is a policy for the handling of NaN and
+// infinite values in the data passed to the plotter
+// constructors.
+type NonFinite int
+
+const (
+	// NonFiniteError rejects data holding non-finite
+	// values, with ErrNaN or ErrInfinity. It is the
+	// policy of data that does not specify one.
+	NonFiniteError NonFinite = iota
+
+	// NonFiniteSkip drops the points holding non-finite
+	// values, so that a Line joins the points either
+	// side of them.
+	NonFiniteSkip
+
+	// NonFiniteBreak keeps the points holding non-finite
+	// values in the data copied by CopyXYs, and a Line
+	// is broken into separate segments at them. Other
+	// plotters, such as Scatter, draw nothing for the
+	// points. Data that is not copied by CopyXYs, such as
+	// the values of a Histogram, drop the points as for
+	// NonFiniteSkip.
+	NonFiniteBreak
+)
+
+// NonFiniteXYs is an XYer whose non-finite values are
+// handled by the plotter constructors according to
+// Policy.
+type NonFiniteXYs struct {
+	XYer
+	Policy NonFinite
+}
+
+// NonFinitePolicy returns the policy of the data.
+func (d NonFiniteXYs) NonFinitePolicy() NonFinite { return d.Policy }
+
+// NonFiniteValues is a Valuer whose non-finite values
+// are handled by the plotter constructors according to
+// Policy.
+type NonFiniteValues struct {
+	Valuer
+	Policy NonFinite
+}
+
+// NonFinitePolicy returns the policy of the data.
+func (d NonFiniteValues) NonFinitePolicy() NonFinite { return d.Policy }
+
+// policyOf returns the non-finite policy of data.
+func policyOf(data interface{}) NonFinite {
+	if p, ok := data.(interface{ NonFinitePolicy() NonFinite }); ok {
+		return p.NonFinitePolicy()
+	}
+	return NonFiniteError
+}
+
+// finiteXYs returns the finite points of data. If data
+// holds non-finite values, an error is returned unless
+// the policy of data is NonFiniteSkip or NonFiniteBreak.
+func finiteXYs(data XYer) (XYer, error) {
+	policy := policyOf(data)
+	if policy == NonFiniteBreak {
+		policy = NonFiniteSkip
+	}
+	return CopyXYs(NonFiniteXYs{XYer: data, Policy: policy})
+}
@@ -0,0 +1,193 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+// ExampleNonFiniteXYs draws a sine wave with gaps where
+// the data is missing, broken into segments at the NaN
+// values, and the same data with the missing points
+// skipped, joining the points either side of the gaps.
+func ExampleNonFiniteXYs() {
+	data := make(XYs, 60)
+	for i := range data {
+		data[i].X = float64(i) / 6
+		data[i].Y = math.Sin(data[i].X)
+		if (i >= 15 && i < 20) || i == 40 {
+			data[i].Y = math.NaN()
+		}
+	}
+	skipped := make(XYs, len(data))
+	for i, d := range data {
+		skipped[i].X, skipped[i].Y = d.X, d.Y-1.5
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Non-finite values"
+
+	broken, err := NewLine(NonFiniteXYs{XYer: data, Policy: NonFiniteBreak})
+	if err != nil {
+		log.Panic(err)
+	}
+	broken.Width = vg.Points(1.5)
+	broken.Color = color.RGBA{B: 255, A: 255}
+	joined, err := NewLine(NonFiniteXYs{XYer: skipped, Policy: NonFiniteSkip})
+	if err != nil {
+		log.Panic(err)
+	}
+	joined.Width = vg.Points(1.5)
+	joined.Color = color.RGBA{R: 255, A: 255}
+	points, err := NewScatter(NonFiniteXYs{XYer: data, Policy: NonFiniteBreak})
+	if err != nil {
+		log.Panic(err)
+	}
+	points.Radius = vg.Points(2)
+	p.Add(broken, joined, points)
+	p.Legend.Add("break", broken)
+	p.Legend.Add("skip", joined)
+
+	err = p.Save(200, 200, "testdata/nonFinite.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestNonFinite(t *testing.T) {
+	cmpimg.CheckPlot(ExampleNonFiniteXYs, t, "nonFinite.png")
+
+	data := XYs{{X: 0, Y: 0}, {X: 1, Y: math.NaN()}, {X: 2, Y: 2}, {X: math.Inf(1), Y: 3}, {X: 4, Y: 4}}
+	if _, err := CopyXYs(data); err != ErrNaN {
+		t.Errorf("unexpected error for default policy: got:%v want:%v", err, ErrNaN)
+	}
+	skipped, err := CopyXYs(NonFiniteXYs{XYer: data, Policy: NonFiniteSkip})
+	if err != nil || len(skipped) != 3 {
+		t.Errorf("unexpected skipped copy: got:%v err:%v", skipped, err)
+	}
+	kept, err := CopyXYs(NonFiniteXYs{XYer: data, Policy: NonFiniteBreak})
+	if err != nil || len(kept) != len(data) {
+		t.Errorf("unexpected broken copy: got:%v err:%v", kept, err)
+	}
+	vals, err := CopyValues(NonFiniteValues{Valuer: Values{1, math.NaN(), 3}, Policy: NonFiniteBreak})
+	if err != nil || len(vals) != 2 {
+		t.Errorf("unexpected values copy: got:%v err:%v", vals, err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gappy := XYs{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: math.NaN()}, {X: 3, Y: 3}, {X: 4, Y: 4}}
+	l, err := NewLine(NonFiniteXYs{XYer: gappy, Policy: NonFiniteBreak})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l)
+	xmin, xmax, ymin, ymax := l.DataRange()
+	if xmin != 0 || xmax != 4 || ymin != 0 || ymax != 4 {
+		t.Errorf("unexpected data range: got:[%v, %v]x[%v, %v] want:[0, 4]x[0, 4]", xmin, xmax, ymin, ymax)
+	}
+	var rec recorder.Canvas
+	l.Plot(draw.NewCanvas(&rec, 100, 100), p)
+	var strokes int
+	for _, a := range rec.Actions {
+		if _, ok := a.(*recorder.Stroke); ok {
+			strokes++
+		}
+	}
+	if strokes != 2 {
+		t.Errorf("unexpected number of line segments: got:%d want:2", strokes)
+	}
+
+	// The shaded area is broken at non-finite points,
+	// and runs of fewer than two points are not shaded.
+	var fill color.Color = color.RGBA{B: 255, A: 255}
+	for _, test := range []struct {
+		xys  XYs
+		want int
+	}{
+		{xys: gappy, want: 2},
+		{xys: XYs{{X: 0, Y: 0}, {X: 1, Y: math.NaN()}, {X: 2, Y: 2}, {X: 3, Y: math.NaN()}}, want: 0},
+		{xys: XYs{{X: 0, Y: math.NaN()}, {X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: math.NaN()}}, want: 1},
+	} {
+		l, err := NewLine(NonFiniteXYs{XYer: test.xys, Policy: NonFiniteBreak})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		l.ShadeColor = &fill
+		rec = recorder.Canvas{}
+		l.Plot(draw.NewCanvas(&rec, 100, 100), p)
+		var fills int
+		for _, a := range rec.Actions {
+			if _, ok := a.(*recorder.Fill); ok {
+				fills++
+			}
+		}
+		if fills != test.want {
+			t.Errorf("unexpected number of shaded areas for %v: got:%d want:%d", test.xys, fills, test.want)
+		}
+	}
+
+	s, err := NewScatter(NonFiniteXYs{XYer: data, Policy: NonFiniteBreak})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := len(s.GlyphBoxes(p)); got != 3 {
+		t.Errorf("unexpected number of glyph boxes: got:%d want:3", got)
+	}
+
+	if _, err := NewHist(Values{1, math.NaN(), 2}, 2); err != ErrNaN {
+		t.Errorf("unexpected histogram error: got:%v want:%v", err, ErrNaN)
+	}
+	h, err := NewHist(NonFiniteValues{Valuer: Values{1, math.NaN(), 2}, Policy: NonFiniteSkip}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var total float64
+	for _, b := range h.Bins {
+		total += b.Weight
+	}
+	if total != 2 {
+		t.Errorf("unexpected histogram total: got:%v want:2", total)
+	}
+}
+
+func TestNonFiniteNoData(t *testing.T) {
+	nan := math.NaN()
+	for _, test := range []struct {
+		policy NonFinite
+		want   error
+	}{
+		{policy: NonFiniteError, want: ErrNaN},
+		{policy: NonFiniteSkip, want: ErrNoData},
+		{policy: NonFiniteBreak, want: ErrNoData},
+	} {
+		xys := NonFiniteXYs{XYer: XYs{{X: nan, Y: 1}, {X: 2, Y: nan}}, Policy: test.policy}
+		vals := NonFiniteValues{Valuer: Values{nan, nan}, Policy: test.policy}
+
+		if _, err := NewHist(vals, 2); err != test.want {
+			t.Errorf("unexpected histogram error for policy %d: got:%v want:%v", test.policy, err, test.want)
+		}
+		if _, err := NewScatter(xys); err != test.want {
+			t.Errorf("unexpected scatter error for policy %d: got:%v want:%v", test.policy, err, test.want)
+		}
+		if _, err := NewLine(xys); err != test.want {
+			t.Errorf("unexpected line error for policy %d: got:%v want:%v", test.policy, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !race
+
+package plotter
+
+// raceEnabled is whether the race detector is on.
+const raceEnabled = false
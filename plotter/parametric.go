@@ -0,0 +1,158 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Parametric implements the Plotter interface, drawing
+// the curve traced by the point (X(t), Y(t)) as t
+// increases from TMin to TMax.
+//
+// The curve is sampled adaptively, refining the sampling
+// where the curve bends sharply on the canvas and breaking
+// the line where X or Y is not finite or jumps
+// discontinuously.
+type Parametric struct {
+	X, Y func(t float64) float64
+
+	// TMin and TMax specify the range of t values
+	// to pass to X and Y.
+	TMin, TMax float64
+
+	// Samples is the number of uniform samples of t
+	// refined by the adaptive sampling.
+	Samples int
+
+	// Arrows is the number of arrows drawn along the
+	// curve, at evenly spaced values of t, pointing in
+	// the direction of increasing t.
+	Arrows int
+
+	// ArrowSize is the length of the arrows.
+	ArrowSize vg.Length
+
+	draw.LineStyle
+}
+
+// NewParametric returns a Parametric that plots the curve
+// (x(t), y(t)) for t in [tmin, tmax] using the default
+// line style with 50 samples and no arrows.
+func NewParametric(x, y func(t float64) float64, tmin, tmax float64) *Parametric {
+	return &Parametric{
+		X:         x,
+		Y:         y,
+		TMin:      tmin,
+		TMax:      tmax,
+		Samples:   50,
+		ArrowSize: vg.Points(6),
+		LineStyle: DefaultLineStyle,
+	}
+}
+
+// Plot implements the Plotter interface, drawing the
+// curve and its direction arrows.
+func (f *Parametric) Plot(c draw.Canvas, p *plot.Plot) {
+	trX, trY := p.Transforms(&c)
+	point := func(t float64) (vg.Point, bool) {
+		x, y := f.X(t), f.Y(t)
+		return vg.Point{X: trX(x), Y: trY(y)}, isFinite(x, y)
+	}
+	size := c.Max.Sub(c.Min)
+	s := sampler{
+		point:     point,
+		deviation: midpointDistance,
+		jump:      vg.Length(math.Max(float64(size.X), float64(size.Y))) / 8,
+	}
+	c.StrokeLines(f.LineStyle, c.ClipLinesXY(s.sample(f.TMin, f.TMax, f.Samples)...)...)
+
+	if f.Arrows < 1 || f.LineStyle.Color == nil {
+		return
+	}
+	c.SetColor(f.LineStyle.Color)
+	dt := (f.TMax - f.TMin) / float64(f.Arrows)
+	for i := 0; i < f.Arrows; i++ {
+		// Centre the arrows in equal intervals of t so
+		// that none is drawn at an end of the curve.
+		t := f.TMin + (float64(i)+0.5)*dt
+		pt, ok := point(t)
+		if !ok || !c.Contains(pt) {
+			continue
+		}
+		dir, ok := f.tangent(point, t, dt)
+		if !ok {
+			continue
+		}
+		c.Fill(arrowHead(pt, dir, f.ArrowSize))
+	}
+}
+
+// tangent returns the unit direction on the canvas of the
+// curve at t, estimated by central differences over a
+// small fraction of the interval dt.
+func (f *Parametric) tangent(point func(float64) (vg.Point, bool), t, dt float64) (vg.Point, bool) {
+	h := dt * 1e-3
+	a, okA := point(t - h)
+	b, okB := point(t + h)
+	if !okA || !okB {
+		return vg.Point{}, false
+	}
+	d := distance(a, b)
+	if d == 0 {
+		return vg.Point{}, false
+	}
+	return b.Sub(a).Scale(1 / d), true
+}
+
+// arrowHead returns a closed triangular path of length size
+// centred on p and pointing in the unit direction dir.
+func arrowHead(p, dir vg.Point, size vg.Length) vg.Path {
+	norm := vg.Point{X: -dir.Y, Y: dir.X}
+	tip := p.Add(dir.Scale(size / 2))
+	base := p.Sub(dir.Scale(size / 2))
+	var pa vg.Path
+	pa.Move(tip)
+	pa.Line(base.Add(norm.Scale(size / 3)))
+	pa.Line(base.Sub(norm.Scale(size / 3)))
+	pa.Close()
+	return pa
+}
+
+// DataRange returns the minimum and maximum x and y values
+// of the curve at Samples uniform values of t, implementing
+// the plot.DataRanger interface.
+func (f *Parametric) DataRange() (xmin, xmax, ymin, ymax float64) {
+	n := f.Samples
+	if n < 2 {
+		n = 2
+	}
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	for i := 0; i < n; i++ {
+		t := f.TMin + (f.TMax-f.TMin)*float64(i)/float64(n-1)
+		x, y := f.X(t), f.Y(t)
+		if !isFinite(x, y) {
+			continue
+		}
+		xmin = math.Min(xmin, x)
+		xmax = math.Max(xmax, x)
+		ymin = math.Min(ymin, y)
+		ymax = math.Max(ymax, y)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// Thumbnail draws a line in the given style down the
+// center of a DrawArea as a thumbnail representation
+// of the LineStyle of the curve.
+func (f *Parametric) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(f.LineStyle, c.Min.X, y, c.Max.X, y)
+}
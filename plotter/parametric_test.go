@@ -0,0 +1,99 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+)
+
+// ExampleParametric draws a Lissajous figure and a
+// spiral with arrows showing the direction of the
+// curve.
+func ExampleParametric() {
+	lissajous := NewParametric(
+		func(t float64) float64 { return math.Sin(3 * t) },
+		func(t float64) float64 { return math.Sin(4 * t) },
+		0, 2*math.Pi,
+	)
+	lissajous.Color = color.RGBA{B: 255, A: 255}
+
+	spiral := NewParametric(
+		func(t float64) float64 { return 0.1 * t * math.Cos(t) },
+		func(t float64) float64 { return 0.1 * t * math.Sin(t) },
+		0, 4*math.Pi,
+	)
+	spiral.Arrows = 8
+	spiral.Color = color.RGBA{R: 196, A: 255}
+	spiral.Width = vg.Points(1.5)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Parametric curves"
+	p.Add(NewGrid(), lissajous, spiral)
+	p.X.Min, p.X.Max = -1.5, 1.5
+	p.Y.Min, p.Y.Max = -1.5, 1.5
+	p.DataAspect = 1
+
+	err = p.Save(200, 200, "testdata/parametric.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestParametric(t *testing.T) {
+	cmpimg.CheckPlot(ExampleParametric, t, "parametric.png")
+}
+
+func TestParametricDataRange(t *testing.T) {
+	f := NewParametric(math.Cos, math.Sin, 0, 2*math.Pi)
+	f.Samples = 5
+	xmin, xmax, ymin, ymax := f.DataRange()
+	for _, test := range []struct {
+		name      string
+		got, want float64
+	}{
+		{name: "xmin", got: xmin, want: -1},
+		{name: "xmax", got: xmax, want: 1},
+		{name: "ymin", got: ymin, want: -1},
+		{name: "ymax", got: ymax, want: 1},
+	} {
+		if math.Abs(test.got-test.want) > 1e-12 {
+			t.Errorf("unexpected %s: got:%v want:%v", test.name, test.got, test.want)
+		}
+	}
+}
+
+func TestParametricBreaks(t *testing.T) {
+	// The hyperbola (1/t, t) jumps across the
+	// y-axis at t=0.
+	s := sampler{
+		point: func(t float64) (vg.Point, bool) {
+			x := 1 / t
+			return vg.Point{X: vg.Length(x), Y: vg.Length(t)}, isFinite(x, t)
+		},
+		deviation: midpointDistance,
+		jump:      10,
+	}
+	lines := s.sample(-1.1, 1.1, 10)
+	if len(lines) != 2 {
+		t.Fatalf("unexpected number of lines: got:%d want:2", len(lines))
+	}
+	for i, l := range lines {
+		for _, p := range l {
+			if (p.X < 0) != (i == 0) {
+				t.Errorf("point %v on wrong branch in line %d", p, i)
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+)
+
+// PeriodicGrid is a GridXYZ wrapping a grid that is periodic
+// in X, such as a grid of longitudes spanning 360°. The columns
+// of the wrapped grid are rotated so that the grid starts at a
+// chosen X, and the first column is repeated one period later
+// as an extra last column. Contour lines drawn from a
+// PeriodicGrid therefore connect across the seam of the grid,
+// and a HeatMap of it has no gap at the seam.
+type PeriodicGrid struct {
+	grid GridXYZ
+
+	// xs holds the X coordinates of the columns, and
+	// cols the index of the column of grid for each.
+	xs   []float64
+	cols []int
+}
+
+// NewPeriodicGrid returns a PeriodicGrid wrapping g, which is
+// periodic in X with the given period. The returned grid's X
+// coordinates are those of g shifted by multiples of the period
+// into [start, start+period), with the first repeated at the
+// end. For example, a grid of longitudes from 0° to 359° may be
+// re-centered on the prime meridian with a period of 360 and a
+// start of -180. The columns of g must have increasing X
+// coordinates spanning less than the period.
+func NewPeriodicGrid(g GridXYZ, period, start float64) (*PeriodicGrid, error) {
+	if !(period > 0) || math.IsInf(period, 0) {
+		return nil, errors.New("plotter: invalid period")
+	}
+	c, _ := g.Dims()
+	if c == 0 {
+		return nil, errors.New("plotter: empty periodic grid")
+	}
+	for i := 1; i < c; i++ {
+		if !(g.X(i) > g.X(i-1)) {
+			return nil, errors.New("plotter: periodic grid X coordinates not increasing")
+		}
+	}
+	if g.X(c-1)-g.X(0) >= period {
+		return nil, errors.New("plotter: periodic grid spans more than its period")
+	}
+
+	// wrap returns x shifted into [start, start+period).
+	wrap := func(x float64) float64 {
+		x = math.Mod(x-start, period)
+		if x < 0 {
+			x += period
+		}
+		return start + x
+	}
+
+	// The first column of the rotated grid is the one with
+	// the smallest wrapped X coordinate.
+	first := 0
+	for i := 1; i < c; i++ {
+		if wrap(g.X(i)) < wrap(g.X(first)) {
+			first = i
+		}
+	}
+	p := &PeriodicGrid{
+		grid: g,
+		xs:   make([]float64, c+1),
+		cols: make([]int, c+1),
+	}
+	for k := 0; k < c; k++ {
+		i := (first + k) % c
+		p.cols[k] = i
+		p.xs[k] = wrap(g.X(i))
+	}
+	p.cols[c] = first
+	p.xs[c] = p.xs[0] + period
+	return p, nil
+}
+
+// Dims implements the Dims method of the GridXYZ interface.
+// The grid has one more column than the grid it wraps.
+func (p *PeriodicGrid) Dims() (c, r int) {
+	_, r = p.grid.Dims()
+	return len(p.xs), r
+}
+
+// Z implements the Z method of the GridXYZ interface.
+func (p *PeriodicGrid) Z(c, r int) float64 { return p.grid.Z(p.cols[c], r) }
+
+// X implements the X method of the GridXYZ interface.
+func (p *PeriodicGrid) X(c int) float64 { return p.xs[c] }
+
+// Y implements the Y method of the GridXYZ interface.
+func (p *PeriodicGrid) Y(r int) float64 { return p.grid.Y(r) }
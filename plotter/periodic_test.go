@@ -0,0 +1,125 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/palette"
+)
+
+// lonLatGrid is a grid of values at longitudes from 0° to
+// 350° and latitudes from -80° to 80°, in steps of 10°.
+type lonLatGrid struct{ mat.Matrix }
+
+func (g lonLatGrid) Dims() (c, r int)   { r, c = g.Matrix.Dims(); return c, r }
+func (g lonLatGrid) Z(c, r int) float64 { return g.Matrix.At(r, c) }
+func (g lonLatGrid) X(c int) float64    { return 10 * float64(c) }
+func (g lonLatGrid) Y(r int) float64    { return 10*float64(r) - 80 }
+
+// ExamplePeriodicGrid draws a field on a longitude grid
+// starting at 0°, re-centered on the prime meridian, so that
+// the features that straddle the seam of the grid are drawn
+// whole.
+func ExamplePeriodicGrid() {
+	data := mat.NewDense(17, 36, nil)
+	for r := 0; r < 17; r++ {
+		for c := 0; c < 36; c++ {
+			lon := 10 * float64(c) * math.Pi / 180
+			lat := (10*float64(r) - 80) * math.Pi / 180
+			data.Set(r, c, math.Cos(2*lon)*math.Cos(lat)*math.Cos(lat))
+		}
+	}
+	g, err := NewPeriodicGrid(lonLatGrid{data}, 360, -180)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	h := NewHeatMap(g, palette.Heat(12, 1))
+	c := NewContour(g, []float64{-0.5, 0, 0.5}, nil)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Periodic grid"
+	p.Add(h, c)
+	p.X.Min, p.X.Max = -180, 180
+	p.X.Padding = 0
+	p.Y.Padding = 0
+
+	err = p.Save(300, 180, "testdata/periodicGrid.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestPeriodicGrid(t *testing.T) {
+	cmpimg.CheckPlot(ExamplePeriodicGrid, t, "periodicGrid.png")
+}
+
+func TestNewPeriodicGrid(t *testing.T) {
+	data := mat.NewDense(17, 36, nil)
+	for r := 0; r < 17; r++ {
+		for c := 0; c < 36; c++ {
+			data.Set(r, c, float64(c))
+		}
+	}
+	g, err := NewPeriodicGrid(lonLatGrid{data}, 360, -180)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c, r := g.Dims()
+	if c != 37 || r != 17 {
+		t.Fatalf("unexpected dimensions: got:%d×%d want:37×17", c, r)
+	}
+	for i := 0; i < c; i++ {
+		wantX := 10*float64(i) - 180
+		// The columns start at 180°, which is column 18.
+		wantZ := float64((i + 18) % 36)
+		if g.X(i) != wantX || g.Z(i, 3) != wantZ {
+			t.Errorf("unexpected column %d: got:(x=%v, z=%v) want:(x=%v, z=%v)", i, g.X(i), g.Z(i, 3), wantX, wantZ)
+		}
+	}
+	if g.Y(0) != -80 {
+		t.Errorf("unexpected Y: got:%v want:-80", g.Y(0))
+	}
+
+	// A contour line crossing the seam of the wrapped grid
+	// is not broken there.
+	for r := 0; r < 17; r++ {
+		for c := 0; c < 36; c++ {
+			lon := 10 * float64(c) * math.Pi / 180
+			lat := (10*float64(r) - 80) * math.Pi / 180
+			data.Set(r, c, math.Cos(lon)*math.Cos(lat))
+		}
+	}
+	g, err = NewPeriodicGrid(lonLatGrid{data}, 360, -180)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := NewContour(g, []float64{0.5}, nil).Isolines()[0.5]
+	if len(lines) != 1 || lines[0][0] != lines[0][len(lines[0])-1] {
+		t.Errorf("expected one closed contour around the seam, got %d lines", len(lines))
+	}
+
+	for _, test := range []struct {
+		name   string
+		period float64
+	}{
+		{name: "zero period", period: 0},
+		{name: "short period", period: 300},
+	} {
+		_, err := NewPeriodicGrid(lonLatGrid{data}, test.period, 0)
+		if err == nil {
+			t.Errorf("expected error for %s", test.name)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// PhasePortrait implements the Plotter interface, drawing the
+// phase portrait of the autonomous system of ordinary
+// differential equations
+//
+//	dx/dt, dy/dt = F(x, y).
+//
+// The portrait is made of the vector field of F drawn by a
+// Quiver over the range of the plot, the nullclines on which
+// dx/dt or dy/dt is zero, and the trajectories of the system
+// from a set of initial conditions.
+type PhasePortrait struct {
+	F func(x, y float64) (dx, dy float64)
+
+	// Initial is a copy of the initial conditions of
+	// the trajectories.
+	Initial XYs
+
+	// Duration is the length of time over which the
+	// trajectories are integrated. If Duration is
+	// negative the trajectories are integrated
+	// backward in time.
+	Duration float64
+
+	// Step is the time step of the fourth order
+	// Runge-Kutta integration of the trajectories.
+	Step float64
+
+	// FieldSamples is the number of arrows of the
+	// vector field along each axis. If FieldSamples
+	// is zero the vector field is not drawn.
+	FieldSamples int
+
+	// FieldStyle is the style of the vector field
+	// arrows.
+	FieldStyle draw.LineStyle
+
+	// Trajectory is the style of the trajectories.
+	Trajectory draw.LineStyle
+
+	// Arrows is the number of arrows drawn along each
+	// trajectory in the direction of motion.
+	Arrows int
+
+	// XNullcline and YNullcline draw the curves on
+	// which dx/dt and dy/dt are zero. A nil nullcline
+	// is not drawn.
+	XNullcline, YNullcline *ImplicitFunction
+}
+
+// NewPhasePortrait returns a PhasePortrait of the system f
+// with trajectories from the initial conditions in initial
+// integrated over the given duration in 1000 steps. The
+// vector field is drawn with 15×15 grey arrows and the
+// x and y nullclines are drawn in orange and blue.
+func NewPhasePortrait(f func(x, y float64) (dx, dy float64), initial XYer, duration float64) (*PhasePortrait, error) {
+	init, err := CopyXYs(initial)
+	if err != nil {
+		return nil, err
+	}
+	field := DefaultLineStyle
+	field.Color = color.Gray{Y: 160}
+	field.Width = vg.Points(0.5)
+
+	xnull := NewImplicitFunction(func(x, y float64) float64 {
+		dx, _ := f(x, y)
+		return dx
+	})
+	xnull.Color = color.RGBA{R: 196, G: 64, A: 255}
+	ynull := NewImplicitFunction(func(x, y float64) float64 {
+		_, dy := f(x, y)
+		return dy
+	})
+	ynull.Color = color.RGBA{G: 128, B: 196, A: 255}
+
+	return &PhasePortrait{
+		F:            f,
+		Initial:      init,
+		Duration:     duration,
+		Step:         duration / 1000,
+		FieldSamples: 15,
+		FieldStyle:   field,
+		Trajectory:   DefaultLineStyle,
+		Arrows:       2,
+		XNullcline:   xnull,
+		YNullcline:   ynull,
+	}, nil
+}
+
+// Trajectories returns the integrated trajectories from each
+// of the initial conditions. A trajectory ends early if it
+// becomes infinite or NaN.
+func (pp *PhasePortrait) Trajectories() []XYs {
+	h := pp.Step
+	if h == 0 || (h < 0) != (pp.Duration < 0) {
+		return make([]XYs, len(pp.Initial))
+	}
+	n := int(pp.Duration/h + 0.5)
+	trs := make([]XYs, len(pp.Initial))
+	for i, p := range pp.Initial {
+		tr := make(XYs, 1, n+1)
+		tr[0] = p
+		x, y := p.X, p.Y
+		for k := 0; k < n; k++ {
+			x, y = pp.rk4(x, y, h)
+			if !isFinite(x, y) {
+				break
+			}
+			tr = append(tr, struct{ X, Y float64 }{x, y})
+		}
+		trs[i] = tr
+	}
+	return trs
+}
+
+// rk4 returns the state at time h after (x, y) estimated
+// by the classical fourth order Runge-Kutta method.
+func (pp *PhasePortrait) rk4(x, y, h float64) (float64, float64) {
+	k1x, k1y := pp.F(x, y)
+	k2x, k2y := pp.F(x+h/2*k1x, y+h/2*k1y)
+	k3x, k3y := pp.F(x+h/2*k2x, y+h/2*k2y)
+	k4x, k4y := pp.F(x+h*k3x, y+h*k3y)
+	return x + h/6*(k1x+2*k2x+2*k3x+k4x), y + h/6*(k1y+2*k2y+2*k3y+k4y)
+}
+
+// Plot draws the PhasePortrait, implementing the plot.Plotter
+// interface.
+func (pp *PhasePortrait) Plot(c draw.Canvas, p *plot.Plot) {
+	if pp.FieldSamples > 0 {
+		q, err := NewVectorField(pp.F, p.X.Min, p.X.Max, p.Y.Min, p.Y.Max, pp.FieldSamples)
+		if err == nil {
+			q.LineStyle = pp.FieldStyle
+			q.HeadSize = vg.Points(3)
+			q.Plot(c, p)
+		}
+	}
+	for _, null := range []*ImplicitFunction{pp.XNullcline, pp.YNullcline} {
+		if null != nil {
+			null.Plot(c, p)
+		}
+	}
+
+	trX, trY := p.Transforms(&c)
+	for _, tr := range pp.Trajectories() {
+		if len(tr) < 2 {
+			continue
+		}
+		line := make([]vg.Point, len(tr))
+		for i, q := range tr {
+			line[i] = vg.Point{X: trX(q.X), Y: trY(q.Y)}
+		}
+		c.StrokeLines(pp.Trajectory, c.ClipLinesXY(line)...)
+
+		if pp.Arrows < 1 || pp.Trajectory.Color == nil {
+			continue
+		}
+		c.SetColor(pp.Trajectory.Color)
+		for k := 0; k < pp.Arrows; k++ {
+			// Place the arrows at the middles of equal
+			// intervals of time along the trajectory.
+			i := int((float64(k) + 0.5) / float64(pp.Arrows) * float64(len(line)-1))
+			a, b := line[i], line[i+1]
+			d := distance(a, b)
+			if d == 0 || !c.Contains(a) {
+				continue
+			}
+			c.Fill(arrowHead(a, b.Sub(a).Scale(1/d), vg.Points(6)))
+		}
+	}
+}
+
+// DataRange returns the minimum and maximum x and y values
+// of the trajectories, implementing the plot.DataRanger
+// interface.
+func (pp *PhasePortrait) DataRange() (xmin, xmax, ymin, ymax float64) {
+	var all XYs
+	for _, tr := range pp.Trajectories() {
+		all = append(all, tr...)
+	}
+	return XYRange(all)
+}
+
+// Thumbnail draws a line in the style of the trajectories,
+// implementing the plot.Thumbnailer interface.
+func (pp *PhasePortrait) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(pp.Trajectory, c.Min.X, y, c.Max.X, y)
+}
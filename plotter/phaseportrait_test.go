@@ -0,0 +1,84 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+)
+
+// ExamplePhasePortrait draws the phase portrait of the
+// van der Pol oscillator, whose trajectories approach
+// a limit cycle.
+func ExamplePhasePortrait() {
+	const mu = 1
+	vanDerPol := func(x, y float64) (dx, dy float64) {
+		return y, mu*(1-x*x)*y - x
+	}
+	pp, err := NewPhasePortrait(vanDerPol, XYs{{X: 0.1, Y: 0}, {X: -3, Y: 3}, {X: 3, Y: -3}}, 15)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "van der Pol oscillator"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "dx/dt"
+	p.Add(pp)
+	p.X.Min, p.X.Max = -4, 4
+	p.Y.Min, p.Y.Max = -4, 4
+
+	err = p.Save(250, 250, "testdata/phasePortrait.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestPhasePortrait(t *testing.T) {
+	cmpimg.CheckPlot(ExamplePhasePortrait, t, "phasePortrait.png")
+}
+
+func TestPhasePortraitTrajectories(t *testing.T) {
+	oscillator := func(x, y float64) (dx, dy float64) { return y, -x }
+	pp, err := NewPhasePortrait(oscillator, XYs{{X: 1, Y: 0}}, 2*math.Pi)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	trs := pp.Trajectories()
+	if len(trs) != 1 || len(trs[0]) != 1001 {
+		t.Fatalf("unexpected trajectories: got %d", len(trs))
+	}
+	for i, p := range trs[0] {
+		tm := float64(i) * pp.Step
+		if math.Abs(p.X-math.Cos(tm)) > 1e-9 || math.Abs(p.Y+math.Sin(tm)) > 1e-9 {
+			t.Fatalf("unexpected state at t=%v: got:%v want:{%v %v}", tm, p, math.Cos(tm), -math.Sin(tm))
+		}
+	}
+
+	pp.Duration = -math.Pi
+	pp.Step = -math.Pi / 100
+	end := pp.Trajectories()[0][100]
+	if math.Abs(end.X+1) > 1e-6 || math.Abs(end.Y) > 1e-6 {
+		t.Errorf("unexpected backward state: got:%v want:{-1 0}", end)
+	}
+
+	blowup := func(x, y float64) (dx, dy float64) { return x * x, 0 }
+	pp, err = NewPhasePortrait(blowup, XYs{{X: 1, Y: 0}}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, p := range pp.Trajectories()[0] {
+		if !isFinite(p.X, p.Y) {
+			t.Fatalf("trajectory not ended at blow up: %v", p)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// pickXYs returns the point of xys drawn by p on the data canvas c
+// of plt nearest to pt, measuring the distance to a disc of radius
+// returned by rad about each point. Points outside the axis ranges
+// are ignored.
+func pickXYs(p plot.Plotter, xys XYs, c draw.Canvas, plt *plot.Plot, pt vg.Point, rad func(i int) vg.Length) (plot.Pick, bool) {
+	trX, trY := plt.Transforms(&c)
+	var (
+		pick  plot.Pick
+		found bool
+	)
+	for i, xy := range xys {
+		if !inRange(plt, xy.X, xy.Y) {
+			continue
+		}
+		d := vg.Length(math.Hypot(float64(trX(xy.X)-pt.X), float64(trY(xy.Y)-pt.Y))) - rad(i)
+		if d < 0 {
+			d = 0
+		}
+		if !found || d < pick.Distance {
+			pick = plot.Pick{Plotter: p, Index: i, X: xy.X, Y: xy.Y, Distance: d}
+			found = true
+		}
+	}
+	return pick, found
+}
+
+// Pick returns the point of the Scatter nearest to pt,
+// implementing the plot.Picker interface. The distance
+// to a point is measured to the edge of its glyph as it
+// is drawn, scaled by the Density of the Scatter.
+func (pts *Scatter) Pick(c draw.Canvas, plt *plot.Plot, pt vg.Point) (plot.Pick, bool) {
+	scale := vg.Length(pts.density(plt).radius)
+	return pickXYs(pts, pts.XYs, c, plt, pt, func(i int) vg.Length {
+		if pts.GlyphStyleFunc != nil {
+			return pts.GlyphStyleFunc(i).Radius * scale
+		}
+		return pts.Radius * scale
+	})
+}
+
+// Pick returns the vertex of the Line nearest to pt,
+// implementing the plot.Picker interface.
+func (pts *Line) Pick(c draw.Canvas, plt *plot.Plot, pt vg.Point) (plot.Pick, bool) {
+	return pickXYs(pts, pts.XYs, c, plt, pt, func(int) vg.Length { return pts.Width / 2 })
+}
+
+// selectXYs returns the indices of the points of xys drawn
+// on the data canvas c of plt whose locations are reported to
+// be within a region by in. Points outside the axis ranges
+// are ignored.
+func selectXYs(xys XYs, c draw.Canvas, plt *plot.Plot, in func(vg.Point) bool) []int {
+	trX, trY := plt.Transforms(&c)
+	var idx []int
+	for i, xy := range xys {
+		if inRange(plt, xy.X, xy.Y) && in(vg.Point{X: trX(xy.X), Y: trY(xy.Y)}) {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// Select returns the indices of the points of the Scatter
+// within a region, implementing the plot.Selector interface.
+func (pts *Scatter) Select(c draw.Canvas, plt *plot.Plot, in func(vg.Point) bool) []int {
+	return selectXYs(pts.XYs, c, plt, in)
+}
+
+// Select returns the indices of the vertices of the Line
+// within a region, implementing the plot.Selector interface.
+func (pts *Line) Select(c draw.Canvas, plt *plot.Plot, in func(vg.Point) bool) []int {
+	return selectXYs(pts.XYs, c, plt, in)
+}
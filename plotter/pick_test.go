@@ -0,0 +1,71 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+func TestPick(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s, err := NewScatter(XYs{{X: 0, Y: 0}, {X: 1, Y: 2}, {X: 2, Y: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Radius = vg.Points(3)
+	l, err := NewLine(XYs{{X: 0, Y: 2}, {X: 3, Y: 0}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(s, l)
+
+	c := draw.New(vgimg.New(vg.Points(200), vg.Points(200)))
+	dc := p.DataCanvas(c)
+	trX, trY := p.Transforms(&dc)
+	at := func(x, y float64) vg.Point { return vg.Point{X: trX(x), Y: trY(y)} }
+
+	for _, test := range []struct {
+		pt      vg.Point
+		plotter plot.Plotter
+		index   int
+		dist    vg.Length
+	}{
+		{pt: at(1, 2), plotter: s, index: 1, dist: 0},
+		{pt: at(1, 2).Add(vg.Point{X: 2}), plotter: s, index: 1, dist: 0},
+		{pt: at(2, 1).Add(vg.Point{Y: -10}), plotter: s, index: 2, dist: 7},
+		{pt: at(3, 0).Add(vg.Point{X: -4}), plotter: l, index: 1, dist: 4 - l.Width/2},
+	} {
+		pick, ok := p.PickAt(c, test.pt)
+		if !ok {
+			t.Errorf("no pick at %v", test.pt)
+			continue
+		}
+		if pick.Plotter != test.plotter || pick.Index != test.index {
+			t.Errorf("unexpected pick at %v: got:%T %d want:%T %d", test.pt, pick.Plotter, pick.Index, test.plotter, test.index)
+		}
+		if d := pick.Distance - test.dist; d < -1e-9 || 1e-9 < d {
+			t.Errorf("unexpected pick distance at %v: got:%v want:%v", test.pt, pick.Distance, test.dist)
+		}
+	}
+
+	// Picks measure to the edge of the glyph as drawn,
+	// scaled by the density of the scatter.
+	s.Density = DensityScaling{Radius: func(int) float64 { return 0.5 }}
+	pick, ok := s.Pick(dc, p, at(2, 1).Add(vg.Point{Y: -10}))
+	if !ok || pick.Index != 2 {
+		t.Fatalf("unexpected pick of dense scatter: got:%+v ok:%t", pick, ok)
+	}
+	if d := pick.Distance - 8.5; d < -1e-9 || 1e-9 < d {
+		t.Errorf("unexpected pick distance of dense scatter: got:%v want:8.5", pick.Distance)
+	}
+}
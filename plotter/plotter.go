@@ -19,6 +19,7 @@ import (
 	"image/color"
 	"math"
 
+	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 )
@@ -52,11 +53,15 @@ type Valuer interface {
 }
 
 // Range returns the minimum and maximum values.
+// Non-finite values are ignored.
 func Range(vs Valuer) (min, max float64) {
 	min = math.Inf(1)
 	max = math.Inf(-1)
 	for i := 0; i < vs.Len(); i++ {
 		v := vs.Value(i)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
 		min = math.Min(min, v)
 		max = math.Max(max, v)
 	}
@@ -87,17 +92,28 @@ func CheckFloats(fs ...float64) error {
 
 // CopyValues returns a Values that is a copy of the values
 // from a Valuer, or an error if there are no values, or if one of
-// the copied values is a NaN or Infinity.
+// the copied values is a NaN or Infinity. If vs has a NonFinite
+// policy other than NonFiniteError, the non-finite values are
+// dropped rather than returning an error, and ErrNoData is
+// returned if all of the values are dropped.
 func CopyValues(vs Valuer) (Values, error) {
 	if vs.Len() == 0 {
 		return nil, ErrNoData
 	}
-	cpy := make(Values, vs.Len())
+	policy := policyOf(vs)
+	cpy := make(Values, 0, vs.Len())
 	for i := 0; i < vs.Len(); i++ {
-		cpy[i] = vs.Value(i)
-		if err := CheckFloats(cpy[i]); err != nil {
-			return nil, err
+		v := vs.Value(i)
+		if err := CheckFloats(v); err != nil {
+			if policy == NonFiniteError {
+				return nil, err
+			}
+			continue
 		}
+		cpy = append(cpy, v)
+	}
+	if len(cpy) == 0 {
+		return nil, ErrNoData
 	}
 	return cpy, nil
 }
@@ -132,14 +148,28 @@ type XYs []struct{ X, Y float64 }
 
 // CopyXYs returns an XYs that is a copy of the x and y values from
 // an XYer, or an error if one of the data points contains a NaN or
-// Infinity.
+// Infinity. If data has a NonFinite policy other than NonFiniteError,
+// the points holding non-finite values are handled as described by
+// the policy rather than returning an error, and ErrNoData is
+// returned if data holds points but none of them are finite.
 func CopyXYs(data XYer) (XYs, error) {
-	cpy := make(XYs, data.Len())
-	for i := range cpy {
-		cpy[i].X, cpy[i].Y = data.XY(i)
-		if err := CheckFloats(cpy[i].X, cpy[i].Y); err != nil {
+	policy := policyOf(data)
+	cpy := make(XYs, 0, data.Len())
+	var finite int
+	for i := 0; i < data.Len(); i++ {
+		x, y := data.XY(i)
+		switch err := CheckFloats(x, y); {
+		case err == nil:
+			finite++
+		case policy == NonFiniteSkip:
+			continue
+		case policy != NonFiniteBreak:
 			return nil, err
 		}
+		cpy = append(cpy, struct{ X, Y float64 }{x, y})
+	}
+	if data.Len() > 0 && finite == 0 {
+		return nil, ErrNoData
 	}
 	return cpy, nil
 }
@@ -260,3 +290,15 @@ type YErrors Errors
 func (ye YErrors) YError(i int) (float64, float64) {
 	return ye[i].Low, ye[i].High
 }
+
+// cancelInterval is the number of iterations of
+// expensive loops between checks of the context of
+// the rendering.
+const cancelInterval = 1 << 12
+
+// canceled returns whether the rendering of plt has
+// been canceled, checking the context of the rendering
+// only when i is a multiple of cancelInterval.
+func canceled(plt *plot.Plot, i int) bool {
+	return i%cancelInterval == 0 && plt.Context().Err() != nil
+}
@@ -0,0 +1,348 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+)
+
+// PolygonUnion returns the rings of the union of the regions
+// a and b. The regions are each given by a set of rings, and
+// a point is in a region if it is inside an odd number of its
+// rings. The returned rings describe the union in the same
+// way, so a Polygon drawing them should use the vg.EvenOdd
+// fill rule.
+func PolygonUnion(a, b []XYs) ([]XYs, error) {
+	return polygonOp(a, b, polyUnion)
+}
+
+// PolygonIntersection returns the rings of the intersection
+// of the regions a and b, which are described as for
+// PolygonUnion.
+func PolygonIntersection(a, b []XYs) ([]XYs, error) {
+	return polygonOp(a, b, polyIntersection)
+}
+
+// PolygonDifference returns the rings of the region a less
+// the region b, which are described as for PolygonUnion.
+func PolygonDifference(a, b []XYs) ([]XYs, error) {
+	return polygonOp(a, b, polyDifference)
+}
+
+// polyOperation is a boolean operation on polygons.
+type polyOperation int
+
+const (
+	polyUnion polyOperation = iota
+	polyIntersection
+	polyDifference
+)
+
+// errDegenerate is returned when the rings of two polygons
+// touch at a vertex or overlap along an edge.
+var errDegenerate = errors.New("plotter: degenerate polygon intersection")
+
+// polygonOp returns the result of the operation op on a and b
+// using the Greiner-Hormann clipping algorithm, extended to
+// regions of several rings. Degenerate intersections, where
+// a vertex lies on an edge of the other polygon, are avoided
+// by perturbing b by a small fraction of the extent of the
+// polygons.
+func polygonOp(a, b []XYs, op polyOperation) ([]XYs, error) {
+	a = closedRings(a)
+	b = closedRings(b)
+	extent := 0.0
+	for _, r := range append(append([]XYs(nil), a...), b...) {
+		xmin, xmax, ymin, ymax := XYRange(r)
+		extent = math.Max(extent, math.Max(xmax-xmin, ymax-ymin))
+	}
+	for i := 0; i < 8; i++ {
+		pb := b
+		if i > 0 {
+			// Shift b in a direction unlikely to be parallel
+			// to any edge.
+			eps := extent * 1e-10 * math.Pow(10, float64(i))
+			pb = shiftRings(b, eps, eps*math.Phi)
+		}
+		res, err := greinerHormann(a, pb, op)
+		if err == errDegenerate {
+			continue
+		}
+		return res, err
+	}
+	return nil, errDegenerate
+}
+
+// closedRings returns the rings of p that have at least three
+// vertices, without a repeated closing vertex.
+func closedRings(p []XYs) []XYs {
+	var rings []XYs
+	for _, r := range p {
+		if len(r) > 1 && r[0] == r[len(r)-1] {
+			r = r[:len(r)-1]
+		}
+		if len(r) >= 3 {
+			rings = append(rings, r)
+		}
+	}
+	return rings
+}
+
+// shiftRings returns a copy of p translated by (dx, dy).
+func shiftRings(p []XYs, dx, dy float64) []XYs {
+	s := make([]XYs, len(p))
+	for i, r := range p {
+		s[i] = make(XYs, len(r))
+		for j, v := range r {
+			s[i][j].X, s[i][j].Y = v.X+dx, v.Y+dy
+		}
+	}
+	return s
+}
+
+// polyNode is a vertex of a ring, or an intersection of
+// the rings of two polygons, in a circular doubly linked
+// list.
+type polyNode struct {
+	x, y       float64
+	next, prev *polyNode
+
+	// intersect is true for intersections, which are
+	// linked to the same intersection in the other
+	// polygon by neighbor. alpha is the position of the
+	// intersection along the edge it was inserted into.
+	intersect bool
+	neighbor  *polyNode
+	alpha     float64
+
+	// entry is whether travelling forward along the
+	// ring at the intersection enters the part of the
+	// other polygon retained by the operation.
+	entry   bool
+	visited bool
+}
+
+// polyRing returns the circular list of the vertices of r.
+func polyRing(r XYs) *polyNode {
+	var first, last *polyNode
+	for _, v := range r {
+		n := &polyNode{x: v.X, y: v.Y}
+		if first == nil {
+			first = n
+		} else {
+			last.next, n.prev = n, last
+		}
+		last = n
+	}
+	last.next, first.prev = first, last
+	return first
+}
+
+// insertAfter inserts the intersection n into the edge that
+// starts at the vertex v, ordered by alpha.
+func insertAfter(v, n *polyNode) {
+	at := v
+	for at.next.intersect && at.next.alpha < n.alpha {
+		at = at.next
+	}
+	n.prev, n.next = at, at.next
+	at.next.prev = n
+	at.next = n
+}
+
+// greinerHormann returns the result of the operation op on
+// a and b, or errDegenerate if the rings of a and b touch.
+func greinerHormann(a, b []XYs, op polyOperation) ([]XYs, error) {
+	ra := make([]*polyNode, len(a))
+	for i, r := range a {
+		ra[i] = polyRing(r)
+	}
+	rb := make([]*polyNode, len(b))
+	for i, r := range b {
+		rb[i] = polyRing(r)
+	}
+
+	// Find the intersections of each pair of edges, and
+	// insert them into both rings. The edges start at
+	// the original vertices of the rings.
+	edges := func(first *polyNode) []*polyNode {
+		var vs []*polyNode
+		n := first
+		for {
+			if !n.intersect {
+				vs = append(vs, n)
+			}
+			n = n.next
+			if n == first {
+				return vs
+			}
+		}
+	}
+	hasIntersection := make([]bool, len(ra)+len(rb))
+	for i, fa := range ra {
+		va := edges(fa)
+		for j, fb := range rb {
+			vb := edges(fb)
+			for k, p := range va {
+				p1 := a[i][(k+1)%len(a[i])]
+				for l, q := range vb {
+					q1 := b[j][(l+1)%len(b[j])]
+					alpha, beta, ok, err := segmentIntersection(p.x, p.y, p1.X, p1.Y, q.x, q.y, q1.X, q1.Y)
+					if err != nil {
+						return nil, err
+					}
+					if !ok {
+						continue
+					}
+					x := p.x + alpha*(p1.X-p.x)
+					y := p.y + alpha*(p1.Y-p.y)
+					na := &polyNode{x: x, y: y, intersect: true, alpha: alpha}
+					nb := &polyNode{x: x, y: y, intersect: true, alpha: beta}
+					na.neighbor, nb.neighbor = nb, na
+					insertAfter(p, na)
+					insertAfter(q, nb)
+					hasIntersection[i] = true
+					hasIntersection[len(ra)+j] = true
+				}
+			}
+		}
+	}
+
+	// Mark whether each intersection is an entry into the
+	// retained part of the other polygon. For union the
+	// outside of both polygons is retained, and for
+	// difference the outside of b is retained by a.
+	mark := func(first *polyNode, other []XYs, invert bool) {
+		inside := pointInRings(first.x, first.y, other) != invert
+		n := first
+		for {
+			if n.intersect {
+				n.entry = !inside
+				inside = !inside
+			}
+			n = n.next
+			if n == first {
+				return
+			}
+		}
+	}
+	for _, f := range ra {
+		mark(f, b, op == polyUnion || op == polyDifference)
+	}
+	for _, f := range rb {
+		mark(f, a, op == polyUnion)
+	}
+
+	// Trace the rings of the result through the
+	// intersections.
+	var res []XYs
+	for _, f := range ra {
+		n := f
+		for {
+			if n.intersect && !n.visited {
+				res = append(res, traceRing(n))
+			}
+			n = n.next
+			if n == f {
+				break
+			}
+		}
+	}
+
+	// Rings without intersections are wholly inside or
+	// outside the other polygon.
+	keep := func(r XYs, other []XYs, inside bool) {
+		if pointInRings(r[0].X, r[0].Y, other) == inside {
+			res = append(res, r)
+		}
+	}
+	for i, r := range a {
+		if !hasIntersection[i] {
+			keep(r, b, op == polyIntersection)
+		}
+	}
+	for j, r := range b {
+		if !hasIntersection[len(ra)+j] {
+			keep(r, a, op != polyUnion)
+		}
+	}
+	return res, nil
+}
+
+// traceRing returns the ring of the result that passes
+// through the intersection start.
+func traceRing(start *polyNode) XYs {
+	ring := XYs{{X: start.x, Y: start.y}}
+	n := start
+	for {
+		n.visited, n.neighbor.visited = true, true
+		forward := n.entry
+		for {
+			if forward {
+				n = n.next
+			} else {
+				n = n.prev
+			}
+			if n.intersect {
+				break
+			}
+			ring = append(ring, struct{ X, Y float64 }{n.x, n.y})
+		}
+		if n.visited {
+			return ring
+		}
+		ring = append(ring, struct{ X, Y float64 }{n.x, n.y})
+		n = n.neighbor
+	}
+}
+
+// segmentIntersection returns the positions along the segments
+// from (px, py) to (px1, py1) and from (qx, qy) to (qx1, qy1) of
+// their intersection, and whether they intersect. It returns
+// errDegenerate if the segments touch at an end or overlap.
+func segmentIntersection(px, py, px1, py1, qx, qy, qx1, qy1 float64) (alpha, beta float64, ok bool, err error) {
+	rx, ry := px1-px, py1-py
+	sx, sy := qx1-qx, qy1-qy
+	den := rx*sy - ry*sx
+	dx, dy := qx-px, qy-py
+	if den == 0 {
+		if dx*ry-dy*rx == 0 {
+			// The segments are collinear, and overlap if
+			// their projections onto the line do.
+			rr := rx*rx + ry*ry
+			t0 := (dx*rx + dy*ry) / rr
+			t1 := t0 + (sx*rx+sy*ry)/rr
+			if math.Max(t0, t1) >= 0 && math.Min(t0, t1) <= 1 {
+				return 0, 0, false, errDegenerate
+			}
+		}
+		return 0, 0, false, nil
+	}
+	alpha = (dx*sy - dy*sx) / den
+	beta = (dx*ry - dy*rx) / den
+	if alpha < 0 || alpha > 1 || beta < 0 || beta > 1 {
+		return 0, 0, false, nil
+	}
+	if alpha == 0 || alpha == 1 || beta == 0 || beta == 1 {
+		return 0, 0, false, errDegenerate
+	}
+	return alpha, beta, true, nil
+}
+
+// pointInRings returns whether (x, y) is inside an odd number
+// of the rings.
+func pointInRings(x, y float64, rings []XYs) bool {
+	in := false
+	for _, r := range rings {
+		for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+			a, b := r[i], r[j]
+			if (a.Y > y) != (b.Y > y) && x < (b.X-a.X)*(y-a.Y)/(b.Y-a.Y)+a.X {
+				in = !in
+			}
+		}
+	}
+	return in
+}
@@ -0,0 +1,164 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+)
+
+// ExamplePolygonUnion combines two circles and a square
+// into a single region with a hole, and draws it with the
+// even-odd fill rule.
+func ExamplePolygonUnion() {
+	// circle returns a ring approximating a circle
+	// centred at (x, y) with radius r.
+	circle := func(x, y, r float64) XYs {
+		c := make(XYs, 64)
+		for i := range c {
+			a := 2 * math.Pi * float64(i) / float64(len(c))
+			c[i].X = x + r*math.Cos(a)
+			c[i].Y = y + r*math.Sin(a)
+		}
+		return c
+	}
+	// polygon returns a Polygon drawing the rings of a
+	// region with the even-odd fill rule.
+	polygon := func(rings []XYs, c color.Color) *Polygon {
+		xys := make([]XYer, len(rings))
+		for i, r := range rings {
+			xys[i] = r
+		}
+		poly, err := NewPolygon(xys...)
+		if err != nil {
+			log.Panic(err)
+		}
+		poly.Color = c
+		poly.FillRule = vg.EvenOdd
+		return poly
+	}
+	square := XYs{{X: 1, Y: -1}, {X: 3, Y: -1}, {X: 3, Y: 1}, {X: 1, Y: 1}}
+
+	rings, err := PolygonUnion([]XYs{circle(0, 0, 1.5)}, []XYs{circle(4, 0, 1.5)})
+	if err != nil {
+		log.Panic(err)
+	}
+	rings, err = PolygonUnion(rings, []XYs{square})
+	if err != nil {
+		log.Panic(err)
+	}
+	rings, err = PolygonDifference(rings, []XYs{circle(2, 0, 0.5)})
+	if err != nil {
+		log.Panic(err)
+	}
+	union := polygon(rings, color.NRGBA{B: 255, A: 128})
+
+	rings, err = PolygonIntersection([]XYs{circle(0, 0, 1.5)}, []XYs{square})
+	if err != nil {
+		log.Panic(err)
+	}
+	inter := polygon(rings, color.NRGBA{R: 255, A: 255})
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Polygon union"
+	p.Add(union, inter)
+	p.DataAspect = 1
+
+	err = p.Save(200, 100, "testdata/polygonUnion.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestPolygonUnion(t *testing.T) {
+	cmpimg.CheckPlot(ExamplePolygonUnion, t, "polygonUnion.png")
+}
+
+func TestPolygonBoolean(t *testing.T) {
+	square := func(x, y, size float64) XYs {
+		return XYs{{X: x, Y: y}, {X: x + size, Y: y}, {X: x + size, Y: y + size}, {X: x, Y: y + size}}
+	}
+	for _, test := range []struct {
+		name string
+		a, b []XYs
+	}{
+		{
+			name: "overlapping squares",
+			a:    []XYs{square(0, 0, 2)},
+			b:    []XYs{square(1, 1, 2)},
+		},
+		{
+			name: "opposite winding",
+			a:    []XYs{square(0, 0, 2)},
+			b:    []XYs{{{X: 1, Y: 1}, {X: 1, Y: 3}, {X: 3, Y: 3}, {X: 3, Y: 1}}},
+		},
+		{
+			name: "square with hole",
+			a:    []XYs{square(0, 0, 4), square(1, 1, 2)},
+			b:    []XYs{square(1.5, -1, 1)},
+		},
+		{
+			name: "nested",
+			a:    []XYs{square(0, 0, 4)},
+			b:    []XYs{square(1, 1, 1)},
+		},
+		{
+			name: "disjoint",
+			a:    []XYs{square(0, 0, 1)},
+			b:    []XYs{square(2, 2, 1)},
+		},
+		{
+			name: "shared vertex",
+			a:    []XYs{square(0, 0, 2)},
+			b:    []XYs{square(2, 2, 2)},
+		},
+		{
+			name: "shared edge",
+			a:    []XYs{square(0, 0, 2)},
+			b:    []XYs{square(2, 0.5, 2)},
+		},
+		{
+			name: "closed rings",
+			a:    []XYs{append(square(0, 0, 2), struct{ X, Y float64 }{0, 0})},
+			b:    []XYs{square(1, -1, 2)},
+		},
+	} {
+		for _, op := range []struct {
+			name string
+			fn   func(a, b []XYs) ([]XYs, error)
+			in   func(a, b bool) bool
+		}{
+			{name: "union", fn: PolygonUnion, in: func(a, b bool) bool { return a || b }},
+			{name: "intersection", fn: PolygonIntersection, in: func(a, b bool) bool { return a && b }},
+			{name: "difference", fn: PolygonDifference, in: func(a, b bool) bool { return a && !b }},
+		} {
+			got, err := op.fn(test.a, test.b)
+			if err != nil {
+				t.Errorf("unexpected error for %s %s: %v", test.name, op.name, err)
+				continue
+			}
+			// Check points away from the edges of the
+			// rings, which are at multiples of 0.5.
+			for x := -1.15; x < 5; x += 0.2 {
+				for y := -1.15; y < 5; y += 0.2 {
+					want := op.in(pointInRings(x, y, test.a), pointInRings(x, y, test.b))
+					if pointInRings(x, y, got) != want {
+						t.Errorf("unexpected membership of (%.2f, %.2f) for %s %s: got:%t want:%t",
+							x, y, test.name, op.name, !want, want)
+					}
+				}
+			}
+		}
+	}
+}
@@ -26,14 +26,22 @@ type Polygon struct {
 
 	// Color is the fill color of the polygon.
 	Color color.Color
+
+	// FillRule is the rule used to fill the polygon on
+	// backends that implement vg.FillRuleSetter. With
+	// the EvenOdd rule, rings nested within other rings
+	// are holes whatever their winding order. If FillRule
+	// is vg.DefaultFillRule the rule of the backend is
+	// used.
+	FillRule vg.FillRule
 }
 
 // NewPolygon returns a polygon that uses the default line style and
 // no fill color, where xys are the rings of the polygon.
 // Different backends may render overlapping rings and self-intersections
-// differently, but all built-in backends treat inner rings
-// with the opposite winding order from the outer ring as
-// holes.
+// differently unless the FillRule of the polygon is set, but all
+// built-in backends treat inner rings with the opposite winding
+// order from the outer ring as holes.
 func NewPolygon(xys ...XYer) (*Polygon, error) {
 	data := make([]XYs, len(xys))
 	for i, d := range xys {
@@ -64,6 +72,11 @@ func (pts *Polygon) Plot(c draw.Canvas, plt *plot.Plot) {
 		ps[i] = c.ClipPolygonXY(ps[i])
 	}
 	if pts.Color != nil && len(ps) > 0 {
+		ok := pts.FillRule != vg.DefaultFillRule
+		if ok {
+			c.Push()
+			c.SetFillRule(pts.FillRule)
+		}
 		c.SetColor(pts.Color)
 		var pa vg.Path
 		for _, ring := range ps {
@@ -77,6 +90,9 @@ func (pts *Polygon) Plot(c draw.Canvas, plt *plot.Plot) {
 			pa.Close()
 		}
 		c.Fill(pa)
+		if ok {
+			c.Pop()
+		}
 	}
 
 	for _, ring := range ps {
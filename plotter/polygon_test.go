@@ -185,3 +185,34 @@ func TestPolygon_clip(t *testing.T) {
 	dc := draw.NewCanvas(c, vg.Centimeter, vg.Centimeter)
 	p.Draw(dc) // If this does not panic, then the test passes.
 }
+
+func TestPolygonFillRule(t *testing.T) {
+	for _, rule := range []vg.FillRule{vg.DefaultFillRule, vg.NonZero, vg.EvenOdd} {
+		poly, err := NewPolygon(XYs{{0, 0}, {1, 0}, {1, 1}, {0, 1}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		poly.Color = color.Black
+		poly.FillRule = rule
+		p, err := plot.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.Add(poly)
+		c := new(recorder.Canvas)
+		p.Draw(draw.NewCanvas(c, vg.Centimeter, vg.Centimeter))
+
+		var got []vg.FillRule
+		for _, a := range c.Actions {
+			if s, ok := a.(*recorder.SetFillRule); ok {
+				got = append(got, s.Rule)
+			}
+		}
+		switch {
+		case rule == vg.DefaultFillRule && len(got) != 0:
+			t.Errorf("unexpected fill rule set for default rule: %v", got)
+		case rule != vg.DefaultFillRule && (len(got) != 1 || got[0] != rule):
+			t.Errorf("unexpected fill rules set for rule %v: got:%v", rule, got)
+		}
+	}
+}
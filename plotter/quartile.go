@@ -5,7 +5,9 @@
 package plotter
 
 import (
+	"errors"
 	"image/color"
+	"math"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/vg"
@@ -37,11 +39,21 @@ var (
 type QuartPlot struct {
 	fiveStatPlot
 
-	// Offset is added to the x location of each plot.
-	// When the Offset is zero, the plot is drawn
-	// centered at its x location.
+	// Offset is added to the x location of each plot,
+	// or to the y location of a horizontal plot, so that
+	// plots sharing a location can be grouped as for a
+	// BoxPlot. When the Offset is zero, the plot is drawn
+	// centered at its location.
 	Offset vg.Length
 
+	// Low and High are the values between which the gap
+	// around the median is drawn, with the whiskers
+	// extending from them. NewQuartPlot sets them to the
+	// first and third quartiles, and SetPercentiles sets
+	// them to percentiles of the values. If Low and High
+	// are both zero, the quartiles are used.
+	Low, High float64
+
 	// MedianStyle is the line style for the median point.
 	MedianStyle draw.GlyphStyle
 
@@ -73,12 +85,54 @@ func NewQuartPlot(loc float64, values Valuer) (*QuartPlot, error) {
 		return nil, err
 	}
 
+	b.Low = b.Quartile1
+	b.High = b.Quartile3
 	b.MedianStyle = DefaultQuartMedianStyle
 	b.WhiskerStyle = DefaultQuartWhiskerStyle
 
 	return b, err
 }
 
+// SetPercentiles sets Low and High to the lo and hi
+// percentiles of the values of the plot, so that the plot
+// shows the pair of percentiles rather than the quartiles.
+// The percentiles are estimated by the R-7 method, which
+// may differ slightly from the quartiles of the plot for
+// 25 and 75. The whiskers are extended to the percentiles
+// if they lie outside the adjacent values. An error is
+// returned unless 0 ≤ lo ≤ hi ≤ 100.
+func (b *QuartPlot) SetPercentiles(lo, hi float64) error {
+	if !(0 <= lo && lo <= hi && hi <= 100) {
+		return errors.New("plotter: invalid percentiles")
+	}
+	vals := make([]float64, len(b.Values))
+	copy(vals, b.Values)
+	var weights []float64
+	if b.Weights != nil {
+		weights = make([]float64, len(b.Weights))
+		copy(weights, b.Weights)
+	}
+	sample := newWeightedSample(vals, weights)
+	b.Low = sample.quantileR7(lo / 100)
+	b.High = sample.quantileR7(hi / 100)
+	return nil
+}
+
+// gap returns the values between which the gap around
+// the median is drawn.
+func (b *QuartPlot) gap() (low, high float64) {
+	if b.Low == 0 && b.High == 0 {
+		return b.Quartile1, b.Quartile3
+	}
+	return b.Low, b.High
+}
+
+// whiskers returns the outer ends of the whiskers.
+func (b *QuartPlot) whiskers() (low, high float64) {
+	gapLow, gapHigh := b.gap()
+	return math.Min(b.AdjLow, gapLow), math.Max(b.AdjHigh, gapHigh)
+}
+
 // Plot draws the QuartPlot on Canvas c and Plot plt.
 func (b *QuartPlot) Plot(c draw.Canvas, plt *plot.Plot) {
 	if b.Horizontal {
@@ -94,11 +148,13 @@ func (b *QuartPlot) Plot(c draw.Canvas, plt *plot.Plot) {
 	}
 	x += b.Offset
 
+	low, high := b.whiskers()
+	gapLow, gapHigh := b.gap()
 	med := vg.Point{X: x, Y: trY(b.Median)}
-	q1 := trY(b.Quartile1)
-	q3 := trY(b.Quartile3)
-	aLow := trY(b.AdjLow)
-	aHigh := trY(b.AdjHigh)
+	q1 := trY(gapLow)
+	q3 := trY(gapHigh)
+	aLow := trY(low)
+	aHigh := trY(high)
 
 	c.StrokeLine2(b.WhiskerStyle, x, aHigh, x, q3)
 	if c.ContainsY(med.Y) {
@@ -144,11 +200,13 @@ func (b *QuartPlot) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 		bs[i].Y = plt.Y.Norm(b.Value(out))
 		bs[i].Rectangle = ostyle.Rectangle()
 		bs[i].Rectangle.Min.X += b.Offset
+		bs[i].Rectangle.Max.X += b.Offset
 	}
 	bs[len(bs)-1].X = plt.X.Norm(b.Location)
 	bs[len(bs)-1].Y = plt.Y.Norm(b.Median)
 	bs[len(bs)-1].Rectangle = b.MedianStyle.Rectangle()
 	bs[len(bs)-1].Rectangle.Min.X += b.Offset
+	bs[len(bs)-1].Rectangle.Max.X += b.Offset
 	return bs
 }
 
@@ -170,7 +228,7 @@ func (b *QuartPlot) OutsideLabels(labels Labeller) (*Labels, error) {
 	if err != nil {
 		return nil, err
 	}
-	ls.XOffset += b.MedianStyle.Radius / 2
+	ls.XOffset += b.MedianStyle.Radius/2 + b.Offset
 	ls.YOffset += b.MedianStyle.Radius / 2
 	return ls, nil
 }
@@ -204,11 +262,13 @@ func (b horizQuartPlot) Plot(c draw.Canvas, plt *plot.Plot) {
 	}
 	y += b.Offset
 
+	low, high := b.whiskers()
+	gapLow, gapHigh := b.gap()
 	med := vg.Point{X: trX(b.Median), Y: y}
-	q1 := trX(b.Quartile1)
-	q3 := trX(b.Quartile3)
-	aLow := trX(b.AdjLow)
-	aHigh := trX(b.AdjHigh)
+	q1 := trX(gapLow)
+	q3 := trX(gapHigh)
+	aLow := trX(low)
+	aHigh := trX(high)
 
 	c.StrokeLine2(b.WhiskerStyle, aHigh, y, q3, y)
 	if c.ContainsX(med.X) {
@@ -245,11 +305,13 @@ func (b horizQuartPlot) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 		bs[i].Y = plt.Y.Norm(b.Location)
 		bs[i].Rectangle = ostyle.Rectangle()
 		bs[i].Rectangle.Min.Y += b.Offset
+		bs[i].Rectangle.Max.Y += b.Offset
 	}
 	bs[len(bs)-1].X = plt.X.Norm(b.Median)
 	bs[len(bs)-1].Y = plt.Y.Norm(b.Location)
 	bs[len(bs)-1].Rectangle = b.MedianStyle.Rectangle()
 	bs[len(bs)-1].Rectangle.Min.Y += b.Offset
+	bs[len(bs)-1].Rectangle.Max.Y += b.Offset
 	return bs
 }
 
@@ -270,7 +332,7 @@ func (b *horizQuartPlot) OutsideLabels(labels Labeller) (*Labels, error) {
 		return nil, err
 	}
 	ls.XOffset += b.MedianStyle.Radius / 2
-	ls.YOffset += b.MedianStyle.Radius / 2
+	ls.YOffset += b.MedianStyle.Radius/2 + b.Offset
 	return ls, nil
 }
 
@@ -13,6 +13,8 @@ import (
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/internal/cmpimg"
 	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
 )
 
 func ExampleQuartPlot() {
@@ -127,3 +129,131 @@ func TestQuartPlot(t *testing.T) {
 		"horizontalQuartPlot.png",
 		"groupedQuartPlot.png")
 }
+
+// ExampleQuartPlot_percentiles draws grouped horizontal
+// quartile plots showing the 10th and 90th percentiles of
+// the values instead of their quartiles.
+func ExampleQuartPlot_percentiles() {
+	rnd := rand.New(rand.NewSource(1))
+
+	n := 100
+	groups := make([][2]Values, 3)
+	for i := range groups {
+		for j := range groups[i] {
+			groups[i][j] = make(Values, n)
+			for k := range groups[i][j] {
+				groups[i][j][k] = rnd.NormFloat64()*float64(j+1) + float64(i)
+			}
+		}
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "10th and 90th percentiles"
+	p.X.Label.Text = "plotter.Values"
+
+	w := vg.Points(5)
+	for i, g := range groups {
+		for j, vs := range g {
+			qp, err := NewQuartPlot(float64(i), vs)
+			if err != nil {
+				log.Panic(err)
+			}
+			err = qp.SetPercentiles(10, 90)
+			if err != nil {
+				log.Panic(err)
+			}
+			qp.Horizontal = true
+			qp.Offset = w * vg.Length(2*j-1)
+			qp.MedianStyle.Radius = vg.Points(1.5)
+			qp.WhiskerStyle.Width = vg.Points(0.5)
+			p.Add(qp)
+		}
+	}
+	p.NominalY("Group 0", "Group 1", "Group 2")
+
+	err = p.Save(200, 200, "testdata/percentileQuartPlot.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestQuartPlotPercentiles(t *testing.T) {
+	cmpimg.CheckPlot(ExampleQuartPlot_percentiles, t, "percentileQuartPlot.png")
+
+	vs := make(Values, 101)
+	for i := range vs {
+		vs[i] = float64(100 - i)
+	}
+	qp, err := NewQuartPlot(0, vs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if qp.Low != qp.Quartile1 || qp.High != qp.Quartile3 {
+		t.Errorf("unexpected default range: got:[%v, %v] want:[%v, %v]", qp.Low, qp.High, qp.Quartile1, qp.Quartile3)
+	}
+	err = qp.SetPercentiles(2.5, 97.5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if qp.Low != 2.5 || qp.High != 97.5 {
+		t.Errorf("unexpected percentiles: got:[%v, %v] want:[2.5, 97.5]", qp.Low, qp.High)
+	}
+	for _, p := range [][2]float64{{-1, 50}, {50, 101}, {60, 40}} {
+		if qp.SetPercentiles(p[0], p[1]) == nil {
+			t.Errorf("expected error for percentiles %v", p)
+		}
+	}
+
+	// Outside points are labelled at the offset of the plot.
+	qp, err = NewQuartPlot(0, Values{0, 10, 10, 10, 10, 100})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	qp.Offset = vg.Points(10)
+	ls, err := qp.OutsideLabels(XYLabels{XYs: make(XYs, 6), Labels: []string{"a", "b", "c", "d", "e", "f"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := qp.MedianStyle.Radius/2 + qp.Offset; ls.XOffset != want {
+		t.Errorf("unexpected label offset: got:%v want:%v", ls.XOffset, want)
+	}
+}
+
+func TestQuartPlotLiteral(t *testing.T) {
+	// A QuartPlot with its quartiles set but Low and
+	// High unset draws the gap at the quartiles.
+	qp := &QuartPlot{
+		MedianStyle:  DefaultQuartMedianStyle,
+		WhiskerStyle: DefaultQuartWhiskerStyle,
+	}
+	qp.Median = 5
+	qp.Quartile1, qp.Quartile3 = 3, 7
+	qp.AdjLow, qp.AdjHigh = 0, 10
+	if low, high := qp.gap(); low != 3 || high != 7 {
+		t.Errorf("unexpected gap: got:[%v, %v] want:[3, 7]", low, high)
+	}
+	if low, high := qp.whiskers(); low != 0 || high != 10 {
+		t.Errorf("unexpected whiskers: got:[%v, %v] want:[0, 10]", low, high)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = -1, 1
+	p.Y.Min, p.Y.Max = 0, 10
+	var rec recorder.Canvas
+	qp.Plot(draw.NewCanvas(&rec, 100, 100), p)
+	var ends []vg.Length
+	for _, a := range rec.Actions {
+		if s, ok := a.(*recorder.Stroke); ok {
+			ends = append(ends, s.Path[len(s.Path)-1].Pos.Y)
+		}
+	}
+	if len(ends) != 2 || ends[0] != 70 || ends[1] != 30 {
+		t.Errorf("unexpected inner ends of whiskers: got:%v want:[70 30]", ends)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Quiver implements the Plotter interface, drawing an
+// arrow for each of a set of vectors based at a set of
+// points.
+type Quiver struct {
+	// XYs is a copy of the base points of the arrows.
+	XYs
+
+	// Vectors is a copy of the vectors drawn at each
+	// of the base points.
+	Vectors XYs
+
+	// Scale is the length in data units of the arrow
+	// drawn for a vector of unit length. If Scale is
+	// zero the arrows are scaled so that the longest
+	// is the typical spacing of the base points.
+	Scale float64
+
+	// HeadSize is the length of the arrow heads.
+	HeadSize vg.Length
+
+	draw.LineStyle
+}
+
+// NewQuiver returns a Quiver drawing the vectors in vecs at
+// the points in xys using the default line style.
+func NewQuiver(xys, vecs XYer) (*Quiver, error) {
+	if xys.Len() != vecs.Len() {
+		return nil, errors.New("plotter: points and vectors lengths mismatch")
+	}
+	pts, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	vs, err := CopyXYs(vecs)
+	if err != nil {
+		return nil, err
+	}
+	return &Quiver{
+		XYs:       pts,
+		Vectors:   vs,
+		HeadSize:  vg.Points(4),
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// NewVectorField returns a Quiver drawing the vectors of f
+// on an n×n grid of points spanning the given ranges.
+func NewVectorField(f func(x, y float64) (dx, dy float64), xmin, xmax, ymin, ymax float64, n int) (*Quiver, error) {
+	if n < 1 {
+		return nil, ErrNoData
+	}
+	pts := make(XYs, 0, n*n)
+	vs := make(XYs, 0, n*n)
+	for i := 0; i < n; i++ {
+		x := gridCentre(xmin, xmax, i, n)
+		for j := 0; j < n; j++ {
+			y := gridCentre(ymin, ymax, j, n)
+			dx, dy := f(x, y)
+			if !isFinite(dx, dy) {
+				continue
+			}
+			pts = append(pts, struct{ X, Y float64 }{x, y})
+			vs = append(vs, struct{ X, Y float64 }{dx, dy})
+		}
+	}
+	return NewQuiver(pts, vs)
+}
+
+// gridCentre returns the centre of the ith of n equal
+// divisions of [min, max].
+func gridCentre(min, max float64, i, n int) float64 {
+	return min + (max-min)*(float64(i)+0.5)/float64(n)
+}
+
+// scale returns the length in data units of the arrow of a
+// vector of unit length.
+func (q *Quiver) scale() float64 {
+	if q.Scale != 0 {
+		return q.Scale
+	}
+	var max float64
+	for _, v := range q.Vectors {
+		max = math.Max(max, math.Hypot(v.X, v.Y))
+	}
+	if max == 0 || len(q.XYs) == 0 {
+		return 1
+	}
+	xmin, xmax, ymin, ymax := XYRange(q.XYs)
+	w, h := xmax-xmin, ymax-ymin
+	n := math.Sqrt(float64(len(q.XYs)))
+	var spacing float64
+	switch {
+	case w == 0 && h == 0:
+		return 1 / max
+	case w == 0 || h == 0:
+		spacing = (w + h) / (float64(len(q.XYs)) - 1)
+	default:
+		spacing = math.Sqrt(w*h) / (n - 1)
+	}
+	if math.IsInf(spacing, 0) || spacing == 0 {
+		return 1 / max
+	}
+	return spacing / max
+}
+
+// Plot draws the Quiver, implementing the plot.Plotter
+// interface.
+func (q *Quiver) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	s := q.scale()
+	if q.LineStyle.Color != nil {
+		c.SetColor(q.LineStyle.Color)
+	}
+	for i, p := range q.XYs {
+		v := q.Vectors[i]
+		tail := vg.Point{X: trX(p.X), Y: trY(p.Y)}
+		tip := vg.Point{X: trX(p.X + s*v.X), Y: trY(p.Y + s*v.Y)}
+		if !c.Contains(tail) {
+			continue
+		}
+		d := distance(tail, tip)
+		if d == 0 {
+			continue
+		}
+		c.StrokeLines(q.LineStyle, c.ClipLinesXY([]vg.Point{tail, tip})...)
+		if q.HeadSize == 0 || q.LineStyle.Color == nil || !c.Contains(tip) {
+			continue
+		}
+		dir := tip.Sub(tail).Scale(1 / d)
+		c.Fill(arrowHead(tip.Sub(dir.Scale(q.HeadSize/2)), dir, q.HeadSize))
+	}
+}
+
+// DataRange returns the minimum and maximum x and y values
+// of the tails and tips of the arrows, implementing the
+// plot.DataRanger interface.
+func (q *Quiver) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	s := q.scale()
+	for i, p := range q.XYs {
+		v := q.Vectors[i]
+		xmin = math.Min(xmin, math.Min(p.X, p.X+s*v.X))
+		xmax = math.Max(xmax, math.Max(p.X, p.X+s*v.X))
+		ymin = math.Min(ymin, math.Min(p.Y, p.Y+s*v.Y))
+		ymax = math.Max(ymax, math.Max(p.Y, p.Y+s*v.Y))
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// Thumbnail draws an arrow in the style of the Quiver,
+// implementing the plot.Thumbnailer interface.
+func (q *Quiver) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(q.LineStyle, c.Min.X, y, c.Max.X, y)
+	if q.HeadSize == 0 || q.LineStyle.Color == nil {
+		return
+	}
+	c.SetColor(q.LineStyle.Color)
+	tip := vg.Point{X: c.Max.X - q.HeadSize/2, Y: y}
+	c.Fill(arrowHead(tip, vg.Point{X: 1}, q.HeadSize))
+}
@@ -0,0 +1,68 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+)
+
+// ExampleQuiver draws the vector field of a vortex.
+func ExampleQuiver() {
+	q, err := NewVectorField(func(x, y float64) (dx, dy float64) {
+		r := math.Hypot(x, y) + 0.5
+		return -y / r, x / r
+	}, -2, 2, -2, 2, 10)
+	if err != nil {
+		log.Panic(err)
+	}
+	q.Color = color.RGBA{B: 196, A: 255}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Quiver"
+	p.Add(q)
+	p.DataAspect = 1
+
+	err = p.Save(200, 200, "testdata/quiver.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestQuiver(t *testing.T) {
+	cmpimg.CheckPlot(ExampleQuiver, t, "quiver.png")
+}
+
+func TestQuiverScale(t *testing.T) {
+	q, err := NewVectorField(func(x, y float64) (dx, dy float64) { return x, 0 }, 0, 4, 0, 4, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(q.XYs) != 16 {
+		t.Fatalf("unexpected number of arrows: got:%d want:16", len(q.XYs))
+	}
+	// The grid spacing is 1 and the longest vector
+	// has length 3.5.
+	if got, want := q.scale(), 1/3.5; math.Abs(got-want) > 1e-12 {
+		t.Errorf("unexpected automatic scale: got:%v want:%v", got, want)
+	}
+	xmin, xmax, _, _ := q.DataRange()
+	if xmin != 0.5 || math.Abs(xmax-4.5) > 1e-12 {
+		t.Errorf("unexpected x range: got:[%v, %v] want:[0.5, 4.5]", xmin, xmax)
+	}
+
+	_, err = NewQuiver(q.XYs, q.Vectors[1:])
+	if err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+}
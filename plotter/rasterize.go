@@ -0,0 +1,265 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// RasterReduction specifies how the points falling
+// within a raster cell are combined into a single value.
+type RasterReduction int
+
+const (
+	// RasterCount counts the points in each cell.
+	RasterCount RasterReduction = iota
+
+	// RasterMean averages the Z values of the points
+	// in each cell.
+	RasterMean
+
+	// RasterMax takes the maximum Z value of the points
+	// in each cell.
+	RasterMax
+)
+
+// Rasterize implements the Plotter interface, aggregating
+// a large number of points into a grid at the resolution
+// of the canvas and drawing the grid as an image colored
+// by a ColorMap. Rasterize is intended for data sets
+// that are too large to draw glyph by glyph.
+//
+// Unlike most plotters, Rasterize does not copy its data.
+type Rasterize struct {
+	// XYs holds the points to aggregate. If XYs also
+	// implements XYZer, its Z values are used by the
+	// RasterMean and RasterMax reductions.
+	XYs XYer
+
+	// Reduction specifies how points are combined
+	// within a cell.
+	Reduction RasterReduction
+
+	// ColorMap is used to color the cells. The
+	// aggregated cell values are scaled to the range
+	// of the ColorMap.
+	ColorMap palette.ColorMap
+
+	// Equalize specifies whether the cell values are
+	// histogram equalized before being colored, spreading
+	// the colors evenly over the populated cells.
+	Equalize bool
+
+	// Resolution is the side length of a cell. If
+	// Resolution is not positive, cells are one point
+	// square.
+	Resolution vg.Length
+}
+
+// NewRasterize returns a Rasterize that counts the points in
+// xys and colors the cells using cmap.
+func NewRasterize(xys XYer, cmap palette.ColorMap) (*Rasterize, error) {
+	if xys.Len() == 0 {
+		return nil, ErrNoData
+	}
+	return &Rasterize{
+		XYs:      xys,
+		ColorMap: cmap,
+	}, nil
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (r *Rasterize) Plot(c draw.Canvas, plt *plot.Plot) {
+	if r.ColorMap == nil {
+		plt.Fail(errors.New("plotter: nil ColorMap in Rasterize"))
+		return
+	}
+	res := r.Resolution
+	if res <= 0 {
+		res = 1
+	}
+	cols := int(math.Ceil(float64((c.Max.X - c.Min.X) / res)))
+	rows := int(math.Ceil(float64((c.Max.Y - c.Min.Y) / res)))
+	if cols <= 0 || rows <= 0 {
+		return
+	}
+
+	grid := r.aggregate(plt, cols, rows)
+	if grid == nil {
+		return
+	}
+	plt.ReportProgress(0.9, "rasterize image")
+
+	// Find the values used to scale the cells to the ColorMap.
+	var vals []float64
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range grid {
+		if math.IsNaN(v) {
+			continue
+		}
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+		if r.Equalize {
+			vals = append(vals, v)
+		}
+	}
+	if math.IsInf(lo, 0) {
+		return
+	}
+	sort.Float64s(vals)
+
+	cmin, cmax := r.ColorMap.Min(), r.ColorMap.Max()
+	img := image.NewNRGBA(image.Rect(0, 0, cols, rows))
+	for i, v := range grid {
+		if math.IsNaN(v) {
+			continue
+		}
+		var t float64
+		switch {
+		case r.Equalize:
+			t = float64(sort.SearchFloat64s(vals, v)) / float64(len(vals))
+		case hi > lo:
+			t = (v - lo) / (hi - lo)
+		}
+		col, err := r.ColorMap.At(cmin + t*(cmax-cmin))
+		if err != nil {
+			continue
+		}
+		img.Set(i%cols, rows-1-i/cols, col)
+	}
+
+	rect := vg.Rectangle{
+		Min: c.Min,
+		Max: vg.Point{
+			X: c.Min.X + vg.Length(cols)*res,
+			Y: c.Min.Y + vg.Length(rows)*res,
+		},
+	}
+	c.DrawImage(rect, img)
+}
+
+// aggregate returns the row-major grid of reduced cell values,
+// with row zero at the bottom of the canvas. Empty cells
+// hold NaN. The returned grid is nil if the rendering of
+// plt is canceled.
+func (r *Rasterize) aggregate(plt *plot.Plot, cols, rows int) []float64 {
+	grid := make([]float64, cols*rows)
+	var count []float64
+	if r.Reduction == RasterMean {
+		count = make([]float64, cols*rows)
+	}
+	for i := range grid {
+		grid[i] = math.NaN()
+	}
+
+	xyz, hasZ := r.XYs.(XYZer)
+	for i := 0; i < r.XYs.Len(); i++ {
+		if i%cancelInterval == 0 {
+			if plt.Context().Err() != nil {
+				return nil
+			}
+			plt.ReportProgress(0.9*float64(i)/float64(r.XYs.Len()), "rasterize points")
+		}
+		x, y := r.XYs.XY(i)
+		z := 1.0
+		if hasZ && r.Reduction != RasterCount {
+			_, _, z = xyz.XYZ(i)
+		}
+		if CheckFloats(x, y, z) != nil {
+			continue
+		}
+		nx, ny := plt.X.Norm(x), plt.Y.Norm(y)
+		if nx < 0 || nx > 1 || ny < 0 || ny > 1 {
+			continue
+		}
+		col := minInt(int(nx*float64(cols)), cols-1)
+		row := minInt(int(ny*float64(rows)), rows-1)
+		k := row*cols + col
+
+		v := grid[k]
+		if math.IsNaN(v) {
+			v = 0
+			if r.Reduction == RasterMax {
+				v = math.Inf(-1)
+			}
+		}
+		switch r.Reduction {
+		case RasterCount:
+			v++
+		case RasterMean:
+			v += z
+			count[k]++
+		case RasterMax:
+			v = math.Max(v, z)
+		default:
+			panic("plotter: unknown raster reduction")
+		}
+		grid[k] = v
+	}
+	if r.Reduction == RasterMean {
+		for k, n := range count {
+			if n > 0 {
+				grid[k] /= n
+			}
+		}
+	}
+	return grid
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (r *Rasterize) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for i := 0; i < r.XYs.Len(); i++ {
+		x, y := r.XYs.XY(i)
+		if CheckFloats(x, y) != nil {
+			continue
+		}
+		xmin = math.Min(xmin, x)
+		xmax = math.Max(xmax, x)
+		ymin = math.Min(ymin, y)
+		ymax = math.Max(ymax, y)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// rasterThumbnailColor is the ColorMap value used
+// to draw the Rasterize thumbnail.
+const rasterThumbnailColor = 0.75
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (r *Rasterize) Thumbnail(c *draw.Canvas) {
+	if r.ColorMap == nil {
+		return
+	}
+	cmin, cmax := r.ColorMap.Min(), r.ColorMap.Max()
+	col, err := r.ColorMap.At(cmin + rasterThumbnailColor*(cmax-cmin))
+	if err != nil {
+		col = color.Black
+	}
+	c.FillPolygon(col, []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+	})
+}
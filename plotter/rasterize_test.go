@@ -0,0 +1,81 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/vg"
+)
+
+func TestRasterizeAggregate(t *testing.T) {
+	data := XYZs{
+		{X: 0.1, Y: 0.1, Z: 1},
+		{X: 0.2, Y: 0.2, Z: 3},
+		{X: 0.9, Y: 0.9, Z: 5},
+		{X: 0.6, Y: 0.1, Z: math.NaN()},
+	}
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+
+	for _, test := range []struct {
+		reduction RasterReduction
+		want      []float64
+	}{
+		{reduction: RasterCount, want: []float64{2, 1, math.NaN(), 1}},
+		{reduction: RasterMean, want: []float64{2, math.NaN(), math.NaN(), 5}},
+		{reduction: RasterMax, want: []float64{3, math.NaN(), math.NaN(), 5}},
+	} {
+		r, err := NewRasterize(data, moreland.SmoothBlueRed())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		r.Reduction = test.reduction
+		got := r.aggregate(p, 2, 2)
+		for i := range got {
+			if got[i] != test.want[i] && !(math.IsNaN(got[i]) && math.IsNaN(test.want[i])) {
+				t.Errorf("unexpected result for reduction %d: got:%v want:%v", test.reduction, got, test.want)
+				break
+			}
+		}
+	}
+}
+
+func TestRasterize(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	data := make(XYs, 100000)
+	for i := range data {
+		data[i].X = rnd.NormFloat64()
+		data[i].Y = data[i].X + rnd.NormFloat64()
+	}
+	for _, eq := range []bool{false, true} {
+		r, err := NewRasterize(data, moreland.ExtendedBlackBody())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		r.Equalize = eq
+		r.Resolution = 2
+
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Add(r)
+		p.Legend.Add("density", r)
+		_, err = p.WriterTo(5*vg.Centimeter, 5*vg.Centimeter, "png")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
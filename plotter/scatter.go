@@ -5,7 +5,12 @@
 package plotter
 
 import (
+	"image/color"
+	"math"
+	"sync"
+
 	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 )
@@ -23,10 +28,49 @@ type Scatter struct {
 	// GlyphStyle is the style of the glyphs drawn
 	// at each point.
 	draw.GlyphStyle
+
+	// ColorMap, if not nil, colors the glyph of each
+	// point by its value in Z, overriding the color of
+	// its glyph style. Values outside the range of the
+	// ColorMap are drawn with the color of the nearest
+	// limit. The ColorMap is not used if the length of
+	// Z differs from that of XYs.
+	ColorMap palette.ColorMap
+
+	// Z holds the values of the points mapped to
+	// colors by the ColorMap.
+	Z Values
+
+	// Downsampler, if not nil, is used to reduce the
+	// number of glyphs drawn when the number of points
+	// greatly exceeds the width of the canvas in dots
+	// at its resolution.
+	Downsampler Downsampler
+
+	// Density scales the radius and alpha of the glyphs
+	// by the number of points within the axis ranges, so
+	// that dense scatters remain legible. The zero value
+	// does not scale the glyphs. The legend thumbnail is
+	// not scaled.
+	Density DensityScaling
+}
+
+// scatterBuffers holds storage reused between calls
+// to Scatter.Plot.
+type scatterBuffers struct {
+	idx []int
+	ps  []vg.Point
+}
+
+// scatterBufferPool holds the scatterBuffers of calls to
+// Scatter.Plot, so that a Scatter may be drawn concurrently.
+var scatterBufferPool = sync.Pool{
+	New: func() interface{} { return new(scatterBuffers) },
 }
 
 // NewScatter returns a Scatter that uses the
-// default glyph style.
+// default glyph style. The points of xys are
+// copied into the XYs of the Scatter.
 func NewScatter(xys XYer) (*Scatter, error) {
 	data, err := CopyXYs(xys)
 	if err != nil {
@@ -39,16 +83,138 @@ func NewScatter(xys XYer) (*Scatter, error) {
 }
 
 // Plot draws the Scatter, implementing the plot.Plotter
-// interface.
+// interface. Points outside the axis ranges are skipped
+// before they are transformed to the canvas.
 func (pts *Scatter) Plot(c draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&c)
 	glyph := func(i int) draw.GlyphStyle { return pts.GlyphStyle }
 	if pts.GlyphStyleFunc != nil {
 		glyph = pts.GlyphStyleFunc
 	}
+	if pts.ColorMap != nil && len(pts.Z) == len(pts.XYs) {
+		style := glyph
+		glyph = func(i int) draw.GlyphStyle {
+			sty := style(i)
+			sty.Color = mapColor(pts.ColorMap, pts.Z[i])
+			return sty
+		}
+	}
+	ds := pts.density(plt)
+	if pts.Downsampler == nil {
+		for i, p := range pts.XYs {
+			if canceled(plt, i) {
+				return
+			}
+			if !inRange(plt, p.X, p.Y) {
+				continue
+			}
+			c.DrawGlyph(ds.apply(glyph(i)), vg.Point{X: trX(p.X), Y: trY(p.Y)})
+		}
+		return
+	}
+
+	buf := scatterBufferPool.Get().(*scatterBuffers)
+	defer scatterBufferPool.Put(buf)
+	idx := buf.idx[:0]
+	ps := buf.ps[:0]
 	for i, p := range pts.XYs {
-		c.DrawGlyph(glyph(i), vg.Point{X: trX(p.X), Y: trY(p.Y)})
+		if !inRange(plt, p.X, p.Y) {
+			continue
+		}
+		idx = append(idx, i)
+		ps = append(ps, vg.Point{X: trX(p.X), Y: trY(p.Y)})
+	}
+	buf.idx, buf.ps = idx, ps
+	if sel := downsample(pts.Downsampler, ps, widthDots(c)); sel != nil {
+		for i, j := range sel {
+			if canceled(plt, i) {
+				return
+			}
+			c.DrawGlyph(ds.apply(glyph(idx[j])), ps[j])
+		}
+		return
+	}
+	for j, p := range ps {
+		if canceled(plt, j) {
+			return
+		}
+		c.DrawGlyph(ds.apply(glyph(idx[j])), p)
+	}
+}
+
+// DensityScaling scales the glyphs of a Scatter by the
+// number of points drawn.
+type DensityScaling struct {
+	// Radius and Alpha return the factors by which
+	// the radius and the alpha of the color of glyphs
+	// are scaled when n points are drawn. If either is
+	// nil that property is not scaled.
+	Radius, Alpha func(n int) float64
+}
+
+// DefaultDensityScaling reduces the radius and alpha of the
+// glyphs of scatters of more than one thousand points, to
+// a third of the radius and a tenth of the alpha at one
+// hundred thousand points.
+var DefaultDensityScaling = DensityScaling{
+	Radius: DensityCurve(1000, 0.25, 0.3),
+	Alpha:  DensityCurve(1000, 0.5, 0.1),
+}
+
+// DensityCurve returns a scaling function for DensityScaling that
+// returns one for up to n0 points and (n0/n)^exponent for n points
+// greater than n0, but no less than min.
+func DensityCurve(n0 int, exponent, min float64) func(n int) float64 {
+	return func(n int) float64 {
+		if n <= n0 {
+			return 1
+		}
+		return math.Max(min, math.Pow(float64(n0)/float64(n), exponent))
+	}
+}
+
+// densityScale holds the factors by which the radius and
+// alpha of glyphs are scaled.
+type densityScale struct {
+	radius, alpha float64
+}
+
+// scale returns the factors of the scaling for n points.
+func (d DensityScaling) scale(n int) densityScale {
+	s := densityScale{radius: 1, alpha: 1}
+	if d.Radius != nil {
+		s.radius = d.Radius(n)
 	}
+	if d.Alpha != nil {
+		s.alpha = math.Max(0, math.Min(1, d.Alpha(n)))
+	}
+	return s
+}
+
+// density returns the scaling of the glyphs of the
+// Scatter for the number of its points drawn in plt.
+func (pts *Scatter) density(plt *plot.Plot) densityScale {
+	if pts.Density.Radius == nil && pts.Density.Alpha == nil {
+		return densityScale{radius: 1, alpha: 1}
+	}
+	var n int
+	for _, p := range pts.XYs {
+		if inRange(plt, p.X, p.Y) {
+			n++
+		}
+	}
+	return pts.Density.scale(n)
+}
+
+// apply returns the glyph style sty scaled by s.
+func (s densityScale) apply(sty draw.GlyphStyle) draw.GlyphStyle {
+	sty.Radius *= vg.Length(s.radius)
+	if s.alpha != 1 && sty.Color != nil {
+		c := color.NRGBAModel.Convert(sty.Color).(color.NRGBA)
+		c.A = uint8(math.Round(float64(c.A) * s.alpha))
+		sty.Color = c
+	}
+	return sty
 }
 
 // DataRange returns the minimum and maximum
@@ -65,15 +231,20 @@ func (pts *Scatter) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 	if pts.GlyphStyleFunc != nil {
 		glyph = pts.GlyphStyleFunc
 	}
-	bs := make([]plot.GlyphBox, len(pts.XYs))
+	bs := make([]plot.GlyphBox, 0, len(pts.XYs))
 	for i, p := range pts.XYs {
-		bs[i].X = plt.X.Norm(p.X)
-		bs[i].Y = plt.Y.Norm(p.Y)
-		r := glyph(i).Radius
-		bs[i].Rectangle = vg.Rectangle{
-			Min: vg.Point{X: -r, Y: -r},
-			Max: vg.Point{X: +r, Y: +r},
+		if !isFinite(p.X, p.Y) {
+			continue
 		}
+		r := glyph(i).Radius
+		bs = append(bs, plot.GlyphBox{
+			X: plt.X.Norm(p.X),
+			Y: plt.Y.Norm(p.Y),
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{X: -r, Y: -r},
+				Max: vg.Point{X: +r, Y: +r},
+			},
+		})
 	}
 	return bs
 }
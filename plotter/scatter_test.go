@@ -5,8 +5,10 @@
 package plotter
 
 import (
+	"context"
 	"image/color"
 	"log"
+	"math"
 	"testing"
 
 	"golang.org/x/exp/rand"
@@ -15,6 +17,7 @@ import (
 	"gonum.org/v1/plot/internal/cmpimg"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
 )
 
 // ExampleScatter draws some scatter points, a line,
@@ -88,3 +91,108 @@ func ExampleScatter() {
 func TestScatter(t *testing.T) {
 	cmpimg.CheckPlot(ExampleScatter, t, "scatter.png")
 }
+
+// ExampleScatter_density draws a large scatter with the radius
+// and alpha of its glyphs reduced by the number of points.
+func ExampleScatter_density() {
+	rnd := rand.New(rand.NewSource(1))
+	data := make(XYs, 20000)
+	for i := range data {
+		data[i].X = rnd.NormFloat64()
+		data[i].Y = data[i].X + rnd.NormFloat64()
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Density scaled glyphs"
+
+	s, err := NewScatter(data)
+	if err != nil {
+		log.Panic(err)
+	}
+	s.GlyphStyle.Color = color.RGBA{B: 160, A: 255}
+	s.GlyphStyle.Radius = vg.Points(3)
+	s.GlyphStyle.Shape = draw.CircleGlyph{}
+	s.Density = DefaultDensityScaling
+	p.Add(s)
+
+	err = p.Save(250, 250, "testdata/scatterDensity.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestScatterDensity(t *testing.T) {
+	cmpimg.CheckPlot(ExampleScatter_density, t, "scatterDensity.png")
+
+	curve := DensityCurve(100, 0.5, 0.2)
+	for _, test := range []struct {
+		n    int
+		want float64
+	}{
+		{n: 0, want: 1},
+		{n: 100, want: 1},
+		{n: 400, want: 0.5},
+		{n: 1e6, want: 0.2},
+	} {
+		if got := curve(test.n); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("unexpected scale for %d points: got:%v want:%v", test.n, got, test.want)
+		}
+	}
+
+	base := draw.GlyphStyle{Color: color.NRGBA{B: 255, A: 200}, Radius: 4}
+	d := DensityScaling{Radius: curve, Alpha: curve}
+	got := d.scale(400).apply(base)
+	want := draw.GlyphStyle{Color: color.NRGBA{B: 255, A: 100}, Radius: 2}
+	if got != want {
+		t.Errorf("unexpected scaled style: got:%+v want:%+v", got, want)
+	}
+	got = DensityScaling{Radius: curve}.scale(400).apply(base)
+	want = draw.GlyphStyle{Color: base.Color, Radius: 2}
+	if got != want {
+		t.Errorf("unexpected radius scaled style: got:%+v want:%+v", got, want)
+	}
+}
+
+// cancelGlyph counts the glyphs it draws and
+// calls cancel after drawing the first.
+type cancelGlyph struct {
+	n      *int
+	cancel context.CancelFunc
+}
+
+func (g cancelGlyph) DrawGlyph(*draw.Canvas, draw.GlyphStyle, vg.Point) {
+	*g.n++
+	g.cancel()
+}
+
+func TestScatterCancel(t *testing.T) {
+	pts := make(XYs, 3*cancelInterval)
+	for i := range pts {
+		pts[i].X = float64(i)
+		pts[i].Y = float64(i)
+	}
+	s, err := NewScatter(pts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	var n int
+	s.Shape = cancelGlyph{n: &n, cancel: cancel}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(s)
+	c := draw.Canvas{Canvas: new(recorder.Canvas), Rectangle: vg.Rectangle{Max: vg.Point{X: 10 * vg.Centimeter, Y: 10 * vg.Centimeter}}}
+	err = p.DrawContext(ctx, c)
+	if err != context.Canceled {
+		t.Errorf("unexpected error: got:%v want:%v", err, context.Canceled)
+	}
+	if n != cancelInterval {
+		t.Errorf("unexpected number of glyphs drawn after cancellation: got:%d want:%d", n, cancelInterval)
+	}
+}
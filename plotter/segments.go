@@ -0,0 +1,96 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Segments implements the Plotter interface, drawing a
+// set of independent line segments, such as the lines of
+// a slopegraph or the connections between points.
+type Segments struct {
+	// From and To are copies of the start and end
+	// points of the segments.
+	From, To XYs
+
+	// LineStyleFunc, if not nil, specifies the
+	// LineStyles of individual segments.
+	LineStyleFunc func(int) draw.LineStyle
+
+	// LineStyle is the style of the segments.
+	draw.LineStyle
+}
+
+// NewSegments returns Segments drawing the segments from the
+// points in from to the corresponding points in to using the
+// default line style.
+func NewSegments(from, to XYer) (*Segments, error) {
+	if from.Len() != to.Len() {
+		return nil, errors.New("plotter: segment start and end lengths mismatch")
+	}
+	f, err := CopyXYs(from)
+	if err != nil {
+		return nil, err
+	}
+	t, err := CopyXYs(to)
+	if err != nil {
+		return nil, err
+	}
+	return &Segments{
+		From:      f,
+		To:        t,
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// Plot draws the Segments, implementing the plot.Plotter
+// interface.
+func (s *Segments) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for i, p := range s.From {
+		if canceled(plt, i) {
+			return
+		}
+		sty := s.LineStyle
+		if s.LineStyleFunc != nil {
+			sty = s.LineStyleFunc(i)
+		}
+		q := s.To[i]
+		seg := []vg.Point{
+			{X: trX(p.X), Y: trY(p.Y)},
+			{X: trX(q.X), Y: trY(q.Y)},
+		}
+		c.StrokeLines(sty, c.ClipLinesXY(seg)...)
+	}
+}
+
+// DataRange returns the minimum and maximum x and y values
+// of the ends of the segments, implementing the
+// plot.DataRanger interface.
+func (s *Segments) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	for i, p := range s.From {
+		q := s.To[i]
+		xmin = math.Min(xmin, math.Min(p.X, q.X))
+		xmax = math.Max(xmax, math.Max(p.X, q.X))
+		ymin = math.Min(ymin, math.Min(p.Y, q.Y))
+		ymax = math.Max(ymax, math.Max(p.Y, q.Y))
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// Thumbnail draws a line in the style of the Segments,
+// implementing the plot.Thumbnailer interface.
+func (s *Segments) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(s.LineStyle, c.Min.X, y, c.Max.X, y)
+}
@@ -0,0 +1,112 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+// ExampleSegments draws a slopegraph of the values of
+// five groups in two years, with the groups whose value
+// fell drawn in red.
+func ExampleSegments() {
+	before := XYs{{X: 0, Y: 42}, {X: 0, Y: 35}, {X: 0, Y: 30}, {X: 0, Y: 21}, {X: 0, Y: 12}}
+	after := XYs{{X: 1, Y: 48}, {X: 1, Y: 27}, {X: 1, Y: 37}, {X: 1, Y: 25}, {X: 1, Y: 9}}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Slopegraph"
+	p.Y.Label.Text = "Value"
+	p.NominalX("2010", "2020")
+
+	s, err := NewSegments(before, after)
+	if err != nil {
+		log.Panic(err)
+	}
+	s.LineStyle = draw.LineStyle{Color: color.Black, Width: vg.Points(1.5)}
+	s.LineStyleFunc = func(i int) draw.LineStyle {
+		sty := s.LineStyle
+		if s.To[i].Y < s.From[i].Y {
+			sty.Color = color.RGBA{R: 200, A: 255}
+		}
+		return sty
+	}
+	p.Add(s)
+
+	for _, ends := range []XYs{before, after} {
+		sc, err := NewScatter(ends)
+		if err != nil {
+			log.Panic(err)
+		}
+		sc.GlyphStyle = draw.GlyphStyle{Color: color.Black, Radius: vg.Points(3), Shape: draw.CircleGlyph{}}
+		p.Add(sc)
+	}
+
+	err = p.Save(150, 200, "testdata/segments.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestSegmentsPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleSegments, t, "segments.png")
+}
+
+func TestSegments(t *testing.T) {
+	from := XYs{{X: 0, Y: 5}, {X: -2, Y: 1}}
+	to := XYs{{X: 3, Y: -1}, {X: 1, Y: 2}}
+	if _, err := NewSegments(from, to[:1]); err == nil {
+		t.Errorf("expected error for mismatched lengths")
+	}
+
+	s, err := NewSegments(from, to)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xmin, xmax, ymin, ymax := s.DataRange()
+	if xmin != -2 || xmax != 3 || ymin != -1 || ymax != 5 {
+		t.Errorf("unexpected data range: got:%v %v %v %v want:-2 3 -1 5", xmin, xmax, ymin, ymax)
+	}
+
+	widths := []vg.Length{1, 3}
+	s.LineStyleFunc = func(i int) draw.LineStyle {
+		sty := s.LineStyle
+		sty.Width = widths[i]
+		return sty
+	}
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.HideAxes()
+	p.Add(s)
+	var rec recorder.Canvas
+	p.Draw(draw.NewCanvas(&rec, 100, 100))
+	var got []vg.Length
+	var width vg.Length
+	for _, a := range rec.Actions {
+		switch a := a.(type) {
+		case *recorder.SetLineWidth:
+			width = a.Width
+		case *recorder.Stroke:
+			if len(a.Path) == 2 && width > 0 {
+				got = append(got, width)
+			}
+		}
+	}
+	if len(got) != 2 || got[0] != widths[0] || got[1] != widths[1] {
+		t.Errorf("unexpected segment widths: got:%v want:%v", got, widths)
+	}
+}
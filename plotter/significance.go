@@ -0,0 +1,249 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Comparison is a statistical comparison between the
+// data at two categorical positions on the X axis.
+type Comparison struct {
+	// A and B are the X positions of the compared
+	// categories.
+	A, B float64
+
+	// P is the p-value of the comparison.
+	P float64
+
+	// Label is the label of the comparison. If Label
+	// is empty, the label is Stars(P).
+	Label string
+}
+
+// Stars returns the conventional label of the p-value p:
+// "****" for p ≤ 0.0001, "***" for p ≤ 0.001, "**" for
+// p ≤ 0.01, "*" for p ≤ 0.05 and "ns" otherwise.
+func Stars(p float64) string {
+	switch {
+	case p <= 0.0001:
+		return "****"
+	case p <= 0.001:
+		return "***"
+	case p <= 0.01:
+		return "**"
+	case p <= 0.05:
+		return "*"
+	default:
+		return "ns"
+	}
+}
+
+// SignificanceBrackets implements the Plotter interface, drawing
+// brackets spanning the compared categories of statistical
+// comparisons, labelled with the significance of each, above
+// vertical categorical plots such as box plots or bar charts.
+//
+// Each bracket is drawn above the tops of the data it spans.
+// Brackets whose spans overlap are stacked, narrower brackets
+// below wider ones, so that no two brackets or labels collide.
+type SignificanceBrackets struct {
+	// Comparisons are the comparisons to draw.
+	Comparisons []Comparison
+
+	// Tops holds the height of the top of the data at
+	// each categorical position, such as the largest
+	// value or outlier of a box plot. A bracket is drawn
+	// above the tops of the positions within its span.
+	Tops XYs
+
+	// LineStyle is the style of the brackets.
+	LineStyle draw.LineStyle
+
+	// TextStyle is the style of the labels.
+	TextStyle draw.TextStyle
+
+	// Gap is the vertical space between a bracket and
+	// the data or bracket below it.
+	Gap vg.Length
+
+	// Tip is the length of the ticks at the ends of
+	// each bracket, pointing down to the compared data.
+	Tip vg.Length
+}
+
+// NewSignificanceBrackets returns SignificanceBrackets drawing the
+// given comparisons above data with tops at the given positions,
+// using the default line style and the DefaultFont and
+// DefaultFontSize for labels. An error is returned if no top
+// lies within the span of a comparison.
+func NewSignificanceBrackets(tops XYer, comps ...Comparison) (*SignificanceBrackets, error) {
+	xys, err := CopyXYs(tops)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range comps {
+		if err := CheckFloats(c.A, c.B); err != nil {
+			return nil, err
+		}
+		lo, hi := c.span()
+		var ok bool
+		for _, t := range xys {
+			if lo <= t.X && t.X <= hi {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return nil, errors.New("plotter: no data within comparison")
+		}
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &SignificanceBrackets{
+		Comparisons: comps,
+		Tops:        xys,
+		LineStyle:   DefaultLineStyle,
+		TextStyle:   draw.TextStyle{Font: fnt, XAlign: draw.XCenter},
+		Gap:         vg.Points(4),
+		Tip:         vg.Points(3),
+	}, nil
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (b *SignificanceBrackets) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	step := b.step()
+	for i, l := range b.layout() {
+		comp := b.Comparisons[i]
+		x0, x1 := trX(comp.A), trX(comp.B)
+		y := trY(l.base) + b.Gap + b.Tip + vg.Length(l.depth)*step
+		c.StrokeLines(b.LineStyle, []vg.Point{
+			{X: x0, Y: y - b.Tip},
+			{X: x0, Y: y},
+			{X: x1, Y: y},
+			{X: x1, Y: y - b.Tip},
+		})
+		c.FillText(b.TextStyle, vg.Point{X: (x0 + x1) / 2, Y: y}, b.label(i))
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (b *SignificanceBrackets) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = XYRange(b.Tops)
+	for _, c := range b.Comparisons {
+		lo, hi := c.span()
+		xmin = math.Min(xmin, lo)
+		xmax = math.Max(xmax, hi)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface. The glyph box
+// of each bracket reserves the space above the data
+// for the bracket and its label.
+func (b *SignificanceBrackets) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	step := b.step()
+	layout := b.layout()
+	bs := make([]plot.GlyphBox, len(layout))
+	for i, l := range layout {
+		c := b.Comparisons[i]
+		r := b.TextStyle.Rectangle(b.label(i))
+		bs[i].X = plt.X.Norm((c.A + c.B) / 2)
+		bs[i].Y = plt.Y.Norm(l.base)
+		bs[i].Rectangle = vg.Rectangle{
+			Min: vg.Point{X: r.Min.X},
+			Max: vg.Point{X: r.Max.X, Y: b.Gap + b.Tip + vg.Length(l.depth)*step + r.Max.Y - r.Min.Y},
+		}
+	}
+	return bs
+}
+
+// label returns the label of the ith comparison.
+func (b *SignificanceBrackets) label(i int) string {
+	if b.Comparisons[i].Label != "" {
+		return b.Comparisons[i].Label
+	}
+	return Stars(b.Comparisons[i].P)
+}
+
+// step returns the vertical distance between stacked
+// brackets.
+func (b *SignificanceBrackets) step() vg.Length {
+	var h vg.Length
+	for i := range b.Comparisons {
+		r := b.TextStyle.Rectangle(b.label(i))
+		if rh := r.Max.Y - r.Min.Y; rh > h {
+			h = rh
+		}
+	}
+	return h + b.Gap + b.Tip
+}
+
+// bracketLevel is the placement of a bracket. The bracket
+// is drawn depth steps above the base, in data coordinates.
+type bracketLevel struct {
+	base  float64
+	depth int
+}
+
+// layout returns the placement of each comparison's bracket.
+// Brackets are placed from narrowest to widest. Each is placed
+// above the tops within its span and one step above each
+// already placed bracket whose span overlaps its own.
+func (b *SignificanceBrackets) layout() []bracketLevel {
+	order := make([]int, len(b.Comparisons))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		loI, hiI := b.Comparisons[order[i]].span()
+		loJ, hiJ := b.Comparisons[order[j]].span()
+		if hiI-loI != hiJ-loJ {
+			return hiI-loI < hiJ-loJ
+		}
+		return loI < loJ
+	})
+
+	levels := make([]bracketLevel, len(b.Comparisons))
+	var placed []int
+	for _, i := range order {
+		lo, hi := b.Comparisons[i].span()
+		l := bracketLevel{base: math.Inf(-1)}
+		for _, t := range b.Tops {
+			if lo <= t.X && t.X <= hi {
+				l.base = math.Max(l.base, t.Y)
+			}
+		}
+		for _, j := range placed {
+			loJ, hiJ := b.Comparisons[j].span()
+			if hiJ < lo || hi < loJ {
+				continue
+			}
+			l.base = math.Max(l.base, levels[j].base)
+			if levels[j].depth+1 > l.depth {
+				l.depth = levels[j].depth + 1
+			}
+		}
+		levels[i] = l
+		placed = append(placed, i)
+	}
+	return levels
+}
+
+// span returns the X range spanned by the comparison.
+func (c Comparison) span() (lo, hi float64) {
+	return math.Min(c.A, c.B), math.Max(c.A, c.B)
+}
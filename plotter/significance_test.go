@@ -0,0 +1,121 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+)
+
+// ExampleSignificanceBrackets draws box plots of three groups
+// with brackets showing the significance of the differences
+// between them.
+func ExampleSignificanceBrackets() {
+	rnd := rand.New(rand.NewSource(1))
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Treatment response"
+	p.Y.Label.Text = "Response"
+
+	var tops XYs
+	for i, mean := range []float64{1, 1.6, 2.8} {
+		vs := make(Values, 30)
+		for j := range vs {
+			vs[j] = mean + 0.6*rnd.NormFloat64()
+		}
+		b, err := NewBoxPlot(vg.Points(30), float64(i), vs)
+		if err != nil {
+			log.Panic(err)
+		}
+		p.Add(b)
+
+		// The brackets are drawn above the
+		// largest value of each group.
+		top := vs[0]
+		for _, v := range vs {
+			if v > top {
+				top = v
+			}
+		}
+		tops = append(tops, struct{ X, Y float64 }{X: float64(i), Y: top})
+	}
+	p.NominalX("Control", "Low dose", "High dose")
+
+	brackets, err := NewSignificanceBrackets(tops,
+		Comparison{A: 0, B: 1, P: 0.04},
+		Comparison{A: 1, B: 2, P: 0.0006},
+		Comparison{A: 0, B: 2, P: 0.00002},
+	)
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Add(brackets)
+
+	err = p.Save(250, 250, "testdata/significanceBrackets.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestSignificanceBrackets(t *testing.T) {
+	cmpimg.CheckPlot(ExampleSignificanceBrackets, t, "significanceBrackets.png")
+}
+
+func TestStars(t *testing.T) {
+	for _, test := range []struct {
+		p    float64
+		want string
+	}{
+		{p: 0.00001, want: "****"},
+		{p: 0.0001, want: "****"},
+		{p: 0.0005, want: "***"},
+		{p: 0.005, want: "**"},
+		{p: 0.05, want: "*"},
+		{p: 0.2, want: "ns"},
+	} {
+		if got := Stars(test.p); got != test.want {
+			t.Errorf("unexpected label for p=%v: got:%q want:%q", test.p, got, test.want)
+		}
+	}
+}
+
+func TestSignificanceBracketsLayout(t *testing.T) {
+	tops := XYs{{X: 0, Y: 1}, {X: 1, Y: 3}, {X: 2, Y: 2}, {X: 3, Y: 1}}
+	b, err := NewSignificanceBrackets(tops,
+		Comparison{A: 0, B: 3},
+		Comparison{A: 2, B: 3},
+		Comparison{A: 0, B: 1},
+		Comparison{A: 1, B: 0},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := b.layout()
+	want := []bracketLevel{
+		{base: 3, depth: 2},
+		{base: 2, depth: 0},
+		{base: 3, depth: 0},
+		{base: 3, depth: 1},
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("unexpected placement of bracket %d: got:%+v want:%+v", i, got[i], want[i])
+		}
+	}
+
+	_, err = NewSignificanceBrackets(tops, Comparison{A: 4, B: 5})
+	if err == nil {
+		t.Error("expected error for comparison without data")
+	}
+}
@@ -0,0 +1,381 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// SmoothMethod specifies the method used by a Smoother
+// to fit a curve to data.
+type SmoothMethod int
+
+const (
+	// LOESS fits a polynomial of degree Degree by
+	// weighted least squares to the fraction Span of
+	// the data nearest each point of the curve.
+	LOESS SmoothMethod = iota
+
+	// Polynomial fits a polynomial of degree Degree
+	// to all the data by least squares.
+	Polynomial
+
+	// MovingAverage takes the mean of the Window
+	// points centred on each point of the data.
+	MovingAverage
+)
+
+// Smoother implements the Plotter interface, drawing a
+// smooth curve fitted to a set of points, with a band
+// showing the pointwise confidence interval of the fit.
+type Smoother struct {
+	// XYs is a copy of the points, sorted by x.
+	XYs
+
+	// Method is the method used to fit the curve.
+	Method SmoothMethod
+
+	// Span is the fraction of the points used for
+	// each local fit by the LOESS method.
+	Span float64
+
+	// Degree is the degree of the polynomials fitted
+	// by the LOESS and Polynomial methods.
+	Degree int
+
+	// Window is the number of points averaged by
+	// the MovingAverage method.
+	Window int
+
+	// Samples is the number of points at which the
+	// LOESS and Polynomial fits are evaluated. The
+	// moving average is evaluated at each point.
+	Samples int
+
+	// Level is the confidence level of the band. If
+	// Level is zero the band is not drawn.
+	Level float64
+
+	// LineStyle is the style of the fitted curve.
+	draw.LineStyle
+
+	// BandColor is the fill color of the confidence
+	// band.
+	BandColor color.Color
+}
+
+// NewSmoother returns a Smoother fitting the points in xys
+// using the method m. The span is 0.75, the window is 5
+// points, the LOESS degree is 2 and the Polynomial degree
+// is 1, giving a linear regression. The fit is evaluated
+// at 100 points with a 95% confidence band.
+func NewSmoother(xys XYer, m SmoothMethod) (*Smoother, error) {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 {
+		return nil, errors.New("plotter: too few points to smooth")
+	}
+	sort.Stable(xySorter(data))
+	degree := 2
+	if m == Polynomial {
+		degree = 1
+	}
+	return &Smoother{
+		XYs:       data,
+		Method:    m,
+		Span:      0.75,
+		Degree:    degree,
+		Window:    5,
+		Samples:   100,
+		Level:     0.95,
+		LineStyle: DefaultLineStyle,
+		BandColor: color.NRGBA{R: 128, G: 128, B: 128, A: 64},
+	}, nil
+}
+
+// xySorter sorts XYs by x.
+type xySorter XYs
+
+func (s xySorter) Len() int           { return len(s) }
+func (s xySorter) Less(i, j int) bool { return s[i].X < s[j].X }
+func (s xySorter) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// Curve returns the fitted curve and the lower and upper
+// bounds of its confidence interval at each of its points.
+// The bounds are nil if Level is zero or the fit leaves
+// too few degrees of freedom to estimate them.
+func (s *Smoother) Curve() (fit XYs, lower, upper []float64) {
+	if s.Method == MovingAverage {
+		return s.movingAverage()
+	}
+
+	xs := make([]float64, len(s.XYs))
+	ys := make([]float64, len(s.XYs))
+	for i, p := range s.XYs {
+		xs[i], ys[i] = p.X, p.Y
+	}
+	ws := make([]float64, len(xs))
+	weights := func(x0 float64) []float64 {
+		if s.Method == Polynomial {
+			for i := range ws {
+				ws[i] = 1
+			}
+			return ws
+		}
+		return s.loessWeights(xs, x0, ws)
+	}
+
+	// Estimate the residual variance from the fit at
+	// the data, using the trace of the smoothing matrix
+	// as the equivalent number of parameters.
+	var rss, trace float64
+	for i, x := range xs {
+		l := fitWeights(xs, weights(x), x, s.Degree)
+		trace += l[i]
+		r := ys[i] - dot(l, ys)
+		rss += r * r
+	}
+	dof := float64(len(xs)) - trace
+	band := s.Level != 0 && dof > 0
+	var t, sigma float64
+	if band {
+		t = distuv.StudentsT{Mu: 0, Sigma: 1, Nu: dof}.Quantile(0.5 + s.Level/2)
+		sigma = math.Sqrt(rss / dof)
+	}
+
+	n := s.Samples
+	if n < 2 {
+		n = 2
+	}
+	min, max := xs[0], xs[len(xs)-1]
+	fit = make(XYs, n)
+	if band {
+		lower = make([]float64, n)
+		upper = make([]float64, n)
+	}
+	for i := range fit {
+		x := min + (max-min)*float64(i)/float64(n-1)
+		l := fitWeights(xs, weights(x), x, s.Degree)
+		fit[i].X, fit[i].Y = x, dot(l, ys)
+		if band {
+			se := sigma * math.Sqrt(dot(l, l))
+			lower[i], upper[i] = fit[i].Y-t*se, fit[i].Y+t*se
+		}
+	}
+	return fit, lower, upper
+}
+
+// loessWeights returns in ws the tricube weights of the
+// points at xs for a local fit at x0.
+func (s *Smoother) loessWeights(xs []float64, x0 float64, ws []float64) []float64 {
+	q := int(math.Ceil(s.Span * float64(len(xs))))
+	if q < s.Degree+1 {
+		q = s.Degree + 1
+	}
+	if q > len(xs) {
+		q = len(xs)
+	}
+	d := make([]float64, len(xs))
+	for i, x := range xs {
+		d[i] = math.Abs(x - x0)
+	}
+	sort.Float64s(d)
+	h := d[q-1]
+	if s.Span > 1 {
+		h *= s.Span
+	}
+	for i, x := range xs {
+		u := math.Abs(x-x0) / h
+		if h == 0 || u >= 1 {
+			ws[i] = 0
+			if x == x0 {
+				ws[i] = 1
+			}
+			continue
+		}
+		w := 1 - u*u*u
+		ws[i] = w * w * w
+	}
+	return ws
+}
+
+// fitWeights returns the vector l such that the value at x0
+// of the polynomial of degree d fitted to points at xs by
+// least squares with weights ws is the dot product of l and
+// the values of the points. If the fit is singular the
+// degree is reduced.
+func fitWeights(xs, ws []float64, x0 float64, d int) []float64 {
+	// Scale the basis to keep the normal
+	// equations well conditioned.
+	var scale float64
+	for i, x := range xs {
+		if ws[i] != 0 {
+			scale = math.Max(scale, math.Abs(x-x0))
+		}
+	}
+	if scale == 0 {
+		scale = 1
+	}
+	for ; d >= 0; d-- {
+		p := d + 1
+		a := mat.NewSymDense(p, nil)
+		basis := make([]float64, p)
+		for i, x := range xs {
+			if ws[i] == 0 {
+				continue
+			}
+			powers(basis, (x-x0)/scale)
+			for j := 0; j < p; j++ {
+				for k := j; k < p; k++ {
+					a.SetSym(j, k, a.At(j, k)+ws[i]*basis[j]*basis[k])
+				}
+			}
+		}
+		var chol mat.Cholesky
+		if !chol.Factorize(a) {
+			continue
+		}
+		e := mat.NewVecDense(p, nil)
+		e.SetVec(0, 1)
+		var z mat.VecDense
+		if err := chol.SolveVec(&z, e); err != nil {
+			continue
+		}
+		l := make([]float64, len(xs))
+		for i, x := range xs {
+			if ws[i] == 0 {
+				continue
+			}
+			powers(basis, (x-x0)/scale)
+			for j := range basis {
+				l[i] += z.AtVec(j) * basis[j]
+			}
+			l[i] *= ws[i]
+		}
+		return l
+	}
+	return make([]float64, len(xs))
+}
+
+// powers fills dst with successive powers of x from x⁰.
+func powers(dst []float64, x float64) {
+	v := 1.0
+	for i := range dst {
+		dst[i] = v
+		v *= x
+	}
+}
+
+// dot returns the dot product of a and b.
+func dot(a, b []float64) float64 {
+	var s float64
+	for i, v := range a {
+		s += v * b[i]
+	}
+	return s
+}
+
+// movingAverage returns the centred moving average of the
+// points with the confidence interval of each mean.
+func (s *Smoother) movingAverage() (fit XYs, lower, upper []float64) {
+	w := s.Window
+	if w < 1 {
+		w = 1
+	}
+	band := s.Level != 0 && w > 1
+	fit = make(XYs, len(s.XYs))
+	if band {
+		lower = make([]float64, len(fit))
+		upper = make([]float64, len(fit))
+	}
+	for i, p := range s.XYs {
+		lo := i - w/2
+		if lo < 0 {
+			lo = 0
+		}
+		hi := lo + w
+		if hi > len(s.XYs) {
+			hi = len(s.XYs)
+			lo = hi - w
+			if lo < 0 {
+				lo = 0
+			}
+		}
+		var sum, sum2 float64
+		for _, q := range s.XYs[lo:hi] {
+			sum += q.Y
+			sum2 += q.Y * q.Y
+		}
+		k := float64(hi - lo)
+		mean := sum / k
+		fit[i].X, fit[i].Y = p.X, mean
+		if band {
+			sd := math.Sqrt(math.Max(0, (sum2-k*mean*mean)/(k-1)))
+			t := distuv.StudentsT{Mu: 0, Sigma: 1, Nu: k - 1}.Quantile(0.5 + s.Level/2)
+			lower[i], upper[i] = mean-t*sd/math.Sqrt(k), mean+t*sd/math.Sqrt(k)
+		}
+	}
+	return fit, lower, upper
+}
+
+// Plot draws the Smoother, implementing the plot.Plotter
+// interface.
+func (s *Smoother) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	fit, lower, upper := s.Curve()
+
+	if lower != nil && s.BandColor != nil {
+		band := make([]vg.Point, 0, 2*len(fit))
+		for i, p := range fit {
+			band = append(band, vg.Point{X: trX(p.X), Y: trY(upper[i])})
+		}
+		for i := len(fit) - 1; i >= 0; i-- {
+			band = append(band, vg.Point{X: trX(fit[i].X), Y: trY(lower[i])})
+		}
+		c.FillPolygon(s.BandColor, c.ClipPolygonXY(band))
+	}
+
+	line := make([]vg.Point, len(fit))
+	for i, p := range fit {
+		line[i] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+	}
+	c.StrokeLines(s.LineStyle, c.ClipLinesXY(line)...)
+}
+
+// DataRange returns the minimum and maximum x and y values
+// of the fitted curve and its confidence band, implementing
+// the plot.DataRanger interface.
+func (s *Smoother) DataRange() (xmin, xmax, ymin, ymax float64) {
+	fit, lower, upper := s.Curve()
+	xmin, xmax = fit[0].X, fit[len(fit)-1].X
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for i, p := range fit {
+		ymin = math.Min(ymin, p.Y)
+		ymax = math.Max(ymax, p.Y)
+		if lower != nil {
+			ymin = math.Min(ymin, lower[i])
+			ymax = math.Max(ymax, upper[i])
+		}
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// Thumbnail draws a line in the style of the fitted curve,
+// implementing the plot.Thumbnailer interface.
+func (s *Smoother) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(s.LineStyle, c.Min.X, y, c.Max.X, y)
+}
@@ -0,0 +1,130 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+)
+
+func ExampleSmoother() {
+	rnd := rand.New(rand.NewSource(1))
+	xys := make(XYs, 80)
+	for i := range xys {
+		x := 10 * rnd.Float64()
+		xys[i].X = x
+		xys[i].Y = math.Sin(x) + 0.1*x + 0.3*rnd.NormFloat64()
+	}
+	s, err := NewScatter(xys)
+	if err != nil {
+		log.Panic(err)
+	}
+	s.Radius = vg.Points(2)
+
+	loess, err := NewSmoother(xys, LOESS)
+	if err != nil {
+		log.Panic(err)
+	}
+	loess.Span = 0.3
+	loess.Color = color.RGBA{R: 196, A: 255}
+	loess.BandColor = color.NRGBA{R: 196, A: 48}
+
+	linear, err := NewSmoother(xys, Polynomial)
+	if err != nil {
+		log.Panic(err)
+	}
+	linear.Color = color.RGBA{B: 196, A: 255}
+	linear.BandColor = color.NRGBA{B: 196, A: 48}
+
+	mavg, err := NewSmoother(xys, MovingAverage)
+	if err != nil {
+		log.Panic(err)
+	}
+	mavg.Window = 9
+	mavg.Level = 0
+	mavg.Color = color.RGBA{G: 128, A: 255}
+	mavg.Dashes = []vg.Length{vg.Points(3), vg.Points(2)}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Smoothers"
+	p.Add(s, linear, loess, mavg)
+	p.Legend.Add("LOESS", loess)
+	p.Legend.Add("linear", linear)
+	p.Legend.Add("moving average", mavg)
+	p.Legend.Top = true
+	p.Y.Max = 3.5
+
+	err = p.Save(250, 200, "testdata/smoother.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestSmoother(t *testing.T) {
+	cmpimg.CheckPlot(ExampleSmoother, t, "smoother.png")
+}
+
+func TestSmootherFit(t *testing.T) {
+	quad := make(XYs, 20)
+	for i := range quad {
+		x := float64(i)
+		quad[i].X = x
+		quad[i].Y = 2 + 3*x - 0.5*x*x
+	}
+	for _, test := range []struct {
+		method SmoothMethod
+		degree int
+	}{
+		{method: Polynomial, degree: 2},
+		{method: LOESS, degree: 2},
+	} {
+		s, err := NewSmoother(quad, test.method)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		s.Degree = test.degree
+		s.Samples = 7
+		fit, lower, upper := s.Curve()
+		if len(fit) != 7 {
+			t.Fatalf("unexpected number of fitted points: got:%d want:7", len(fit))
+		}
+		for i, p := range fit {
+			want := 2 + 3*p.X - 0.5*p.X*p.X
+			if math.Abs(p.Y-want) > 1e-8 {
+				t.Errorf("unexpected fit for method %d at %v: got:%v want:%v", test.method, p.X, p.Y, want)
+			}
+			// An exact fit has no residual variance.
+			if math.Abs(upper[i]-lower[i]) > 1e-6 {
+				t.Errorf("unexpected band width for method %d at %v: %v", test.method, p.X, upper[i]-lower[i])
+			}
+		}
+	}
+
+	s, err := NewSmoother(XYs{{X: 3, Y: 3}, {X: 1, Y: 1}, {X: 2, Y: 2}, {X: 4, Y: 7}}, MovingAverage)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Window = 3
+	fit, lower, upper := s.Curve()
+	want := []float64{2, 2, 4, 4}
+	for i, p := range fit {
+		if p.X != float64(i+1) || math.Abs(p.Y-want[i]) > 1e-12 {
+			t.Errorf("unexpected moving average at %d: got:%v want:{%d %v}", i, p, i+1, want[i])
+		}
+		if lower[i] > p.Y || upper[i] < p.Y {
+			t.Errorf("moving average outside band at %d: %v not in [%v, %v]", i, p.Y, lower[i], upper[i])
+		}
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// DefaultSpanColor is the default fill color of spans.
+var DefaultSpanColor = color.NRGBA{R: 128, G: 128, B: 128, A: 48}
+
+// labelPad is the distance between reference lines and
+// spans and their labels.
+var labelPad = vg.Points(2)
+
+// HLine implements the plot.Plotter interface, drawing a
+// horizontal reference line at a Y value across the full
+// width of the plot, whatever the range of the X axis
+// when the plot is drawn.
+type HLine struct {
+	Y float64
+
+	// LineStyle is the style of the line.
+	draw.LineStyle
+
+	// Label, if not empty, is drawn above the right
+	// end of the line.
+	Label string
+
+	// TextStyle is the style of the label.
+	TextStyle draw.TextStyle
+}
+
+// NewHLine returns an HLine at y with the given label, using
+// the default line style.
+func NewHLine(y float64, label string) (*HLine, error) {
+	sty, err := spanTextStyle()
+	if err != nil {
+		return nil, err
+	}
+	return &HLine{Y: y, LineStyle: DefaultLineStyle, Label: label, TextStyle: sty}, nil
+}
+
+// Plot draws the HLine, implementing the plot.Plotter
+// interface.
+func (l *HLine) Plot(c draw.Canvas, plt *plot.Plot) {
+	_, trY := plt.Transforms(&c)
+	y := trY(l.Y)
+	if !c.ContainsY(y) {
+		return
+	}
+	c.StrokeLine2(l.LineStyle, c.Min.X, y, c.Max.X, y)
+	if l.Label == "" {
+		return
+	}
+	sty := l.TextStyle
+	sty.XAlign, sty.YAlign = draw.XRight, draw.YBottom
+	c.FillText(sty, vg.Point{X: c.Max.X - labelPad, Y: y + labelPad}, l.Label)
+}
+
+// DataRange returns the Y value of the line, implementing
+// the plot.DataRanger interface. The X range is empty so
+// that the line does not extend the X axis.
+func (l *HLine) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return math.Inf(1), math.Inf(-1), l.Y, l.Y
+}
+
+// Thumbnail draws a line in the style of the HLine,
+// implementing the plot.Thumbnailer interface.
+func (l *HLine) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(l.LineStyle, c.Min.X, y, c.Max.X, y)
+}
+
+// VLine implements the plot.Plotter interface, drawing a
+// vertical reference line at an X value across the full
+// height of the plot, whatever the range of the Y axis
+// when the plot is drawn.
+type VLine struct {
+	X float64
+
+	// LineStyle is the style of the line.
+	draw.LineStyle
+
+	// Label, if not empty, is drawn beside the top
+	// of the line, reading upwards.
+	Label string
+
+	// TextStyle is the style of the label.
+	TextStyle draw.TextStyle
+}
+
+// NewVLine returns a VLine at x with the given label, using
+// the default line style.
+func NewVLine(x float64, label string) (*VLine, error) {
+	sty, err := spanTextStyle()
+	if err != nil {
+		return nil, err
+	}
+	return &VLine{X: x, LineStyle: DefaultLineStyle, Label: label, TextStyle: sty}, nil
+}
+
+// Plot draws the VLine, implementing the plot.Plotter
+// interface.
+func (l *VLine) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, _ := plt.Transforms(&c)
+	x := trX(l.X)
+	if !c.ContainsX(x) {
+		return
+	}
+	c.StrokeLine2(l.LineStyle, x, c.Min.Y, x, c.Max.Y)
+	if l.Label == "" {
+		return
+	}
+	sty := l.TextStyle
+	sty.Rotation = math.Pi / 2
+	sty.XAlign, sty.YAlign = draw.XRight, draw.YBottom
+	c.FillText(sty, vg.Point{X: x - labelPad, Y: c.Max.Y - labelPad}, l.Label)
+}
+
+// DataRange returns the X value of the line, implementing
+// the plot.DataRanger interface. The Y range is empty so
+// that the line does not extend the Y axis.
+func (l *VLine) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return l.X, l.X, math.Inf(1), math.Inf(-1)
+}
+
+// Thumbnail draws a line in the style of the VLine,
+// implementing the plot.Thumbnailer interface.
+func (l *VLine) Thumbnail(c *draw.Canvas) {
+	x := c.Center().X
+	c.StrokeLine2(l.LineStyle, x, c.Min.Y, x, c.Max.Y)
+}
+
+// HSpan implements the plot.Plotter interface, shading the
+// band between two Y values across the full width of the
+// plot.
+type HSpan struct {
+	YMin, YMax float64
+
+	// Color is the fill color of the band.
+	Color color.Color
+
+	// LineStyle is the style of the edges of the band.
+	// If the Color of LineStyle is nil the edges are
+	// not drawn.
+	LineStyle draw.LineStyle
+
+	// Label, if not empty, is drawn inside the top
+	// left corner of the band.
+	Label string
+
+	// TextStyle is the style of the label.
+	TextStyle draw.TextStyle
+}
+
+// NewHSpan returns an HSpan shading the band from ymin to
+// ymax with the given label, using the default span color
+// and no edges.
+func NewHSpan(ymin, ymax float64, label string) (*HSpan, error) {
+	sty, err := spanTextStyle()
+	if err != nil {
+		return nil, err
+	}
+	return &HSpan{YMin: ymin, YMax: ymax, Color: DefaultSpanColor, Label: label, TextStyle: sty}, nil
+}
+
+// Plot draws the HSpan, implementing the plot.Plotter
+// interface.
+func (s *HSpan) Plot(c draw.Canvas, plt *plot.Plot) {
+	_, trY := plt.Transforms(&c)
+	lo, hi := trY(s.YMin), trY(s.YMax)
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	drawSpan(&c, s.Color, s.LineStyle, vg.Rectangle{
+		Min: vg.Point{X: c.Min.X, Y: lo},
+		Max: vg.Point{X: c.Max.X, Y: hi},
+	}, false)
+	if s.Label == "" || hi < c.Min.Y || lo > c.Max.Y {
+		return
+	}
+	sty := s.TextStyle
+	sty.XAlign, sty.YAlign = draw.XLeft, draw.YTop
+	top := vg.Length(math.Min(float64(hi), float64(c.Max.Y)))
+	c.FillText(sty, vg.Point{X: c.Min.X + labelPad, Y: top - labelPad}, s.Label)
+}
+
+// DataRange returns the Y range of the band, implementing
+// the plot.DataRanger interface. The X range is empty so
+// that the band does not extend the X axis.
+func (s *HSpan) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return math.Inf(1), math.Inf(-1), math.Min(s.YMin, s.YMax), math.Max(s.YMin, s.YMax)
+}
+
+// Thumbnail fills the thumbnail with the color of the
+// band, implementing the plot.Thumbnailer interface.
+func (s *HSpan) Thumbnail(c *draw.Canvas) {
+	drawSpan(c, s.Color, s.LineStyle, c.Rectangle, false)
+}
+
+// VSpan implements the plot.Plotter interface, shading the
+// band between two X values across the full height of the
+// plot.
+type VSpan struct {
+	XMin, XMax float64
+
+	// Color is the fill color of the band.
+	Color color.Color
+
+	// LineStyle is the style of the edges of the band.
+	// If the Color of LineStyle is nil the edges are
+	// not drawn.
+	LineStyle draw.LineStyle
+
+	// Label, if not empty, is drawn inside the top
+	// left corner of the band.
+	Label string
+
+	// TextStyle is the style of the label.
+	TextStyle draw.TextStyle
+}
+
+// NewVSpan returns a VSpan shading the band from xmin to
+// xmax with the given label, using the default span color
+// and no edges.
+func NewVSpan(xmin, xmax float64, label string) (*VSpan, error) {
+	sty, err := spanTextStyle()
+	if err != nil {
+		return nil, err
+	}
+	return &VSpan{XMin: xmin, XMax: xmax, Color: DefaultSpanColor, Label: label, TextStyle: sty}, nil
+}
+
+// Plot draws the VSpan, implementing the plot.Plotter
+// interface.
+func (s *VSpan) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, _ := plt.Transforms(&c)
+	lo, hi := trX(s.XMin), trX(s.XMax)
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	drawSpan(&c, s.Color, s.LineStyle, vg.Rectangle{
+		Min: vg.Point{X: lo, Y: c.Min.Y},
+		Max: vg.Point{X: hi, Y: c.Max.Y},
+	}, true)
+	if s.Label == "" || hi < c.Min.X || lo > c.Max.X {
+		return
+	}
+	sty := s.TextStyle
+	sty.XAlign, sty.YAlign = draw.XLeft, draw.YTop
+	left := vg.Length(math.Max(float64(lo), float64(c.Min.X)))
+	c.FillText(sty, vg.Point{X: left + labelPad, Y: c.Max.Y - labelPad}, s.Label)
+}
+
+// DataRange returns the X range of the band, implementing
+// the plot.DataRanger interface. The Y range is empty so
+// that the band does not extend the Y axis.
+func (s *VSpan) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return math.Min(s.XMin, s.XMax), math.Max(s.XMin, s.XMax), math.Inf(1), math.Inf(-1)
+}
+
+// Thumbnail fills the thumbnail with the color of the
+// band, implementing the plot.Thumbnailer interface.
+func (s *VSpan) Thumbnail(c *draw.Canvas) {
+	drawSpan(c, s.Color, s.LineStyle, c.Rectangle, true)
+}
+
+// drawSpan fills the rectangle r clipped to the canvas and
+// strokes its vertical edges if vertical is true, and its
+// horizontal edges otherwise.
+func drawSpan(c *draw.Canvas, fill color.Color, edge draw.LineStyle, r vg.Rectangle, vertical bool) {
+	pts := []vg.Point{
+		r.Min, {X: r.Max.X, Y: r.Min.Y},
+		r.Max, {X: r.Min.X, Y: r.Max.Y},
+	}
+	if fill != nil {
+		c.FillPolygon(fill, c.ClipPolygonXY(pts))
+	}
+	if edge.Color == nil {
+		return
+	}
+	if vertical {
+		for _, x := range []vg.Length{r.Min.X, r.Max.X} {
+			if c.ContainsX(x) {
+				c.StrokeLine2(edge, x, r.Min.Y, x, r.Max.Y)
+			}
+		}
+		return
+	}
+	for _, y := range []vg.Length{r.Min.Y, r.Max.Y} {
+		if c.ContainsY(y) {
+			c.StrokeLine2(edge, r.Min.X, y, r.Max.X, y)
+		}
+	}
+}
+
+// spanTextStyle returns the default style of the labels
+// of reference lines and spans.
+func spanTextStyle() (draw.TextStyle, error) {
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return draw.TextStyle{}, err
+	}
+	return draw.TextStyle{Color: color.Black, Font: fnt}, nil
+}
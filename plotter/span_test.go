@@ -0,0 +1,98 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+)
+
+// ExampleHSpan draws reference lines and shaded bands
+// over a line.
+func ExampleHSpan() {
+	xys := make(XYs, 50)
+	for i := range xys {
+		x := float64(i) / 5
+		xys[i].X = x
+		xys[i].Y = math.Sin(x) * math.Exp(-x/8)
+	}
+	l, err := NewLine(xys)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	band, err := NewHSpan(-0.25, 0.25, "tolerance")
+	if err != nil {
+		log.Panic(err)
+	}
+	recession, err := NewVSpan(3, 4.5, "event")
+	if err != nil {
+		log.Panic(err)
+	}
+	recession.Color = color.NRGBA{R: 196, A: 48}
+	recession.LineStyle = DefaultLineStyle
+	recession.LineStyle.Color = color.RGBA{R: 196, A: 255}
+	recession.LineStyle.Width = vg.Points(0.5)
+
+	zero, err := NewHLine(0, "baseline")
+	if err != nil {
+		log.Panic(err)
+	}
+	zero.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+	onset, err := NewVLine(7.5, "onset")
+	if err != nil {
+		log.Panic(err)
+	}
+	onset.Color = color.RGBA{B: 196, A: 255}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Spans and reference lines"
+	p.Add(band, recession, zero, onset, l)
+
+	// The spans and lines extend across the axes
+	// after the ranges are changed.
+	p.X.Min, p.X.Max = -1, 11
+	p.Y.Max = 1
+
+	err = p.Save(250, 200, "testdata/span.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestSpan(t *testing.T) {
+	cmpimg.CheckPlot(ExampleHSpan, t, "span.png")
+}
+
+func TestSpanDataRange(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h, err := NewHLine(2, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	v, err := NewVSpan(5, -1, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(h, v)
+	if p.X.Min != -1 || p.X.Max != 5 {
+		t.Errorf("unexpected x range: got:[%v, %v] want:[-1, 5]", p.X.Min, p.X.Max)
+	}
+	if p.Y.Min != 2 || p.Y.Max != 2 {
+		t.Errorf("unexpected y range: got:[%v, %v] want:[2, 2]", p.Y.Min, p.Y.Max)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// DefaultSpectrogramFloor is the default lowest power in
+// decibels drawn by a Spectrogram.
+var DefaultSpectrogramFloor = -80.0
+
+// Spectra is a GridXYZ holding the power spectra of a
+// short-time Fourier transform, with the times of its
+// frames as X and the frequencies of its bins as Y.
+type Spectra struct {
+	// Power holds the power spectra of the frames,
+	// with Power[i][j] the power in the j'th frequency
+	// bin of the i'th frame. All frames must have
+	// the same number of bins.
+	Power [][]float64
+
+	// Start is the time of the first frame and
+	// Step is the time between frames, in seconds.
+	Start, Step float64
+
+	// BinWidth is the width of the frequency bins
+	// in hertz. The first bin is at 0 Hz.
+	BinWidth float64
+}
+
+var _ GridXYZ = Spectra{}
+
+// Dims implements the GridXYZ interface.
+func (s Spectra) Dims() (c, r int) {
+	if len(s.Power) == 0 {
+		return 0, 0
+	}
+	return len(s.Power), len(s.Power[0])
+}
+
+// Z implements the GridXYZ interface.
+func (s Spectra) Z(c, r int) float64 { return s.Power[c][r] }
+
+// X implements the GridXYZ interface.
+func (s Spectra) X(c int) float64 { return s.Start + float64(c)*s.Step }
+
+// Y implements the GridXYZ interface.
+func (s Spectra) Y(r int) float64 { return float64(r) * s.BinWidth }
+
+// Spectrogram implements the Plotter interface, drawing the
+// power of a short-time Fourier transform as a heat map of
+// time and frequency. The GridXYZ of the embedded HeatMap
+// holds the power in decibels relative to the greatest power
+// of the spectrogram, so Max is zero. Min is the floor of the
+// dynamic range of the spectrogram: lower powers are clipped
+// to it and drawn in the lowest color of the palette.
+type Spectrogram struct {
+	HeatMap
+
+	// LogFrequency specifies whether the spectrogram is
+	// drawn on a log frequency axis. The bins at and
+	// below 0 Hz are then not drawn. ConfigureAxes sets
+	// the scale of the frequency axis accordingly.
+	LogFrequency bool
+}
+
+// NewSpectrogram returns a Spectrogram of the powers in g, which
+// has times as X and frequencies as Y, such as Spectra, using the
+// provided palette. The floor of the spectrogram is set to
+// DefaultSpectrogramFloor.
+func NewSpectrogram(g GridXYZ, p palette.Palette) *Spectrogram {
+	ref := math.Inf(-1)
+	c, r := g.Dims()
+	for i := 0; i < c; i++ {
+		for j := 0; j < r; j++ {
+			if v := g.Z(i, j); !math.IsNaN(v) {
+				ref = math.Max(ref, v)
+			}
+		}
+	}
+	if !(ref > 0) || math.IsInf(ref, 1) {
+		ref = 1
+	}
+	return &Spectrogram{
+		HeatMap: HeatMap{
+			GridXYZ: decibelGrid{GridXYZ: g, ref: ref},
+			Palette: p,
+			Min:     DefaultSpectrogramFloor,
+			Max:     0,
+		},
+	}
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (s *Spectrogram) Plot(c draw.Canvas, plt *plot.Plot) {
+	if s.Min > s.Max {
+		plt.Fail(errors.New("plotter: invalid spectrogram dB range: floor greater than max"))
+		return
+	}
+	pal := s.Palette.Colors()
+	if len(pal) == 0 {
+		plt.Fail(errors.New("plotter: empty spectrogram palette"))
+		return
+	}
+	// ps scales the palette uniformly across the dB range.
+	ps := float64(len(pal)-1) / (s.Max - s.Min)
+
+	h := s.heatMap()
+	h.plot(c, plt, func(i, j int) color.Color {
+		return s.color(pal, ps, math.Max(h.GridXYZ.Z(i, j), s.Min))
+	})
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (s *Spectrogram) DataRange() (xmin, xmax, ymin, ymax float64) {
+	h := s.heatMap()
+	return h.DataRange()
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface.
+func (s *Spectrogram) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	h := s.heatMap()
+	return h.GlyphBoxes(plt)
+}
+
+// ConfigureAxes configures the axes of p for the spectrogram,
+// labeling time on the X axis in minutes and seconds and
+// frequency in hertz on the Y axis, which has a log scale if
+// LogFrequency is true.
+func (s *Spectrogram) ConfigureAxes(p *plot.Plot) {
+	p.X.Label.Text = "Time (m:s)"
+	p.X.Tick.Marker = minuteTicks{}
+	p.Y.Label.Text = "Frequency (Hz)"
+	if s.LogFrequency {
+		p.Y.Scale = plot.LogScale{}
+		p.Y.Tick.Marker = plot.LogTicks{}
+	}
+}
+
+// heatMap returns the heat map drawn for the spectrogram,
+// without the bins at and below 0 Hz if LogFrequency is true.
+func (s *Spectrogram) heatMap() HeatMap {
+	h := s.HeatMap
+	if !s.LogFrequency {
+		return h
+	}
+	_, rows := h.GridXYZ.Dims()
+	var skip int
+	for skip < rows && h.GridXYZ.Y(skip) <= 0 {
+		skip++
+	}
+	if skip > 0 {
+		h.GridXYZ = skipRowsGrid{GridXYZ: h.GridXYZ, skip: skip}
+	}
+	return h
+}
+
+// decibelGrid is a GridXYZ holding the powers of a grid
+// in decibels relative to a reference power.
+type decibelGrid struct {
+	GridXYZ
+	ref float64
+}
+
+func (g decibelGrid) Z(c, r int) float64 { return 10 * math.Log10(g.GridXYZ.Z(c, r)/g.ref) }
+
+// skipRowsGrid is a GridXYZ without the first rows of a grid.
+type skipRowsGrid struct {
+	GridXYZ
+	skip int
+}
+
+func (g skipRowsGrid) Dims() (c, r int)   { c, r = g.GridXYZ.Dims(); return c, r - g.skip }
+func (g skipRowsGrid) Z(c, r int) float64 { return g.GridXYZ.Z(c, r+g.skip) }
+func (g skipRowsGrid) Y(r int) float64    { return g.GridXYZ.Y(r + g.skip) }
+
+// minuteTicks labels the default ticks of an axis of times in
+// seconds as minutes and seconds, such as 1:30 or 0:02.5.
+type minuteTicks struct{}
+
+// Ticks implements the plot.Ticker interface.
+func (minuteTicks) Ticks(min, max float64) []plot.Tick {
+	ticks := plot.DefaultTicks{}.Ticks(min, max)
+	for i, t := range ticks {
+		if t.Label == "" {
+			continue
+		}
+		// Keep the precision of the default label.
+		var prec int
+		if dot := strings.Index(t.Label, "."); dot >= 0 && !strings.ContainsAny(t.Label, "eE") {
+			prec = len(t.Label) - dot - 1
+		}
+		ticks[i].Label = minutesSeconds(t.Value, prec)
+	}
+	return ticks
+}
+
+// minutesSeconds returns t seconds formatted as minutes and
+// seconds, with prec digits after the decimal point.
+func minutesSeconds(t float64, prec int) string {
+	var sign string
+	if t < 0 {
+		sign = "-"
+		t = -t
+	}
+	scale := math.Pow(10, float64(prec))
+	t = math.Floor(t*scale+0.5) / scale
+	m := math.Floor(t / 60)
+	sec := t - 60*m
+	width := 2
+	if prec > 0 {
+		width += prec + 1
+	}
+	return fmt.Sprintf("%s%.0f:%0*.*f", sign, m, width, prec, sec)
+}
@@ -0,0 +1,164 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/palette/moreland"
+)
+
+// ExampleSpectrogram draws the spectrogram of a chirp
+// sweeping up from 100 Hz, with a steady tone at 440 Hz,
+// on a log frequency axis.
+func ExampleSpectrogram() {
+	const (
+		rate  = 8000.0 // Samples per second.
+		size  = 256    // Samples per frame.
+		hop   = 128    // Samples between frames.
+		total = 2 * rate
+	)
+	signal := make([]float64, total)
+	for i := range signal {
+		t := float64(i) / rate
+		// The frequency of the chirp rises
+		// exponentially from 100 Hz.
+		chirp := math.Sin(2 * math.Pi * 100 * (math.Pow(15, t/2) - 1) / (math.Log(15) / 2))
+		signal[i] = chirp + 0.1*math.Sin(2*math.Pi*440*t)
+	}
+
+	// Take the discrete Fourier transform of each
+	// Hann windowed frame.
+	spectra := Spectra{Step: hop / rate, BinWidth: rate / size}
+	for start := 0; start+size <= len(signal); start += hop {
+		power := make([]float64, size/2+1)
+		for k := range power {
+			var sum complex128
+			for n := 0; n < size; n++ {
+				w := 0.5 - 0.5*math.Cos(2*math.Pi*float64(n)/size)
+				sum += complex(w*signal[start+n], 0) * cmplx.Rect(1, -2*math.Pi*float64(k*n)/size)
+			}
+			power[k] = real(sum)*real(sum) + imag(sum)*imag(sum)
+		}
+		spectra.Power = append(spectra.Power, power)
+	}
+	spectra.Start = float64(size/2) / rate
+
+	s := NewSpectrogram(spectra, moreland.ExtendedBlackBody().Palette(64))
+	s.Min = -60
+	s.LogFrequency = true
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Spectrogram"
+	s.ConfigureAxes(p)
+	p.X.Padding = 0
+	p.Y.Padding = 0
+	p.Add(s)
+
+	err = p.Save(300, 200, "testdata/spectrogram.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestSpectrogramPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleSpectrogram, t, "spectrogram.png")
+}
+
+func TestSpectrogram(t *testing.T) {
+	spectra := Spectra{
+		Power: [][]float64{
+			{1, 100, 0.001},
+			{0, 10, math.NaN()},
+		},
+		Start:    0.5,
+		Step:     1,
+		BinWidth: 10,
+	}
+	if c, r := spectra.Dims(); c != 2 || r != 3 {
+		t.Fatalf("unexpected dimensions: got:%dx%d want:2x3", c, r)
+	}
+	if x, y := spectra.X(1), spectra.Y(2); x != 1.5 || y != 20 {
+		t.Errorf("unexpected coordinates: got:(%v, %v) want:(1.5, 20)", x, y)
+	}
+
+	s := NewSpectrogram(spectra, moreland.SmoothBlueRed().Palette(8))
+	if s.Min != DefaultSpectrogramFloor || s.Max != 0 {
+		t.Errorf("unexpected dB range: got:[%v, %v] want:[%v, 0]", s.Min, s.Max, DefaultSpectrogramFloor)
+	}
+	// Powers are in dB relative to the greatest power.
+	for _, test := range []struct {
+		c, r int
+		want float64
+	}{
+		{c: 0, r: 0, want: -20},
+		{c: 0, r: 1, want: 0},
+		{c: 0, r: 2, want: -50},
+		{c: 1, r: 0, want: math.Inf(-1)},
+		{c: 1, r: 1, want: -10},
+	} {
+		got := s.GridXYZ.Z(test.c, test.r)
+		if math.Abs(got-test.want) > 1e-12 && got != test.want {
+			t.Errorf("unexpected dB value at (%d, %d): got:%v want:%v", test.c, test.r, got, test.want)
+		}
+	}
+	if z := s.GridXYZ.Z(1, 2); !math.IsNaN(z) {
+		t.Errorf("unexpected dB value of NaN power: got:%v", z)
+	}
+
+	_, _, ymin, ymax := s.DataRange()
+	if ymin != -5 || ymax != 25 {
+		t.Errorf("unexpected frequency range: got:[%v, %v] want:[-5, 25]", ymin, ymax)
+	}
+	// On a log frequency axis, the bin at 0 Hz is not
+	// drawn, and the frequency range starts half a bin
+	// below the first bin above 0 Hz.
+	s.LogFrequency = true
+	_, _, ymin, ymax = s.DataRange()
+	if ymin != 5 || ymax != 25 {
+		t.Errorf("unexpected log frequency range: got:[%v, %v] want:[5, 25]", ymin, ymax)
+	}
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.ConfigureAxes(p)
+	if _, ok := p.Y.Scale.(plot.LogScale); !ok {
+		t.Errorf("unexpected frequency axis scale: %T", p.Y.Scale)
+	}
+}
+
+func TestMinuteTicks(t *testing.T) {
+	for _, test := range []struct {
+		t    float64
+		prec int
+		want string
+	}{
+		{t: 0, want: "0:00"},
+		{t: 90, want: "1:30"},
+		{t: 2.5, prec: 1, want: "0:02.5"},
+		{t: 59.96, prec: 1, want: "1:00.0"},
+		{t: -75, want: "-1:15"},
+	} {
+		got := minutesSeconds(test.t, test.prec)
+		if got != test.want {
+			t.Errorf("unexpected label for %v: got:%q want:%q", test.t, got, test.want)
+		}
+	}
+
+	for _, tick := range (minuteTicks{}).Ticks(0, 150) {
+		if tick.Value == 100 && tick.Label != "1:40" {
+			t.Errorf("unexpected label for 100s: got:%q want:%q", tick.Label, "1:40")
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Sprite is a small picture drawn by a Sprites plotter, such
+// as an image or a custom glyph drawing. The plot.ImageGraphic
+// and plot.GlyphGraphic tick label graphics are Sprites.
+type Sprite interface {
+	// Size returns the width and height of the sprite
+	// at a scale of one.
+	Size() (w, h vg.Length)
+
+	// Draw draws the sprite to fill the rectangle r.
+	Draw(c draw.Canvas, r vg.Rectangle)
+}
+
+// Sprites implements the Plotter interface, drawing a sprite
+// centered at each of a set of points, such as icons marking
+// the locations of species sightings. Sprites at points
+// outside the data area are not drawn.
+type Sprites struct {
+	XYs
+
+	// SpriteFunc, if not nil, specifies the sprites
+	// of individual points.
+	SpriteFunc func(int) Sprite
+
+	// Sprite is the sprite drawn at each point.
+	Sprite Sprite
+
+	// ScaleFunc, if not nil, specifies the scales of
+	// the sprites of individual points. The sprites
+	// are drawn at a scale of one otherwise.
+	ScaleFunc func(int) float64
+
+	// RotationFunc, if not nil, specifies the rotations
+	// of the sprites of individual points, in radians
+	// counterclockwise about their centers.
+	RotationFunc func(int) float64
+}
+
+// NewSprites returns Sprites drawing the sprite s at each of
+// the points in xys.
+func NewSprites(xys XYer, s Sprite) (*Sprites, error) {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	return &Sprites{
+		XYs:    data,
+		Sprite: s,
+	}, nil
+}
+
+// Plot draws the Sprites, implementing the plot.Plotter
+// interface.
+func (s *Sprites) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for i, p := range s.XYs {
+		if canceled(plt, i) {
+			return
+		}
+		pt := vg.Point{X: trX(p.X), Y: trY(p.Y)}
+		sp := s.sprite(i)
+		if sp == nil || !c.Contains(pt) {
+			continue
+		}
+		r := s.rect(i, sp)
+		rot := s.rotation(i)
+		if rot == 0 {
+			r = vg.Rectangle{Min: r.Min.Add(pt), Max: r.Max.Add(pt)}
+			sp.Draw(draw.Canvas{Canvas: c.Canvas, Rectangle: r}, r)
+			continue
+		}
+		c.Push()
+		c.Translate(pt)
+		c.Rotate(rot)
+		sp.Draw(draw.Canvas{Canvas: c.Canvas, Rectangle: r}, r)
+		c.Pop()
+	}
+}
+
+// DataRange returns the minimum and maximum x and y values,
+// implementing the plot.DataRanger interface.
+func (s *Sprites) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return XYRange(s)
+}
+
+// GlyphBoxes returns a glyph box bounding the rotated sprite
+// at each point, implementing the plot.GlyphBoxer interface.
+func (s *Sprites) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, 0, len(s.XYs))
+	for i, p := range s.XYs {
+		sp := s.sprite(i)
+		if sp == nil {
+			continue
+		}
+		r := s.rect(i, sp)
+		sin, cos := math.Sincos(s.rotation(i))
+		w := float64(r.Max.X - r.Min.X)
+		h := float64(r.Max.Y - r.Min.Y)
+		half := vg.Point{
+			X: vg.Length(math.Abs(w*cos)+math.Abs(h*sin)) / 2,
+			Y: vg.Length(math.Abs(w*sin)+math.Abs(h*cos)) / 2,
+		}
+		bs = append(bs, plot.GlyphBox{
+			X:         plt.X.Norm(p.X),
+			Y:         plt.Y.Norm(p.Y),
+			Rectangle: vg.Rectangle{Min: vg.Point{X: -half.X, Y: -half.Y}, Max: half},
+		})
+	}
+	return bs
+}
+
+// Thumbnail draws the sprite of the Sprites, scaled to fit
+// the thumbnail, implementing the plot.Thumbnailer interface.
+func (s *Sprites) Thumbnail(c *draw.Canvas) {
+	if s.Sprite == nil {
+		return
+	}
+	w, h := s.Sprite.Size()
+	if w <= 0 || h <= 0 {
+		return
+	}
+	size := c.Size()
+	scale := math.Min(1, math.Min(float64(size.X/w), float64(size.Y/h)))
+	half := vg.Point{X: w * vg.Length(scale) / 2, Y: h * vg.Length(scale) / 2}
+	ctr := c.Center()
+	s.Sprite.Draw(*c, vg.Rectangle{Min: ctr.Sub(half), Max: ctr.Add(half)})
+}
+
+// sprite returns the sprite of the i'th point.
+func (s *Sprites) sprite(i int) Sprite {
+	if s.SpriteFunc != nil {
+		return s.SpriteFunc(i)
+	}
+	return s.Sprite
+}
+
+// rect returns the rectangle of the sprite sp of the i'th
+// point, scaled and centered on the origin.
+func (s *Sprites) rect(i int, sp Sprite) vg.Rectangle {
+	w, h := sp.Size()
+	if s.ScaleFunc != nil {
+		scale := vg.Length(s.ScaleFunc(i))
+		w *= scale
+		h *= scale
+	}
+	return vg.Rectangle{
+		Min: vg.Point{X: -w / 2, Y: -h / 2},
+		Max: vg.Point{X: w / 2, Y: h / 2},
+	}
+}
+
+// rotation returns the rotation of the sprite of the i'th point.
+func (s *Sprites) rotation(i int) float64 {
+	if s.RotationFunc != nil {
+		return s.RotationFunc(i)
+	}
+	return 0
+}
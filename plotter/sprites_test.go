@@ -0,0 +1,143 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"log"
+	"math"
+	"os"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+// ExampleSprites draws a logo at each of a set of points,
+// scaled by a value of the point and rotated along the
+// path through the points, with glyph sprites marking
+// the ends of the path.
+func ExampleSprites() {
+	f, err := os.Open("testdata/image_plot_input.png")
+	if err != nil {
+		log.Panic(err)
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	path := XYs{{X: 0, Y: 0}, {X: 1, Y: 2}, {X: 2, Y: 2.5}, {X: 3, Y: 1.5}, {X: 4, Y: 2}}
+	sizes := []float64{0.6, 0.8, 1, 1.2, 1.4}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Sprites"
+	p.Add(NewGrid())
+
+	s, err := NewSprites(path, plot.ImageGraphic{Image: img, Width: vg.Points(20), Height: vg.Points(20)})
+	if err != nil {
+		log.Panic(err)
+	}
+	s.ScaleFunc = func(i int) float64 { return sizes[i] }
+	s.RotationFunc = func(i int) float64 {
+		j := i + 1
+		if j == len(path) {
+			i, j = i-1, i
+		}
+		return math.Atan2(path[j].Y-path[i].Y, path[j].X-path[i].X)
+	}
+	p.Add(s)
+
+	ends, err := NewSprites(XYs{path[0], path[len(path)-1]}, nil)
+	if err != nil {
+		log.Panic(err)
+	}
+	ends.SpriteFunc = func(i int) Sprite {
+		sty := draw.GlyphStyle{Color: color.RGBA{R: 200, A: 255}, Radius: vg.Points(4), Shape: draw.BoxGlyph{}}
+		if i == 0 {
+			sty.Color = color.RGBA{G: 160, A: 255}
+		}
+		return plot.GlyphGraphic{GlyphStyle: sty}
+	}
+	ends.RotationFunc = func(int) float64 { return math.Pi / 4 }
+	p.Add(ends)
+
+	err = p.Save(250, 200, "testdata/sprites.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestSpritesPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleSprites, t, "sprites.png")
+}
+
+func TestSprites(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	s, err := NewSprites(XYs{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}}, plot.ImageGraphic{Image: img, Width: 20, Height: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.ScaleFunc = func(i int) float64 { return float64(i + 1) }
+	s.RotationFunc = func(i int) float64 {
+		if i == 1 {
+			return math.Pi / 2
+		}
+		return 0
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 1.5
+	p.Y.Min, p.Y.Max = 0, 1.5
+	boxes := s.GlyphBoxes(p)
+	if len(boxes) != 3 {
+		t.Fatalf("unexpected number of glyph boxes: got:%d want:3", len(boxes))
+	}
+	// The second sprite is scaled by two and turned
+	// on its side.
+	r := boxes[1].Rectangle
+	w, h := r.Max.X-r.Min.X, r.Max.Y-r.Min.Y
+	if math.Abs(float64(w-20)) > 1e-9 || math.Abs(float64(h-40)) > 1e-9 {
+		t.Errorf("unexpected rotated glyph box size: got:%vx%v want:20x40", w, h)
+	}
+
+	// The third point is outside the data area
+	// and its sprite is not drawn.
+	p.HideAxes()
+	p.Add(s)
+	p.X.Max, p.Y.Max = 1.5, 1.5
+	var rec recorder.Canvas
+	p.Draw(draw.NewCanvas(&rec, 200, 200))
+	var (
+		images int
+		angles []float64
+	)
+	for _, a := range rec.Actions {
+		switch a := a.(type) {
+		case *recorder.DrawImage:
+			images++
+		case *recorder.Rotate:
+			angles = append(angles, a.Angle)
+		}
+	}
+	if images != 2 {
+		t.Errorf("unexpected number of sprites drawn: got:%d want:2", images)
+	}
+	if len(angles) != 1 || angles[0] != math.Pi/2 {
+		t.Errorf("unexpected sprite rotations: got:%v want:[%v]", angles, math.Pi/2)
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// RingXYs implements the XYer interface using a fixed
+// capacity ring buffer. Appending to a full RingXYs
+// overwrites the oldest point, so a RingXYs holds a
+// rolling window of the most recently appended data
+// without reallocating.
+//
+// RingXYs maintains its data range incrementally so
+// that DataRange is cheap to call after every Append,
+// and counts its changes so that it implements the
+// plot.Versioner interface.
+type RingXYs struct {
+	plot.ChangeCounter
+
+	// Window, if positive, limits the points held
+	// to those with an x value no more than Window
+	// less than the x value of the most recently
+	// appended point. This can be used to keep, for
+	// example, the last minute of data when x holds
+	// times in seconds.
+	Window float64
+
+	buf   XYs
+	start int
+	n     int
+
+	// xmin, xmax, ymin and ymax hold the cached
+	// range of the data. They are recomputed lazily
+	// when valid is false.
+	xmin, xmax, ymin, ymax float64
+	valid                  bool
+}
+
+// NewRingXYs returns a RingXYs able to hold
+// up to capacity points.
+func NewRingXYs(capacity int) (*RingXYs, error) {
+	if capacity <= 0 {
+		return nil, errors.New("plotter: non-positive ring capacity")
+	}
+	return &RingXYs{
+		buf:   make(XYs, capacity),
+		valid: true,
+		xmin:  math.Inf(1),
+		xmax:  math.Inf(-1),
+		ymin:  math.Inf(1),
+		ymax:  math.Inf(-1),
+	}, nil
+}
+
+// Len returns the number of points held.
+func (r *RingXYs) Len() int {
+	return r.n
+}
+
+// Cap returns the maximum number of points that can be held.
+func (r *RingXYs) Cap() int {
+	return len(r.buf)
+}
+
+// XY returns the ith oldest point held.
+func (r *RingXYs) XY(i int) (float64, float64) {
+	if i < 0 || i >= r.n {
+		panic("plotter: ring index out of range")
+	}
+	p := r.buf[(r.start+i)%len(r.buf)]
+	return p.X, p.Y
+}
+
+// Append adds the point (x, y) to the ring, discarding
+// the oldest point if the ring is full and any points
+// that have fallen outside Window. Append returns an
+// error if x or y is NaN or Infinity.
+func (r *RingXYs) Append(x, y float64) error {
+	if err := CheckFloats(x, y); err != nil {
+		return err
+	}
+	if r.n == len(r.buf) {
+		r.evict()
+	}
+	i := (r.start + r.n) % len(r.buf)
+	r.buf[i].X, r.buf[i].Y = x, y
+	r.n++
+	r.Changed()
+	if r.valid {
+		r.xmin = math.Min(r.xmin, x)
+		r.xmax = math.Max(r.xmax, x)
+		r.ymin = math.Min(r.ymin, y)
+		r.ymax = math.Max(r.ymax, y)
+	}
+	if r.Window > 0 {
+		for r.n > 1 && x-r.buf[r.start].X > r.Window {
+			r.evict()
+		}
+	}
+	return nil
+}
+
+// Reset discards all points held by the ring.
+func (r *RingXYs) Reset() {
+	r.start = 0
+	r.n = 0
+	r.xmin, r.xmax = math.Inf(1), math.Inf(-1)
+	r.ymin, r.ymax = math.Inf(1), math.Inf(-1)
+	r.valid = true
+	r.Changed()
+}
+
+// evict removes the oldest point, invalidating the
+// cached range if the point lay on its boundary.
+func (r *RingXYs) evict() {
+	p := r.buf[r.start]
+	if p.X == r.xmin || p.X == r.xmax || p.Y == r.ymin || p.Y == r.ymax {
+		r.valid = false
+	}
+	r.start = (r.start + 1) % len(r.buf)
+	r.n--
+}
+
+// DataRange returns the minimum and maximum x and
+// y values of the points held, implementing the
+// plot.DataRanger interface.
+func (r *RingXYs) DataRange() (xmin, xmax, ymin, ymax float64) {
+	if !r.valid {
+		r.xmin, r.xmax, r.ymin, r.ymax = XYRange(r)
+		r.valid = true
+	}
+	return r.xmin, r.xmax, r.ymin, r.ymax
+}
+
+// StreamLine implements the Plotter interface, drawing
+// a line through the points held in a RingXYs.
+//
+// The plot.Plot only consults DataRange when a plotter
+// is added, so callers rendering a StreamLine repeatedly
+// with the same plot.Plot should update the axis ranges
+// from DataRange before each render, or render it with
+// a plot.Layers with AutoRange set.
+type StreamLine struct {
+	*RingXYs
+
+	// LineStyle is the style of the line connecting
+	// the points.
+	draw.LineStyle
+}
+
+// NewStreamLine returns a StreamLine holding up to
+// capacity points and using the default line style.
+func NewStreamLine(capacity int) (*StreamLine, error) {
+	r, err := NewRingXYs(capacity)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamLine{
+		RingXYs:   r,
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// Plot draws the StreamLine, implementing the
+// plot.Plotter interface.
+func (l *StreamLine) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	buf := lineBufferPool.Get().(*lineBuffers)
+	defer lineBufferPool.Put(buf)
+
+	pts := buf.pts[:0]
+	for i := 0; i < l.Len(); i++ {
+		x, y := l.XY(i)
+		pts = append(pts, vg.Point{X: trX(x), Y: trY(y)})
+	}
+	buf.pts = pts
+	c.StrokeLines(l.LineStyle, c.ClipLinesXY(pts)...)
+}
+
+// Thumbnail draws the thumbnail for the StreamLine,
+// implementing the plot.Thumbnailer interface.
+func (l *StreamLine) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(l.LineStyle, c.Min.X, y, c.Max.X, y)
+}
+
+// StreamScatter implements the Plotter interface,
+// drawing a glyph for each of the points held in
+// a RingXYs.
+//
+// As with StreamLine, callers reusing a plot.Plot
+// should update the axis ranges from DataRange before
+// each render.
+type StreamScatter struct {
+	*RingXYs
+
+	// GlyphStyle is the style of the glyphs drawn
+	// at each point.
+	draw.GlyphStyle
+}
+
+// NewStreamScatter returns a StreamScatter holding
+// up to capacity points and using the default glyph
+// style.
+func NewStreamScatter(capacity int) (*StreamScatter, error) {
+	r, err := NewRingXYs(capacity)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamScatter{
+		RingXYs:    r,
+		GlyphStyle: DefaultGlyphStyle,
+	}, nil
+}
+
+// Plot draws the StreamScatter, implementing the
+// plot.Plotter interface.
+func (s *StreamScatter) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for i := 0; i < s.Len(); i++ {
+		x, y := s.XY(i)
+		c.DrawGlyph(s.GlyphStyle, vg.Point{X: trX(x), Y: trY(y)})
+	}
+}
+
+// GlyphBoxes returns a slice of plot.GlyphBoxes,
+// implementing the plot.GlyphBoxer interface.
+func (s *StreamScatter) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, s.Len())
+	r := s.GlyphStyle.Rectangle()
+	for i := range bs {
+		x, y := s.XY(i)
+		bs[i].X = plt.X.Norm(x)
+		bs[i].Y = plt.Y.Norm(y)
+		bs[i].Rectangle = r
+	}
+	return bs
+}
+
+// Thumbnail draws the thumbnail for the StreamScatter,
+// implementing the plot.Thumbnailer interface.
+func (s *StreamScatter) Thumbnail(c *draw.Canvas) {
+	c.DrawGlyph(s.GlyphStyle, c.Center())
+}
@@ -0,0 +1,118 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+func TestRingXYs(t *testing.T) {
+	r, err := NewRingXYs(3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := r.Append(float64(i), float64(10-i)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if r.Len() != 3 {
+		t.Fatalf("unexpected length: got:%d want:3", r.Len())
+	}
+	got, err := CopyXYs(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := XYs{{2, 8}, {3, 7}, {4, 6}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected data: got:%v want:%v", got, want)
+	}
+	xmin, xmax, ymin, ymax := r.DataRange()
+	if xmin != 2 || xmax != 4 || ymin != 6 || ymax != 8 {
+		t.Errorf("unexpected range: got:[%v %v %v %v] want:[2 4 6 8]", xmin, xmax, ymin, ymax)
+	}
+
+	if err := r.Append(math.NaN(), 0); err != ErrNaN {
+		t.Errorf("unexpected error for NaN: got:%v want:%v", err, ErrNaN)
+	}
+
+	r.Reset()
+	if r.Len() != 0 {
+		t.Errorf("unexpected length after Reset: got:%d want:0", r.Len())
+	}
+}
+
+func TestRingXYsWindow(t *testing.T) {
+	r, err := NewRingXYs(100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r.Window = 2.5
+	for i := 0; i < 10; i++ {
+		r.Append(float64(i), float64(i*i))
+	}
+	xmin, xmax, ymin, ymax := r.DataRange()
+	if xmin != 7 || xmax != 9 || ymin != 49 || ymax != 81 {
+		t.Errorf("unexpected range: got:[%v %v %v %v] want:[7 9 49 81]", xmin, xmax, ymin, ymax)
+	}
+}
+
+func TestStreamLine(t *testing.T) {
+	l, err := NewStreamLine(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s, err := NewStreamScatter(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		l.Append(float64(i), math.Sin(float64(i)))
+		s.Append(float64(i), math.Cos(float64(i)))
+
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Add(l, s)
+		_, err = p.WriterTo(4*vg.Centimeter, 4*vg.Centimeter, "png")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// The same StreamLine may be drawn concurrently.
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l)
+	var wg sync.WaitGroup
+	recs := make([]recorder.Canvas, 4)
+	for i := range recs {
+		wg.Add(1)
+		go func(rec *recorder.Canvas) {
+			defer wg.Done()
+			l.Plot(draw.NewCanvas(rec, 100, 100), p)
+		}(&recs[i])
+	}
+	wg.Wait()
+	if len(recs[0].Actions) == 0 {
+		t.Fatal("no actions drawn")
+	}
+	for i, rec := range recs {
+		if !reflect.DeepEqual(rec.Actions, recs[0].Actions) {
+			t.Errorf("unexpected actions of concurrent draw %d", i)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Weighter wraps the Weight method. Data implementing Weighter
+// in addition to Valuer or XYer hold frequency weighted samples,
+// and the statistical plotters, Histogram, BoxPlot, QuartPlot and
+// Density2D, treat the ith sample as if it occurred Weight(i)
+// times, without the data being expanded.
+type Weighter interface {
+	// Weight returns the non-negative weight
+	// of the ith sample.
+	Weight(int) float64
+}
+
+// GridWeighter wraps the Weight method of a GridXYZ holding
+// weighted values. NewContour chooses its default levels from
+// the weighted quantiles of the values of a GridXYZ that
+// implements GridWeighter.
+type GridWeighter interface {
+	// Weight returns the non-negative weight of
+	// the value at column c and row r.
+	Weight(c, r int) float64
+}
+
+// WeightedValues implements the Valuer and Weighter
+// interfaces, weighting each value of Valuer by the
+// corresponding value of Weights.
+type WeightedValues struct {
+	Valuer
+	Weights Valuer
+}
+
+// Weight returns the ith weight, implementing
+// the Weighter interface.
+func (w WeightedValues) Weight(i int) float64 {
+	return w.Weights.Value(i)
+}
+
+// errWeight is returned for data with invalid weights.
+var errWeight = errors.New("plotter: negative or non-finite weight")
+
+// weightsOf returns the weights of the first n samples of
+// data for which keep returns true, or nil if data does not
+// implement Weighter.
+func weightsOf(data interface{}, n int, keep func(i int) bool) ([]float64, error) {
+	w, ok := data.(Weighter)
+	if !ok {
+		return nil, nil
+	}
+	weights := make([]float64, 0, n)
+	for i := 0; i < n; i++ {
+		if !keep(i) {
+			continue
+		}
+		v := w.Weight(i)
+		if !(v >= 0) || math.IsInf(v, 0) {
+			return nil, errWeight
+		}
+		weights = append(weights, v)
+	}
+	return weights, nil
+}
+
+// weightedXYs is an XYer whose y values are scaled by
+// the weights of a Weighter. weightedXYs has the
+// non-finite policy of its XYer.
+type weightedXYs struct {
+	XYer
+	w Weighter
+}
+
+func (w weightedXYs) XY(i int) (float64, float64) {
+	x, y := w.XYer.XY(i)
+	return x, y * w.w.Weight(i)
+}
+
+func (w weightedXYs) NonFinitePolicy() NonFinite { return policyOf(w.XYer) }
+
+// weightXYs returns xy with its y values scaled by the
+// weights of data, or xy if data does not implement
+// Weighter. The weights of the finite points of xy are
+// checked.
+func weightXYs(data interface{}, xy XYer) (XYer, error) {
+	w, ok := data.(Weighter)
+	if !ok {
+		return xy, nil
+	}
+	_, err := weightsOf(w, xy.Len(), func(i int) bool {
+		return isFinite(xy.XY(i))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return weightedXYs{XYer: xy, w: w}, nil
+}
+
+// weightedSample is a sample of values answering order
+// statistics as if each value were repeated by its weight.
+type weightedSample struct {
+	// vals holds the values in ascending order.
+	vals []float64
+
+	// cum holds the cumulative weights of vals,
+	// so that cum[i] is the total weight of
+	// vals[:i+1].
+	cum []float64
+}
+
+// newWeightedSample returns the sample of vals weighted by
+// weights, which must be nil or have the length of vals.
+// A nil weights gives each value unit weight. vals and
+// weights are sorted in place.
+func newWeightedSample(vals, weights []float64) weightedSample {
+	if weights == nil {
+		sort.Float64s(vals)
+	} else {
+		sort.Sort(byValue{vals: vals, weights: weights})
+	}
+	cum := make([]float64, len(vals))
+	var sum float64
+	for i := range vals {
+		if weights == nil {
+			sum++
+		} else {
+			sum += weights[i]
+		}
+		cum[i] = sum
+	}
+	return weightedSample{vals: vals, cum: cum}
+}
+
+// total returns the total weight of the sample.
+func (s weightedSample) total() float64 {
+	if len(s.cum) == 0 {
+		return 0
+	}
+	return s.cum[len(s.cum)-1]
+}
+
+// at returns the value at position k, from zero, of the
+// sorted sample with each value repeated by its weight.
+// Positions beyond the end of the sample return the last
+// value of positive weight.
+func (s weightedSample) at(k float64) float64 {
+	i := sort.Search(len(s.cum), func(i int) bool { return s.cum[i] > k })
+	if i == len(s.cum) {
+		i = sort.SearchFloat64s(s.cum, s.total())
+	}
+	return s.vals[i]
+}
+
+// median returns the median of the positions lo to hi,
+// exclusive, of the sample.
+func (s weightedSample) median(lo, hi float64) float64 {
+	m := hi - lo
+	if m <= 1 {
+		return s.at(lo)
+	}
+	half := math.Floor(m / 2)
+	med := s.at(lo + half)
+	if m == 2*half {
+		med = (med + s.at(lo+half-1)) / 2
+	}
+	return med
+}
+
+// quantileR7 returns the qth quantile of the sample according
+// to the R-7 method.
+func (s weightedSample) quantileR7(q float64) float64 {
+	h := (s.total() - 1) * q
+	lo := math.Floor(h)
+	return s.at(lo) + (h-lo)*(s.at(lo+1)-s.at(lo))
+}
+
+// byValue sorts values and their weights by value.
+type byValue struct {
+	vals, weights []float64
+}
+
+func (b byValue) Len() int           { return len(b.vals) }
+func (b byValue) Less(i, j int) bool { return b.vals[i] < b.vals[j] }
+func (b byValue) Swap(i, j int) {
+	b.vals[i], b.vals[j] = b.vals[j], b.vals[i]
+	b.weights[i], b.weights[j] = b.weights[j], b.weights[i]
+}
@@ -0,0 +1,193 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// weightedXYsData is an XYs weighted by w.
+type weightedXYsData struct {
+	XYs
+	w []float64
+}
+
+func (d weightedXYsData) Weight(i int) float64 { return d.w[i] }
+
+// weightedGrid is a unitGrid with the values of column c
+// weighted by w[c].
+type weightedGrid struct {
+	unitGrid
+	w []float64
+}
+
+func (g weightedGrid) Weight(c, _ int) float64 { return g.w[c] }
+
+// weightedData returns n random values with small integer
+// weights, some zero, and the values repeated by their
+// weights.
+func weightedData(n int, seed uint64) (vals, weights, expanded Values) {
+	rnd := rand.New(rand.NewSource(seed))
+	vals = make(Values, n)
+	weights = make(Values, n)
+	for i := range vals {
+		vals[i] = math.Floor(rnd.NormFloat64()*100) / 10
+		weights[i] = float64(rnd.Intn(4))
+		for k := 0; k < int(weights[i]); k++ {
+			expanded = append(expanded, vals[i])
+		}
+	}
+	return vals, weights, expanded
+}
+
+func TestWeightedSample(t *testing.T) {
+	for seed := uint64(1); seed <= 20; seed++ {
+		vals, weights, expanded := weightedData(int(seed), seed)
+		if len(expanded) == 0 {
+			continue
+		}
+		w := newWeightedSample(append(Values(nil), vals...), append(Values(nil), weights...))
+		u := newWeightedSample(append(Values(nil), expanded...), nil)
+		if w.total() != u.total() {
+			t.Errorf("seed %d: unexpected total: got:%v want:%v", seed, w.total(), u.total())
+		}
+		for k := 0.0; k <= u.total(); k++ {
+			if got, want := w.at(k), u.at(k); got != want {
+				t.Errorf("seed %d: unexpected value at %v: got:%v want:%v", seed, k, got, want)
+			}
+		}
+		for _, q := range []float64{0, 0.01, 0.25, 0.5, 0.75, 0.99, 1} {
+			if got, want := w.quantileR7(q), u.quantileR7(q); math.Abs(got-want) > 1e-12 {
+				t.Errorf("seed %d: unexpected %v quantile: got:%v want:%v", seed, q, got, want)
+			}
+		}
+	}
+}
+
+func TestWeightedBoxPlot(t *testing.T) {
+	for seed := uint64(1); seed <= 20; seed++ {
+		vals, weights, expanded := weightedData(3*int(seed), seed)
+		if len(expanded) == 0 {
+			continue
+		}
+		got, err := NewBoxPlot(1, 0, WeightedValues{Valuer: vals, Weights: weights})
+		if err != nil {
+			t.Fatalf("seed %d: unexpected error: %v", seed, err)
+		}
+		want, err := NewBoxPlot(1, 0, expanded)
+		if err != nil {
+			t.Fatalf("seed %d: unexpected error: %v", seed, err)
+		}
+		for _, s := range []struct {
+			name      string
+			got, want float64
+		}{
+			{"median", got.Median, want.Median},
+			{"first quartile", got.Quartile1, want.Quartile1},
+			{"third quartile", got.Quartile3, want.Quartile3},
+			{"min", got.Min, want.Min},
+			{"max", got.Max, want.Max},
+			{"low whisker", got.AdjLow, want.AdjLow},
+			{"high whisker", got.AdjHigh, want.AdjHigh},
+		} {
+			if s.got != s.want {
+				t.Errorf("seed %d: unexpected %s: got:%v want:%v", seed, s.name, s.got, s.want)
+			}
+		}
+		if len(got.Weights) != len(vals) {
+			t.Errorf("seed %d: unexpected number of weights: got:%d want:%d", seed, len(got.Weights), len(vals))
+		}
+	}
+
+	_, err := NewBoxPlot(1, 0, WeightedValues{Valuer: Values{1, 2}, Weights: Values{1, -1}})
+	if err != errWeight {
+		t.Errorf("unexpected error for negative weight: got:%v want:%v", err, errWeight)
+	}
+	_, err = NewBoxPlot(1, 0, WeightedValues{Valuer: Values{1, 2}, Weights: Values{0, 0}})
+	if err != ErrNoData {
+		t.Errorf("unexpected error for zero weights: got:%v want:%v", err, ErrNoData)
+	}
+}
+
+func TestWeightedHist(t *testing.T) {
+	vals, weights, expanded := weightedData(50, 1)
+	got, err := NewHist(WeightedValues{Valuer: vals, Weights: weights}, 8)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, err := NewHist(expanded, 8)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The weighted histogram spans the values of zero
+	// weight, so only the total weight is comparable.
+	var gotSum, wantSum float64
+	for _, b := range got.Bins {
+		gotSum += b.Weight
+	}
+	for _, b := range want.Bins {
+		wantSum += b.Weight
+	}
+	if gotSum != wantSum {
+		t.Errorf("unexpected total weight: got:%v want:%v", gotSum, wantSum)
+	}
+
+	xys := weightedXYsData{XYs: XYs{{X: 0, Y: 1}, {X: 1, Y: 2}, {X: 3, Y: 1}}, w: []float64{2, 0.5, 3}}
+	h, err := NewHistogram(xys, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, want := range []float64{2, 1, 3} {
+		if h.Bins[i].Weight != want {
+			t.Errorf("unexpected weight of bin %d: got:%v want:%v", i, h.Bins[i].Weight, want)
+		}
+	}
+}
+
+func TestWeightedDensity2D(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	data := weightedXYsData{XYs: make(XYs, 40), w: make([]float64, 40)}
+	var expanded XYs
+	for i := range data.XYs {
+		data.XYs[i].X = rnd.NormFloat64()
+		data.XYs[i].Y = rnd.NormFloat64()
+		data.w[i] = float64(1 + rnd.Intn(3))
+		for k := 0; k < int(data.w[i]); k++ {
+			expanded = append(expanded, data.XYs[i])
+		}
+	}
+	got, err := NewDensity2D(data, 20, 20, 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, err := NewDensity2D(expanded, 20, 20, 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c, r := want.Dims()
+	for i := 0; i < c; i++ {
+		for j := 0; j < r; j++ {
+			if math.Abs(got.Z(i, j)-want.Z(i, j)) > 1e-12 {
+				t.Fatalf("unexpected density at (%d, %d): got:%v want:%v", i, j, got.Z(i, j), want.Z(i, j))
+			}
+		}
+	}
+}
+
+func TestWeightedContourQuantiles(t *testing.T) {
+	vals, weights, expanded := weightedData(30, 3)
+	got := quantilesR7(weightedGrid{unitGrid: unitGrid{mat.NewDense(1, len(vals), vals)}, w: weights}, defaultQuantiles)
+	want := quantilesR7(unitGrid{mat.NewDense(1, len(expanded), expanded)}, defaultQuantiles)
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-12 {
+			t.Errorf("unexpected %v quantile: got:%v want:%v", defaultQuantiles[i], got[i], want[i])
+		}
+	}
+}
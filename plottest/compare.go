@@ -0,0 +1,250 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plottest
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"reflect"
+	"strings"
+
+	"rsc.io/pdf"
+
+	_ "image/jpeg"
+	_ "image/png"
+
+	_ "golang.org/x/image/tiff"
+)
+
+// Tolerance specifies how different two raster images may
+// be and still be considered equal. It has no effect on the
+// comparison of vector formats, which must match exactly.
+type Tolerance struct {
+	// Threshold is the perceptual color distance, from
+	// 0 to 1, above which two pixels are counted as
+	// different. The distance is measured in the YIQ
+	// color space, weighting differences in brightness
+	// above differences in hue, so that antialiasing
+	// differences between platforms may be ignored.
+	Threshold float64
+
+	// Pixels is the fraction of pixels that may differ.
+	Pixels float64
+}
+
+// Exact is the Tolerance requiring images to be identical.
+var Exact = Tolerance{}
+
+// Equal takes the raw representation of two images, raw1 and raw2,
+// together with the underlying image type ("eps", "jpeg", "jpg", "pdf", "png", "svg", "tiff"),
+// and returns whether the two images are equal within the given
+// tolerance.
+//
+// Equal may return an error if the decoding of the raw image somehow failed.
+func Equal(typ string, raw1, raw2 []byte, tol Tolerance) (bool, error) {
+	switch typ {
+	case "svg", "tex":
+		return bytes.Equal(raw1, raw2), nil
+
+	case "eps":
+		lines1, lines2 := strings.Split(string(raw1), "\n"), strings.Split(string(raw2), "\n")
+		if len(lines1) != len(lines2) {
+			return false, nil
+		}
+		for i, line1 := range lines1 {
+			if strings.Contains(line1, "CreationDate") {
+				continue
+			}
+			if line1 != lines2[i] {
+				return false, nil
+			}
+		}
+		return true, nil
+
+	case "pdf":
+		r1 := bytes.NewReader(raw1)
+		pdf1, err := pdf.NewReader(r1, r1.Size())
+		if err != nil {
+			return false, err
+		}
+
+		r2 := bytes.NewReader(raw2)
+		pdf2, err := pdf.NewReader(r2, r2.Size())
+		if err != nil {
+			return false, err
+		}
+
+		return cmpPdf(pdf1, pdf2), nil
+
+	case "jpeg", "jpg", "png", "tiff":
+		v1, _, err := image.Decode(bytes.NewReader(raw1))
+		if err != nil {
+			return false, err
+		}
+		v2, _, err := image.Decode(bytes.NewReader(raw2))
+		if err != nil {
+			return false, err
+		}
+		if tol == Exact {
+			return reflect.DeepEqual(v1, v2), nil
+		}
+		return similar(v1, v2, tol), nil
+
+	default:
+		return false, fmt.Errorf("plottest: unknown image type %q", typ)
+	}
+}
+
+// similar returns whether the images a and b have the
+// same bounds and differ at no more than the fraction of
+// pixels allowed by tol.
+func similar(a, b image.Image, tol Tolerance) bool {
+	if a.Bounds() != b.Bounds() {
+		return false
+	}
+	r := a.Bounds()
+	if r.Empty() {
+		return true
+	}
+	var n int
+	for x := r.Min.X; x < r.Max.X; x++ {
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			if colorDistance(a.At(x, y), b.At(x, y)) > tol.Threshold {
+				n++
+			}
+		}
+	}
+	return float64(n) <= tol.Pixels*float64(r.Dx()*r.Dy())
+}
+
+// colorDistance returns the perceptual distance between
+// the colors c1 and c2 after blending them with white, on
+// a scale from 0 to 1. It is the weighted YIQ distance of
+// Kotsarenko and Ramos, "Measuring perceived color
+// difference using YIQ NTSC transmission color space in
+// mobile applications".
+func colorDistance(c1, c2 color.Color) float64 {
+	y1, i1, q1 := yiq(c1)
+	y2, i2, q2 := yiq(c2)
+	dy, di, dq := y1-y2, i1-i2, q1-q2
+	// maxDistance is the distance between black and white.
+	const maxDistance = 0.5053
+	return (0.5053*dy*dy + 0.299*di*di + 0.1957*dq*dq) / maxDistance
+}
+
+// yiq returns the YIQ components of c blended with white.
+func yiq(c color.Color) (y, i, q float64) {
+	r, g, b, a := c.RGBA()
+	white := float64(math.MaxUint16 - a)
+	rf := (float64(r) + white) / math.MaxUint16
+	gf := (float64(g) + white) / math.MaxUint16
+	bf := (float64(b) + white) / math.MaxUint16
+	y = 0.29889531*rf + 0.58662247*gf + 0.11448223*bf
+	i = 0.59597799*rf - 0.27417610*gf - 0.32180189*bf
+	q = 0.21147017*rf - 0.52261711*gf + 0.31114694*bf
+	return y, i, q
+}
+
+func cmpPdf(pdf1, pdf2 *pdf.Reader) bool {
+	n1 := pdf1.NumPage()
+	n2 := pdf2.NumPage()
+	if n1 != n2 {
+		return false
+	}
+
+	for i := 1; i <= n1; i++ {
+		p1 := pdf1.Page(i).Content()
+		p2 := pdf2.Page(i).Content()
+		if !reflect.DeepEqual(p1, p2) {
+			return false
+		}
+	}
+
+	t1 := pdf1.Trailer().String()
+	t2 := pdf2.Trailer().String()
+	return t1 == t2
+}
+
+// Diff calculates an intensity-scaled difference between images a and b
+// and places the result in dst, returning the intersection of a, b and
+// dst. It is the responsibility of the caller to construct dst so that
+// it will overlap with a and b. For the purposes of Diff, alpha is not
+// considered.
+//
+// Diff is not intended to be used for quantitative analysis of the
+// difference between the input images, but rather to highlight differences
+// between them for testing purposes, so the calculation is rather naive.
+func Diff(dst draw.Image, a, b image.Image) image.Rectangle {
+	rect := dst.Bounds().Intersect(a.Bounds()).Intersect(b.Bounds())
+
+	// Determine greyscale dynamic range.
+	min := uint16(math.MaxUint16)
+	max := uint16(0)
+	for x := rect.Min.X; x < rect.Max.X; x++ {
+		for y := rect.Min.Y; y < rect.Max.Y; y++ {
+			p := diffColor{a.At(x, y), b.At(x, y)}
+			g := color.Gray16Model.Convert(p).(color.Gray16)
+			if g.Y < min {
+				min = g.Y
+			}
+			if g.Y > max {
+				max = g.Y
+			}
+		}
+	}
+
+	// Render intensity-scaled difference.
+	for x := rect.Min.X; x < rect.Max.X; x++ {
+		for y := rect.Min.Y; y < rect.Max.Y; y++ {
+			dst.Set(x, y, scaledColor{
+				min: uint32(min), max: uint32(max),
+				c: diffColor{a.At(x, y), b.At(x, y)},
+			})
+		}
+	}
+
+	return rect
+}
+
+type diffColor struct {
+	a, b color.Color
+}
+
+func (c diffColor) RGBA() (r, g, b, a uint32) {
+	ra, ga, ba, _ := c.a.RGBA()
+	rb, gb, bb, _ := c.b.RGBA()
+	return diff(ra, rb), diff(ga, gb), diff(ba, bb), math.MaxUint16
+}
+
+func diff(a, b uint32) uint32 {
+	if a < b {
+		return b - a
+	}
+	return a - b
+}
+
+type scaledColor struct {
+	min, max uint32
+	c        color.Color
+}
+
+func (c scaledColor) RGBA() (r, g, b, a uint32) {
+	if c.max == c.min {
+		return 0, 0, 0, 0
+	}
+	f := uint32(math.MaxUint16) / (c.max - c.min)
+	r, g, b, _ = c.c.RGBA()
+	r -= c.min
+	r *= f
+	g -= c.min
+	g *= f
+	b -= c.min
+	b *= f
+	return r, g, b, math.MaxUint16
+}
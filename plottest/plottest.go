@@ -0,0 +1,179 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package plottest provides golden image regression tests of plots.
+//
+// A test renders a plot to a file in the package's testdata directory
+// and compares it with a previously approved golden image, stored next
+// to it with a _golden suffix: testdata/scatter.png is compared with
+// testdata/scatter_golden.png. Setting Regenerate, for example with
+// the -regen flag added by RegisterFlags, replaces the golden images
+// with the current output.
+//
+// Raster images rendered on different platforms may differ slightly in
+// their antialiasing, so raster comparisons may be given a perceptual
+// Tolerance. Fonts should be pinned with PinFonts so that the fonts
+// bundled with the vg package are used regardless of the fonts found
+// in vg.FontDirs.
+package plottest // import "gonum.org/v1/plot/plottest"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"flag"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/freetype"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/fonts"
+)
+
+// Regenerate is whether golden images are replaced by the
+// output of the tests rather than compared with it.
+var Regenerate bool
+
+// RegisterFlags adds a -regen flag setting Regenerate to fs.
+// Test packages may call it with flag.CommandLine in an init
+// function or in TestMain before flag.Parse is called.
+func RegisterFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&Regenerate, "regen", false, "Uses the current state to regenerate the test data.")
+}
+
+// GoldenPath returns the path of the golden image for the
+// image file at path.
+func GoldenPath(path string) string {
+	ext := filepath.Ext(path)
+	noext := strings.TrimSuffix(path, ext)
+	return noext + "_golden" + ext
+}
+
+// UpdateGolden replaces the golden image for the image file
+// at path with the file.
+func UpdateGolden(path string) error {
+	golden := GoldenPath(path)
+	_ = os.Remove(golden)
+	return os.Rename(path, golden)
+}
+
+// Check calls fn, which writes the named image files to the
+// testdata directory, and checks each image against its golden
+// image within the tolerance tol. If Regenerate is true the
+// golden images are replaced instead.
+//
+// For raster formats, a base64 encoded png of the difference
+// between the images is output to the test log when they differ.
+func Check(t *testing.T, fn func(), tol Tolerance, filenames ...string) {
+	paths := make([]string, len(filenames))
+	for i, fn := range filenames {
+		paths[i] = filepath.Join("testdata", fn)
+	}
+
+	if Regenerate {
+		// Recreate Golden images and exit.
+		fn()
+		for _, path := range paths {
+			if err := UpdateGolden(path); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return
+	}
+
+	// Run the example.
+	fn()
+
+	// Read the images we've just generated and check them against the
+	// Golden Images.
+	for _, path := range paths {
+		got, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Errorf("Failed to read %s: %v", path, err)
+			continue
+		}
+		golden := GoldenPath(path)
+		want, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Errorf("Failed to read golden file %s: %v", golden, err)
+			continue
+		}
+		typ := filepath.Ext(path)[1:] // remove the dot in e.g. ".pdf"
+		ok, err := Equal(typ, got, want, tol)
+		if err != nil {
+			t.Errorf("failed to compare image for %s: %v", path, err)
+			continue
+		}
+		if !ok {
+			t.Errorf("image mismatch for %s\n", path)
+			switch typ {
+			case "jpeg", "jpg", "png", "tiff", "tif":
+				v1, _, err := image.Decode(bytes.NewReader(got))
+				if err != nil {
+					t.Errorf("failed to decode %s: %v", path, err)
+					continue
+				}
+				v2, _, err := image.Decode(bytes.NewReader(want))
+				if err != nil {
+					t.Errorf("failed to decode %s: %v", golden, err)
+					continue
+				}
+
+				dst := image.NewRGBA64(v1.Bounds().Union(v2.Bounds()))
+				rect := Diff(dst, v1, v2)
+				t.Logf("image bounds union:%+v diff bounds intersection:%+v", dst.Bounds(), rect)
+
+				var buf bytes.Buffer
+				err = png.Encode(&buf, dst)
+				if err != nil {
+					t.Errorf("failed to encode difference png: %v", err)
+					continue
+				}
+				t.Log("IMAGE:" + base64.StdEncoding.EncodeToString(buf.Bytes()))
+			}
+		}
+	}
+}
+
+// Saver is the interface implemented by plots, and figures
+// composed of plots, that can be saved to an image file.
+type Saver interface {
+	Save(w, h vg.Length, file string) error
+}
+
+// CheckSave saves s with width w and height h to the named
+// file in the testdata directory and checks it against its
+// golden image as for Check.
+func CheckSave(t *testing.T, s Saver, w, h vg.Length, file string, tol Tolerance) {
+	Check(t, func() {
+		err := s.Save(w, h, filepath.Join("testdata", file))
+		if err != nil {
+			t.Fatalf("failed to save %s: %v", file, err)
+		}
+	}, tol, file)
+}
+
+// PinFonts loads each font of vg.FontMap from the fonts bundled
+// with the vg package, so that plots are rendered with the same
+// fonts on every platform. It must be called before any plot is
+// made, for example in TestMain.
+func PinFonts() error {
+	for name, file := range vg.FontMap {
+		data, err := fonts.Asset(file + ".ttf")
+		if err != nil {
+			return err
+		}
+		font, err := freetype.ParseFont(data)
+		if err != nil {
+			return err
+		}
+		vg.AddFont(name, font)
+	}
+	return nil
+}
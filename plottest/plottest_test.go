@@ -0,0 +1,156 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plottest
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gonum.org/v1/plot/vg"
+)
+
+// encode returns the png encoding of a w×h white image with
+// the given pixels set to c.
+func encode(t *testing.T, w, h int, c color.Color, pixels ...image.Point) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	for _, p := range pixels {
+		img.Set(p.X, p.Y, c)
+	}
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	if err != nil {
+		t.Fatalf("failed to encode image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestEqualTolerance(t *testing.T) {
+	want := encode(t, 10, 10, color.Black)
+	faint := color.RGBA{R: 250, G: 250, B: 250, A: 255}
+	for _, test := range []struct {
+		name string
+		got  []byte
+		tol  Tolerance
+		want bool
+	}{
+		{
+			name: "identical",
+			got:  encode(t, 10, 10, color.Black),
+			tol:  Exact,
+			want: true,
+		},
+		{
+			name: "faint pixel exact",
+			got:  encode(t, 10, 10, faint, image.Pt(1, 1)),
+			tol:  Exact,
+			want: false,
+		},
+		{
+			name: "faint pixels below threshold",
+			got:  encode(t, 10, 10, faint, image.Pt(1, 1), image.Pt(2, 2), image.Pt(3, 3)),
+			tol:  Tolerance{Threshold: 0.01},
+			want: true,
+		},
+		{
+			name: "black pixel within allowed fraction",
+			got:  encode(t, 10, 10, color.Black, image.Pt(5, 5)),
+			tol:  Tolerance{Threshold: 0.01, Pixels: 0.01},
+			want: true,
+		},
+		{
+			name: "black pixels beyond allowed fraction",
+			got:  encode(t, 10, 10, color.Black, image.Pt(5, 5), image.Pt(6, 6)),
+			tol:  Tolerance{Threshold: 0.01, Pixels: 0.01},
+			want: false,
+		},
+		{
+			name: "different bounds",
+			got:  encode(t, 10, 11, color.Black),
+			tol:  Tolerance{Threshold: 1, Pixels: 1},
+			want: false,
+		},
+	} {
+		got, err := Equal("png", test.got, want, test.tol)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", test.name, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("unexpected result for %s: got:%t want:%t", test.name, got, test.want)
+		}
+	}
+
+	ok, err := Equal("svg", []byte("<svg/>"), []byte("<svg />"), Tolerance{Threshold: 1, Pixels: 1})
+	if err != nil || ok {
+		t.Errorf("unexpected result for differing svg: got:%t err:%v want:false", ok, err)
+	}
+}
+
+func TestColorDistance(t *testing.T) {
+	if d := colorDistance(color.Black, color.White); d < 0.999 || d > 1.001 {
+		t.Errorf("unexpected black-white distance: got:%v want:1", d)
+	}
+	if d := colorDistance(color.Transparent, color.White); d != 0 {
+		t.Errorf("unexpected transparent-white distance: got:%v want:0", d)
+	}
+}
+
+func TestUpdateGolden(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plottest")
+	if err != nil {
+		t.Fatalf("failed to make temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "plot.png")
+	if got, want := GoldenPath(path), filepath.Join(dir, "plot_golden.png"); got != want {
+		t.Errorf("unexpected golden path: got:%q want:%q", got, want)
+	}
+	for _, data := range []string{"old", "new"} {
+		err = ioutil.WriteFile(path, []byte(data), 0644)
+		if err != nil {
+			t.Fatalf("failed to write image: %v", err)
+		}
+		err = UpdateGolden(path)
+		if err != nil {
+			t.Fatalf("unexpected error updating golden: %v", err)
+		}
+		got, err := ioutil.ReadFile(GoldenPath(path))
+		if err != nil {
+			t.Fatalf("failed to read golden: %v", err)
+		}
+		if string(got) != data {
+			t.Errorf("unexpected golden contents: got:%q want:%q", got, data)
+		}
+	}
+}
+
+func TestPinFonts(t *testing.T) {
+	dirs := vg.FontDirs
+	defer func() { vg.FontDirs = dirs }()
+	vg.FontDirs = []string{"does-not-exist"}
+
+	err := PinFonts()
+	if err != nil {
+		t.Fatalf("unexpected error pinning fonts: %v", err)
+	}
+	for name := range vg.FontMap {
+		_, err := vg.MakeFont(name, 12)
+		if err != nil {
+			t.Errorf("failed to make pinned font %s: %v", name, err)
+		}
+	}
+}
@@ -7,6 +7,7 @@ package plotutil
 import (
 	"errors"
 	"fmt"
+	"image/color"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
@@ -122,6 +123,107 @@ func AddBoxPlots(plt *plot.Plot, width vg.Length, vs ...interface{}) error {
 	return nil
 }
 
+// HistogramLayout is the arrangement of the histograms
+// added by AddHistograms.
+type HistogramLayout int
+
+const (
+	// OverlaidHistograms draws the histograms over each
+	// other with translucent fills.
+	OverlaidHistograms HistogramLayout = iota
+
+	// GroupedHistograms draws the bars of the histograms
+	// side by side within each bin.
+	GroupedHistograms
+
+	// StackedHistograms draws the bars of each histogram
+	// on top of the bars of the histogram before it.
+	StackedHistograms
+)
+
+// AddHistograms adds Histogram plotters sharing the same
+// n bins to a plot, arranged according to layout, and
+// returns them so that their styles can be changed.
+// The variadic arguments must be either strings,
+// plotter.XYers or plotter.Valuers. Each plotter.Valuer
+// is a sample of values, and each plotter.XYer is a sample
+// of x values weighted by the y values, as for
+// plotter.NewHistogram. Each sample is drawn using the
+// next color via the Color function. If a sample is
+// immediately preceeded by a string then a legend entry
+// is added to the plot using the string as the name.
+//
+// If an error occurs then none of the plotters are added
+// to the plot, and the error is returned.
+func AddHistograms(plt *plot.Plot, layout HistogramLayout, n int, vs ...interface{}) ([]*plotter.Histogram, error) {
+	var xys []plotter.XYer
+	var names []string
+	name := ""
+	for _, v := range vs {
+		switch t := v.(type) {
+		case string:
+			name = t
+
+		case plotter.XYer:
+			xys = append(xys, t)
+			names = append(names, name)
+			name = ""
+
+		case plotter.Valuer:
+			xys = append(xys, unitWeights{t})
+			names = append(names, name)
+			name = ""
+
+		default:
+			panic(fmt.Sprintf("AddHistograms handles strings, plotter.XYers and plotter.Valuers, got %T", t))
+		}
+	}
+	hs, err := plotter.NewHistograms(xys, n)
+	if err != nil {
+		return nil, err
+	}
+
+	ps := make([]plot.Plotter, len(hs))
+	for i, h := range hs {
+		c := Color(i)
+		h.FillColor = c
+		switch layout {
+		case OverlaidHistograms:
+			fill := color.NRGBAModel.Convert(c).(color.NRGBA)
+			fill.A = 128
+			h.FillColor = fill
+			h.LineStyle.Color = c
+		case GroupedHistograms:
+			// Divide each bin between the histograms.
+			for j, b := range h.Bins {
+				w := (b.Max - b.Min) / float64(len(hs))
+				h.Bins[j].Min = b.Min + float64(i)*w
+				h.Bins[j].Max = b.Min + float64(i+1)*w
+			}
+		case StackedHistograms:
+			if i > 0 {
+				h.StackOn(hs[i-1])
+			}
+		default:
+			panic(fmt.Sprintf("AddHistograms: unknown layout %d", layout))
+		}
+		ps[i] = h
+	}
+	plt.Add(ps...)
+	for i, name := range names {
+		if name != "" {
+			plt.Legend.Add(name, hs[i])
+		}
+	}
+	return hs, nil
+}
+
+// unitWeights is a plotter.XYer of values with
+// weights of one.
+type unitWeights struct{ plotter.Valuer }
+
+func (u unitWeights) XY(i int) (float64, float64) { return u.Value(i), 1 }
+
 // AddScatters adds Scatter plotters to a plot.
 // The variadic arguments must be either strings
 // or plotter.XYers.  Each plotter.XYer is added to
@@ -129,7 +231,9 @@ func AddBoxPlots(plt *plot.Plot, width vg.Length, vs ...interface{}) error {
 // via the Color and Shape functions. If a
 // plotter.XYer is immediately preceeded by
 // a string then a legend entry is added to the plot
-// using the string as the name.
+// using the string as the name. The error bars of a
+// plotter.XYer wrapped in WithErrorBars are added in
+// the same color.
 //
 // If an error occurs then none of the plotters are added
 // to the plot, and the error is returned.
@@ -150,6 +254,15 @@ func AddScatters(plt *plot.Plot, vs ...interface{}) error {
 			}
 			s.Color = Color(i)
 			s.Shape = Shape(i)
+			if w, ok := t.(WithErrorBars); ok {
+				e, err := errorBars(w.XYer, Color(i))
+				if err != nil {
+					return err
+				}
+				if e != nil {
+					ps = append(ps, e)
+				}
+			}
 			i++
 			ps = append(ps, s)
 			if name != "" {
@@ -175,7 +288,9 @@ func AddScatters(plt *plot.Plot, vs ...interface{}) error {
 // shape via the Color and Dashes functions.
 // If a plotter.XYer is immediately preceeded by
 // a string then a legend entry is added to the plot
-// using the string as the name.
+// using the string as the name. The error bars of a
+// plotter.XYer wrapped in WithErrorBars are added in
+// the same color.
 //
 // If an error occurs then none of the plotters are added
 // to the plot, and the error is returned.
@@ -196,6 +311,15 @@ func AddLines(plt *plot.Plot, vs ...interface{}) error {
 			}
 			l.Color = Color(i)
 			l.Dashes = Dashes(i)
+			if w, ok := t.(WithErrorBars); ok {
+				e, err := errorBars(w.XYer, Color(i))
+				if err != nil {
+					return err
+				}
+				if e != nil {
+					ps = append(ps, e)
+				}
+			}
 			i++
 			ps = append(ps, l)
 			if name != "" {
@@ -221,7 +345,9 @@ func AddLines(plt *plot.Plot, vs ...interface{}) error {
 // shape via the Color, Dashes, and Shape functions.
 // If a plotter.XYer is immediately preceeded by
 // a string then a legend entry is added to the plot
-// using the string as the name.
+// using the string as the name. The error bars of a
+// plotter.XYer wrapped in WithErrorBars are added in
+// the same color.
 //
 // If an error occurs then none of the plotters are added
 // to the plot, and the error is returned.
@@ -248,6 +374,15 @@ func AddLinePoints(plt *plot.Plot, vs ...interface{}) error {
 			l.Dashes = Dashes(i)
 			s.Color = Color(i)
 			s.Shape = Shape(i)
+			if w, ok := t.(WithErrorBars); ok {
+				e, err := errorBars(w.XYer, Color(i))
+				if err != nil {
+					return err
+				}
+				if e != nil {
+					ps = append(ps, e)
+				}
+			}
 			i++
 			ps = append(ps, l, s)
 			if name != "" {
@@ -267,9 +402,9 @@ func AddLinePoints(plt *plot.Plot, vs ...interface{}) error {
 	return nil
 }
 
-// AddErrorBars adds XErrorBars and YErrorBars
-// to a plot.  The variadic arguments must be
-// of type plotter.XYer, and must be either a
+// AddErrorBars adds ErrorBars to a plot.
+// The variadic arguments must be of type
+// plotter.XYer, and must be either a
 // plotter.XErrorer, plotter.YErrorer, or both.
 // Each errorer is added to the plot the color from
 // the Colors function corresponding to its position
@@ -280,36 +415,15 @@ func AddLinePoints(plt *plot.Plot, vs ...interface{}) error {
 func AddErrorBars(plt *plot.Plot, vs ...interface{}) error {
 	var ps []plot.Plotter
 	for i, v := range vs {
-		added := false
-
-		if xerr, ok := v.(interface {
-			plotter.XYer
-			plotter.XErrorer
-		}); ok {
-			e, err := plotter.NewXErrorBars(xerr)
+		if xys, ok := v.(plotter.XYer); ok {
+			e, err := errorBars(xys, Color(i))
 			if err != nil {
 				return err
 			}
-			e.Color = Color(i)
-			ps = append(ps, e)
-			added = true
-		}
-
-		if yerr, ok := v.(interface {
-			plotter.XYer
-			plotter.YErrorer
-		}); ok {
-			e, err := plotter.NewYErrorBars(yerr)
-			if err != nil {
-				return err
+			if e != nil {
+				ps = append(ps, e)
+				continue
 			}
-			e.Color = Color(i)
-			ps = append(ps, e)
-			added = true
-		}
-
-		if added {
-			continue
 		}
 		panic(fmt.Sprintf("AddErrorBars expects plotter.XErrorer or plotter.YErrorer, got %T", v))
 	}
@@ -317,6 +431,31 @@ func AddErrorBars(plt *plot.Plot, vs ...interface{}) error {
 	return nil
 }
 
+// WithErrorBars wraps a plotter.XYer passed to AddScatters,
+// AddLines or AddLinePoints so that the error bars of the
+// XYer, if it is a plotter.XErrorer or plotter.YErrorer,
+// are added with it.
+type WithErrorBars struct {
+	plotter.XYer
+}
+
+// errorBars returns ErrorBars drawn in the color c for
+// xys if it is a plotter.XErrorer or plotter.YErrorer,
+// and nil otherwise.
+func errorBars(xys plotter.XYer, c color.Color) (*plotter.ErrorBars, error) {
+	_, isX := xys.(plotter.XErrorer)
+	_, isY := xys.(plotter.YErrorer)
+	if !isX && !isY {
+		return nil, nil
+	}
+	e, err := plotter.NewErrorBars(xys)
+	if err != nil {
+		return nil, err
+	}
+	e.Color = c
+	return e, nil
+}
+
 // AddXErrorBars adds XErrorBars to a plot.
 // The variadic arguments must be
 // of type plotter.XYer, and plotter.XErrorer.
@@ -0,0 +1,120 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+)
+
+func TestAddLinePointsErrorBars(t *testing.T) {
+	pts := []plotter.XYer{
+		plotter.XYs{{X: 0, Y: 0}, {X: 1, Y: 1}},
+		plotter.XYs{{X: 0, Y: 2}, {X: 1, Y: 3}},
+	}
+	ep, err := NewErrorPoints(MeanAndConf95, pts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xys := plotter.XYs{{X: 0, Y: 1}, {X: 1, Y: 0}}
+
+	ymin, ymax := ep.XYs[0].Y, ep.XYs[0].Y
+	for _, p := range ep.XYs {
+		if p.Y < ymin {
+			ymin = p.Y
+		}
+		if p.Y > ymax {
+			ymax = p.Y
+		}
+	}
+
+	// Error bars are only added when requested.
+	plt, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = AddLinePoints(plt, "errors", ep, "plain", xys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plt.Y.Min != 0 || plt.Y.Max != ymax {
+		t.Errorf("unexpected y range without error bars: got:[%v, %v] want:[0, %v]", plt.Y.Min, plt.Y.Max, ymax)
+	}
+
+	// Adding the error bars extends the range of the
+	// plot beyond the points.
+	plt, err = plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = AddLinePoints(plt, "errors", WithErrorBars{ep}, "plain", xys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plt.Y.Min >= ymin || plt.Y.Max <= ymax {
+		t.Errorf("error bars not added: y range [%v, %v] within points [%v, %v]", plt.Y.Min, plt.Y.Max, ymin, ymax)
+	}
+
+	plt, err = plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = AddScatters(plt, WithErrorBars{xys})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plt.Y.Min != 0 || plt.Y.Max != 1 {
+		t.Errorf("unexpected y range for points without errors: got:[%v, %v] want:[0, 1]", plt.Y.Min, plt.Y.Max)
+	}
+}
+
+func TestAddHistograms(t *testing.T) {
+	a := plotter.Values{0, 1, 1, 2, 2, 2, 3}
+	b := plotter.XYs{{X: 1, Y: 2}, {X: 4, Y: 1}}
+
+	for _, test := range []struct {
+		layout HistogramLayout
+		ymax   float64
+	}{
+		{layout: OverlaidHistograms, ymax: 3},
+		{layout: GroupedHistograms, ymax: 3},
+		// The second bin, [1, 2), holds two values
+		// of a and a weight of two from b.
+		{layout: StackedHistograms, ymax: 4},
+	} {
+		plt, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		hs, err := AddHistograms(plt, test.layout, 4, "a", a, "b", b)
+		if err != nil {
+			t.Fatalf("unexpected error for layout %d: %v", test.layout, err)
+		}
+		if len(hs) != 2 {
+			t.Fatalf("unexpected number of histograms for layout %d: got:%d want:2", test.layout, len(hs))
+		}
+		if plt.X.Min != 0 || plt.X.Max != 4 {
+			t.Errorf("unexpected x range for layout %d: got:[%v, %v] want:[0, 4]", test.layout, plt.X.Min, plt.X.Max)
+		}
+		if plt.Y.Max != test.ymax {
+			t.Errorf("unexpected y max for layout %d: got:%v want:%v", test.layout, plt.Y.Max, test.ymax)
+		}
+		for i := range hs[0].Bins {
+			a, b := hs[0].Bins[i], hs[1].Bins[i]
+			switch test.layout {
+			case GroupedHistograms:
+				if a.Max != b.Min {
+					t.Errorf("bin %d not grouped: a:[%v, %v] b:[%v, %v]", i, a.Min, a.Max, b.Min, b.Max)
+				}
+			default:
+				if a.Min != b.Min || a.Max != b.Max {
+					t.Errorf("bin %d not shared: a:[%v, %v] b:[%v, %v]", i, a.Min, a.Max, b.Min, b.Max)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// BlandAltman returns the plotters of a Bland-Altman plot
+// comparing the paired measurements a and b: a scatter of
+// the difference a-b against the mean of each pair, with
+// horizontal lines at the mean difference and, dashed, at
+// the limits of agreement 1.96 standard deviations either
+// side of it.
+func BlandAltman(a, b []float64) ([]plot.Plotter, error) {
+	if len(a) != len(b) {
+		return nil, errors.New("plotutil: paired data lengths mismatch")
+	}
+	if len(a) < 2 {
+		return nil, errors.New("plotutil: too few pairs")
+	}
+	xys := make(plotter.XYs, len(a))
+	diff := make([]float64, len(a))
+	for i := range a {
+		if err := plotter.CheckFloats(a[i], b[i]); err != nil {
+			return nil, err
+		}
+		xys[i].X = (a[i] + b[i]) / 2
+		xys[i].Y = a[i] - b[i]
+		diff[i] = xys[i].Y
+	}
+	mean, sd := stat.MeanStdDev(diff, nil)
+
+	s, err := plotter.NewScatter(xys)
+	if err != nil {
+		return nil, err
+	}
+	return []plot.Plotter{
+		s,
+		hline(mean, nil),
+		hline(mean-1.96*sd, dashed),
+		hline(mean+1.96*sd, dashed),
+	}, nil
+}
+
+// ResidualsVsFitted returns the plotters of a plot of the
+// residuals of a regression against its fitted values, with
+// a dashed horizontal line at zero.
+func ResidualsVsFitted(fitted, residuals []float64) ([]plot.Plotter, error) {
+	xys, err := pairs(fitted, residuals)
+	if err != nil {
+		return nil, err
+	}
+	s, err := plotter.NewScatter(xys)
+	if err != nil {
+		return nil, err
+	}
+	return []plot.Plotter{s, hline(0, dashed)}, nil
+}
+
+// ScaleLocation returns the plotters of a scale-location
+// plot of a regression: a scatter of the square root of the
+// absolute standardized residuals against the fitted values.
+func ScaleLocation(fitted, residuals []float64) ([]plot.Plotter, error) {
+	xys, err := pairs(fitted, residuals)
+	if err != nil {
+		return nil, err
+	}
+	std := standardize(residuals)
+	for i := range xys {
+		xys[i].Y = math.Sqrt(math.Abs(std[i]))
+	}
+	s, err := plotter.NewScatter(xys)
+	if err != nil {
+		return nil, err
+	}
+	return []plot.Plotter{s}, nil
+}
+
+// ResidualQQ returns the plotters of a normal quantile-quantile
+// plot of the residuals of a regression: a scatter of the
+// ordered standardized residuals against the quantiles of the
+// standard normal distribution at (i+0.5)/n, with a dashed
+// reference line of unit slope through the origin.
+func ResidualQQ(residuals []float64) ([]plot.Plotter, error) {
+	if len(residuals) < 2 {
+		return nil, errors.New("plotutil: too few residuals")
+	}
+	for _, r := range residuals {
+		if err := plotter.CheckFloats(r); err != nil {
+			return nil, err
+		}
+	}
+	std := standardize(residuals)
+	sort.Float64s(std)
+	n := float64(len(std))
+	xys := make(plotter.XYs, len(std))
+	for i, r := range std {
+		xys[i].X = distuv.UnitNormal.Quantile((float64(i) + 0.5) / n)
+		xys[i].Y = r
+	}
+	s, err := plotter.NewScatter(xys)
+	if err != nil {
+		return nil, err
+	}
+	ref := plotter.NewFunction(func(x float64) float64 { return x })
+	ref.Dashes = dashed
+	return []plot.Plotter{s, ref}, nil
+}
+
+// RegressionDiagnostics returns the residuals against fitted
+// values, scale-location and residual Q-Q plots of a regression,
+// titled and with labelled axes, ready to be drawn in a row of
+// tiles.
+func RegressionDiagnostics(fitted, residuals []float64) ([]*plot.Plot, error) {
+	type panel struct {
+		title, x, y string
+		plotters    func() ([]plot.Plotter, error)
+	}
+	panels := []panel{
+		{
+			title: "Residuals vs fitted", x: "Fitted", y: "Residual",
+			plotters: func() ([]plot.Plotter, error) { return ResidualsVsFitted(fitted, residuals) },
+		},
+		{
+			title: "Scale-location", x: "Fitted", y: "√|Standardized residual|",
+			plotters: func() ([]plot.Plotter, error) { return ScaleLocation(fitted, residuals) },
+		},
+		{
+			title: "Normal Q-Q", x: "Theoretical quantile", y: "Standardized residual",
+			plotters: func() ([]plot.Plotter, error) { return ResidualQQ(residuals) },
+		},
+	}
+	plots := make([]*plot.Plot, len(panels))
+	for i, pn := range panels {
+		ps, err := pn.plotters()
+		if err != nil {
+			return nil, err
+		}
+		p, err := plot.New()
+		if err != nil {
+			return nil, err
+		}
+		p.Title.Text = pn.title
+		p.X.Label.Text = pn.x
+		p.Y.Label.Text = pn.y
+		p.Add(ps...)
+		plots[i] = p
+	}
+	return plots, nil
+}
+
+// dashed is the dash pattern of reference lines.
+var dashed = []vg.Length{vg.Points(4), vg.Points(2)}
+
+// hline returns a horizontal line at y.
+func hline(y float64, dashes []vg.Length) *plotter.Function {
+	f := plotter.NewFunction(func(float64) float64 { return y })
+	f.Dashes = dashes
+	return f
+}
+
+// pairs returns the points (x[i], y[i]).
+func pairs(x, y []float64) (plotter.XYs, error) {
+	if len(x) != len(y) {
+		return nil, errors.New("plotutil: fitted and residuals lengths mismatch")
+	}
+	if len(x) < 2 {
+		return nil, errors.New("plotutil: too few residuals")
+	}
+	xys := make(plotter.XYs, len(x))
+	for i := range x {
+		if err := plotter.CheckFloats(x[i], y[i]); err != nil {
+			return nil, err
+		}
+		xys[i].X, xys[i].Y = x[i], y[i]
+	}
+	return xys, nil
+}
+
+// standardize returns the residuals divided by their
+// standard deviation.
+func standardize(residuals []float64) []float64 {
+	sd := stat.StdDev(residuals, nil)
+	std := make([]float64, len(residuals))
+	for i, r := range residuals {
+		std[i] = r / sd
+	}
+	return std
+}
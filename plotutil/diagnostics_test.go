@@ -0,0 +1,84 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+func TestBlandAltman(t *testing.T) {
+	a := []float64{10, 12, 14, 16}
+	b := []float64{9, 12, 15, 14}
+	ps, err := BlandAltman(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ps) != 4 {
+		t.Fatalf("unexpected number of plotters: got:%d want:4", len(ps))
+	}
+	s := ps[0].(*plotter.Scatter)
+	want := plotter.XYs{{X: 9.5, Y: 1}, {X: 12, Y: 0}, {X: 14.5, Y: -1}, {X: 15, Y: 2}}
+	for i, p := range s.XYs {
+		if p != want[i] {
+			t.Errorf("unexpected point %d: got:%v want:%v", i, p, want[i])
+		}
+	}
+	// The differences have mean 0.5 and standard deviation √(5/3).
+	sd := math.Sqrt(5.0 / 3)
+	for i, want := range []float64{0.5, 0.5 - 1.96*sd, 0.5 + 1.96*sd} {
+		if got := ps[i+1].(*plotter.Function).F(0); math.Abs(got-want) > 1e-12 {
+			t.Errorf("unexpected line %d: got:%v want:%v", i, got, want)
+		}
+	}
+
+	_, err = BlandAltman(a, b[:3])
+	if err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+}
+
+func TestRegressionDiagnostics(t *testing.T) {
+	fitted := []float64{1, 2, 3, 4, 5}
+	residuals := []float64{0.5, -1, 0.25, 1, -0.75}
+	plots, err := RegressionDiagnostics(fitted, residuals)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plots) != 3 {
+		t.Fatalf("unexpected number of plots: got:%d want:3", len(plots))
+	}
+
+	ps, err := ResidualQQ(residuals)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	qq := ps[0].(*plotter.Scatter).XYs
+	for i := 1; i < len(qq); i++ {
+		if qq[i].X <= qq[i-1].X || qq[i].Y < qq[i-1].Y {
+			t.Errorf("Q-Q points not ordered at %d: %v", i, qq)
+		}
+	}
+	if math.Abs(qq[2].X) > 1e-12 {
+		t.Errorf("unexpected median theoretical quantile: got:%v want:0", qq[2].X)
+	}
+
+	ps, err = ScaleLocation(fitted, residuals)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, p := range ps[0].(*plotter.Scatter).XYs {
+		if p.Y < 0 {
+			t.Errorf("negative scale-location value: %v", p)
+		}
+	}
+
+	_, err = ResidualsVsFitted(fitted, residuals[:1])
+	if err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"errors"
+	"image/color"
+	"io"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Facets is a set of small multiples: plots of the groups
+// of a data set drawn in a grid of panels with the same
+// axis ranges. Each panel is labelled by its group in a
+// strip along its edge, and a legend shared by the panels
+// is drawn to their right.
+//
+// Only the panels on the bottom of each column and the
+// left of each row label their axis ticks, so axis
+// labels are best given to those panels.
+type Facets struct {
+	// Plots are the panels, indexed by row then column.
+	// Cells of the grid without a group are nil.
+	Plots [][]*plot.Plot
+
+	// Strips are the labels drawn in strips above the
+	// panels, indexed as Plots. Panels with an empty
+	// label have no strip.
+	Strips [][]string
+
+	// RowStrips are the labels drawn in strips to the
+	// right of the last panel of each row. RowStrips is
+	// nil if the data are grouped by one key.
+	RowStrips []string
+
+	// StripStyle is the style of the strip labels, and
+	// StripColor is the background color of the strips.
+	StripStyle draw.TextStyle
+	StripColor color.Color
+
+	// Legend is the legend shared by the panels. It is
+	// drawn only if it has entries.
+	Legend plot.Legend
+
+	// Pad is the space between panels.
+	Pad vg.Length
+}
+
+// NewFacets returns Facets of the points in xy grouped by
+// the keys in cols and, if rows is not nil, rows. Each
+// group is drawn in a panel holding the plotters added
+// by add, which is called with the panel and the points
+// of its group. If add is nil the points are drawn as a
+// scatter plot.
+//
+// If the points are grouped by one key the panels are
+// wrapped into a grid that is about as wide as it is
+// high, in the order in which the keys first appear,
+// with each panel labelled by its key. Otherwise the
+// columns and rows of the grid are the keys of cols and
+// rows, labelled in strips above the top row and to the
+// right of the last column.
+func NewFacets(xy plotter.XYer, cols, rows []string, add func(p *plot.Plot, xys plotter.XYs) error) (*Facets, error) {
+	if len(cols) != xy.Len() || (rows != nil && len(rows) != xy.Len()) {
+		return nil, errors.New("plotutil: keys and data lengths mismatch")
+	}
+	if xy.Len() == 0 {
+		return nil, errors.New("plotutil: no data for facets")
+	}
+	if add == nil {
+		add = func(p *plot.Plot, xys plotter.XYs) error {
+			s, err := plotter.NewScatter(xys)
+			if err != nil {
+				return err
+			}
+			s.Color = Color(0)
+			p.Add(s)
+			return nil
+		}
+	}
+
+	colKeys, colIdx := groupKeys(cols)
+	rowKeys, rowIdx := groupKeys(rows)
+	var nrows, ncols int
+	var cell func(k int) (i, j int)
+	if rows == nil {
+		ncols = int(math.Ceil(math.Sqrt(float64(len(colKeys)))))
+		nrows = (len(colKeys) + ncols - 1) / ncols
+		cell = func(k int) (int, int) { return colIdx[k] / ncols, colIdx[k] % ncols }
+	} else {
+		nrows, ncols = len(rowKeys), len(colKeys)
+		cell = func(k int) (int, int) { return rowIdx[k], colIdx[k] }
+	}
+
+	font, err := vg.MakeFont(plot.DefaultFont, vg.Points(10))
+	if err != nil {
+		return nil, err
+	}
+	legend, err := plot.NewLegend()
+	if err != nil {
+		return nil, err
+	}
+	legend.Top = true
+	f := &Facets{
+		Plots:      make([][]*plot.Plot, nrows),
+		Strips:     make([][]string, nrows),
+		StripStyle: draw.TextStyle{Color: color.Black, Font: font, XAlign: draw.XCenter, YAlign: draw.YCenter},
+		StripColor: color.Gray{Y: 217},
+		Legend:     legend,
+		Pad:        vg.Points(4),
+	}
+
+	// Group the points by cell.
+	data := make([][]plotter.XYs, nrows)
+	for i := range data {
+		data[i] = make([]plotter.XYs, ncols)
+		f.Plots[i] = make([]*plot.Plot, ncols)
+		f.Strips[i] = make([]string, ncols)
+	}
+	for k := 0; k < xy.Len(); k++ {
+		i, j := cell(k)
+		x, y := xy.XY(k)
+		data[i][j] = append(data[i][j], struct{ X, Y float64 }{x, y})
+	}
+	if rows == nil {
+		for k, key := range colKeys {
+			f.Strips[k/ncols][k%ncols] = key
+		}
+	} else {
+		copy(f.Strips[0], colKeys)
+		f.RowStrips = rowKeys
+	}
+
+	// Make the panels and find the union of their ranges.
+	xmin, xmax := math.Inf(1), math.Inf(-1)
+	ymin, ymax := math.Inf(1), math.Inf(-1)
+	for i := range f.Plots {
+		for j := range f.Plots[i] {
+			if rows == nil && i*ncols+j >= len(colKeys) {
+				continue
+			}
+			p, err := plot.New()
+			if err != nil {
+				return nil, err
+			}
+			if len(data[i][j]) != 0 {
+				err = add(p, data[i][j])
+				if err != nil {
+					return nil, err
+				}
+				xmin, xmax = math.Min(xmin, p.X.Min), math.Max(xmax, p.X.Max)
+				ymin, ymax = math.Min(ymin, p.Y.Min), math.Max(ymax, p.Y.Max)
+			}
+			f.Plots[i][j] = p
+		}
+	}
+	for i, row := range f.Plots {
+		for j, p := range row {
+			if p == nil {
+				continue
+			}
+			p.X.Min, p.X.Max = xmin, xmax
+			p.Y.Min, p.Y.Max = ymin, ymax
+			if i+1 < nrows && f.Plots[i+1][j] != nil {
+				p.X.Tick.Marker = unlabelledTicks{p.X.Tick.Marker}
+			}
+			if j > 0 {
+				p.Y.Tick.Marker = unlabelledTicks{p.Y.Tick.Marker}
+			}
+		}
+	}
+	return f, nil
+}
+
+// groupKeys returns the distinct keys in the order in
+// which they first appear, and the index into them of
+// each key.
+func groupKeys(keys []string) (distinct []string, idx []int) {
+	seen := make(map[string]int)
+	idx = make([]int, len(keys))
+	for k, key := range keys {
+		i, ok := seen[key]
+		if !ok {
+			i = len(distinct)
+			seen[key] = i
+			distinct = append(distinct, key)
+		}
+		idx[k] = i
+	}
+	return distinct, idx
+}
+
+// Draw draws the panels, strips and legend to c, aligning
+// the data areas of the panels.
+func (f *Facets) Draw(c draw.Canvas) {
+	h := f.StripStyle.Height("M") - 2*f.StripStyle.Font.Extents().Descent
+	if lr := f.Legend.Rectangle(c); lr.Max.X > lr.Min.X {
+		lc := c
+		c.Max.X -= lr.Max.X - lr.Min.X + f.Pad
+		lc.Min.X = c.Max.X + f.Pad
+		f.Legend.Draw(lc)
+	}
+
+	t := draw.Tiles{
+		Rows:   len(f.Plots),
+		Cols:   len(f.Plots[0]),
+		PadX:   f.Pad,
+		PadY:   f.Pad,
+		PadTop: h,
+	}
+	if f.RowStrips == nil {
+		t.PadY += h
+	} else {
+		t.PadRight = h
+	}
+	cs := plot.Align(f.Plots, t, c)
+
+	for i, row := range f.Plots {
+		for j, p := range row {
+			if p == nil {
+				continue
+			}
+			p.Draw(cs[i][j])
+			dc := p.DataCanvas(cs[i][j])
+			if f.Strips[i][j] != "" {
+				f.drawStrip(c, vg.Rectangle{
+					Min: vg.Point{X: dc.Min.X, Y: dc.Max.Y},
+					Max: vg.Point{X: dc.Max.X, Y: dc.Max.Y + h},
+				}, f.Strips[i][j], 0)
+			}
+			if f.RowStrips != nil && j == len(row)-1 {
+				f.drawStrip(c, vg.Rectangle{
+					Min: vg.Point{X: dc.Max.X, Y: dc.Min.Y},
+					Max: vg.Point{X: dc.Max.X + h, Y: dc.Max.Y},
+				}, f.RowStrips[i], -math.Pi/2)
+			}
+		}
+	}
+}
+
+// drawStrip draws a strip filling r with the label txt
+// centered in it and rotated by rot.
+func (f *Facets) drawStrip(c draw.Canvas, r vg.Rectangle, txt string, rot float64) {
+	if f.StripColor != nil {
+		c.FillPolygon(f.StripColor, []vg.Point{
+			r.Min, {X: r.Max.X, Y: r.Min.Y}, r.Max, {X: r.Min.X, Y: r.Max.Y},
+		})
+	}
+	sty := f.StripStyle
+	sty.Rotation = rot
+	c.FillText(sty, vg.Point{X: (r.Min.X + r.Max.X) / 2, Y: (r.Min.Y + r.Max.Y) / 2}, txt)
+}
+
+// WriterTo returns an io.WriterTo that will write the
+// facets as the specified image format, as for
+// plot.Plot.WriterTo.
+func (f *Facets) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
+	return drawnWriterTo(w, h, format, f.Draw)
+}
+
+// Save saves the facets to an image file as for
+// plot.Plot.Save.
+func (f *Facets) Save(w, h vg.Length, file string) error {
+	return saveDrawn(w, h, file, f.Draw)
+}
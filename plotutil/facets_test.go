@@ -0,0 +1,143 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"log"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+func ExampleFacets() {
+	rnd := rand.New(rand.NewSource(1))
+	sites := []string{"North", "South", "East", "West", "Central"}
+	var xys plotter.XYs
+	var keys []string
+	for s, site := range sites {
+		for i := 0; i < 30; i++ {
+			x := 10 * rnd.Float64()
+			y := float64(s+1)*0.3*x + rnd.NormFloat64()
+			xys = append(xys, struct{ X, Y float64 }{x, y})
+			keys = append(keys, site)
+		}
+	}
+
+	// Draw each site's measurements with a fitted line,
+	// keeping the styles for the shared legend.
+	var measured, fitted plot.Thumbnailer
+	f, err := NewFacets(xys, keys, nil, func(p *plot.Plot, xys plotter.XYs) error {
+		s, err := plotter.NewScatter(xys)
+		if err != nil {
+			return err
+		}
+		s.Color = Color(0)
+		var sx, sy, sxx, sxy float64
+		for _, v := range xys {
+			sx, sy, sxx, sxy = sx+v.X, sy+v.Y, sxx+v.X*v.X, sxy+v.X*v.Y
+		}
+		n := float64(len(xys))
+		slope := (n*sxy - sx*sy) / (n*sxx - sx*sx)
+		fit := plotter.NewFunction(func(x float64) float64 { return sy/n + slope*(x-sx/n) })
+		fit.Color = Color(1)
+		p.Add(s, fit)
+		measured, fitted = s, fit
+		return nil
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+	f.Legend.Add("measured", measured)
+	f.Legend.Add("fit", fitted)
+	for _, p := range f.Plots[1] {
+		if p != nil {
+			p.X.Label.Text = "Depth"
+		}
+	}
+	f.Plots[0][0].Y.Label.Text = "Flow"
+	f.Plots[1][0].Y.Label.Text = "Flow"
+
+	err = f.Save(6*vg.Inch, 4*vg.Inch, "facets.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestFacets(t *testing.T) {
+	xys := plotter.XYs{{X: 1, Y: 1}, {X: 2, Y: 5}, {X: 3, Y: 2}, {X: 10, Y: 3}, {X: 4, Y: -2}}
+	cols := []string{"a", "b", "a", "c", "b"}
+	rows := []string{"x", "x", "y", "y", "y"}
+
+	f, err := NewFacets(xys, cols, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantStrips := [][]string{{"a", "b"}, {"c", ""}}
+	if len(f.Plots) != 2 || len(f.Plots[0]) != 2 {
+		t.Fatalf("unexpected wrapped grid size: got:%d×%d want:2×2", len(f.Plots), len(f.Plots[0]))
+	}
+	if f.Plots[1][1] != nil {
+		t.Error("expected nil panel in unused cell")
+	}
+	for i, row := range wantStrips {
+		for j, want := range row {
+			if f.Strips[i][j] != want {
+				t.Errorf("unexpected strip (%d, %d): got:%q want:%q", i, j, f.Strips[i][j], want)
+			}
+		}
+	}
+	if f.RowStrips != nil {
+		t.Errorf("unexpected row strips for one key: got:%q", f.RowStrips)
+	}
+
+	f, err = NewFacets(xys, cols, rows, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(f.Plots) != 2 || len(f.Plots[0]) != 3 {
+		t.Fatalf("unexpected grid size: got:%d×%d want:2×3", len(f.Plots), len(f.Plots[0]))
+	}
+	if f.RowStrips[0] != "x" || f.RowStrips[1] != "y" {
+		t.Errorf("unexpected row strips: got:%q want:[x y]", f.RowStrips)
+	}
+	canvases := make([][]draw.Canvas, 2)
+	for i, row := range f.Plots {
+		canvases[i] = make([]draw.Canvas, 3)
+		for j, p := range row {
+			if p.X.Min != 1 || p.X.Max != 10 || p.Y.Min != -2 || p.Y.Max != 5 {
+				t.Errorf("unexpected range of panel (%d, %d): got:[%v, %v]×[%v, %v] want:[1, 10]×[-2, 5]",
+					i, j, p.X.Min, p.X.Max, p.Y.Min, p.Y.Max)
+			}
+			p.Add(canvasRecorder{c: &canvases[i][j]})
+		}
+	}
+	f.Draw(draw.Canvas{
+		Canvas:    new(recorder.Canvas),
+		Rectangle: vg.Rectangle{Max: vg.Point{X: 300, Y: 200}},
+	})
+	for i := range canvases {
+		for j := range canvases[i] {
+			got, want := canvases[i][j], canvases[1][j]
+			if !closeLength(got.Min.X, want.Min.X) || !closeLength(got.Max.X, want.Max.X) {
+				t.Errorf("panel (%d, %d) not aligned with its column", i, j)
+			}
+			want = canvases[i][0]
+			if !closeLength(got.Min.Y, want.Min.Y) || !closeLength(got.Max.Y, want.Max.Y) {
+				t.Errorf("panel (%d, %d) not aligned with its row", i, j)
+			}
+		}
+	}
+
+	_, err = NewFacets(xys, cols[:2], nil, nil)
+	if err == nil {
+		t.Error("expected error for mismatched keys")
+	}
+}
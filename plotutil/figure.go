@@ -0,0 +1,293 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"fmt"
+	"image/color"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Theme is the style of the plots made by a Figure.
+type Theme struct {
+	// Colors, Shapes and Dashes are the colors, glyph
+	// shapes and line dashes of successive series of a
+	// figure. Each is cycled through if there are more
+	// series than entries. If Colors or Shapes is empty
+	// the Color or Shape functions are used, and if
+	// Dashes is empty lines are solid.
+	Colors []color.Color
+	Shapes []draw.GlyphDrawer
+	Dashes [][]vg.Length
+
+	// Background is the background color of the plot.
+	// If Background is nil the plot's default is used.
+	Background color.Color
+
+	// Grid dictates whether grid lines are drawn
+	// behind the series.
+	Grid bool
+}
+
+var (
+	// DefaultTheme draws series with the default colors,
+	// glyph shapes and dashes on a white background.
+	DefaultTheme = Theme{
+		Colors:     SoftColors,
+		Shapes:     DefaultGlyphShapes,
+		Dashes:     DefaultDashes,
+		Background: color.White,
+	}
+
+	// BoldTheme draws solid series with the dark colors
+	// over a grid.
+	BoldTheme = Theme{
+		Colors:     DarkColors,
+		Shapes:     DefaultGlyphShapes,
+		Background: color.White,
+		Grid:       true,
+	}
+)
+
+// color returns the color of the ith series.
+func (t Theme) color(i int) color.Color {
+	if len(t.Colors) == 0 {
+		return Color(i)
+	}
+	return t.Colors[i%len(t.Colors)]
+}
+
+// shape returns the glyph shape of the ith series.
+func (t Theme) shape(i int) draw.GlyphDrawer {
+	if len(t.Shapes) == 0 {
+		return Shape(i)
+	}
+	return t.Shapes[i%len(t.Shapes)]
+}
+
+// dashes returns the line dashes of the ith series.
+func (t Theme) dashes(i int) []vg.Length {
+	if len(t.Dashes) == 0 {
+		return nil
+	}
+	return t.Dashes[i%len(t.Dashes)]
+}
+
+// Figure builds an everyday plot from series of data,
+// styling each series with the next color, glyph shape
+// and dashes of its theme and adding named series to
+// the legend. The methods of a Figure return the Figure
+// so that calls can be chained:
+//
+//	err := plotutil.NewFigure().
+//		Title("Measurements").
+//		Lines("model", model).
+//		Scatter("observed", observed).
+//		Save(4*vg.Inch, 3*vg.Inch, "measurements.png")
+//
+// The first error from building the figure is returned
+// by Plot or Save.
+type Figure struct {
+	title, xLabel, yLabel string
+	logX, logY            bool
+
+	theme    Theme
+	series   []series
+	plotters []plot.Plotter
+
+	err error
+}
+
+// seriesKind is the kind of plotter drawing a series.
+type seriesKind int
+
+const (
+	lineSeries seriesKind = iota
+	scatterSeries
+	linePointsSeries
+)
+
+// series is a set of data drawn by a figure.
+type series struct {
+	kind seriesKind
+	name string
+	data plotter.XYer
+}
+
+// NewFigure returns a new Figure using the DefaultTheme.
+func NewFigure() *Figure {
+	return &Figure{theme: DefaultTheme}
+}
+
+// Title sets the title of the figure.
+func (f *Figure) Title(title string) *Figure {
+	f.title = title
+	return f
+}
+
+// XLabel sets the label of the X axis.
+func (f *Figure) XLabel(label string) *Figure {
+	f.xLabel = label
+	return f
+}
+
+// YLabel sets the label of the Y axis.
+func (f *Figure) YLabel(label string) *Figure {
+	f.yLabel = label
+	return f
+}
+
+// LogX sets the X axis to a log scale with log tick marks.
+func (f *Figure) LogX() *Figure {
+	f.logX = true
+	return f
+}
+
+// LogY sets the Y axis to a log scale with log tick marks.
+func (f *Figure) LogY() *Figure {
+	f.logY = true
+	return f
+}
+
+// Theme sets the theme used to style the figure.
+func (f *Figure) Theme(t Theme) *Figure {
+	f.theme = t
+	return f
+}
+
+// Lines adds series drawn as lines. The arguments are
+// as for AddLines.
+func (f *Figure) Lines(vs ...interface{}) *Figure {
+	return f.add(lineSeries, "Lines", vs)
+}
+
+// Scatter adds series drawn as scatter plots. The
+// arguments are as for AddScatters.
+func (f *Figure) Scatter(vs ...interface{}) *Figure {
+	return f.add(scatterSeries, "Scatter", vs)
+}
+
+// LinePoints adds series drawn as lines with a glyph
+// at each point. The arguments are as for AddLinePoints.
+func (f *Figure) LinePoints(vs ...interface{}) *Figure {
+	return f.add(linePointsSeries, "LinePoints", vs)
+}
+
+// Add adds plotters to the figure without styling them.
+// They are drawn after all of the series.
+func (f *Figure) Add(ps ...plot.Plotter) *Figure {
+	f.plotters = append(f.plotters, ps...)
+	return f
+}
+
+// add adds the series in vs, which are strings or
+// plotter.XYers, as for AddLines.
+func (f *Figure) add(kind seriesKind, method string, vs []interface{}) *Figure {
+	name := ""
+	for _, v := range vs {
+		switch t := v.(type) {
+		case string:
+			name = t
+		case plotter.XYer:
+			f.series = append(f.series, series{kind: kind, name: name, data: t})
+			name = ""
+		default:
+			if f.err == nil {
+				f.err = fmt.Errorf("plotutil: %s handles strings and plotter.XYers, got %T", method, t)
+			}
+		}
+	}
+	return f
+}
+
+// Plot returns a new plot of the figure.
+func (f *Figure) Plot() (*plot.Plot, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	p, err := plot.New()
+	if err != nil {
+		return nil, err
+	}
+	p.Title.Text = f.title
+	p.X.Label.Text = f.xLabel
+	p.Y.Label.Text = f.yLabel
+	if f.logX {
+		p.X.Scale = plot.LogScale{}
+		p.X.Tick.Marker = plot.LogTicks{}
+	}
+	if f.logY {
+		p.Y.Scale = plot.LogScale{}
+		p.Y.Tick.Marker = plot.LogTicks{}
+	}
+	t := f.theme
+	if t.Background != nil {
+		p.BackgroundColor = t.Background
+	}
+	if t.Grid {
+		p.Add(plotter.NewGrid())
+	}
+
+	for i, s := range f.series {
+		e, err := errorBars(s.data, t.color(i))
+		if err != nil {
+			return nil, err
+		}
+		if e != nil {
+			p.Add(e)
+		}
+		var thumbs []plot.Thumbnailer
+		switch s.kind {
+		case lineSeries:
+			l, err := plotter.NewLine(s.data)
+			if err != nil {
+				return nil, err
+			}
+			l.Color = t.color(i)
+			l.Dashes = t.dashes(i)
+			p.Add(l)
+			thumbs = append(thumbs, l)
+		case scatterSeries:
+			sc, err := plotter.NewScatter(s.data)
+			if err != nil {
+				return nil, err
+			}
+			sc.Color = t.color(i)
+			sc.Shape = t.shape(i)
+			p.Add(sc)
+			thumbs = append(thumbs, sc)
+		case linePointsSeries:
+			l, sc, err := plotter.NewLinePoints(s.data)
+			if err != nil {
+				return nil, err
+			}
+			l.Color = t.color(i)
+			l.Dashes = t.dashes(i)
+			sc.Color = t.color(i)
+			sc.Shape = t.shape(i)
+			p.Add(l, sc)
+			thumbs = append(thumbs, l, sc)
+		}
+		if s.name != "" {
+			p.Legend.Add(s.name, thumbs...)
+		}
+	}
+	p.Add(f.plotters...)
+	return p, nil
+}
+
+// Save saves a plot of the figure to an image file as
+// for plot.Plot.Save.
+func (f *Figure) Save(w, h vg.Length, file string) error {
+	p, err := f.Plot()
+	if err != nil {
+		return err
+	}
+	return p.Save(w, h, file)
+}
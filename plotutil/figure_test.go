@@ -0,0 +1,115 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+func ExampleFigure() {
+	model := make(plotter.XYs, 50)
+	observed := make(plotter.XYs, 10)
+	for i := range model {
+		model[i].X = float64(i) / 5
+		model[i].Y = math.Sin(model[i].X)
+	}
+	for i := range observed {
+		observed[i].X = float64(i)
+		observed[i].Y = math.Sin(observed[i].X) + 0.1*math.Cos(7*observed[i].X)
+	}
+
+	err := NewFigure().
+		Title("Measurements").
+		XLabel("Time").
+		YLabel("Signal").
+		Lines("model", model).
+		Scatter("observed", observed).
+		Theme(BoldTheme).
+		Save(4*vg.Inch, 3*vg.Inch, "measurements.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestFigure(t *testing.T) {
+	a := plotter.XYs{{X: 1, Y: 2}, {X: 10, Y: 20}}
+	b := plotter.XYs{{X: 2, Y: 1}, {X: 100, Y: 5}}
+	p, err := NewFigure().
+		Title("title").
+		XLabel("x").
+		YLabel("y").
+		Lines("a", a).
+		Scatter(b).
+		LogX().
+		Plot()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Title.Text != "title" || p.X.Label.Text != "x" || p.Y.Label.Text != "y" {
+		t.Errorf("unexpected text: got title:%q x:%q y:%q", p.Title.Text, p.X.Label.Text, p.Y.Label.Text)
+	}
+	if p.X.Min != 1 || p.X.Max != 100 || p.Y.Min != 1 || p.Y.Max != 20 {
+		t.Errorf("unexpected range: got:[%v, %v]×[%v, %v] want:[1, 100]×[1, 20]", p.X.Min, p.X.Max, p.Y.Min, p.Y.Max)
+	}
+	if _, ok := p.X.Scale.(plot.LogScale); !ok {
+		t.Errorf("unexpected X scale: got:%T want:plot.LogScale", p.X.Scale)
+	}
+	if _, ok := p.X.Tick.Marker.(plot.LogTicks); !ok {
+		t.Errorf("unexpected X tick marker: got:%T want:plot.LogTicks", p.X.Tick.Marker)
+	}
+	if _, ok := p.Y.Scale.(plot.LinearScale); !ok {
+		t.Errorf("unexpected Y scale: got:%T want:plot.LinearScale", p.Y.Scale)
+	}
+
+	_, err = NewFigure().Lines(a, 1.0).Plot()
+	if err == nil {
+		t.Error("expected error for invalid series")
+	}
+}
+
+func TestThemeCycles(t *testing.T) {
+	th := Theme{Colors: DarkColors[:2]}
+	for i, want := range []int{0, 1, 0, 1} {
+		if th.color(i) != DarkColors[want] {
+			t.Errorf("unexpected color %d: got:%v want:%v", i, th.color(i), DarkColors[want])
+		}
+	}
+	if th.dashes(3) != nil {
+		t.Errorf("unexpected dashes for theme without dashes: got:%v", th.dashes(3))
+	}
+	if th.shape(1) != Shape(1) {
+		t.Errorf("unexpected default shape: got:%v want:%v", th.shape(1), Shape(1))
+	}
+}
+
+func TestThemeGlyphShapes(t *testing.T) {
+	shapes, err := GlyphShapes("filled-star", "Half-Circle", "ring")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	th := Theme{Shapes: shapes}
+	for i, want := range []draw.GlyphDrawer{
+		draw.StarGlyph{Filled: true},
+		draw.HalfFilledGlyph{Shape: draw.RingGlyph{}, Angle: math.Pi / 2},
+		draw.RingGlyph{},
+		draw.StarGlyph{Filled: true},
+	} {
+		if got := th.shape(i); got != want {
+			t.Errorf("unexpected shape %d: got:%#v want:%#v", i, got, want)
+		}
+	}
+
+	_, err = GlyphShapes("ring", "blob")
+	if err == nil {
+		t.Error("expected error for unknown glyph name")
+	}
+}
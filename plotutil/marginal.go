@@ -0,0 +1,255 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gonum.org/v1/gonum/stat"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// MarginalKind is the kind of plot drawn in the
+// marginal panels of a Marginal.
+type MarginalKind int
+
+const (
+	// MarginalHistogram draws histograms of the
+	// marginal distributions.
+	MarginalHistogram MarginalKind = iota
+
+	// MarginalKDE draws Gaussian kernel density
+	// estimates of the marginal distributions.
+	MarginalKDE
+)
+
+// Marginal is a central plot with the distributions of
+// its X and Y values drawn in panels attached to its top
+// and right. The marginal panels share the X and Y axis
+// ranges of the central plot, and their data areas are
+// aligned with its data area when drawn.
+type Marginal struct {
+	// Plot is the central plot. Further plotters, such
+	// as a heat map of the density of the points, may be
+	// added to it.
+	Plot *plot.Plot
+
+	// Top and Right are the plots of the marginal
+	// distributions of the X and Y values. Their axes
+	// are hidden. A title for the whole figure is best
+	// given to Top, so that it is drawn above both the
+	// central plot and Top.
+	Top, Right *plot.Plot
+
+	// Size is the fraction of the width and height of
+	// the figure taken by the marginal panels.
+	Size float64
+
+	// Pad is the space between the data area of the
+	// central plot and the marginal panels.
+	Pad vg.Length
+}
+
+// NewMarginal returns a Marginal with a scatter plot of
+// xy and marginal distributions of the kind given.
+// Histograms use bins chosen by the Freedman–Diaconis
+// rule.
+func NewMarginal(xy plotter.XYer, kind MarginalKind) (*Marginal, error) {
+	if xy.Len() == 0 {
+		return nil, errors.New("plotutil: no data for marginal plot")
+	}
+	m := &Marginal{Size: 0.2, Pad: vg.Points(2)}
+
+	var err error
+	m.Plot, err = plot.New()
+	if err != nil {
+		return nil, err
+	}
+	s, err := plotter.NewScatter(xy)
+	if err != nil {
+		return nil, err
+	}
+	s.Color = Color(0)
+	m.Plot.Add(s)
+
+	m.Top, err = marginalPlot(plotter.XValues{XYer: xy}, kind, false)
+	if err != nil {
+		return nil, err
+	}
+	m.Right, err = marginalPlot(plotter.YValues{XYer: xy}, kind, true)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// marginalPlot returns a plot of the distribution of vs
+// with hidden axes. If horizontal is true the values are
+// along the Y axis.
+func marginalPlot(vs plotter.Valuer, kind MarginalKind, horizontal bool) (*plot.Plot, error) {
+	p, err := plot.New()
+	if err != nil {
+		return nil, err
+	}
+	p.HideAxes()
+	switch kind {
+	case MarginalHistogram:
+		h, err := plotter.NewHistogramRule(unitWeights{vs}, plotter.FreedmanDiaconis)
+		if err != nil {
+			return nil, err
+		}
+		h.Horizontal = horizontal
+		h.FillColor = Color(0)
+		p.Add(h)
+	case MarginalKDE:
+		xs, ds := kde(vs, 100)
+		pts := make(plotter.XYs, len(xs))
+		for i := range pts {
+			pts[i].X, pts[i].Y = xs[i], ds[i]
+			if horizontal {
+				pts[i].X, pts[i].Y = ds[i], xs[i]
+			}
+		}
+		l, err := plotter.NewLine(pts)
+		if err != nil {
+			return nil, err
+		}
+		l.Color = Color(0)
+		p.Add(l)
+	default:
+		return nil, errors.New("plotutil: unknown marginal kind")
+	}
+	return p, nil
+}
+
+// Draw draws the central plot and the marginal panels
+// to c.
+func (m *Marginal) Draw(c draw.Canvas) {
+	// Share the ranges of the central plot's axes.
+	m.Top.X.Min, m.Top.X.Max, m.Top.X.Scale = m.Plot.X.Min, m.Plot.X.Max, m.Plot.X.Scale
+	m.Right.Y.Min, m.Right.Y.Max, m.Right.Y.Scale = m.Plot.Y.Min, m.Plot.Y.Max, m.Plot.Y.Scale
+
+	w := c.Max.X - c.Min.X
+	h := c.Max.Y - c.Min.Y
+	center := c
+	center.Max.X -= vg.Length(m.Size) * w
+	center.Max.Y -= vg.Length(m.Size) * h
+	dc := m.Plot.DataCanvas(center)
+
+	top := c
+	top.Min.X, top.Max.X = center.Min.X, center.Max.X
+	top.Min.Y = dc.Max.Y + m.Pad
+	dt := m.Top.DataCanvas(top)
+	top.Min.X += dc.Min.X - dt.Min.X
+	top.Max.X += dc.Max.X - dt.Max.X
+
+	right := c
+	right.Min.Y, right.Max.Y = center.Min.Y, center.Max.Y
+	right.Min.X = dc.Max.X + m.Pad
+	dr := m.Right.DataCanvas(right)
+	right.Min.Y += dc.Min.Y - dr.Min.Y
+	right.Max.Y += dc.Max.Y - dr.Max.Y
+
+	m.Plot.Draw(center)
+	m.Top.Draw(top)
+	m.Right.Draw(right)
+}
+
+// WriterTo returns an io.WriterTo that will write the
+// figure as the specified image format, as for
+// plot.Plot.WriterTo.
+func (m *Marginal) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
+	return drawnWriterTo(w, h, format, m.Draw)
+}
+
+// Save saves the figure to an image file as for
+// plot.Plot.Save.
+func (m *Marginal) Save(w, h vg.Length, file string) error {
+	return saveDrawn(w, h, file, m.Draw)
+}
+
+// drawnWriterTo returns an io.WriterTo that will write
+// the drawing made by fn as the specified image format.
+func drawnWriterTo(w, h vg.Length, format string, fn func(draw.Canvas)) (io.WriterTo, error) {
+	c, err := draw.NewFormattedCanvas(w, h, format)
+	if err != nil {
+		return nil, err
+	}
+	fn(draw.New(c))
+	return c, nil
+}
+
+// saveDrawn saves the drawing made by fn to an image
+// file whose format is determined by its extension.
+func saveDrawn(w, h vg.Length, file string, fn func(draw.Canvas)) (err error) {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		e := f.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+
+	format := strings.ToLower(filepath.Ext(file))
+	if len(format) != 0 {
+		format = format[1:]
+	}
+	c, err := drawnWriterTo(w, h, format, fn)
+	if err != nil {
+		return err
+	}
+	_, err = c.WriteTo(f)
+	return err
+}
+
+// kde returns a Gaussian kernel density estimate of the
+// distribution of vs evaluated at n points spanning the
+// values and three bandwidths either side of them. The
+// bandwidth is chosen by Silverman's rule of thumb.
+func kde(vs plotter.Valuer, n int) (xs, ds []float64) {
+	vals := make([]float64, vs.Len())
+	for i := range vals {
+		vals[i] = vs.Value(i)
+	}
+	sort.Float64s(vals)
+	std := stat.StdDev(vals, nil)
+	iqr := stat.Quantile(0.75, stat.Empirical, vals, nil) - stat.Quantile(0.25, stat.Empirical, vals, nil)
+	spread := std
+	if iqr > 0 && iqr/1.34 < spread {
+		spread = iqr / 1.34
+	}
+	bw := 0.9 * spread * math.Pow(float64(len(vals)), -0.2)
+	if !(bw > 0) {
+		bw = 1
+	}
+
+	min, max := vals[0]-3*bw, vals[len(vals)-1]+3*bw
+	xs = make([]float64, n)
+	ds = make([]float64, n)
+	norm := 1 / (float64(len(vals)) * bw * math.Sqrt(2*math.Pi))
+	for i := range xs {
+		xs[i] = min + (max-min)*float64(i)/float64(n-1)
+		var d float64
+		for _, v := range vals {
+			z := (xs[i] - v) / bw
+			d += math.Exp(-z * z / 2)
+		}
+		ds[i] = d * norm
+	}
+	return xs, ds
+}
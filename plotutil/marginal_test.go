@@ -0,0 +1,104 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+func ExampleMarginal() {
+	rnd := rand.New(rand.NewSource(1))
+	xys := make(plotter.XYs, 500)
+	for i := range xys {
+		xys[i].X = rnd.NormFloat64()
+		xys[i].Y = xys[i].X + 0.5*rnd.NormFloat64()
+	}
+
+	m, err := NewMarginal(xys, MarginalHistogram)
+	if err != nil {
+		log.Panic(err)
+	}
+	m.Top.Title.Text = "Correlated samples"
+	m.Plot.X.Label.Text = "X"
+	m.Plot.Y.Label.Text = "Y"
+
+	err = m.Save(4*vg.Inch, 4*vg.Inch, "marginal.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestMarginal(t *testing.T) {
+	xys := plotter.XYs{{X: 1, Y: 10}, {X: 2, Y: 30}, {X: 2.5, Y: 20}, {X: 4, Y: 25}}
+	for _, kind := range []MarginalKind{MarginalHistogram, MarginalKDE} {
+		m, err := NewMarginal(xys, kind)
+		if err != nil {
+			t.Fatalf("unexpected error for kind %d: %v", kind, err)
+		}
+		m.Top.Title.Text = "title"
+		m.Plot.X.Label.Text = "x"
+		m.Plot.Y.Label.Text = "y"
+
+		c := draw.Canvas{
+			Canvas:    new(recorder.Canvas),
+			Rectangle: vg.Rectangle{Max: vg.Point{X: 300, Y: 200}},
+		}
+		var top, right, center draw.Canvas
+		for _, p := range []struct {
+			plot *plot.Plot
+			c    *draw.Canvas
+		}{{m.Top, &top}, {m.Right, &right}, {m.Plot, &center}} {
+			p.plot.Add(canvasRecorder{c: p.c})
+		}
+		m.Draw(c)
+
+		if m.Top.X.Min != m.Plot.X.Min || m.Top.X.Max != m.Plot.X.Max {
+			t.Errorf("unexpected top X range for kind %d: got:[%v, %v] want:[%v, %v]",
+				kind, m.Top.X.Min, m.Top.X.Max, m.Plot.X.Min, m.Plot.X.Max)
+		}
+		if m.Right.Y.Min != m.Plot.Y.Min || m.Right.Y.Max != m.Plot.Y.Max {
+			t.Errorf("unexpected right Y range for kind %d: got:[%v, %v] want:[%v, %v]",
+				kind, m.Right.Y.Min, m.Right.Y.Max, m.Plot.Y.Min, m.Plot.Y.Max)
+		}
+		if !closeLength(top.Min.X, center.Min.X) || !closeLength(top.Max.X, center.Max.X) {
+			t.Errorf("top data area not aligned for kind %d: got:[%v, %v] want:[%v, %v]",
+				kind, top.Min.X, top.Max.X, center.Min.X, center.Max.X)
+		}
+		if !closeLength(right.Min.Y, center.Min.Y) || !closeLength(right.Max.Y, center.Max.Y) {
+			t.Errorf("right data area not aligned for kind %d: got:[%v, %v] want:[%v, %v]",
+				kind, right.Min.Y, right.Max.Y, center.Min.Y, center.Max.Y)
+		}
+		if top.Min.Y <= center.Max.Y || right.Min.X <= center.Max.X {
+			t.Errorf("marginal panels overlap central plot for kind %d", kind)
+		}
+	}
+
+	_, err := NewMarginal(plotter.XYs{}, MarginalHistogram)
+	if err == nil {
+		t.Error("expected error for empty data")
+	}
+}
+
+// canvasRecorder is a plot.Plotter that records the data
+// canvas it is drawn to.
+type canvasRecorder struct {
+	c *draw.Canvas
+}
+
+func (r canvasRecorder) Plot(c draw.Canvas, _ *plot.Plot) { *r.c = c }
+
+func closeLength(a, b vg.Length) bool {
+	return math.Abs(float64(a-b)) < 1e-9
+}
@@ -0,0 +1,240 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"errors"
+	"image/color"
+	"io"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// PairPlot is a scatter plot matrix of several variables.
+// The panel in row i and column j is a scatter plot of the
+// jth variable against the ith, and the panels on the
+// diagonal show the distribution of each variable. The
+// panels of a column share their X axis range and the
+// off-diagonal panels of a row share their Y axis range.
+// Only the panels on the bottom row and left column label
+// their axes.
+type PairPlot struct {
+	// Plots are the panels of the pair plot, indexed by
+	// row then column.
+	Plots [][]*plot.Plot
+
+	// Pad is the space between panels.
+	Pad vg.Length
+}
+
+// NewPairPlot returns a PairPlot of the variables vars,
+// which must all be of the same length, named by names.
+// The diagonal panels show distributions of the kind
+// given. If groups is not nil, it holds a non-negative
+// group for each observation, and the points and
+// distributions of group g are drawn in Color(g).
+func NewPairPlot(names []string, vars []plotter.Valuer, diag MarginalKind, groups []int) (*PairPlot, error) {
+	n := len(vars)
+	if n == 0 {
+		return nil, errors.New("plotutil: no variables for pair plot")
+	}
+	if len(names) != n {
+		return nil, errors.New("plotutil: names and variables lengths mismatch")
+	}
+	m := vars[0].Len()
+	for _, v := range vars[1:] {
+		if v.Len() != m {
+			return nil, errors.New("plotutil: variables lengths mismatch")
+		}
+	}
+	if groups == nil {
+		groups = make([]int, m)
+	}
+	if len(groups) != m {
+		return nil, errors.New("plotutil: groups and variables lengths mismatch")
+	}
+	var ngroups int
+	for _, g := range groups {
+		if g < 0 {
+			return nil, errors.New("plotutil: negative group")
+		}
+		if g >= ngroups {
+			ngroups = g + 1
+		}
+	}
+
+	pp := &PairPlot{Plots: make([][]*plot.Plot, n), Pad: vg.Points(4)}
+	for i := range pp.Plots {
+		pp.Plots[i] = make([]*plot.Plot, n)
+		for j := range pp.Plots[i] {
+			p, err := plot.New()
+			if err != nil {
+				return nil, err
+			}
+			if i == j {
+				err = addDistributions(p, vars[j], groups, ngroups, diag)
+			} else {
+				err = addGroupScatters(p, vars[j], vars[i], groups, ngroups)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			p.X.Min, p.X.Max = plotter.Range(vars[j])
+			if i == j {
+				p.HideY()
+			} else {
+				p.Y.Min, p.Y.Max = plotter.Range(vars[i])
+			}
+			if i == n-1 {
+				p.X.Label.Text = names[j]
+			} else {
+				p.X.Tick.Marker = unlabelledTicks{p.X.Tick.Marker}
+			}
+			if j == 0 {
+				p.Y.Label.Text = names[i]
+			} else if i != j {
+				p.Y.Tick.Marker = unlabelledTicks{p.Y.Tick.Marker}
+			}
+			pp.Plots[i][j] = p
+		}
+	}
+	return pp, nil
+}
+
+// addGroupScatters adds a scatter plot of ys against xs
+// for each group to p.
+func addGroupScatters(p *plot.Plot, xs, ys plotter.Valuer, groups []int, ngroups int) error {
+	pts := make([]plotter.XYs, ngroups)
+	for k, g := range groups {
+		pts[g] = append(pts[g], struct{ X, Y float64 }{xs.Value(k), ys.Value(k)})
+	}
+	for g, xys := range pts {
+		if len(xys) == 0 {
+			continue
+		}
+		s, err := plotter.NewScatter(xys)
+		if err != nil {
+			return err
+		}
+		s.Color = Color(g)
+		s.Radius = vg.Points(1.5)
+		p.Add(s)
+	}
+	return nil
+}
+
+// addDistributions adds the distribution of vs for each
+// group to p. Histograms of the groups share their bins
+// and are overlaid with translucent fills.
+func addDistributions(p *plot.Plot, vs plotter.Valuer, groups []int, ngroups int, kind MarginalKind) error {
+	vals := make([]plotter.Values, ngroups)
+	for k, g := range groups {
+		vals[g] = append(vals[g], vs.Value(k))
+	}
+	switch kind {
+	case MarginalHistogram:
+		var xys []plotter.XYer
+		var colors []color.Color
+		for g, v := range vals {
+			if len(v) != 0 {
+				xys = append(xys, unitWeights{v})
+				colors = append(colors, Color(g))
+			}
+		}
+		xs := valuesOf(vs)
+		ws := make([]float64, len(xs))
+		for k := range ws {
+			ws[k] = 1
+		}
+		n := plotter.FreedmanDiaconis(xs, ws)
+		hs, err := plotter.NewHistograms(xys, n)
+		if err != nil {
+			return err
+		}
+		for k, h := range hs {
+			h.FillColor = colors[k]
+			if len(hs) > 1 {
+				r, g, b, _ := colors[k].RGBA()
+				h.FillColor = color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: 128}
+			}
+			p.Add(h)
+		}
+	case MarginalKDE:
+		for g, v := range vals {
+			if len(v) == 0 {
+				continue
+			}
+			xs, ds := kde(v, 100)
+			pts := make(plotter.XYs, len(xs))
+			for k := range pts {
+				pts[k].X, pts[k].Y = xs[k], ds[k]
+			}
+			l, err := plotter.NewLine(pts)
+			if err != nil {
+				return err
+			}
+			l.Color = Color(g)
+			p.Add(l)
+		}
+	default:
+		return errors.New("plotutil: unknown distribution kind")
+	}
+	return nil
+}
+
+// valuesOf returns the values of vs.
+func valuesOf(vs plotter.Valuer) []float64 {
+	v := make([]float64, vs.Len())
+	for i := range v {
+		v[i] = vs.Value(i)
+	}
+	return v
+}
+
+// unlabelledTicks is a plot.Ticker returning the ticks
+// of a Ticker without their labels.
+type unlabelledTicks struct {
+	plot.Ticker
+}
+
+// Ticks returns the ticks of the wrapped Ticker
+// without labels.
+func (t unlabelledTicks) Ticks(min, max float64) []plot.Tick {
+	ticks := t.Ticker.Ticks(min, max)
+	for i := range ticks {
+		ticks[i].Label = ""
+	}
+	return ticks
+}
+
+// Draw draws the panels of the pair plot to c, aligning
+// their data areas.
+func (pp *PairPlot) Draw(c draw.Canvas) {
+	n := len(pp.Plots)
+	t := draw.Tiles{Rows: n, Cols: n, PadX: pp.Pad, PadY: pp.Pad}
+	cs := plot.Align(pp.Plots, t, c)
+	for i, row := range pp.Plots {
+		for j, p := range row {
+			p.Draw(cs[i][j])
+		}
+	}
+}
+
+// WriterTo returns an io.WriterTo that will write the
+// pair plot as the specified image format, as for
+// plot.Plot.WriterTo.
+func (pp *PairPlot) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
+	return drawnWriterTo(w, h, format, pp.Draw)
+}
+
+// Save saves the pair plot to an image file as for
+// plot.Plot.Save.
+func (pp *PairPlot) Save(w, h vg.Length, file string) error {
+	return saveDrawn(w, h, file, pp.Draw)
+}
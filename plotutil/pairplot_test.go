@@ -0,0 +1,120 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"log"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+func ExamplePairPlot() {
+	rnd := rand.New(rand.NewSource(1))
+	const n = 150
+	length := make(plotter.Values, n)
+	width := make(plotter.Values, n)
+	height := make(plotter.Values, n)
+	species := make([]int, n)
+	for i := range species {
+		species[i] = i % 3
+		s := float64(species[i])
+		length[i] = 4 + s + 0.4*rnd.NormFloat64()
+		width[i] = 3 - 0.5*s + 0.3*rnd.NormFloat64()
+		height[i] = length[i] + 2*s + 0.5*rnd.NormFloat64()
+	}
+
+	pp, err := NewPairPlot(
+		[]string{"Length", "Width", "Height"},
+		[]plotter.Valuer{length, width, height},
+		MarginalHistogram, species,
+	)
+	if err != nil {
+		log.Panic(err)
+	}
+	err = pp.Save(5*vg.Inch, 5*vg.Inch, "pairplot.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestPairPlot(t *testing.T) {
+	a := plotter.Values{1, 2, 3, 4}
+	b := plotter.Values{10, 40, 20, 30}
+	c := plotter.Values{-1, 0, 1, 5}
+	for _, kind := range []MarginalKind{MarginalHistogram, MarginalKDE} {
+		pp, err := NewPairPlot([]string{"a", "b", "c"}, []plotter.Valuer{a, b, c}, kind, []int{0, 1, 0, 1})
+		if err != nil {
+			t.Fatalf("unexpected error for kind %d: %v", kind, err)
+		}
+		vars := []plotter.Values{a, b, c}
+		canvases := make([][]draw.Canvas, 3)
+		for i, row := range pp.Plots {
+			canvases[i] = make([]draw.Canvas, 3)
+			for j, p := range row {
+				xmin, xmax := plotter.Range(vars[j])
+				if p.X.Min != xmin || p.X.Max != xmax {
+					t.Errorf("unexpected X range of panel (%d, %d) for kind %d: got:[%v, %v] want:[%v, %v]",
+						i, j, kind, p.X.Min, p.X.Max, xmin, xmax)
+				}
+				if i != j {
+					ymin, ymax := plotter.Range(vars[i])
+					if p.Y.Min != ymin || p.Y.Max != ymax {
+						t.Errorf("unexpected Y range of panel (%d, %d) for kind %d: got:[%v, %v] want:[%v, %v]",
+							i, j, kind, p.Y.Min, p.Y.Max, ymin, ymax)
+					}
+				}
+				wantX := ""
+				if i == 2 {
+					wantX = []string{"a", "b", "c"}[j]
+				}
+				if p.X.Label.Text != wantX {
+					t.Errorf("unexpected X label of panel (%d, %d): got:%q want:%q", i, j, p.X.Label.Text, wantX)
+				}
+				p.Add(canvasRecorder{c: &canvases[i][j]})
+			}
+		}
+
+		pp.Draw(draw.Canvas{
+			Canvas:    new(recorder.Canvas),
+			Rectangle: vg.Rectangle{Max: vg.Point{X: 300, Y: 300}},
+		})
+		for i := range canvases {
+			for j := range canvases[i] {
+				got, want := canvases[i][j], canvases[2][j]
+				if !closeLength(got.Min.X, want.Min.X) || !closeLength(got.Max.X, want.Max.X) {
+					t.Errorf("panel (%d, %d) not aligned with its column for kind %d", i, j, kind)
+				}
+				want = canvases[i][0]
+				if !closeLength(got.Min.Y, want.Min.Y) || !closeLength(got.Max.Y, want.Max.Y) {
+					t.Errorf("panel (%d, %d) not aligned with its row for kind %d", i, j, kind)
+				}
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		name   string
+		names  []string
+		vars   []plotter.Valuer
+		groups []int
+	}{
+		{name: "no variables"},
+		{name: "names mismatch", names: []string{"a"}, vars: []plotter.Valuer{a, b}},
+		{name: "lengths mismatch", names: []string{"a", "b"}, vars: []plotter.Valuer{a, plotter.Values{1}}},
+		{name: "groups mismatch", names: []string{"a"}, vars: []plotter.Valuer{a}, groups: []int{0}},
+		{name: "negative group", names: []string{"a"}, vars: []plotter.Valuer{a}, groups: []int{0, 0, -1, 0}},
+	} {
+		_, err := NewPairPlot(test.names, test.vars, MarginalHistogram, test.groups)
+		if err == nil {
+			t.Errorf("expected error for %s", test.name)
+		}
+	}
+}
@@ -77,6 +77,21 @@ func Shape(i int) draw.GlyphDrawer {
 	return DefaultGlyphShapes[i%n]
 }
 
+// GlyphShapes returns the glyph shapes registered with
+// the given names, for use in a Theme. An error is
+// returned if any name is not registered.
+func GlyphShapes(names ...string) ([]draw.GlyphDrawer, error) {
+	shapes := make([]draw.GlyphDrawer, len(names))
+	for i, name := range names {
+		g, err := draw.LookupGlyph(name)
+		if err != nil {
+			return nil, err
+		}
+		shapes[i] = g
+	}
+	return shapes, nil
+}
+
 // DefaultDashes is a set of dash patterns used by
 // the Dashes function.
 var DefaultDashes = [][]vg.Length{
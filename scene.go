@@ -0,0 +1,440 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Scene is the fully laid out geometry of a plot, in the
+// coordinates of the canvas that it was laid out on. The origin
+// of the coordinates is the bottom left corner of the canvas and
+// lengths are in points. A Scene is written as a JSON document,
+// allowing frontends such as web pages or game engines to render
+// the plot natively.
+type Scene struct {
+	// Width and Height are the size of the canvas.
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+
+	// DataArea is the area of the canvas in which
+	// the data are plotted.
+	DataArea SceneRect `json:"dataArea"`
+
+	// Background, Title and Legend hold the shapes
+	// drawn for the background, title and legend of
+	// the plot.
+	Background []Shape `json:"background,omitempty"`
+	Title      []Shape `json:"title,omitempty"`
+	Legend     []Shape `json:"legend,omitempty"`
+
+	// X and Y are the axes of the plot.
+	X SceneAxis `json:"x"`
+	Y SceneAxis `json:"y"`
+
+	// Plotters holds the shapes drawn by each
+	// of the plotters of the plot, in drawing order.
+	Plotters []ScenePlotter `json:"plotters"`
+}
+
+// SceneRect is a rectangle of a Scene.
+type SceneRect struct {
+	Min [2]float64 `json:"min"`
+	Max [2]float64 `json:"max"`
+}
+
+// SceneAxis is an axis of a Scene.
+type SceneAxis struct {
+	// Min and Max are the range of the axis.
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+
+	// Label is the text of the axis label.
+	Label string `json:"label,omitempty"`
+
+	// Ticks are the tick marks of the axis
+	// within its range.
+	Ticks []SceneTick `json:"ticks"`
+
+	// Shapes holds the shapes drawn for the axis.
+	Shapes []Shape `json:"shapes"`
+}
+
+// SceneTick is a tick mark of an axis of a Scene.
+type SceneTick struct {
+	// Value is the data value of the tick.
+	Value float64 `json:"value"`
+
+	// Label is the label of the tick. Minor
+	// ticks have no label.
+	Label string `json:"label,omitempty"`
+
+	// Position is the canvas coordinate of
+	// the tick along its axis.
+	Position float64 `json:"position"`
+}
+
+// ScenePlotter holds the shapes drawn by a Plotter.
+type ScenePlotter struct {
+	// Type is the Go type of the Plotter,
+	// such as "*plotter.Line".
+	Type string `json:"type"`
+
+	// Shapes holds the shapes drawn by the Plotter.
+	Shapes []Shape `json:"shapes"`
+}
+
+// Shape is a single drawing operation of a Scene.
+type Shape struct {
+	// Kind is the kind of the shape, one of
+	// "stroke", "fill", "text" or "image".
+	Kind string `json:"kind"`
+
+	// Color is the color of the shape as
+	// a "#rrggbbaa" hexadecimal string.
+	Color string `json:"color,omitempty"`
+
+	// Path is the path of a stroked or filled shape,
+	// or the corners of the area of an image. Arcs
+	// are flattened to line segments.
+	Path []SubPath `json:"path,omitempty"`
+
+	// Width, Dashes and DashOffset are the line
+	// style of a stroked shape.
+	Width      float64   `json:"width,omitempty"`
+	Dashes     []float64 `json:"dashes,omitempty"`
+	DashOffset float64   `json:"dashOffset,omitempty"`
+
+	// FillRule is the fill rule of a filled shape,
+	// "nonzero" or "evenodd". It is empty if the
+	// default fill rule of the renderer is used.
+	FillRule string `json:"fillRule,omitempty"`
+
+	// Text is the text of a text shape, drawn with
+	// the left end of its baseline at Position,
+	// rotated anticlockwise by Rotation radians, in
+	// the font Font of size FontSize.
+	Text     string     `json:"text,omitempty"`
+	Position [2]float64 `json:"position,omitempty"`
+	Rotation float64    `json:"rotation,omitempty"`
+	Font     string     `json:"font,omitempty"`
+	FontSize float64    `json:"fontSize,omitempty"`
+
+	// Image is the image of an image shape, as
+	// a PNG data URI.
+	Image string `json:"image,omitempty"`
+}
+
+// SubPath is a connected part of the path of a Shape.
+type SubPath struct {
+	// Points are the vertices of the sub-path.
+	Points [][2]float64 `json:"points"`
+
+	// Closed specifies that the last point
+	// is joined to the first.
+	Closed bool `json:"closed,omitempty"`
+}
+
+// Scene returns the scene of the plot laid out on a canvas of
+// the given width and height.
+func (p *Plot) Scene(w, h vg.Length) *Scene {
+	sc := newSceneCanvas(w, h)
+	c := draw.New(sc)
+
+	s := &Scene{Width: float64(w), Height: float64(h)}
+	var dst *[]Shape
+	flush := func() {
+		if dst != nil {
+			*dst = append(*dst, sc.shapes...)
+		}
+		sc.shapes = nil
+	}
+	p.draw(c, func(name string, pl Plotter) {
+		flush()
+		switch name {
+		case "background":
+			dst = &s.Background
+		case "title":
+			dst = &s.Title
+		case "x":
+			dst = &s.X.Shapes
+		case "y":
+			dst = &s.Y.Shapes
+		case "plotter":
+			s.Plotters = append(s.Plotters, ScenePlotter{Type: fmt.Sprintf("%T", pl)})
+			dst = &s.Plotters[len(s.Plotters)-1].Shapes
+		case "legend":
+			dst = &s.Legend
+		}
+	})
+	flush()
+
+	dataC := p.DataCanvas(c)
+	s.DataArea = SceneRect{
+		Min: [2]float64{float64(dataC.Min.X), float64(dataC.Min.Y)},
+		Max: [2]float64{float64(dataC.Max.X), float64(dataC.Max.Y)},
+	}
+	s.X = p.X.scene(s.X.Shapes, func(v float64) vg.Length { return dataC.X(p.X.Norm(v)) })
+	s.Y = p.Y.scene(s.Y.Shapes, func(v float64) vg.Length { return dataC.Y(p.Y.Norm(v)) })
+	return s
+}
+
+// scene returns the SceneAxis of the axis drawn as the given
+// shapes, where pos returns the canvas coordinate of a value.
+func (a Axis) scene(shapes []Shape, pos func(float64) vg.Length) SceneAxis {
+	s := SceneAxis{
+		Min:    a.Min,
+		Max:    a.Max,
+		Label:  a.labelText(),
+		Ticks:  []SceneTick{},
+		Shapes: shapes,
+	}
+	for _, t := range a.Ticks() {
+		if t.Value < a.Min || t.Value > a.Max {
+			continue
+		}
+		s.Ticks = append(s.Ticks, SceneTick{
+			Value:    t.Value,
+			Label:    t.Label,
+			Position: float64(pos(t.Value)),
+		})
+	}
+	return s
+}
+
+// WriteTo writes the scene to w as a JSON document.
+func (s *Scene) WriteTo(w io.Writer) (int64, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// sceneCanvas is a vg.Canvas recording the shapes
+// drawn on it in the coordinates of the canvas.
+type sceneCanvas struct {
+	w, h   vg.Length
+	stack  []sceneState
+	shapes []Shape
+}
+
+// sceneState is the graphics state of a sceneCanvas.
+type sceneState struct {
+	// m is the affine transform from user to canvas
+	// coordinates, mapping (x, y) to
+	// (m[0]x + m[2]y + m[4], m[1]x + m[3]y + m[5]).
+	m [6]float64
+
+	color      color.Color
+	width      vg.Length
+	dashes     []vg.Length
+	dashOffset vg.Length
+	fillRule   vg.FillRule
+}
+
+var _ vg.FillRuleSetter = (*sceneCanvas)(nil)
+
+func newSceneCanvas(w, h vg.Length) *sceneCanvas {
+	c := &sceneCanvas{
+		w:     w,
+		h:     h,
+		stack: []sceneState{{m: [6]float64{1, 0, 0, 1, 0, 0}}},
+	}
+	vg.Initialize(c)
+	return c
+}
+
+func (c *sceneCanvas) state() *sceneState { return &c.stack[len(c.stack)-1] }
+
+// transform multiplies the current transform by the
+// transform mapping (x, y) to (ax + cy + e, bx + dy + f).
+func (c *sceneCanvas) transform(a, b, cc, d, e, f float64) {
+	m := &c.state().m
+	*m = [6]float64{
+		m[0]*a + m[2]*b, m[1]*a + m[3]*b,
+		m[0]*cc + m[2]*d, m[1]*cc + m[3]*d,
+		m[0]*e + m[2]*f + m[4], m[1]*e + m[3]*f + m[5],
+	}
+}
+
+// point returns the canvas coordinates of pt.
+func (c *sceneCanvas) point(pt vg.Point) [2]float64 {
+	m := c.state().m
+	x, y := float64(pt.X), float64(pt.Y)
+	return [2]float64{m[0]*x + m[2]*y + m[4], m[1]*x + m[3]*y + m[5]}
+}
+
+// scale returns the scale factor of lengths along
+// the X axis of the current transform.
+func (c *sceneCanvas) scale() float64 {
+	m := c.state().m
+	return math.Hypot(m[0], m[1])
+}
+
+func (c *sceneCanvas) SetLineWidth(w vg.Length) { c.state().width = w }
+
+func (c *sceneCanvas) SetLineDash(pattern []vg.Length, offset vg.Length) {
+	c.state().dashes = pattern
+	c.state().dashOffset = offset
+}
+
+func (c *sceneCanvas) SetColor(clr color.Color) {
+	if clr == nil {
+		clr = color.Black
+	}
+	c.state().color = clr
+}
+
+func (c *sceneCanvas) SetFillRule(r vg.FillRule) { c.state().fillRule = r }
+
+func (c *sceneCanvas) Rotate(rad float64) {
+	sin, cos := math.Sincos(rad)
+	c.transform(cos, sin, -sin, cos, 0, 0)
+}
+
+func (c *sceneCanvas) Translate(pt vg.Point) {
+	c.transform(1, 0, 0, 1, float64(pt.X), float64(pt.Y))
+}
+
+func (c *sceneCanvas) Scale(x, y float64) { c.transform(x, 0, 0, y, 0, 0) }
+
+func (c *sceneCanvas) Push() {
+	st := *c.state()
+	st.dashes = append([]vg.Length(nil), st.dashes...)
+	c.stack = append(c.stack, st)
+}
+
+func (c *sceneCanvas) Pop() {
+	if len(c.stack) == 1 {
+		panic("plot: too many pops")
+	}
+	c.stack = c.stack[:len(c.stack)-1]
+}
+
+func (c *sceneCanvas) Stroke(p vg.Path) {
+	st := c.state()
+	s := c.shape("stroke", p)
+	s.Width = float64(st.width) * c.scale()
+	for _, d := range st.dashes {
+		s.Dashes = append(s.Dashes, float64(d)*c.scale())
+	}
+	s.DashOffset = float64(st.dashOffset) * c.scale()
+	c.shapes = append(c.shapes, s)
+}
+
+func (c *sceneCanvas) Fill(p vg.Path) {
+	s := c.shape("fill", p)
+	switch c.state().fillRule {
+	case vg.NonZero:
+		s.FillRule = "nonzero"
+	case vg.EvenOdd:
+		s.FillRule = "evenodd"
+	}
+	c.shapes = append(c.shapes, s)
+}
+
+// arcStep is the largest angle of the line
+// segments approximating an arc.
+const arcStep = math.Pi / 36
+
+// shape returns a Shape of the given kind with
+// the path p in canvas coordinates.
+func (c *sceneCanvas) shape(kind string, p vg.Path) Shape {
+	s := Shape{Kind: kind, Color: sceneColor(c.state().color)}
+	var sub *SubPath
+	for _, comp := range p {
+		switch comp.Type {
+		case vg.MoveComp:
+			s.Path = append(s.Path, SubPath{Points: [][2]float64{c.point(comp.Pos)}})
+			sub = &s.Path[len(s.Path)-1]
+		case vg.LineComp:
+			if sub == nil {
+				s.Path = append(s.Path, SubPath{})
+				sub = &s.Path[len(s.Path)-1]
+			}
+			sub.Points = append(sub.Points, c.point(comp.Pos))
+		case vg.ArcComp:
+			if sub == nil {
+				s.Path = append(s.Path, SubPath{})
+				sub = &s.Path[len(s.Path)-1]
+			}
+			n := int(math.Ceil(math.Abs(comp.Angle) / arcStep))
+			if n < 1 {
+				n = 1
+			}
+			for i := 0; i <= n; i++ {
+				sin, cos := math.Sincos(comp.Start + comp.Angle*float64(i)/float64(n))
+				sub.Points = append(sub.Points, c.point(vg.Point{
+					X: comp.Pos.X + comp.Radius*vg.Length(cos),
+					Y: comp.Pos.Y + comp.Radius*vg.Length(sin),
+				}))
+			}
+		case vg.CloseComp:
+			if sub != nil {
+				sub.Closed = true
+				sub = nil
+			}
+		default:
+			panic(fmt.Sprintf("plot: unknown path component: %d", comp.Type))
+		}
+	}
+	return s
+}
+
+func (c *sceneCanvas) FillString(f vg.Font, pt vg.Point, text string) {
+	m := c.state().m
+	c.shapes = append(c.shapes, Shape{
+		Kind:     "text",
+		Color:    sceneColor(c.state().color),
+		Text:     text,
+		Position: c.point(pt),
+		Rotation: math.Atan2(m[1], m[0]),
+		Font:     f.Name(),
+		FontSize: float64(f.Size) * c.scale(),
+	})
+}
+
+func (c *sceneCanvas) DrawImage(rect vg.Rectangle, img image.Image) {
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	if err != nil {
+		panic(fmt.Errorf("plot: failed to encode image: %v", err))
+	}
+	c.shapes = append(c.shapes, Shape{
+		Kind: "image",
+		Path: []SubPath{{
+			Points: [][2]float64{
+				c.point(rect.Min),
+				c.point(vg.Point{X: rect.Max.X, Y: rect.Min.Y}),
+				c.point(rect.Max),
+				c.point(vg.Point{X: rect.Min.X, Y: rect.Max.Y}),
+			},
+			Closed: true,
+		}},
+		Image: "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+	})
+}
+
+func (c *sceneCanvas) Size() (w, h vg.Length) { return c.w, c.h }
+
+// sceneColor returns the "#rrggbbaa" hexadecimal
+// string of the color clr.
+func sceneColor(clr color.Color) string {
+	n := color.NRGBAModel.Convert(clr).(color.NRGBA)
+	return fmt.Sprintf("#%02x%02x%02x%02x", n.R, n.G, n.B, n.A)
+}
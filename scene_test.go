@@ -0,0 +1,142 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/color"
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// scenePlotter is a Plotter drawing a line and a circle.
+type scenePlotter struct{}
+
+func (scenePlotter) Plot(c draw.Canvas, p *Plot) {
+	trX, trY := p.Transforms(&c)
+	c.StrokeLine2(draw.LineStyle{Color: color.NRGBA{R: 255, A: 255}, Width: vg.Points(2)},
+		trX(0), trY(0), trX(10), trY(10))
+	var path vg.Path
+	path.Arc(vg.Point{X: trX(5), Y: trY(5)}, vg.Points(4), 0, 2*math.Pi)
+	path.Close()
+	c.Fill(path)
+}
+
+func (scenePlotter) DataRange() (xmin, xmax, ymin, ymax float64) { return 0, 10, 0, 10 }
+
+func TestScene(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "Scene"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+	p.Add(scenePlotter{})
+
+	s := p.Scene(200, 150)
+	if s.Width != 200 || s.Height != 150 {
+		t.Errorf("unexpected scene size: got:%vx%v want:200x150", s.Width, s.Height)
+	}
+	if len(s.Title) != 1 || s.Title[0].Kind != "text" || s.Title[0].Text != "Scene" {
+		t.Errorf("unexpected title shapes: %+v", s.Title)
+	}
+	if s.X.Label != "X" || s.Y.Label != "Y" {
+		t.Errorf("unexpected axis labels: got:%q,%q want:\"X\",\"Y\"", s.X.Label, s.Y.Label)
+	}
+
+	da := s.DataArea
+	for _, test := range []struct {
+		axis     SceneAxis
+		min, max float64
+	}{
+		{axis: s.X, min: da.Min[0], max: da.Max[0]},
+		{axis: s.Y, min: da.Min[1], max: da.Max[1]},
+	} {
+		if len(test.axis.Ticks) == 0 {
+			t.Fatal("expected axis ticks")
+		}
+		for _, tk := range test.axis.Ticks {
+			want := test.min + (test.max-test.min)*tk.Value/10
+			if math.Abs(tk.Position-want) > 1e-9 {
+				t.Errorf("unexpected position of tick %v: got:%v want:%v", tk.Value, tk.Position, want)
+			}
+		}
+		if len(test.axis.Shapes) == 0 {
+			t.Error("expected axis shapes")
+		}
+	}
+
+	if len(s.Plotters) != 1 {
+		t.Fatalf("unexpected number of plotters: got:%d want:1", len(s.Plotters))
+	}
+	sp := s.Plotters[0]
+	if sp.Type != "plot.scenePlotter" {
+		t.Errorf("unexpected plotter type: got:%q want:\"plot.scenePlotter\"", sp.Type)
+	}
+	if len(sp.Shapes) != 2 {
+		t.Fatalf("unexpected number of plotter shapes: got:%d want:2", len(sp.Shapes))
+	}
+	line := sp.Shapes[0]
+	if line.Kind != "stroke" || line.Color != "#ff0000ff" || line.Width != 2 {
+		t.Errorf("unexpected line shape: %+v", line)
+	}
+	wantLine := [][2]float64{da.Min, da.Max}
+	if len(line.Path) != 1 || !reflect.DeepEqual(line.Path[0].Points, wantLine) {
+		t.Errorf("unexpected line path: got:%v want:%v", line.Path, wantLine)
+	}
+	circle := sp.Shapes[1]
+	if circle.Kind != "fill" || len(circle.Path) != 1 || !circle.Path[0].Closed {
+		t.Fatalf("unexpected circle shape: %+v", circle)
+	}
+	center := [2]float64{(da.Min[0] + da.Max[0]) / 2, (da.Min[1] + da.Max[1]) / 2}
+	for _, pt := range circle.Path[0].Points {
+		r := math.Hypot(pt[0]-center[0], pt[1]-center[1])
+		if math.Abs(r-4) > 1e-9 {
+			t.Errorf("unexpected circle point %v: radius got:%v want:4", pt, r)
+		}
+	}
+
+	var buf bytes.Buffer
+	_, err = s.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error writing scene: %v", err)
+	}
+	var got Scene
+	err = json.Unmarshal(buf.Bytes(), &got)
+	if err != nil {
+		t.Fatalf("unexpected error reading scene: %v", err)
+	}
+	if !reflect.DeepEqual(&got, s) {
+		t.Error("scene not preserved by JSON round trip")
+	}
+}
+
+func TestSceneCanvasTransform(t *testing.T) {
+	c := newSceneCanvas(100, 100)
+	c.Push()
+	c.Translate(vg.Point{X: 10, Y: 20})
+	c.Rotate(math.Pi / 2)
+	c.FillString(vg.Font{Size: 12}, vg.Point{X: 5}, "text")
+	c.Pop()
+	c.FillString(vg.Font{Size: 12}, vg.Point{X: 5}, "text")
+
+	got := c.shapes[0]
+	if math.Abs(got.Position[0]-10) > 1e-9 || math.Abs(got.Position[1]-25) > 1e-9 {
+		t.Errorf("unexpected transformed text position: got:%v want:[10 25]", got.Position)
+	}
+	if math.Abs(got.Rotation-math.Pi/2) > 1e-9 {
+		t.Errorf("unexpected transformed text rotation: got:%v want:%v", got.Rotation, math.Pi/2)
+	}
+	got = c.shapes[1]
+	if got.Position != [2]float64{5, 0} || got.Rotation != 0 {
+		t.Errorf("unexpected text position after pop: got:%v rotation:%v", got.Position, got.Rotation)
+	}
+}
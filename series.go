@@ -0,0 +1,180 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+// AddNamed adds Plotters to the plot as for Add, as members of
+// the series with the given name, so that the series may later
+// be retrieved, replaced or removed by name, for example to
+// update a single series of a long-lived plot. Plotters added
+// to a series that already exists are appended to the series.
+func (p *Plot) AddNamed(name string, ps ...Plotter) {
+	p.addZ(0, name, ps...)
+}
+
+// Named returns the Plotters of the series with the given
+// name, in the order in which they were added, or nil if
+// there is no such series.
+func (p *Plot) Named(name string) []Plotter {
+	var ps []Plotter
+	for i, n := range p.names {
+		if n == name && name != "" {
+			ps = append(ps, p.plotters[i])
+		}
+	}
+	return ps
+}
+
+// SeriesNames returns the names of the series of the plot
+// in the order in which they were first added.
+func (p *Plot) SeriesNames() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, n := range p.names {
+		if n == "" || seen[n] {
+			continue
+		}
+		seen[n] = true
+		names = append(names, n)
+	}
+	return names
+}
+
+// RemoveNamed removes the Plotters of the series with the
+// given name from the plot as for Remove, and returns whether
+// the series existed.
+func (p *Plot) RemoveNamed(name string) bool {
+	if name == "" {
+		return false
+	}
+	removed := p.removeIf(func(i int) bool { return p.names[i] == name })
+	p.Legend.replace(removed, nil)
+	return len(removed) != 0
+}
+
+// Remove removes each occurrence of the given Plotters from the
+// plot and returns the number of plotters removed. The remaining
+// plotters keep their order and z-orders. The plotters are also
+// removed from the thumbnails of the legend, and legend entries
+// left without thumbnails are removed. The axis ranges of the plot
+// are not changed.
+func (p *Plot) Remove(ps ...Plotter) int {
+	removed := p.removeIf(func(i int) bool {
+		for _, pl := range ps {
+			if same(p.plotters[i], pl) {
+				return true
+			}
+		}
+		return false
+	})
+	p.Legend.replace(removed, nil)
+	return len(removed)
+}
+
+// Replace replaces each occurrence of the Plotter old in the plot
+// with new, which takes its place, z-order and series, and returns
+// whether old was found. Legend thumbnails drawn by old are drawn by
+// new if it is a Thumbnailer, and are otherwise removed as for
+// Remove. The axis ranges of the plot are extended to fit new as
+// for Add.
+func (p *Plot) Replace(old, new Plotter) bool {
+	var found bool
+	for i, pl := range p.plotters {
+		if same(pl, old) {
+			p.plotters[i] = new
+			found = true
+		}
+	}
+	if !found {
+		return false
+	}
+	p.fit(new)
+	p.Legend.replace([]Plotter{old}, thumbnailers(new))
+	return true
+}
+
+// Clear removes all of the Plotters and legend entries
+// of the plot. The axis ranges of the plot are not changed.
+func (p *Plot) Clear() {
+	p.truncate(0)
+	p.Legend.entries = nil
+}
+
+// removeIf removes the plotters for which remove(i) is true
+// for their index i, and returns the removed plotters.
+func (p *Plot) removeIf(remove func(i int) bool) []Plotter {
+	p.align()
+	var removed []Plotter
+	n := 0
+	for i, pl := range p.plotters {
+		if remove(i) {
+			removed = append(removed, pl)
+			continue
+		}
+		p.plotters[n], p.zorders[n], p.names[n] = pl, p.zorders[i], p.names[i]
+		n++
+	}
+	p.truncate(n)
+	return removed
+}
+
+// ReplaceNamed replaces the Plotters of the series with the
+// given name with ps, and returns whether the series existed.
+// The new plotters take the place and z-order of the first
+// plotter of the series among the plotters of the plot, and
+// legend thumbnails of the series are drawn by those of the
+// new plotters that are Thumbnailers. If the series does not
+// exist the plotters are added as for AddNamed. The axis
+// ranges of the plot are extended to fit the new plotters as
+// for Add.
+func (p *Plot) ReplaceNamed(name string, ps ...Plotter) bool {
+	p.align()
+	first := -1
+	for i, n := range p.names {
+		if n == name && name != "" {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		p.AddNamed(name, ps...)
+		return false
+	}
+	z := p.zorders[first]
+	old := p.removeIf(func(i int) bool { return p.names[i] == name })
+	p.Legend.replace(old, thumbnailers(ps...))
+	p.fit(ps...)
+	p.plotters = append(p.plotters[:first], append(ps[:len(ps):len(ps)], p.plotters[first:]...)...)
+	zs := make([]int, len(ps))
+	names := make([]string, len(ps))
+	for i := range ps {
+		zs[i], names[i] = z, name
+	}
+	p.zorders = append(p.zorders[:first], append(zs, p.zorders[first:]...)...)
+	p.names = append(p.names[:first], append(names, p.names[first:]...)...)
+	return true
+}
+
+// thumbnailers returns the plotters of ps
+// that are Thumbnailers.
+func thumbnailers(ps ...Plotter) []Thumbnailer {
+	var ths []Thumbnailer
+	for _, pl := range ps {
+		if th, ok := pl.(Thumbnailer); ok {
+			ths = append(ths, th)
+		}
+	}
+	return ths
+}
+
+// truncate truncates the plotters, z-orders and names
+// of the plot to length n, releasing the removed plotters.
+func (p *Plot) truncate(n int) {
+	for i := n; i < len(p.plotters); i++ {
+		p.plotters[i] = nil
+	}
+	p.plotters = p.plotters[:n]
+	p.zorders = p.zorders[:n]
+	p.names = p.names[:n]
+}
@@ -0,0 +1,215 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// rangePlotter is a Plotter with a data range
+// that draws nothing.
+type rangePlotter struct {
+	name                   string
+	xmin, xmax, ymin, ymax float64
+}
+
+func (*rangePlotter) Plot(draw.Canvas, *Plot) {}
+
+func (*rangePlotter) Thumbnail(*draw.Canvas) {}
+
+func (r *rangePlotter) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return r.xmin, r.xmax, r.ymin, r.ymax
+}
+
+// plotterNames returns the names of the rangePlotters.
+func plotterNames(ps []Plotter) []string {
+	names := make([]string, len(ps))
+	for i, p := range ps {
+		names[i] = p.(*rangePlotter).name
+	}
+	return names
+}
+
+func TestNamedSeries(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a1, a2 := &rangePlotter{name: "a1"}, &rangePlotter{name: "a2"}
+	b := &rangePlotter{name: "b"}
+	grid := &rangePlotter{name: "grid"}
+	p.AddZ(-1, grid)
+	p.AddNamed("a", a1)
+	p.AddNamed("b", b)
+	p.AddNamed("a", a2)
+
+	if got, want := plotterNames(p.Named("a")), []string{"a1", "a2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected series plotters: got:%v want:%v", got, want)
+	}
+	if got := p.Named("c"); got != nil {
+		t.Errorf("unexpected plotters for missing series: got:%v", got)
+	}
+	if got := p.Named(""); got != nil {
+		t.Errorf("unexpected plotters for empty name: got:%v", got)
+	}
+	if got, want := p.SeriesNames(), []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected series names: got:%v want:%v", got, want)
+	}
+
+	// Replacing a series keeps its place and extends the axes.
+	a3 := &rangePlotter{name: "a3", xmin: -5, xmax: 5, ymin: 0, ymax: 20}
+	if !p.ReplaceNamed("a", a3) {
+		t.Error("expected replaced series to exist")
+	}
+	if got, want := plotterNames(p.drawOrder()), []string{"grid", "a3", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected plotters after replace: got:%v want:%v", got, want)
+	}
+	if p.X.Min != -5 || p.X.Max != 5 || p.Y.Max != 20 {
+		t.Errorf("unexpected axis ranges after replace: x:[%v, %v] y:[%v, %v]", p.X.Min, p.X.Max, p.Y.Min, p.Y.Max)
+	}
+	if p.ReplaceNamed("c", &rangePlotter{name: "c"}) {
+		t.Error("unexpected replacement of missing series")
+	}
+	if got, want := plotterNames(p.drawOrder()), []string{"grid", "a3", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected plotters after replacing missing series: got:%v want:%v", got, want)
+	}
+
+	if !p.RemoveNamed("a") {
+		t.Error("expected removed series to exist")
+	}
+	if p.RemoveNamed("a") {
+		t.Error("unexpected removal of missing series")
+	}
+	if got, want := plotterNames(p.drawOrder()), []string{"grid", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected plotters after remove: got:%v want:%v", got, want)
+	}
+	if got, want := p.SeriesNames(), []string{"b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected series names after remove: got:%v want:%v", got, want)
+	}
+	if p.zorders[0] != -1 {
+		t.Errorf("unexpected z-order of unnamed plotter: got:%d want:-1", p.zorders[0])
+	}
+}
+
+// legendNames returns the text and thumbnail
+// names of the entries of the legend.
+func legendNames(l Legend) []string {
+	var names []string
+	for _, e := range l.entries {
+		s := e.text + ":"
+		for _, th := range e.thumbs {
+			s += " " + th.(*rangePlotter).name
+		}
+		names = append(names, s)
+	}
+	return names
+}
+
+func TestRemoveReplace(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l1, s1 := &rangePlotter{name: "l1"}, &rangePlotter{name: "s1"}
+	l2, s2 := &rangePlotter{name: "l2"}, &rangePlotter{name: "s2"}
+	top := &rangePlotter{name: "top"}
+	p.Add(l1, s1)
+	p.AddZ(1, top)
+	p.AddNamed("two", l2, s2)
+	p.Legend.Add("one", l1, s1)
+	p.Legend.Add("two", l2, s2)
+	p.Legend.Add("top", top)
+
+	// Removal keeps the order of the remaining plotters
+	// and removes emptied legend entries.
+	if n := p.Remove(s1, top, &rangePlotter{}); n != 2 {
+		t.Errorf("unexpected number of removed plotters: got:%d want:2", n)
+	}
+	if got, want := plotterNames(p.drawOrder()), []string{"l1", "l2", "s2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected plotters after remove: got:%v want:%v", got, want)
+	}
+	if got, want := legendNames(p.Legend), []string{"one: l1", "two: l2 s2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected legend after remove: got:%q want:%q", got, want)
+	}
+
+	// Replacement keeps the place, z-order and series
+	// of the plotter and its legend thumbnails.
+	l3 := &rangePlotter{name: "l3", xmax: 10, ymax: 10}
+	if !p.Replace(l2, l3) {
+		t.Error("expected replaced plotter to exist")
+	}
+	if p.Replace(l2, l3) {
+		t.Error("unexpected replacement of missing plotter")
+	}
+	if got, want := plotterNames(p.Named("two")), []string{"l3", "s2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected series after replace: got:%v want:%v", got, want)
+	}
+	if got, want := legendNames(p.Legend), []string{"one: l1", "two: l3 s2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected legend after replace: got:%q want:%q", got, want)
+	}
+	if p.X.Max != 10 || p.Y.Max != 10 {
+		t.Errorf("unexpected axis maxima after replace: got:%v,%v want:10,10", p.X.Max, p.Y.Max)
+	}
+
+	// Replacing a named series replaces its thumbnails.
+	l4 := &rangePlotter{name: "l4"}
+	p.ReplaceNamed("two", l4)
+	if got, want := legendNames(p.Legend), []string{"one: l1", "two: l4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected legend after series replacement: got:%q want:%q", got, want)
+	}
+	p.RemoveNamed("two")
+	if got, want := legendNames(p.Legend), []string{"one: l1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected legend after series removal: got:%q want:%q", got, want)
+	}
+
+	p.Clear()
+	if len(p.plotters) != 0 || len(p.Legend.entries) != 0 || len(p.SeriesNames()) != 0 {
+		t.Errorf("unexpected plot contents after clear: %d plotters, %d legend entries",
+			len(p.plotters), len(p.Legend.entries))
+	}
+	p.Add(l1)
+	if got, want := plotterNames(p.drawOrder()), []string{"l1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected plotters after clear: got:%v want:%v", got, want)
+	}
+}
+
+// valuePlotter is a Plotter of a comparable struct type
+// that may hold an uncomparable value.
+type valuePlotter struct {
+	data interface{}
+}
+
+func (valuePlotter) Plot(draw.Canvas, *Plot) {}
+
+func (valuePlotter) Thumbnail(*draw.Canvas) {}
+
+func TestRemoveReplaceUncomparable(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	slice := valuePlotter{data: []float64{1, 2}}
+	scalar := valuePlotter{data: 1.0}
+	p.Add(slice, scalar)
+	p.Legend.Add("slice", slice)
+	p.Legend.Add("scalar", scalar)
+
+	if p.Replace(valuePlotter{data: []float64{1, 2}}, scalar) {
+		t.Error("unexpected replacement of uncomparable plotter")
+	}
+	if n := p.Remove(valuePlotter{data: []float64{1, 2}}); n != 0 {
+		t.Errorf("unexpected number of removed uncomparable plotters: got:%d want:0", n)
+	}
+	if n := p.Remove(valuePlotter{data: 1.0}); n != 1 {
+		t.Errorf("unexpected number of removed plotters: got:%d want:1", n)
+	}
+	if len(p.plotters) != 1 || len(p.Legend.entries) != 1 {
+		t.Errorf("unexpected plot contents after remove: %d plotters, %d legend entries",
+			len(p.plotters), len(p.Legend.entries))
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import "gonum.org/v1/plot/vg"
+
+// Size is the physical size of a figure.
+type Size struct {
+	Width, Height vg.Length
+}
+
+// Figure sizes of the single and double column widths of
+// common journals, with a 4:3 aspect ratio. Figures of other
+// heights are made using WithAspect.
+var (
+	IEEESingleColumn     = Size{Width: 3.5 * vg.Inch, Height: 3.5 * vg.Inch * 3 / 4}
+	IEEEDoubleColumn     = Size{Width: 7.16 * vg.Inch, Height: 7.16 * vg.Inch * 3 / 4}
+	NatureSingleColumn   = Size{Width: 89 * vg.Millimeter, Height: 89 * vg.Millimeter * 3 / 4}
+	NatureDoubleColumn   = Size{Width: 183 * vg.Millimeter, Height: 183 * vg.Millimeter * 3 / 4}
+	ElsevierSingleColumn = Size{Width: 90 * vg.Millimeter, Height: 90 * vg.Millimeter * 3 / 4}
+	ElsevierDoubleColumn = Size{Width: 190 * vg.Millimeter, Height: 190 * vg.Millimeter * 3 / 4}
+)
+
+// Page and slide sizes.
+var (
+	A4        = Size{Width: 210 * vg.Millimeter, Height: 297 * vg.Millimeter}
+	Letter    = Size{Width: 8.5 * vg.Inch, Height: 11 * vg.Inch}
+	Slide16x9 = Size{Width: 13.333 * vg.Inch, Height: 7.5 * vg.Inch}
+	Slide4x3  = Size{Width: 10 * vg.Inch, Height: 7.5 * vg.Inch}
+)
+
+// WithAspect returns the size with the width of s and
+// the height giving the aspect ratio width/height.
+func (s Size) WithAspect(ratio float64) Size {
+	return Size{Width: s.Width, Height: s.Width / vg.Length(ratio)}
+}
+
+// Landscape returns the size with the larger of the
+// width and height of s as its width.
+func (s Size) Landscape() Size {
+	if s.Height > s.Width {
+		s.Width, s.Height = s.Height, s.Width
+	}
+	return s
+}
+
+// FontScale returns the scale factor that draws a plot designed
+// for the size design in proportion at the size s, for use with
+// draw.FontScale or draw.ScaleCanvas. Plots are scaled to match
+// the widths of the sizes.
+func (s Size) FontScale(design Size) float64 {
+	return float64(s.Width / design.Width)
+}
@@ -0,0 +1,63 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot_test
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ExampleSize draws a plot designed as a small inline figure
+// at the single column width of a journal, scaling its text and
+// lines in proportion to the larger size.
+func ExampleSize() {
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Scaled figure"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+	p.Add(plotter.NewGrid())
+
+	f := plotter.NewFunction(math.Sin)
+	p.Add(f)
+	p.X.Min, p.X.Max = 0, 2*math.Pi
+	p.Y.Min, p.Y.Max = -1, 1
+
+	design := plot.Size{Width: 2 * vg.Inch, Height: 1.5 * vg.Inch}
+	size := plot.NatureSingleColumn
+	err = p.Save(size.Width, size.Height, "testdata/size.png", draw.FontScale(size.FontScale(design)))
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestSizePlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleSize, t, "size.png")
+}
+
+func TestSize(t *testing.T) {
+	s := plot.Size{Width: 4 * vg.Inch, Height: 3 * vg.Inch}
+	if got, want := s.WithAspect(2), (plot.Size{Width: 4 * vg.Inch, Height: 2 * vg.Inch}); got != want {
+		t.Errorf("unexpected size with aspect: got:%+v want:%+v", got, want)
+	}
+	if got, want := plot.A4.Landscape(), (plot.Size{Width: plot.A4.Height, Height: plot.A4.Width}); got != want {
+		t.Errorf("unexpected landscape size: got:%+v want:%+v", got, want)
+	}
+	if got := s.Landscape(); got != s {
+		t.Errorf("unexpected landscape size: got:%+v want:%+v", got, s)
+	}
+	if got := s.FontScale(plot.Size{Width: 2 * vg.Inch}); got != 2 {
+		t.Errorf("unexpected font scale: got:%v want:2", got)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot_test
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// ExampleAxis_spines draws a function with axes crossing
+// at the origin in the style of a mathematics textbook,
+// and the same function framed by the axis lines and the
+// lines opposite them.
+func ExampleAxis_spines() {
+	cubic := plotter.NewFunction(func(x float64) float64 { return x*x*x/4 - x })
+	cubic.Width = vg.Points(1)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Crossing axes"
+	p.X.Min, p.X.Max = -3, 3
+	p.Y.Min, p.Y.Max = -3, 3
+	p.X.Cross, p.Y.Cross = true, true
+	p.X.Arrow, p.Y.Arrow = true, true
+	p.Add(cubic)
+
+	err = p.Save(200, 200, "testdata/spinesCross.png")
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err = plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Framed axes"
+	p.X.Min, p.X.Max = -3, 3
+	p.Y.Min, p.Y.Max = -3, 3
+	p.X.Padding, p.Y.Padding = 0, 0
+	p.X.OppositeLine = p.X.LineStyle
+	p.Y.OppositeLine = p.Y.LineStyle
+	p.Add(cubic)
+
+	err = p.Save(200, 200, "testdata/spinesBox.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestAxisSpinesPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleAxis_spines, t, "spinesCross.png", "spinesBox.png")
+}
+
+func TestAxisSpines(t *testing.T) {
+	c := draw.New(vgimg.New(vg.Points(200), vg.Points(200)))
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	base := p.DataCanvas(c)
+
+	p.X.OppositeLine = draw.LineStyle{Width: vg.Points(2)}
+	p.Y.OppositeLine = draw.LineStyle{Width: vg.Points(4)}
+	got := p.DataCanvas(c)
+	if got.Min != base.Min {
+		t.Errorf("unexpected data area minimum: got:%v want:%v", got.Min, base.Min)
+	}
+	want := vg.Point{
+		X: base.Max.X - p.Y.Padding - vg.Points(2),
+		Y: base.Max.Y - p.X.Padding - vg.Points(1),
+	}
+	if math.Abs(float64(got.Max.X-want.X)) > 1e-9 || math.Abs(float64(got.Max.Y-want.Y)) > 1e-9 {
+		t.Errorf("unexpected data area maximum: got:%v want:%v", got.Max, want)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"image"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// TickGraphic is a graphic drawn as the label of a tick
+// mark in place of its text, such as the flag of a country
+// or a color swatch for a category.
+type TickGraphic interface {
+	// Size returns the width and height of the graphic.
+	Size() (w, h vg.Length)
+
+	// Draw draws the graphic to fill the rectangle r.
+	Draw(c draw.Canvas, r vg.Rectangle)
+}
+
+// ImageGraphic is a TickGraphic drawing an image
+// scaled to its width and height.
+type ImageGraphic struct {
+	Image         image.Image
+	Width, Height vg.Length
+}
+
+var _ TickGraphic = ImageGraphic{}
+
+// Size implements the TickGraphic interface.
+func (g ImageGraphic) Size() (w, h vg.Length) {
+	return g.Width, g.Height
+}
+
+// Draw implements the TickGraphic interface.
+func (g ImageGraphic) Draw(c draw.Canvas, r vg.Rectangle) {
+	c.DrawImage(r, g.Image)
+}
+
+// GlyphGraphic is a TickGraphic drawing a glyph, such
+// as a colored box or circle marking a category.
+type GlyphGraphic struct {
+	draw.GlyphStyle
+}
+
+var _ TickGraphic = GlyphGraphic{}
+
+// Size implements the TickGraphic interface.
+func (g GlyphGraphic) Size() (w, h vg.Length) {
+	return 2 * g.Radius, 2 * g.Radius
+}
+
+// Draw implements the TickGraphic interface.
+func (g GlyphGraphic) Draw(c draw.Canvas, r vg.Rectangle) {
+	c.DrawGlyphNoClip(g.GlyphStyle, vg.Point{
+		X: (r.Min.X + r.Max.X) / 2,
+		Y: (r.Min.Y + r.Max.Y) / 2,
+	})
+}
+
+// labelGraphic returns the graphic drawn as the label
+// of the tick mark t, or nil if its label is text.
+func (a Axis) labelGraphic(t Tick) TickGraphic {
+	s := a.tickStyle(t)
+	if s == nil {
+		return nil
+	}
+	return s.LabelGraphic
+}
+
+// tickLabelRect returns the rectangle of the label of
+// the tick mark t relative to the point it is drawn at.
+// The rectangle of a graphic is aligned by the alignment
+// of the tick label text style of the axis.
+func (a Axis) tickLabelRect(t Tick) vg.Rectangle {
+	g := a.labelGraphic(t)
+	if g == nil {
+		return a.Tick.Label.Rectangle(t.Label)
+	}
+	w, h := g.Size()
+	min := vg.Point{
+		X: w * vg.Length(a.Tick.Label.XAlign),
+		Y: h * vg.Length(a.Tick.Label.YAlign),
+	}
+	return vg.Rectangle{Min: min, Max: min.Add(vg.Point{X: w, Y: h})}
+}
+
+// drawTickLabel draws the label of the tick mark t at pt.
+func (a Axis) drawTickLabel(c draw.Canvas, t Tick, pt vg.Point) {
+	g := a.labelGraphic(t)
+	if g == nil {
+		c.FillText(a.tickLabel(t), pt, t.Label)
+		return
+	}
+	r := a.tickLabelRect(t)
+	g.Draw(c, vg.Rectangle{Min: pt.Add(r.Min), Max: pt.Add(r.Max)})
+}
+
+// tickLabelHeight returns height of the tick mark labels.
+func (a Axis) tickLabelHeight(ticks []Tick) vg.Length {
+	maxHeight := vg.Length(0)
+	for _, t := range ticks {
+		if t.IsMinor() {
+			continue
+		}
+		r := a.tickLabelRect(t)
+		h := r.Max.Y - r.Min.Y
+		if h > maxHeight {
+			maxHeight = h
+		}
+	}
+	return maxHeight
+}
+
+// tickLabelWidth returns the width of the widest tick mark label.
+func (a Axis) tickLabelWidth(ticks []Tick) vg.Length {
+	maxWidth := vg.Length(0)
+	for _, t := range ticks {
+		if t.IsMinor() {
+			continue
+		}
+		r := a.tickLabelRect(t)
+		w := r.Max.X - r.Min.X
+		if w > maxWidth {
+			maxWidth = w
+		}
+	}
+	return maxWidth
+}
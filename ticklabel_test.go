@@ -0,0 +1,146 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot_test
+
+import (
+	"image"
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// tricolor returns an image of a flag of three
+// vertical stripes of the given colors.
+func tricolor(c ...color.Color) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 30, 20))
+	for x := 0; x < 30; x++ {
+		for y := 0; y < 20; y++ {
+			img.Set(x, y, c[x/10])
+		}
+	}
+	return img
+}
+
+// ExampleTickGraphic draws a bar chart of the values of
+// countries labelled by their flags.
+func ExampleTickGraphic() {
+	var (
+		white = color.White
+		red   = color.RGBA{R: 206, G: 43, B: 55, A: 255}
+		green = color.RGBA{G: 146, B: 70, A: 255}
+		blue  = color.RGBA{B: 164, A: 255}
+		orng  = color.RGBA{R: 255, G: 136, B: 62, A: 255}
+	)
+	flags := map[string]image.Image{
+		"France":  tricolor(blue, white, red),
+		"Italy":   tricolor(green, white, red),
+		"Ireland": tricolor(green, white, orng),
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Flags as tick labels"
+	p.Y.Label.Text = "Value"
+	bars, err := plotter.NewBarChart(plotter.Values{12, 9, 5}, vg.Points(30))
+	if err != nil {
+		log.Panic(err)
+	}
+	bars.Color = color.Gray{Y: 128}
+	p.Add(bars)
+	p.NominalX("France", "Italy", "Ireland")
+	p.X.Tick.Marker = plot.StyledTicks{
+		Ticker: p.X.Tick.Marker,
+		Style: func(t plot.Tick) *plot.TickStyle {
+			return &plot.TickStyle{LabelGraphic: plot.ImageGraphic{
+				Image:  flags[t.Label],
+				Width:  vg.Points(24),
+				Height: vg.Points(16),
+			}}
+		},
+	}
+
+	err = p.Save(200, 200, "testdata/tickGraphic.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestTickGraphicPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleTickGraphic, t, "tickGraphic.png")
+}
+
+func TestTickGraphic(t *testing.T) {
+	c := draw.New(vgimg.New(vg.Points(200), vg.Points(200)))
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	p.Y.Tick.Marker = plot.ConstantTicks{{Value: 0.5, Label: "a"}}
+	base := p.DataCanvas(c)
+
+	swatch := plot.GlyphGraphic{GlyphStyle: draw.GlyphStyle{
+		Color:  color.RGBA{B: 255, A: 255},
+		Radius: vg.Points(20),
+		Shape:  draw.BoxGlyph{},
+	}}
+	p.Y.Tick.Marker = plot.StyledTicks{
+		Ticker: p.Y.Tick.Marker,
+		Style: func(plot.Tick) *plot.TickStyle {
+			return &plot.TickStyle{LabelGraphic: swatch}
+		},
+	}
+	got := p.DataCanvas(c)
+	r := p.Y.Tick.Label.Rectangle("a")
+	want := base.Min.X + vg.Points(40) - (r.Max.X - r.Min.X)
+	if got.Min.X != want {
+		t.Errorf("unexpected data area minimum X: got:%v want:%v", got.Min.X, want)
+	}
+
+	// The swatch is drawn in place of the label text,
+	// at the left edge of the canvas as the Y axis has
+	// no label.
+	center := vg.Points(20)
+	var rec recorder.Canvas
+	p.Draw(draw.NewCanvas(&rec, 200, 200))
+	var filled, text bool
+	for _, a := range rec.Actions {
+		switch a := a.(type) {
+		case *recorder.Fill:
+			min, max := a.Path[0].Pos, a.Path[0].Pos
+			for _, c := range a.Path {
+				if c.Type == vg.CloseComp {
+					continue
+				}
+				min.X, max.X = vg.Length(math.Min(float64(min.X), float64(c.Pos.X))), vg.Length(math.Max(float64(max.X), float64(c.Pos.X)))
+			}
+			if math.Abs(float64((min.X+max.X)/2-center)) < 1e-9 {
+				filled = true
+			}
+		case *recorder.FillString:
+			if a.String == "a" {
+				text = true
+			}
+		}
+	}
+	if !filled {
+		t.Errorf("swatch not drawn")
+	}
+	if text {
+		t.Errorf("unexpected label text drawn")
+	}
+}
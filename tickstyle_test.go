@@ -0,0 +1,146 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot_test
+
+import (
+	"image/color"
+	"log"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+// ExampleTickStyle draws a decaying signal with the tick
+// marks of the Y axis drawn inside the data area, and the
+// tick at the detection threshold highlighted in red.
+func ExampleTickStyle() {
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Threshold tick"
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 1
+
+	const threshold = 0.3
+	red := color.RGBA{R: 255, A: 255}
+	p.Y.Tick.Marker = plot.StyledTicks{
+		Ticker: plot.ConstantTicks{
+			{Value: 0, Label: "0"},
+			{Value: threshold, Label: "threshold"},
+			{Value: 0.5, Label: "0.5"},
+			{Value: 1, Label: "1"},
+		},
+		Style: func(t plot.Tick) *plot.TickStyle {
+			if t.Value != threshold {
+				return &plot.TickStyle{Inside: true}
+			}
+			return &plot.TickStyle{
+				LineStyle:  draw.LineStyle{Color: red, Width: vg.Points(1.5)},
+				Length:     vg.Points(10),
+				Inside:     true,
+				LabelColor: red,
+			}
+		},
+	}
+
+	decay := plotter.NewFunction(func(x float64) float64 { return 1 / (1 + x*x/4) })
+	decay.Width = vg.Points(1)
+	p.Add(decay)
+
+	err = p.Save(200, 200, "testdata/tickStyle.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestTickStylePlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleTickStyle, t, "tickStyle.png")
+}
+
+func TestTickStyle(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	var drawn []plot.Tick
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	p.X.Tick.Marker = plot.StyledTicks{
+		Ticker: plot.ConstantTicks{{Value: 0.5, Label: "half"}},
+		Style: func(plot.Tick) *plot.TickStyle {
+			return &plot.TickStyle{Inside: true, Length: vg.Points(7), LabelColor: red}
+		},
+	}
+	p.Y.Tick.Marker = plot.StyledTicks{
+		Ticker: plot.ConstantTicks{{Value: 0.5, Label: "custom"}},
+		Style: func(plot.Tick) *plot.TickStyle {
+			return &plot.TickStyle{Draw: func(c draw.Canvas, t plot.Tick, pt, dir vg.Point) {
+				if dir != (vg.Point{X: -1}) {
+					t.Label = "bad direction"
+				}
+				drawn = append(drawn, t)
+			}}
+		},
+	}
+
+	st, ok := p.X.Tick.Marker.(plot.StyledTicker)
+	if !ok {
+		t.Fatalf("unexpected tick marker type: %T", p.X.Tick.Marker)
+	}
+	ticks := st.Ticks(0, 1)
+	if len(ticks) != 1 || ticks[0] != (plot.Tick{Value: 0.5, Label: "half"}) {
+		t.Fatalf("unexpected styled ticks: %+v", ticks)
+	}
+	if s := st.TickStyle(ticks[0]); s == nil || !s.Inside {
+		t.Fatalf("unexpected tick style: %+v", s)
+	}
+
+	var rec recorder.Canvas
+	p.Draw(draw.NewCanvas(&rec, 200, 200))
+	dc := p.DataCanvas(draw.NewCanvas(&recorder.Canvas{}, 200, 200))
+
+	if len(drawn) != 1 || drawn[0].Label != "custom" {
+		t.Errorf("unexpected custom drawn ticks: %+v", drawn)
+	}
+
+	var tick, label bool
+	var col color.Color
+	for _, a := range rec.Actions {
+		switch a := a.(type) {
+		case *recorder.SetColor:
+			col = a.Color
+		case *recorder.Stroke:
+			// The inside X tick runs up from the axis line
+			// at the bottom of the data area into it.
+			if len(a.Path) != 2 {
+				continue
+			}
+			from, to := a.Path[0].Pos, a.Path[1].Pos
+			if from.X != to.X || from.X != dc.X(0.5) {
+				continue
+			}
+			if from.Y-to.Y == vg.Points(7) && to.Y <= dc.Min.Y {
+				tick = true
+			}
+		case *recorder.FillString:
+			if a.String == "half" {
+				label = col == color.Color(red)
+			}
+		}
+	}
+	if !tick {
+		t.Errorf("inside tick mark of length 7pt not drawn")
+	}
+	if !label {
+		t.Errorf("tick label not drawn in red")
+	}
+}
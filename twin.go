@@ -0,0 +1,154 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// TwinAxis is an axis drawn along the top of the data area
+// of a plot showing the values of the X axis converted to
+// other units, such as energy for an X axis of wavelength,
+// with its own tick marks and label.
+//
+// The Min, Max and Scale of the embedded Axis are ignored;
+// the range of the twin axis is the conversion of the range
+// of the X axis, and its values are positioned by converting
+// them back to the X axis.
+type TwinAxis struct {
+	Axis
+
+	// Forward converts a value of the X axis to a value of
+	// the twin axis, and Inverse converts a value of the
+	// twin axis back to a value of the X axis. The
+	// conversions must be monotonic over the range of the
+	// X axis, and may be decreasing.
+	Forward, Inverse func(float64) float64
+}
+
+// NewTwinX returns a new TwinAxis for the X axis of the plot
+// with the given conversions and the default axis styles, and
+// sets it as the TwinX of the plot.
+func (p *Plot) NewTwinX(forward, inverse func(float64) float64) (*TwinAxis, error) {
+	if forward == nil || inverse == nil {
+		return nil, errors.New("plot: twin axis without conversion")
+	}
+	a, err := makeAxis(horizontal)
+	if err != nil {
+		return nil, err
+	}
+	a.Label.YAlign = draw.YBottom
+	a.Tick.Label.YAlign = draw.YBottom
+	a.Tick.Marker = DefaultTicks{}
+	p.TwinX = &TwinAxis{Axis: a, Forward: forward, Inverse: inverse}
+	return p.TwinX, nil
+}
+
+// topAxis is a TwinAxis laid out for drawing along the
+// top of the data area of a plot with the X axis x.
+type topAxis struct {
+	Axis
+	x       Axis
+	inverse func(float64) float64
+}
+
+// twinX returns the TwinX axis of the plot laid out for
+// drawing, and whether the plot has a TwinX axis.
+func (p *Plot) twinX() (topAxis, bool) {
+	t := p.TwinX
+	if t == nil || t.Forward == nil || t.Inverse == nil {
+		return topAxis{}, false
+	}
+	p.X.sanitizeRange()
+	a := t.Axis
+	a.Min, a.Max = t.Forward(p.X.Min), t.Forward(p.X.Max)
+	if a.Min > a.Max {
+		a.Min, a.Max = a.Max, a.Min
+	}
+	if math.IsNaN(a.Min) || math.IsNaN(a.Max) || a.Min == a.Max {
+		return topAxis{}, false
+	}
+	a.Scale = LinearScale{}
+	a.Invert = false
+	a.length = p.X.length
+	a.vertical = false
+	return topAxis{Axis: a, x: p.X, inverse: t.Inverse}, true
+}
+
+// norm returns the position of the twin axis value v
+// along the X axis.
+func (a topAxis) norm(v float64) float64 {
+	return a.x.Norm(a.inverse(v))
+}
+
+// size returns the height of the axis.
+func (a topAxis) size() (h vg.Length) {
+	if label := a.labelText(); label != "" {
+		h += a.Label.Height(label)
+	}
+	marks := a.Ticks()
+	if len(marks) > 0 {
+		if a.drawTicks() {
+			h += a.Tick.Length
+		}
+		h += a.tickLabelHeight(marks)
+	}
+	h += a.Width / 2
+	h += a.Padding
+	return h
+}
+
+// draw draws the axis along the upper edge of a draw.Canvas
+// whose lower edge is the upper edge of the data area.
+func (a topAxis) draw(c draw.Canvas) {
+	y := c.Min.Y + a.Padding + a.Width/2
+	c.StrokeLine2(a.LineStyle, c.Min.X, y, c.Max.X, y)
+
+	marks := a.Ticks()
+	if len(marks) > 0 && a.drawTicks() {
+		for _, t := range marks {
+			x := c.X(a.norm(t.Value))
+			if !c.ContainsX(x) {
+				continue
+			}
+			a.drawTick(c, t, vg.Point{X: x, Y: y}, vg.Point{Y: 1})
+		}
+		y += a.Tick.Length
+	}
+
+	for _, t := range marks {
+		x := c.X(a.norm(t.Value))
+		if !c.ContainsX(x) || t.IsMinor() {
+			continue
+		}
+		a.drawTickLabel(c, t, vg.Point{X: x, Y: y})
+	}
+	if len(marks) > 0 {
+		y += a.tickLabelHeight(marks)
+	}
+
+	if label := a.labelText(); label != "" {
+		c.FillText(a.Label.TextStyle, vg.Point{X: c.Center().X, Y: y}, label)
+	}
+}
+
+// GlyphBoxes returns the GlyphBoxes for the tick labels.
+func (a topAxis) GlyphBoxes(*Plot) []GlyphBox {
+	var boxes []GlyphBox
+	for _, t := range a.Ticks() {
+		if t.IsMinor() {
+			continue
+		}
+		boxes = append(boxes, GlyphBox{
+			X:         a.norm(t.Value),
+			Rectangle: a.tickLabelRect(t),
+		})
+	}
+	return boxes
+}
@@ -0,0 +1,116 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot_test
+
+import (
+	"image/color"
+	"log"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// ExamplePlot_NewTwinX draws the absorbance of a sample
+// against wavelength, with a twin axis along the top of
+// the plot showing the photon energy of the wavelengths.
+func ExamplePlot_NewTwinX() {
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.X.Label.Text = "Wavelength (nm)"
+	p.Y.Label.Text = "Absorbance"
+
+	// The energy in eV of a photon of wavelength λ in nm
+	// is hc/λ, a decreasing conversion.
+	const hc = 1239.84
+	energy, err := p.NewTwinX(
+		func(λ float64) float64 { return hc / λ },
+		func(e float64) float64 { return hc / e },
+	)
+	if err != nil {
+		log.Panic(err)
+	}
+	energy.Label.Text = "Energy (eV)"
+
+	var data plotter.XYs
+	for λ := 400.0; λ <= 700; λ += 5 {
+		d := (λ - 520) / 40
+		data = append(data, struct{ X, Y float64 }{X: λ, Y: 1 / (1 + d*d)})
+	}
+	l, err := plotter.NewLine(data)
+	if err != nil {
+		log.Panic(err)
+	}
+	l.Width = vg.Points(1)
+	l.Color = color.RGBA{G: 128, A: 255}
+	p.Add(l)
+
+	err = p.Save(250, 200, "testdata/twinX.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestTwinXPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExamplePlot_NewTwinX, t, "twinX.png")
+}
+
+func TestTwinX(t *testing.T) {
+	c := draw.New(vgimg.New(vg.Points(200), vg.Points(200)))
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 100
+	p.Y.Min, p.Y.Max = 0, 1
+	base := p.DataCanvas(c)
+
+	if _, err := p.NewTwinX(nil, nil); err == nil {
+		t.Errorf("expected error for twin axis without conversion")
+	}
+	fahrenheit, err := p.NewTwinX(
+		func(c float64) float64 { return c*9/5 + 32 },
+		func(f float64) float64 { return (f - 32) * 5 / 9 },
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.TwinX != fahrenheit {
+		t.Errorf("twin axis not set")
+	}
+	fahrenheit.Tick.Marker = plot.ConstantTicks{{Value: 32, Label: "32"}, {Value: 212, Label: "212"}}
+	got := p.DataCanvas(c)
+	if got.Min.Y != base.Min.Y || !(got.Max.Y < base.Max.Y) {
+		t.Errorf("unexpected data area with twin axis: got:%v base:%v", got.Rectangle, base.Rectangle)
+	}
+
+	// The twin tick labels are drawn above the ends
+	// of the data area, as the values 0 and 100 of
+	// the X axis.
+	var rec recorder.Canvas
+	p.Draw(draw.NewCanvas(&rec, 200, 200))
+	want := map[string]bool{"32": false, "212": false}
+	for _, a := range rec.Actions {
+		txt, ok := a.(*recorder.FillString)
+		if !ok {
+			continue
+		}
+		if _, ok := want[txt.String]; ok {
+			want[txt.String] = true
+		}
+	}
+	for label, found := range want {
+		if !found {
+			t.Errorf("twin tick label %q not drawn", label)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Unit is a unit of measure of the values of an axis.
+type Unit struct {
+	// Symbol is the symbol of the unit, such as "s",
+	// "m" or "dB".
+	Symbol string
+
+	// Quantity is the quantity measured by the unit,
+	// such as "time" or "length". Values may be
+	// converted between units of the same quantity.
+	Quantity string
+
+	// Factor is the size of the unit in the base unit
+	// of its quantity, so that the Factor of a minute
+	// is 60 when the base unit of time is the second.
+	// A zero Factor is treated as one.
+	Factor float64
+
+	// NoPrefix specifies that values in the unit are
+	// not scaled by SI prefixes.
+	NoPrefix bool
+}
+
+// Units of common quantities.
+var (
+	Second  = Unit{Symbol: "s", Quantity: "time"}
+	Minute  = Unit{Symbol: "min", Quantity: "time", Factor: 60, NoPrefix: true}
+	Hour    = Unit{Symbol: "h", Quantity: "time", Factor: 3600, NoPrefix: true}
+	Metre   = Unit{Symbol: "m", Quantity: "length"}
+	Inch    = Unit{Symbol: "in", Quantity: "length", Factor: 0.0254, NoPrefix: true}
+	Gram    = Unit{Symbol: "g", Quantity: "mass"}
+	Hertz   = Unit{Symbol: "Hz", Quantity: "frequency"}
+	Volt    = Unit{Symbol: "V", Quantity: "voltage"}
+	Watt    = Unit{Symbol: "W", Quantity: "power"}
+	Decibel = Unit{Symbol: "dB", Quantity: "level", NoPrefix: true}
+)
+
+// factor returns the size of u in the base unit of its quantity.
+func (u Unit) factor() float64 {
+	if u.Factor == 0 {
+		return 1
+	}
+	return u.Factor
+}
+
+// Convert returns the value v in the unit u converted to the
+// unit to. An error is returned if the units measure different
+// quantities.
+func (u Unit) Convert(v float64, to Unit) (float64, error) {
+	if u.Quantity != to.Quantity {
+		return 0, fmt.Errorf("plot: cannot convert %s to %s", u.Symbol, to.Symbol)
+	}
+	return v * u.factor() / to.factor(), nil
+}
+
+// DataUnits is the interface implemented by Plotters that
+// declare the units of their data. Adding a DataUnits Plotter
+// to a Plot sets the units of any axis of the plot that has no
+// unit to the unit of the plotter's data along that axis.
+type DataUnits interface {
+	// Units returns the units of the X and Y
+	// values of the data.
+	Units() (x, y Unit)
+}
+
+// siPrefixes are the SI prefixes of the powers of
+// one thousand from 10⁻¹² to 10¹².
+var siPrefixes = map[int]string{
+	-12: "p", -9: "n", -6: "µ", -3: "m",
+	0: "", 3: "k", 6: "M", 9: "G", 12: "T",
+}
+
+// unitExponent returns the power of ten of the SI prefix
+// used for the values of the axis.
+func (a Axis) unitExponent() int {
+	if a.Unit.Symbol == "" || a.Unit.NoPrefix {
+		return 0
+	}
+	m := math.Max(math.Abs(a.Min), math.Abs(a.Max))
+	if m == 0 || math.IsInf(m, 0) || math.IsNaN(m) {
+		return 0
+	}
+	exp := 3 * int(math.Floor(math.Log10(m)/3))
+	if exp < -12 {
+		return -12
+	}
+	if exp > 12 {
+		return 12
+	}
+	return exp
+}
+
+// labelText returns the text of the axis label, followed by
+// the symbol of the axis unit with its prefix.
+func (a Axis) labelText() string {
+	if a.Label.Text == "" || a.Unit.Symbol == "" {
+		return a.Label.Text
+	}
+	return a.Label.Text + " (" + siPrefixes[a.unitExponent()] + a.Unit.Symbol + ")"
+}
+
+// relabel formats the labels of the labelled ticks, scaling
+// their values by the SI prefix of the axis unit and formatting
+// them with the axis Tick.Formatter if it is not nil. The ticks
+// are not altered if neither is needed.
+func (a Axis) relabel(ticks []Tick) {
+	exp := a.unitExponent()
+	if exp == 0 && a.Tick.Formatter == nil {
+		return
+	}
+	scale := math.Pow10(-exp)
+	scaled := make([]Tick, len(ticks))
+	for i, t := range ticks {
+		scaled[i] = Tick{Value: t.Value * scale, Label: t.Label}
+	}
+	prec := labelPrecision(scaled)
+	for i, t := range scaled {
+		if t.IsMinor() {
+			continue
+		}
+		if a.Tick.Formatter != nil {
+			ticks[i].Label = a.Tick.Formatter.Format(t.Value, prec)
+		} else {
+			ticks[i].Label = strconv.FormatFloat(t.Value, 'f', prec, 64)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ExampleAxis_unit labels the axes of a plot with their units,
+// scaling the tick labels of the time axis to milliseconds.
+func ExampleAxis_unit() {
+	p, err := New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Impulse response"
+	p.X.Label.Text = "Time"
+	p.X.Unit = Second
+	p.X.Min, p.X.Max = 0, 0.002
+	p.Y.Label.Text = "Level"
+	p.Y.Unit = Decibel
+	p.Y.Min, p.Y.Max = -60, 0
+
+	err = p.Save(200, 150, "testdata/axisUnit.png")
+	if err != nil {
+		panic(err)
+	}
+}
+
+func TestAxisUnitPlot(t *testing.T) {
+	cmpimg.CheckPlot(ExampleAxis_unit, t, "axisUnit.png")
+}
+
+func TestAxisUnit(t *testing.T) {
+	for _, test := range []struct {
+		unit       Unit
+		min, max   float64
+		label      string
+		wantLabel  string
+		wantLabels []string
+	}{
+		{
+			unit: Second, min: 0, max: 0.002,
+			label: "Time", wantLabel: "Time (ms)",
+			wantLabels: []string{"0", "1", "2"},
+		},
+		{
+			unit: Hertz, min: 0, max: 20000,
+			label: "Frequency", wantLabel: "Frequency (kHz)",
+			wantLabels: []string{"0", "10", "20"},
+		},
+		{
+			unit: Metre, min: -2.5e-6, max: 2.5e-6,
+			label: "Offset", wantLabel: "Offset (µm)",
+			wantLabels: []string{"-2.5", "0.0", "2.5"},
+		},
+		{
+			unit: Decibel, min: -60, max: 0,
+			label: "Level", wantLabel: "Level (dB)",
+			wantLabels: labelsOf(DefaultTicks{}.Ticks(-60, 0)),
+		},
+		{
+			unit: Second, min: 0, max: 0.002,
+			label: "", wantLabel: "",
+			wantLabels: []string{"0", "1", "2"},
+		},
+		{
+			min: 0, max: 0.002,
+			label: "Time", wantLabel: "Time",
+			wantLabels: labelsOf(DefaultTicks{}.Ticks(0, 0.002)),
+		},
+	} {
+		a, err := makeAxis(horizontal)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		a.Unit = test.unit
+		a.Min, a.Max = test.min, test.max
+		a.Label.Text = test.label
+		if got := a.labelText(); got != test.wantLabel {
+			t.Errorf("unexpected label for %s in [%v, %v]: got:%q want:%q", test.unit.Symbol, test.min, test.max, got, test.wantLabel)
+		}
+		if got := labelsOf(a.Ticks()); !reflect.DeepEqual(got, test.wantLabels) {
+			t.Errorf("unexpected tick labels for %s in [%v, %v]: got:%q want:%q", test.unit.Symbol, test.min, test.max, got, test.wantLabels)
+		}
+	}
+}
+
+func TestUnitConvert(t *testing.T) {
+	for _, test := range []struct {
+		v        float64
+		from, to Unit
+		want     float64
+	}{
+		{v: 90, from: Minute, to: Hour, want: 1.5},
+		{v: 2, from: Minute, to: Second, want: 120},
+		{v: 10, from: Inch, to: Metre, want: 0.254},
+	} {
+		got, err := test.from.Convert(test.v, test.to)
+		if err != nil {
+			t.Errorf("unexpected error converting %s to %s: %v", test.from.Symbol, test.to.Symbol, err)
+			continue
+		}
+		if d := got - test.want; d < -1e-12 || 1e-12 < d {
+			t.Errorf("unexpected conversion of %v %s to %s: got:%v want:%v", test.v, test.from.Symbol, test.to.Symbol, got, test.want)
+		}
+	}
+	if _, err := Second.Convert(1, Metre); err == nil {
+		t.Error("expected error converting between quantities")
+	}
+}
+
+// unitPlotter is a Plotter declaring the units of its data.
+type unitPlotter struct{ x, y Unit }
+
+func (unitPlotter) Plot(draw.Canvas, *Plot) {}
+
+func (u unitPlotter) Units() (x, y Unit) { return u.x, u.y }
+
+func TestDataUnits(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Y.Unit = Volt
+	p.Add(unitPlotter{x: Second, y: Watt})
+	p.Add(unitPlotter{x: Minute, y: Watt})
+	if p.X.Unit != Second {
+		t.Errorf("unexpected X unit: got:%+v want:%+v", p.X.Unit, Second)
+	}
+	if p.Y.Unit != Volt {
+		t.Errorf("unexpected Y unit: got:%+v want:%+v", p.Y.Unit, Volt)
+	}
+}
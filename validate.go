@@ -0,0 +1,80 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/plot/vg"
+)
+
+// Validator wraps the Validate method. Plotters that can
+// be misconfigured implement Validator so that Plot.Validate
+// reports their misconfiguration.
+type Validator interface {
+	// Validate returns an error describing the
+	// misconfiguration of the receiver, or nil if
+	// it can be drawn.
+	Validate() error
+}
+
+// Validate checks the plot for common misconfigurations that
+// would cause it to be drawn incorrectly or to fail, so that
+// they can be reported before the plot is rendered. The axes
+// are checked for NaN ranges and for log scales with ranges
+// that are not positive, and the plotters of the plot that
+// implement Validator are validated. The size of the canvas
+// is checked when the plot is saved or written.
+//
+// The problems found are returned as DrawErrors, one for each
+// part of the plot, and Validate returns nil if none are found.
+func (p *Plot) Validate() error {
+	var errs DrawErrors
+	if err := p.X.validate("X"); err != nil {
+		errs = append(errs, &DrawError{Part: "x", Err: err})
+	}
+	if err := p.Y.validate("Y"); err != nil {
+		errs = append(errs, &DrawError{Part: "y", Err: err})
+	}
+	for _, d := range p.drawOrder() {
+		v, ok := d.(Validator)
+		if !ok {
+			continue
+		}
+		if err := v.Validate(); err != nil {
+			errs = append(errs, &DrawError{Part: "plotter", Plotter: d, Err: err})
+		}
+	}
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// validate returns an error if the range of the named
+// axis, as it would be drawn, is invalid for its scale.
+func (a Axis) validate(name string) error {
+	if math.IsNaN(a.Min) || math.IsNaN(a.Max) {
+		return fmt.Errorf("plot: %s axis has a NaN range [%v, %v]; set %[1]s.Min and %[1]s.Max, or remove NaN values from the data", name, a.Min, a.Max)
+	}
+	a.sanitizeRange()
+	switch a.Scale.(type) {
+	case LogScale, *LogScale:
+		if a.Min <= 0 {
+			return fmt.Errorf("plot: %s axis has a log scale but its range [%v, %v] is not positive; set %[1]s.Min to a positive value, or remove non-positive values from the data", name, a.Min, a.Max)
+		}
+	}
+	return nil
+}
+
+// validSize returns an error if the canvas size
+// w×h cannot hold a plot.
+func validSize(w, h vg.Length) error {
+	if !(w > 0 && h > 0) {
+		return fmt.Errorf("plot: invalid canvas size %v×%v; the width and height must be positive", w, h)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot_test
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+func TestValidate(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s, err := plotter.NewScatter(plotter.XYs{{X: 1, Y: -1}, {X: 10, Y: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(s)
+	if err := p.Validate(); err != nil {
+		t.Errorf("unexpected error for valid plot: %v", err)
+	}
+
+	p.X.Scale = plot.LogScale{}
+	p.Y.Scale = plot.LogScale{}
+	p.X.Max = math.NaN()
+	bars, err := plotter.NewYErrorBars(struct {
+		plotter.XYs
+		plotter.YErrors
+	}{plotter.XYs{{X: 1, Y: 1}}, plotter.YErrors{{Low: 1, High: 1}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bars.YErrors = nil
+	p.Add(bars)
+
+	errs, ok := p.Validate().(plot.DrawErrors)
+	if !ok {
+		t.Fatalf("unexpected validation error: %v", p.Validate())
+	}
+	want := []struct {
+		part, msg string
+	}{
+		{part: "x", msg: "X axis has a NaN range"},
+		{part: "y", msg: "Y axis has a log scale but its range [-1, 1] is not positive"},
+		{part: "plotter", msg: "y error bars have 1 points but 0 errors"},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("unexpected number of errors: got:%d want:%d\n%v", len(errs), len(want), errs)
+	}
+	for i, w := range want {
+		if errs[i].Part != w.part || !strings.Contains(errs[i].Error(), w.msg) {
+			t.Errorf("unexpected error %d: got:%s %q want:%s %q", i, errs[i].Part, errs[i], w.part, w.msg)
+		}
+	}
+	if errs[2].Plotter != bars {
+		t.Errorf("unexpected failing plotter: %v", errs[2].Plotter)
+	}
+
+	err = p.Stream(new(bytes.Buffer), 0, 10*vg.Centimeter, "png")
+	if err == nil || !strings.Contains(err.Error(), "invalid canvas size") {
+		t.Errorf("unexpected error for zero size canvas: %v", err)
+	}
+}
@@ -2,14 +2,28 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Package draw provides types and functions to draw shapes on
+// a vg.Canvas, and canvases for the image formats supported by
+// the vg back-ends.
+//
+// NewFormattedWriter streams only the eps, svg and svgz formats
+// to its writer as the canvas is drawn. The pdf back-end writes
+// its document in one piece, and the raster formats must encode
+// the complete image, so the other formats are held in memory
+// until the canvas is closed.
 package draw // import "gonum.org/v1/plot/vg/draw"
 
 import (
+	"compress/gzip"
 	"fmt"
 	"image/color"
+	"image/png"
+	"io"
 	"math"
 	"strings"
 
+	"golang.org/x/image/tiff"
+
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/vgeps"
 	"gonum.org/v1/plot/vg/vgimg"
@@ -263,37 +277,246 @@ func New(c vg.CanvasSizer) Canvas {
 	return NewCanvas(c, w, h)
 }
 
+// FormatOption is an encoder option of the image formats
+// written by NewFormattedCanvas and NewFormattedWriter.
+// Options of a format are ignored when writing other
+// formats.
+type FormatOption func(*formatOptions)
+
+// formatOptions holds the encoder options of
+// the image formats.
+type formatOptions struct {
+	background    color.Color
+	hasBackground bool
+
+	scale float64
+
+	pngLevel    png.CompressionLevel
+	jpegQuality int
+	tiff        *tiff.Options
+}
+
+// Background specifies the background color of the canvas of
+// image formats, which is white by default. A nil or transparent
+// background gives png and tiff images a transparent background.
+// Jpeg images, which have no transparency, are composed over white.
+// The vector formats have no background of their own.
+func Background(clr color.Color) FormatOption {
+	return func(o *formatOptions) {
+		o.background = clr
+		o.hasBackground = true
+	}
+}
+
+// FontScale specifies that the text, line widths, glyphs and
+// spacing of drawings are scaled by the factor scale, as described
+// by ScaleCanvas, keeping the size of the image. A FontScale of 2
+// draws a plot designed for a 20cm wide figure in proportion on
+// a 10cm wide figure.
+func FontScale(scale float64) FormatOption {
+	if scale <= 0 {
+		panic("draw: scale must be > 0")
+	}
+	return func(o *formatOptions) { o.scale = scale }
+}
+
+// scaleWriterTo returns c scaled by the
+// scale option if it is set.
+func (o *formatOptions) scaleWriterTo(c vg.CanvasWriterTo) vg.CanvasWriterTo {
+	if o.scale == 0 || o.scale == 1 {
+		return c
+	}
+	return scaledWriterTo{scaledCanvas: &scaledCanvas{CanvasSizer: c, scale: o.scale}, WriterTo: c}
+}
+
+// scaleCloser returns c scaled by the
+// scale option if it is set.
+func (o *formatOptions) scaleCloser(c vg.CanvasCloser) vg.CanvasCloser {
+	if o.scale == 0 || o.scale == 1 {
+		return c
+	}
+	return scaledCloser{scaledCanvas: &scaledCanvas{CanvasSizer: c, scale: o.scale}, Closer: c}
+}
+
+// image returns a new image canvas of the
+// given size with the background option.
+func (o *formatOptions) image(w, h vg.Length, opaque bool) *vgimg.Canvas {
+	if !o.hasBackground {
+		return vgimg.New(w, h)
+	}
+	bg := o.background
+	if opaque {
+		bg = overWhite(bg)
+	}
+	return vgimg.NewWith(vgimg.UseWH(w, h), vgimg.UseBackgroundColor(bg))
+}
+
+// overWhite returns the color clr composed over white.
+func overWhite(clr color.Color) color.Color {
+	if clr == nil {
+		return color.White
+	}
+	r, g, b, a := clr.RGBA()
+	return color.RGBA64{
+		R: uint16(r + 0xffff - a),
+		G: uint16(g + 0xffff - a),
+		B: uint16(b + 0xffff - a),
+		A: 0xffff,
+	}
+}
+
+// PngCompression specifies the compression level of png images.
+// Png images have 8 bits per channel, the depth of the images
+// drawn by the raster back-end.
+func PngCompression(level png.CompressionLevel) FormatOption {
+	return func(o *formatOptions) { o.pngLevel = level }
+}
+
+// JpegQuality specifies the quality of jpeg images, from 1 to 100.
+func JpegQuality(quality int) FormatOption {
+	return func(o *formatOptions) { o.jpegQuality = quality }
+}
+
+// TiffOptions specifies the options of the tiff encoder, such
+// as compression of tiff images with Deflate. The encoder does
+// not support LZW compression.
+func TiffOptions(opts tiff.Options) FormatOption {
+	return func(o *formatOptions) { o.tiff = &opts }
+}
+
 // NewFormattedCanvas creates a new vg.CanvasWriterTo with the specified
-// image format.
+// image format, encoded with the given options.
 //
 // Supported formats are:
 //
-//  eps, jpg|jpeg, pdf, png, svg, and tif|tiff.
-func NewFormattedCanvas(w, h vg.Length, format string) (vg.CanvasWriterTo, error) {
+//  eps, jpg|jpeg, pdf, png, svg, svgz, and tif|tiff.
+func NewFormattedCanvas(w, h vg.Length, format string, opts ...FormatOption) (vg.CanvasWriterTo, error) {
+	var o formatOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	var c vg.CanvasWriterTo
 	switch format {
 	case "eps":
 		c = vgeps.New(w, h)
 
 	case "jpg", "jpeg":
-		c = vgimg.JpegCanvas{Canvas: vgimg.New(w, h)}
+		c = vgimg.JpegCanvas{Canvas: o.image(w, h, true), Quality: o.jpegQuality}
 
 	case "pdf":
 		c = vgpdf.New(w, h)
 
 	case "png":
-		c = vgimg.PngCanvas{Canvas: vgimg.New(w, h)}
+		c = vgimg.PngCanvas{Canvas: o.image(w, h, false), CompressionLevel: o.pngLevel}
 
 	case "svg":
 		c = vgsvg.New(w, h)
 
+	case "svgz":
+		c = svgzCanvas{Canvas: vgsvg.New(w, h)}
+
 	case "tif", "tiff":
-		c = vgimg.TiffCanvas{Canvas: vgimg.New(w, h)}
+		c = vgimg.TiffCanvas{Canvas: o.image(w, h, false), Options: o.tiff}
 
 	default:
 		return nil, fmt.Errorf("unsupported format: %q", format)
 	}
-	return c, nil
+	return o.scaleWriterTo(c), nil
+}
+
+// NewFormattedWriter creates a new vg.CanvasCloser with the specified
+// image format, encoded with the given options and writing to out.
+// Only the eps, svg and svgz formats are written to out as the canvas
+// is drawn, without holding the full document in memory. The pdf and
+// raster formats are drawn in memory and written to out when the
+// canvas is closed, so they use as much memory as NewFormattedCanvas.
+// Closing the canvas does not close out.
+//
+// Supported formats are those of NewFormattedCanvas.
+func NewFormattedWriter(out io.Writer, w, h vg.Length, format string, opts ...FormatOption) (vg.CanvasCloser, error) {
+	var o formatOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	switch format {
+	case "eps":
+		return o.scaleCloser(vgeps.NewWriter(out, w, h, "")), nil
+
+	case "svg":
+		return o.scaleCloser(vgsvg.NewWriter(out, w, h)), nil
+
+	case "svgz":
+		gz := gzip.NewWriter(out)
+		return o.scaleCloser(svgzWriter{Canvas: vgsvg.NewWriter(gz, w, h), gz: gz}), nil
+	}
+	c, err := NewFormattedCanvas(w, h, format, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return closeWriter{CanvasWriterTo: c, out: out}, nil
+}
+
+// svgzCanvas is an SVG canvas that is written
+// compressed with gzip.
+type svgzCanvas struct {
+	*vgsvg.Canvas
+}
+
+// WriteTo implements the io.WriterTo interface, writing
+// the gzip compressed SVG document to w.
+func (c svgzCanvas) WriteTo(w io.Writer) (int64, error) {
+	wc := &writerCounter{Writer: w}
+	gz := gzip.NewWriter(wc)
+	_, err := c.Canvas.WriteTo(gz)
+	if err != nil {
+		return wc.n, err
+	}
+	err = gz.Close()
+	return wc.n, err
+}
+
+// svgzWriter is an SVG canvas that writes its
+// document compressed with gzip as it is drawn.
+type svgzWriter struct {
+	*vgsvg.Canvas
+	gz *gzip.Writer
+}
+
+// Close implements the io.Closer interface.
+func (c svgzWriter) Close() error {
+	err := c.Canvas.Close()
+	if e := c.gz.Close(); err == nil {
+		err = e
+	}
+	return err
+}
+
+// closeWriter is a canvas that writes its
+// output to out when it is closed.
+type closeWriter struct {
+	vg.CanvasWriterTo
+	out io.Writer
+}
+
+// Close implements the io.Closer interface.
+func (c closeWriter) Close() error {
+	_, err := c.WriteTo(c.out)
+	return err
+}
+
+// writerCounter is an io.Writer counting the
+// total number of bytes written.
+type writerCounter struct {
+	io.Writer
+	n int64
+}
+
+func (w *writerCounter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.n += int64(n)
+	return n, err
 }
 
 // NewCanvas returns a new (bounded) draw.Canvas of the given size.
@@ -370,6 +593,52 @@ func Crop(c Canvas, left, right, bottom, top vg.Length) Canvas {
 	}
 }
 
+// WithOuter returns the Canvas c recording outer as the
+// drawing area surrounding it, within which DrawUnclipped
+// draws. For example, the data area of a plot that is
+// passed to its Plotters records the whole of the plot,
+// including the margins holding the axes.
+func WithOuter(c Canvas, outer vg.Rectangle) Canvas {
+	c.Canvas = outerCanvas{Canvas: c.Canvas, outer: outer}
+	return c
+}
+
+// outerCanvas is a vg.Canvas recording the outer drawing
+// area of the Canvases that wrap it.
+type outerCanvas struct {
+	vg.Canvas
+	outer vg.Rectangle
+}
+
+// SetFillRule sets the fill rule of the wrapped vg.Canvas.
+func (c outerCanvas) SetFillRule(r vg.FillRule) {
+	Canvas{Canvas: c.Canvas}.SetFillRule(r)
+}
+
+// DrawUnclipped calls fn with a Canvas covering the outer
+// drawing area recorded for c, or for a Canvas from which
+// c was cropped, by WithOuter. Plotters normally confine
+// their drawing to the Canvas passed to them; DrawUnclipped
+// allows them to draw beyond it deliberately, for example
+// to place labels or marginal plots outside the data area
+// of a plot. If no outer area has been recorded, fn is
+// called with c.
+func (c *Canvas) DrawUnclipped(fn func(Canvas)) {
+	vc := c.Canvas
+	for {
+		switch t := vc.(type) {
+		case outerCanvas:
+			fn(Canvas{Canvas: c.Canvas, Rectangle: t.outer})
+			return
+		case Canvas:
+			vc = t.Canvas
+		default:
+			fn(*c)
+			return
+		}
+	}
+}
+
 // Tiles creates regular subcanvases from a Canvas.
 type Tiles struct {
 	// Cols and Rows specify the number of rows and columns of tiles.
@@ -404,6 +673,17 @@ func (ts Tiles) At(c Canvas, x, y int) Canvas {
 	}
 }
 
+// SetFillRule sets the fill rule of the underlying
+// vg.Canvas if it implements vg.FillRuleSetter, and
+// otherwise does nothing. SetFillRule has a value
+// receiver so that a Canvas wrapping another Canvas,
+// as returned by Crop, is itself a vg.FillRuleSetter.
+func (c Canvas) SetFillRule(r vg.FillRule) {
+	if fr, ok := c.Canvas.(vg.FillRuleSetter); ok {
+		fr.SetFillRule(r)
+	}
+}
+
 // SetLineStyle sets the current line style
 func (c *Canvas) SetLineStyle(sty LineStyle) {
 	c.SetColor(sty.Color)
@@ -0,0 +1,43 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"image/color"
+	"math"
+)
+
+// ContrastColor returns black or white, whichever has the greater
+// contrast with the background color bg, for drawing text that is
+// readable on backgrounds of any color. A nil bg is treated as white.
+func ContrastColor(bg color.Color) color.Color {
+	if bg == nil {
+		return color.Black
+	}
+	// The contrast ratios with black and white are
+	// equal at a luminance of about 0.179.
+	if RelativeLuminance(bg) > 0.179 {
+		return color.Black
+	}
+	return color.White
+}
+
+// RelativeLuminance returns the relative luminance of c as defined
+// by WCAG 2.0, from zero for black to one for white. The color is
+// treated as opaque.
+func RelativeLuminance(c color.Color) float64 {
+	r, g, b, a := c.RGBA()
+	if a == 0 {
+		return 0
+	}
+	lin := func(v uint32) float64 {
+		f := float64(v) / float64(a)
+		if f <= 0.03928 {
+			return f / 12.92
+		}
+		return math.Pow((f+0.055)/1.055, 2.4)
+	}
+	return 0.2126*lin(r) + 0.7152*lin(g) + 0.0722*lin(b)
+}
@@ -0,0 +1,47 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestContrastColor(t *testing.T) {
+	for _, test := range []struct {
+		c    color.Color
+		want color.Color
+	}{
+		{c: color.White, want: color.Black},
+		{c: color.Black, want: color.White},
+		{c: color.RGBA{R: 255, G: 255, A: 255}, want: color.Black},
+		{c: color.RGBA{B: 160, A: 255}, want: color.White},
+		{c: color.NRGBA{R: 255, G: 255, B: 255, A: 64}, want: color.Black},
+		{c: color.NRGBA{B: 160, A: 64}, want: color.White},
+		{c: nil, want: color.Black},
+	} {
+		if got := ContrastColor(test.c); got != test.want {
+			t.Errorf("unexpected contrast color for %v: got:%v want:%v", test.c, got, test.want)
+		}
+	}
+}
+
+func TestRelativeLuminance(t *testing.T) {
+	for _, test := range []struct {
+		c    color.Color
+		want float64
+	}{
+		{c: color.Black, want: 0},
+		{c: color.White, want: 1},
+		{c: color.Transparent, want: 0},
+		{c: color.RGBA{G: 255, A: 255}, want: 0.7152},
+		{c: color.Gray{Y: 128}, want: 0.2158605},
+	} {
+		if got := RelativeLuminance(test.c); math.Abs(got-test.want) > 1e-6 {
+			t.Errorf("unexpected luminance of %v: got:%v want:%v", test.c, got, test.want)
+		}
+	}
+}
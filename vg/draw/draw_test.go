@@ -1,6 +1,7 @@
 package draw
 
 import (
+	"bytes"
 	"image/color"
 	"reflect"
 	"testing"
@@ -111,3 +112,75 @@ func TestTile(t *testing.T) {
 		}
 	}
 }
+
+func TestDrawUnclipped(t *testing.T) {
+	var r recorder.Canvas
+	full := NewCanvas(&r, 10, 10)
+	inner := Crop(full, 2, -2, 2, -2)
+
+	var got vg.Rectangle
+	inner.DrawUnclipped(func(c Canvas) { got = c.Rectangle })
+	if got != inner.Rectangle {
+		t.Errorf("unexpected unclipped area without outer area: got:%+v want:%+v", got, inner.Rectangle)
+	}
+
+	outer := WithOuter(inner, full.Rectangle)
+	cropped := Crop(outer, 1, -1, 1, -1)
+	for _, c := range []Canvas{outer, cropped} {
+		c.DrawUnclipped(func(c Canvas) {
+			got = c.Rectangle
+			c.StrokeLine2(LineStyle{Color: color.Black, Width: 1}, c.Min.X, c.Min.Y, c.Max.X, c.Max.Y)
+		})
+		if got != full.Rectangle {
+			t.Errorf("unexpected unclipped area: got:%+v want:%+v", got, full.Rectangle)
+		}
+	}
+	if len(r.Actions) == 0 {
+		t.Error("no actions recorded for unclipped drawing")
+	}
+
+	outer.SetFillRule(vg.EvenOdd)
+	if _, ok := r.Actions[len(r.Actions)-1].(*recorder.SetFillRule); !ok {
+		t.Errorf("fill rule not set through outer canvas: got:%T", r.Actions[len(r.Actions)-1])
+	}
+}
+
+func TestFormattedWriterStreams(t *testing.T) {
+	for _, test := range []struct {
+		format string
+		stream bool
+	}{
+		{format: "eps", stream: true},
+		{format: "svg", stream: true},
+		{format: "svgz", stream: true},
+		{format: "pdf", stream: false},
+		{format: "png", stream: false},
+		{format: "jpg", stream: false},
+		{format: "tiff", stream: false},
+	} {
+		var buf bytes.Buffer
+		c, err := NewFormattedWriter(&buf, 5*vg.Centimeter, 5*vg.Centimeter, test.format)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", test.format, err)
+		}
+		// Draw enough lines to fill the buffers of the
+		// streaming writers.
+		const n = 5000
+		dc := New(c)
+		for i := 0; i < n; i++ {
+			x := vg.Length(i) / n * dc.Max.X
+			dc.StrokeLine2(LineStyle{Color: color.Black, Width: 1}, x, 0, x, dc.Max.Y)
+		}
+		// Only the formats that stream are written before
+		// the canvas is closed.
+		if streamed := buf.Len() != 0; streamed != test.stream {
+			t.Errorf("unexpected streaming of %s: got:%t want:%t", test.format, streamed, test.stream)
+		}
+		if err := c.Close(); err != nil {
+			t.Fatalf("unexpected error closing %s: %v", test.format, err)
+		}
+		if buf.Len() == 0 {
+			t.Errorf("no output written for %s", test.format)
+		}
+	}
+}
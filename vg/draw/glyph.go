@@ -0,0 +1,264 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"gonum.org/v1/plot/vg"
+)
+
+// DiamondGlyph is a glyph that draws a square standing
+// on one corner, with its corners at the radius of the
+// glyph.
+type DiamondGlyph struct {
+	// Filled specifies that the diamond is
+	// filled rather than outlined.
+	Filled bool
+}
+
+// DrawGlyph implements the GlyphDrawer interface.
+func (g DiamondGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
+	drawPolygonGlyph(c, sty, regularPolygon(pt, sty.Radius, 4, math.Pi/2), g.Filled)
+}
+
+// HexagonGlyph is a glyph that draws a regular hexagon
+// with flat top and bottom sides and its corners at the
+// radius of the glyph.
+type HexagonGlyph struct {
+	// Filled specifies that the hexagon is
+	// filled rather than outlined.
+	Filled bool
+}
+
+// DrawGlyph implements the GlyphDrawer interface.
+func (g HexagonGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
+	drawPolygonGlyph(c, sty, regularPolygon(pt, sty.Radius, 6, 0), g.Filled)
+}
+
+// StarGlyph is a glyph that draws an upright five
+// pointed star with its points at the radius of
+// the glyph.
+type StarGlyph struct {
+	// Filled specifies that the star is
+	// filled rather than outlined.
+	Filled bool
+}
+
+// DrawGlyph implements the GlyphDrawer interface.
+func (g StarGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
+	// inner is the ratio of the inner and outer radii
+	// of a regular star, sin(π/10)/sin(3π/10).
+	const inner = 0.381966011250105
+	outer := regularPolygon(pt, sty.Radius, 5, math.Pi/2)
+	in := regularPolygon(pt, sty.Radius*inner, 5, math.Pi/2+math.Pi/5)
+	pts := make([]vg.Point, 0, 10)
+	for i := range outer {
+		pts = append(pts, outer[i], in[i])
+	}
+	drawPolygonGlyph(c, sty, pts, g.Filled)
+}
+
+// HalfFilledGlyph is a glyph that draws the outline of a
+// circle, square, diamond or hexagon with one half filled.
+type HalfFilledGlyph struct {
+	// Shape is the shape of the glyph. It must be
+	// a RingGlyph, CircleGlyph, SquareGlyph, BoxGlyph,
+	// DiamondGlyph or HexagonGlyph, otherwise the
+	// glyph is drawn as a circle.
+	Shape GlyphDrawer
+
+	// Angle is the direction from the center of the
+	// glyph of the filled half, in radians counter-
+	// clockwise from the positive x direction. The
+	// zero value fills the right half.
+	Angle float64
+}
+
+// DrawGlyph implements the GlyphDrawer interface.
+func (g HalfFilledGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
+	var outline []vg.Point
+	switch g.Shape.(type) {
+	case SquareGlyph, BoxGlyph:
+		// Match the size of SquareGlyph.
+		x := (sty.Radius-sty.Radius*cosπover4)/2 + sty.Radius*cosπover4
+		outline = regularPolygon(pt, x/cosπover4, 4, math.Pi/4)
+	case DiamondGlyph:
+		outline = regularPolygon(pt, sty.Radius, 4, math.Pi/2)
+	case HexagonGlyph:
+		outline = regularPolygon(pt, sty.Radius, 6, 0)
+	default:
+		outline = regularPolygon(pt, sty.Radius, 72, 0)
+	}
+	dir := vg.Point{X: vg.Length(math.Cos(g.Angle)), Y: vg.Length(math.Sin(g.Angle))}
+	if half := clipHalfPlane(outline, pt, dir); len(half) > 2 {
+		c.FillPolygon(sty.Color, half)
+	}
+	drawPolygonGlyph(c, sty, outline, false)
+}
+
+// ArrowGlyph is a glyph that draws an arrow through
+// its point with a filled head at the radius of the
+// glyph.
+type ArrowGlyph struct {
+	// Angle is the direction of the arrow, in radians
+	// counter-clockwise from the positive x direction.
+	// The zero value points right.
+	Angle float64
+}
+
+// DrawGlyph implements the GlyphDrawer interface.
+func (g ArrowGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
+	r := sty.Radius
+	cos, sin := vg.Length(math.Cos(g.Angle)), vg.Length(math.Sin(g.Angle))
+	// at returns the point at x along and y across
+	// the arrow from its center.
+	at := func(x, y vg.Length) vg.Point {
+		return vg.Point{X: pt.X + x*cos - y*sin, Y: pt.Y + x*sin + y*cos}
+	}
+	c.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(0.5)})
+	var p vg.Path
+	p.Move(at(-r, 0))
+	p.Line(at(0, 0))
+	c.Stroke(p)
+	c.FillPolygon(sty.Color, []vg.Point{at(r, 0), at(0, -r/2), at(0, r/2)})
+}
+
+// regularPolygon returns the n corners of the regular polygon
+// centered on pt with its corners at radius r, the first at
+// angle rot.
+func regularPolygon(pt vg.Point, r vg.Length, n int, rot float64) []vg.Point {
+	pts := make([]vg.Point, n)
+	for i := range pts {
+		a := rot + 2*math.Pi*float64(i)/float64(n)
+		pts[i] = vg.Point{X: pt.X + r*vg.Length(math.Cos(a)), Y: pt.Y + r*vg.Length(math.Sin(a))}
+	}
+	return pts
+}
+
+// drawPolygonGlyph fills the polygon pts with the color of
+// the glyph style, or strokes its outline if filled is false.
+func drawPolygonGlyph(c *Canvas, sty GlyphStyle, pts []vg.Point, filled bool) {
+	var p vg.Path
+	p.Move(pts[0])
+	for _, pt := range pts[1:] {
+		p.Line(pt)
+	}
+	p.Close()
+	if filled {
+		c.Fill(p)
+		return
+	}
+	c.SetLineStyle(LineStyle{Color: sty.Color, Width: vg.Points(0.5)})
+	c.Stroke(p)
+}
+
+// clipHalfPlane returns the part of the convex polygon pts
+// on the side of the line through o in the direction dir.
+func clipHalfPlane(pts []vg.Point, o, dir vg.Point) []vg.Point {
+	side := func(p vg.Point) vg.Length { return (p.X-o.X)*dir.X + (p.Y-o.Y)*dir.Y }
+	var clipped []vg.Point
+	for i, a := range pts {
+		b := pts[(i+1)%len(pts)]
+		sa, sb := side(a), side(b)
+		if sa >= 0 {
+			clipped = append(clipped, a)
+		}
+		if (sa < 0) != (sb < 0) {
+			t := sa / (sa - sb)
+			clipped = append(clipped, vg.Point{X: a.X + t*(b.X-a.X), Y: a.Y + t*(b.Y-a.Y)})
+		}
+	}
+	return clipped
+}
+
+// glyphs holds the registered GlyphDrawers,
+// keyed by their lower case names.
+var glyphs = struct {
+	sync.RWMutex
+	byName map[string]namedGlyph
+}{byName: make(map[string]namedGlyph)}
+
+type namedGlyph struct {
+	name string
+	GlyphDrawer
+}
+
+func init() {
+	for _, g := range []struct {
+		name  string
+		glyph GlyphDrawer
+	}{
+		{"circle", CircleGlyph{}},
+		{"ring", RingGlyph{}},
+		{"square", SquareGlyph{}},
+		{"box", BoxGlyph{}},
+		{"triangle", TriangleGlyph{}},
+		{"pyramid", PyramidGlyph{}},
+		{"plus", PlusGlyph{}},
+		{"cross", CrossGlyph{}},
+		{"diamond", DiamondGlyph{}},
+		{"filled-diamond", DiamondGlyph{Filled: true}},
+		{"hexagon", HexagonGlyph{}},
+		{"filled-hexagon", HexagonGlyph{Filled: true}},
+		{"star", StarGlyph{}},
+		{"filled-star", StarGlyph{Filled: true}},
+		{"half-circle", HalfFilledGlyph{Shape: RingGlyph{}, Angle: math.Pi / 2}},
+		{"half-square", HalfFilledGlyph{Shape: SquareGlyph{}, Angle: math.Pi / 2}},
+		{"half-diamond", HalfFilledGlyph{Shape: DiamondGlyph{}, Angle: math.Pi / 2}},
+		{"half-hexagon", HalfFilledGlyph{Shape: HexagonGlyph{}, Angle: math.Pi / 2}},
+		{"arrow-right", ArrowGlyph{}},
+		{"arrow-up", ArrowGlyph{Angle: math.Pi / 2}},
+		{"arrow-left", ArrowGlyph{Angle: math.Pi}},
+		{"arrow-down", ArrowGlyph{Angle: -math.Pi / 2}},
+	} {
+		RegisterGlyph(g.name, g.glyph)
+	}
+}
+
+// RegisterGlyph registers a GlyphDrawer so that it may be referenced
+// by name, for example in configuration files. Names are not case
+// sensitive. RegisterGlyph panics if the name is already registered.
+//
+// The glyphs of this package are registered with lower case names,
+// such as "ring", "filled-star", "half-circle" and "arrow-up"; half
+// filled glyphs have their upper halves filled.
+func RegisterGlyph(name string, g GlyphDrawer) {
+	key := strings.ToLower(name)
+	glyphs.Lock()
+	defer glyphs.Unlock()
+	if _, exists := glyphs.byName[key]; exists {
+		panic(fmt.Sprintf("draw: glyph %q already registered", name))
+	}
+	glyphs.byName[key] = namedGlyph{name: name, GlyphDrawer: g}
+}
+
+// LookupGlyph returns the GlyphDrawer registered with the given
+// name. An error is returned if the name is not registered.
+func LookupGlyph(name string) (GlyphDrawer, error) {
+	glyphs.RLock()
+	g, ok := glyphs.byName[strings.ToLower(name)]
+	glyphs.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("draw: unknown glyph %q", name)
+	}
+	return g.GlyphDrawer, nil
+}
+
+// GlyphNames returns the sorted names of the registered glyphs.
+func GlyphNames() []string {
+	glyphs.RLock()
+	defer glyphs.RUnlock()
+	names := make([]string, 0, len(glyphs.byName))
+	for _, g := range glyphs.byName {
+		names = append(names, g.name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,110 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"image/color"
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+func TestGlyphRegistry(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		want GlyphDrawer
+	}{
+		{name: "ring", want: RingGlyph{}},
+		{name: "Filled-Diamond", want: DiamondGlyph{Filled: true}},
+		{name: "arrow-up", want: ArrowGlyph{Angle: math.Pi / 2}},
+	} {
+		got, err := LookupGlyph(test.name)
+		if err != nil {
+			t.Errorf("unexpected error looking up %q: %v", test.name, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("unexpected glyph for %q: got:%#v want:%#v", test.name, got, test.want)
+		}
+	}
+	_, err := LookupGlyph("blob")
+	if err == nil {
+		t.Error("expected error for unknown glyph")
+	}
+
+	RegisterGlyph("Test-Blob", CircleGlyph{})
+	if _, err := LookupGlyph("test-blob"); err != nil {
+		t.Errorf("unexpected error looking up registered glyph: %v", err)
+	}
+	names := GlyphNames()
+	i := len(names) - 1
+	for ; i >= 0 && names[i] != "Test-Blob"; i-- {
+	}
+	if i < 0 {
+		t.Errorf("registered glyph missing from names: %q", names)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic registering duplicate name")
+			}
+		}()
+		RegisterGlyph("RING", RingGlyph{})
+	}()
+}
+
+func TestGlyphBounds(t *testing.T) {
+	// The glyphs lie within the glyph rectangle.
+	sty := GlyphStyle{Color: color.Black, Radius: 10}
+	for _, name := range []string{
+		"diamond", "filled-diamond", "hexagon", "filled-hexagon", "star", "filled-star",
+		"half-circle", "half-square", "half-diamond", "half-hexagon",
+		"arrow-right", "arrow-up", "arrow-left", "arrow-down",
+	} {
+		g, err := LookupGlyph(name)
+		if err != nil {
+			t.Fatalf("unexpected error looking up %q: %v", name, err)
+		}
+		var rec recorder.Canvas
+		c := NewCanvas(&rec, 100, 100)
+		sty.Shape = g
+		c.DrawGlyph(sty, vg.Point{X: 50, Y: 50})
+		if len(rec.Actions) == 0 {
+			t.Errorf("no drawing for %q", name)
+		}
+		for _, a := range rec.Actions {
+			var p vg.Path
+			switch a := a.(type) {
+			case *recorder.Fill:
+				p = a.Path
+			case *recorder.Stroke:
+				p = a.Path
+			default:
+				continue
+			}
+			for _, comp := range p {
+				if comp.Type == vg.ArcComp || comp.Type == vg.CloseComp {
+					continue
+				}
+				if math.Abs(float64(comp.Pos.X-50)) > 10+1e-9 || math.Abs(float64(comp.Pos.Y-50)) > 10+1e-9 {
+					t.Errorf("point of %q out of bounds: %v", name, comp.Pos)
+				}
+			}
+		}
+	}
+}
+
+func TestClipHalfPlane(t *testing.T) {
+	square := []vg.Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}}
+	got := clipHalfPlane(square, vg.Point{X: 1, Y: 1}, vg.Point{Y: 1})
+	want := []vg.Point{{X: 2, Y: 1}, {X: 2, Y: 2}, {X: 0, Y: 2}, {X: 0, Y: 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected clipped polygon: got:%v want:%v", got, want)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"image"
+	"io"
+
+	"gonum.org/v1/plot/vg"
+)
+
+// ScaleCanvas returns a canvas drawing to c with all lengths,
+// including the sizes of fonts, the widths of lines and the
+// sizes of glyphs, scaled by the factor scale. The size of the
+// returned canvas is the size of c divided by scale, so that a
+// drawing filling the returned canvas fills c. This allows a
+// plot designed for one physical size to be drawn at another
+// with its text and lines in proportion.
+func ScaleCanvas(c vg.CanvasSizer, scale float64) vg.CanvasSizer {
+	if scale <= 0 {
+		panic("draw: scale must be > 0")
+	}
+	return &scaledCanvas{CanvasSizer: c, scale: scale}
+}
+
+// scaledCanvas is a canvas scaling all lengths drawn
+// to its underlying canvas by a factor.
+type scaledCanvas struct {
+	vg.CanvasSizer
+	scale float64
+}
+
+func (c *scaledCanvas) length(l vg.Length) vg.Length { return l * vg.Length(c.scale) }
+
+func (c *scaledCanvas) point(pt vg.Point) vg.Point { return pt.Scale(vg.Length(c.scale)) }
+
+func (c *scaledCanvas) path(p vg.Path) vg.Path {
+	scaled := make(vg.Path, len(p))
+	for i, comp := range p {
+		comp.Pos = c.point(comp.Pos)
+		comp.Radius = c.length(comp.Radius)
+		scaled[i] = comp
+	}
+	return scaled
+}
+
+func (c *scaledCanvas) SetLineWidth(w vg.Length) { c.CanvasSizer.SetLineWidth(c.length(w)) }
+
+func (c *scaledCanvas) SetLineDash(pattern []vg.Length, offset vg.Length) {
+	scaled := make([]vg.Length, len(pattern))
+	for i, d := range pattern {
+		scaled[i] = c.length(d)
+	}
+	c.CanvasSizer.SetLineDash(scaled, c.length(offset))
+}
+
+// SetFillRule implements the vg.FillRuleSetter interface.
+// The fill rule is ignored if the underlying canvas is not
+// a vg.FillRuleSetter.
+func (c *scaledCanvas) SetFillRule(r vg.FillRule) {
+	if fr, ok := c.CanvasSizer.(vg.FillRuleSetter); ok {
+		fr.SetFillRule(r)
+	}
+}
+
+func (c *scaledCanvas) Translate(pt vg.Point) { c.CanvasSizer.Translate(c.point(pt)) }
+
+func (c *scaledCanvas) Stroke(p vg.Path) { c.CanvasSizer.Stroke(c.path(p)) }
+
+func (c *scaledCanvas) Fill(p vg.Path) { c.CanvasSizer.Fill(c.path(p)) }
+
+func (c *scaledCanvas) FillString(f vg.Font, pt vg.Point, text string) {
+	f.Size = c.length(f.Size)
+	c.CanvasSizer.FillString(f, c.point(pt), text)
+}
+
+func (c *scaledCanvas) DrawImage(rect vg.Rectangle, img image.Image) {
+	rect.Min = c.point(rect.Min)
+	rect.Max = c.point(rect.Max)
+	c.CanvasSizer.DrawImage(rect, img)
+}
+
+// BeginGroup implements the vg.Grouper interface. The group
+// is ignored if the underlying canvas is not a vg.Grouper.
+func (c *scaledCanvas) BeginGroup(g vg.Group) {
+	if gr, ok := c.CanvasSizer.(vg.Grouper); ok {
+		gr.BeginGroup(g)
+	}
+}
+
+// EndGroup implements the vg.Grouper interface.
+func (c *scaledCanvas) EndGroup() {
+	if gr, ok := c.CanvasSizer.(vg.Grouper); ok {
+		gr.EndGroup()
+	}
+}
+
+func (c *scaledCanvas) Size() (w, h vg.Length) {
+	w, h = c.CanvasSizer.Size()
+	return w / vg.Length(c.scale), h / vg.Length(c.scale)
+}
+
+// scaledWriterTo is a scaled canvas
+// written by its underlying canvas.
+type scaledWriterTo struct {
+	*scaledCanvas
+	io.WriterTo
+}
+
+// scaledCloser is a scaled canvas
+// closed by its underlying canvas.
+type scaledCloser struct {
+	*scaledCanvas
+	io.Closer
+}
@@ -0,0 +1,57 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+// sizedRecorder is a recorder.Canvas with a size.
+type sizedRecorder struct {
+	*recorder.Canvas
+	w, h vg.Length
+}
+
+func (c sizedRecorder) Size() (w, h vg.Length) { return c.w, c.h }
+
+func TestScaleCanvas(t *testing.T) {
+	rec := new(recorder.Canvas)
+	c := ScaleCanvas(sizedRecorder{Canvas: rec, w: 100, h: 50}, 2)
+
+	if w, h := c.Size(); w != 50 || h != 25 {
+		t.Errorf("unexpected size: got:%vx%v want:50x25", w, h)
+	}
+
+	fnt, err := vg.MakeFont("Helvetica", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.SetLineWidth(1)
+	c.SetLineDash([]vg.Length{2, 3}, 1)
+	c.Translate(vg.Point{X: 5, Y: 6})
+	var p vg.Path
+	p.Move(vg.Point{X: 1, Y: 2})
+	p.Arc(vg.Point{X: 3, Y: 4}, 5, 0, 1)
+	c.Stroke(p)
+	c.FillString(fnt, vg.Point{X: 7, Y: 8}, "text")
+
+	var want vg.Path
+	want.Move(vg.Point{X: 2, Y: 4})
+	want.Arc(vg.Point{X: 6, Y: 8}, 10, 0, 1)
+	wantActions := []recorder.Action{
+		&recorder.SetLineWidth{Width: 2},
+		&recorder.SetLineDash{Dashes: []vg.Length{4, 6}, Offsets: 2},
+		&recorder.Translate{Point: vg.Point{X: 10, Y: 12}},
+		&recorder.Stroke{Path: want},
+		&recorder.FillString{Font: "Helvetica", Size: 20, Point: vg.Point{X: 14, Y: 16}, String: "text"},
+	}
+	if !reflect.DeepEqual(rec.Actions, wantActions) {
+		t.Errorf("unexpected scaled actions:\ngot: %#v\nwant:%#v", rec.Actions, wantActions)
+	}
+}
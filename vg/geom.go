@@ -4,6 +4,8 @@
 
 package vg
 
+import "math"
+
 // A Point is a location in 2d space.
 //
 // Points are used for drawing, not for data.  For
@@ -56,3 +58,37 @@ func (r Rectangle) Path() (p Path) {
 	p.Close()
 	return
 }
+
+// RoundedRectangle returns the path of the rectangle r with
+// corners rounded by circular arcs of the given radius. The
+// radius is limited to half the smaller side of r, so a
+// radius of at least that gives a capsule. If the radius is
+// not positive the corners are square.
+func RoundedRectangle(r Rectangle, radius Length) (p Path) {
+	size := r.Size()
+	w, h := size.X, size.Y
+	if w < 0 {
+		w = -w
+		r.Min.X, r.Max.X = r.Max.X, r.Min.X
+	}
+	if h < 0 {
+		h = -h
+		r.Min.Y, r.Max.Y = r.Max.Y, r.Min.Y
+	}
+	if radius > w/2 {
+		radius = w / 2
+	}
+	if radius > h/2 {
+		radius = h / 2
+	}
+	if radius <= 0 {
+		return r.Path()
+	}
+	p.Move(Point{X: r.Min.X + radius, Y: r.Min.Y})
+	p.Arc(Point{X: r.Max.X - radius, Y: r.Min.Y + radius}, radius, -math.Pi/2, math.Pi/2)
+	p.Arc(Point{X: r.Max.X - radius, Y: r.Max.Y - radius}, radius, 0, math.Pi/2)
+	p.Arc(Point{X: r.Min.X + radius, Y: r.Max.Y - radius}, radius, math.Pi/2, math.Pi/2)
+	p.Arc(Point{X: r.Min.X + radius, Y: r.Min.Y + radius}, radius, math.Pi, math.Pi/2)
+	p.Close()
+	return
+}
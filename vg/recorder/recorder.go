@@ -138,6 +138,35 @@ func (a *SetLineWidth) callerLocation() *callerLocation {
 	return &a.l
 }
 
+// SetFillRule corresponds to the vg.FillRuleSetter.SetFillRule method.
+type SetFillRule struct {
+	Rule vg.FillRule
+
+	l callerLocation
+}
+
+// SetFillRule implements the SetFillRule method of the vg.FillRuleSetter interface.
+func (c *Canvas) SetFillRule(r vg.FillRule) {
+	c.append(&SetFillRule{Rule: r})
+}
+
+// Call returns the method call that generated the action.
+func (a *SetFillRule) Call() string {
+	return fmt.Sprintf("%sSetFillRule(%v)", a.l, a.Rule)
+}
+
+// ApplyTo applies the action to the given vg.Canvas if it
+// is a vg.FillRuleSetter.
+func (a *SetFillRule) ApplyTo(c vg.Canvas) {
+	if c, ok := c.(vg.FillRuleSetter); ok {
+		c.SetFillRule(a.Rule)
+	}
+}
+
+func (a *SetFillRule) callerLocation() *callerLocation {
+	return &a.l
+}
+
 // SetLineDash corresponds to the vg.Canvas.SetLineDash method.
 type SetLineDash struct {
 	Dashes  []vg.Length
@@ -0,0 +1,48 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vg
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+var (
+	// Deterministic specifies whether the vg back-ends
+	// write byte-identical output for identical drawings,
+	// so that rendered plots may be used in reproducible
+	// builds and snapshot tests. If Deterministic is true,
+	// the creation times recorded in documents, and the
+	// names derived from them, are taken from CreationTime
+	// rather than from the current time.
+	//
+	// Deterministic is initially true if the environment
+	// variable SOURCE_DATE_EPOCH holds a Unix time, which
+	// is then used as the CreationTime.
+	Deterministic bool
+
+	// CreationTime is the time recorded in documents when
+	// Deterministic is true.
+	CreationTime = time.Unix(0, 0).UTC()
+)
+
+func init() {
+	epoch, err := strconv.ParseInt(os.Getenv("SOURCE_DATE_EPOCH"), 10, 64)
+	if err == nil {
+		Deterministic = true
+		CreationTime = time.Unix(epoch, 0).UTC()
+	}
+}
+
+// Now returns the time to record as the creation time of
+// a document: CreationTime if Deterministic is true and
+// otherwise the current time.
+func Now() time.Time {
+	if Deterministic {
+		return CreationTime
+	}
+	return time.Now()
+}
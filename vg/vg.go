@@ -93,6 +93,75 @@ type CanvasWriterTo interface {
 	io.WriterTo
 }
 
+// Group holds the metadata of a group of drawing
+// operations, such as the accessible name and
+// description of a part of a plot.
+type Group struct {
+	// Role is the role of the group, such as
+	// "graphics-document" or "graphics-object".
+	Role string
+
+	// Title is the short name of the group.
+	Title string
+
+	// Description is the longer description
+	// of the group.
+	Description string
+}
+
+// Grouper is a Canvas that can mark groups of drawing
+// operations with metadata, for example to make the
+// parts of an SVG document accessible.
+type Grouper interface {
+	Canvas
+
+	// BeginGroup starts a group of drawing operations
+	// with the given metadata. Groups may be nested.
+	// A group must be ended by EndGroup before the
+	// Pop of any Push made before it was begun.
+	BeginGroup(Group)
+
+	// EndGroup ends the most recently begun group.
+	EndGroup()
+}
+
+// CanvasCloser is a CanvasSizer that writes its output
+// as it is drawn, completing the output when it is closed.
+type CanvasCloser interface {
+	CanvasSizer
+	io.Closer
+}
+
+// FillRule specifies how the interior of a filled path
+// is determined where the path overlaps itself.
+type FillRule int
+
+const (
+	// DefaultFillRule is the initial fill rule of a
+	// canvas, which depends on the backend. The vgimg
+	// backend uses the EvenOdd rule by default and the
+	// other built-in backends use the NonZero rule.
+	DefaultFillRule FillRule = iota
+
+	// NonZero fills the points around which the path
+	// winds a non-zero number of times.
+	NonZero
+
+	// EvenOdd fills the points from which a ray crosses
+	// the path an odd number of times, so that nested
+	// sub-paths alternate between filled and unfilled
+	// whatever their winding order.
+	EvenOdd
+)
+
+// FillRuleSetter is a Canvas that can fill paths using
+// either fill rule. The fill rule is saved and restored
+// by Push and Pop.
+type FillRuleSetter interface {
+	Canvas
+	SetFillRule(FillRule)
+}
+
 // Initialize sets all of the canvas's values to their
 // initial values.
 func Initialize(c Canvas) {
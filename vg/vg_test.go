@@ -11,6 +11,7 @@ import (
 	"log"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/internal/cmpimg"
@@ -162,3 +163,86 @@ func TestParseLength(t *testing.T) {
 		}
 	}
 }
+
+func TestRoundedRectangle(t *testing.T) {
+	r := vg.Rectangle{Max: vg.Point{X: 10, Y: 4}}
+	for _, test := range []struct {
+		radius vg.Length
+		want   vg.Length
+		arcs   int
+	}{
+		{radius: 0, arcs: 0},
+		{radius: -1, arcs: 0},
+		{radius: 1, want: 1, arcs: 4},
+		{radius: 10, want: 2, arcs: 4},
+	} {
+		p := vg.RoundedRectangle(r, test.radius)
+		var arcs int
+		for _, comp := range p {
+			if comp.Type != vg.ArcComp {
+				continue
+			}
+			arcs++
+			if comp.Radius != test.want {
+				t.Errorf("unexpected arc radius for radius %v: got:%v want:%v", test.radius, comp.Radius, test.want)
+			}
+		}
+		if arcs != test.arcs {
+			t.Errorf("unexpected number of arcs for radius %v: got:%d want:%d", test.radius, arcs, test.arcs)
+		}
+		if p[len(p)-1].Type != vg.CloseComp {
+			t.Errorf("path not closed for radius %v", test.radius)
+		}
+	}
+}
+
+func TestDeterministic(t *testing.T) {
+	deterministic, created := vg.Deterministic, vg.CreationTime
+	defer func() { vg.Deterministic, vg.CreationTime = deterministic, created }()
+	vg.Deterministic = true
+	vg.CreationTime = time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if !vg.Now().Equal(vg.CreationTime) {
+		t.Errorf("unexpected time: got:%v want:%v", vg.Now(), vg.CreationTime)
+	}
+
+	render := func(format string) []byte {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Title.Text = "title"
+		l, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 0}, {X: 1, Y: 1}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Add(l)
+		w, err := p.WriterTo(4*vg.Centimeter, 4*vg.Centimeter, format)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var buf bytes.Buffer
+		_, err = w.WriteTo(&buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return buf.Bytes()
+	}
+	formats := []string{"eps", "pdf", "svg", "png"}
+	want := make(map[string][]byte)
+	for _, format := range formats {
+		want[format] = render(format)
+	}
+	for _, format := range formats {
+		if got := render(format); !bytes.Equal(got, want[format]) {
+			t.Errorf("%s renders differ", format)
+		}
+	}
+
+	// The creation date of a PDF is taken from
+	// CreationTime rather than from the clock.
+	vg.CreationTime = vg.CreationTime.Add(time.Hour)
+	if got := render("pdf"); bytes.Equal(got, want["pdf"]) {
+		t.Error("pdf render does not depend on creation time")
+	}
+}
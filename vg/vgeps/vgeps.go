@@ -9,12 +9,12 @@ package vgeps // import "gonum.org/v1/plot/vg/vgeps"
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
 	"io"
 	"math"
-	"time"
 
 	"gonum.org/v1/plot/vg"
 )
@@ -26,6 +26,14 @@ type Canvas struct {
 	stack []context
 	w, h  vg.Length
 	buf   *bytes.Buffer
+
+	// out is the writer of a Canvas created by
+	// NewWriter, to which buf is flushed as the
+	// canvas is drawn. err is the first error
+	// writing to out.
+	out    io.Writer
+	err    error
+	closed bool
 }
 
 type context struct {
@@ -35,11 +43,17 @@ type context struct {
 	offs   vg.Length
 	font   string
 	fsize  vg.Length
+	rule   vg.FillRule
 }
 
 // pr is the amount of precision to use when outputting float64s.
 const pr = 5
 
+// streamChunk is the size of the buffered output
+// at which a Canvas created by NewWriter flushes it
+// to its writer.
+const streamChunk = 64 << 10
+
 // New returns a new Canvas.
 func New(w, h vg.Length) *Canvas {
 	return NewTitle(w, h, "")
@@ -59,7 +73,7 @@ func NewTitle(w, h vg.Length, title string) *Canvas {
 	c.buf.WriteString(fmt.Sprintf("%%%%BoundingBox: 0 0 %.*g %.*g\n",
 		pr, w.Dots(DPI),
 		pr, h.Dots(DPI)))
-	c.buf.WriteString(fmt.Sprintf("%%%%CreationDate: %s\n", time.Now()))
+	c.buf.WriteString(fmt.Sprintf("%%%%CreationDate: %s\n", vg.Now()))
 	c.buf.WriteString("%%Orientation: Portrait\n")
 	c.buf.WriteString("%%EndComments\n")
 	c.buf.WriteString("\n")
@@ -67,6 +81,48 @@ func NewTitle(w, h vg.Length, title string) *Canvas {
 	return c
 }
 
+// NewWriter returns a new Canvas with the given title string
+// that writes the EPS document to out as it is drawn, rather
+// than holding the whole document in memory. The document is
+// completed by calling Close. The WriteTo method of the returned
+// Canvas returns an error.
+func NewWriter(out io.Writer, w, h vg.Length, title string) *Canvas {
+	c := NewTitle(w, h, title)
+	c.out = out
+	return c
+}
+
+// flush writes the buffered output of a Canvas created
+// by NewWriter to its writer if there is at least min
+// bytes of it.
+func (e *Canvas) flush(min int) {
+	if e.out == nil || e.buf.Len() < min {
+		return
+	}
+	if e.err == nil {
+		_, e.err = e.buf.WriteTo(e.out)
+	}
+	e.buf.Reset()
+}
+
+// Close completes the EPS document of a Canvas created by
+// NewWriter, writing the remainder of the document to its
+// writer. It does not close the writer. The Canvas may not
+// be drawn to after Close is called. Close returns the first
+// error encountered while writing the document.
+func (e *Canvas) Close() error {
+	if e.out == nil {
+		return errors.New("vgeps: close of canvas without writer")
+	}
+	if e.closed {
+		return e.err
+	}
+	e.closed = true
+	e.buf.WriteString("showpage\n")
+	e.flush(0)
+	return e.err
+}
+
 func (c *Canvas) Size() (w, h vg.Length) {
 	return c.w, c.h
 }
@@ -145,11 +201,22 @@ func (e *Canvas) Stroke(path vg.Path) {
 	}
 	e.trace(path)
 	e.buf.WriteString("stroke\n")
+	e.flush(streamChunk)
+}
+
+// SetFillRule implements the vg.FillRuleSetter interface.
+func (e *Canvas) SetFillRule(r vg.FillRule) {
+	e.context().rule = r
 }
 
 func (e *Canvas) Fill(path vg.Path) {
 	e.trace(path)
-	e.buf.WriteString("fill\n")
+	if e.context().rule == vg.EvenOdd {
+		e.buf.WriteString("eofill\n")
+	} else {
+		e.buf.WriteString("fill\n")
+	}
+	e.flush(streamChunk)
 }
 
 func (e *Canvas) trace(path vg.Path) {
@@ -186,6 +253,7 @@ func (e *Canvas) FillString(fnt vg.Font, pt vg.Point, str string) {
 	}
 	fmt.Fprintf(e.buf, "%.*g %.*g moveto\n", pr, pt.X.Dots(DPI), pr, pt.Y.Dots(DPI))
 	fmt.Fprintf(e.buf, "(%s) show\n", str)
+	e.flush(streamChunk)
 }
 
 // DrawImage implements the vg.Canvas.DrawImage method.
@@ -196,6 +264,9 @@ func (c *Canvas) DrawImage(rect vg.Rectangle, img image.Image) {
 
 // WriteTo writes the canvas to an io.Writer.
 func (e *Canvas) WriteTo(w io.Writer) (int64, error) {
+	if e.out != nil {
+		return 0, errors.New("vgeps: canvas is written to its writer by Close")
+	}
 	b := bufio.NewWriter(w)
 	n, err := e.buf.WriteTo(b)
 	if err != nil {
@@ -18,6 +18,8 @@ import (
 	"io"
 	"sync"
 
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/math/f64"
 	"golang.org/x/image/tiff"
 
 	"github.com/llgcode/draw2d"
@@ -39,6 +41,10 @@ type Canvas struct {
 
 	// width is the current line width.
 	width vg.Length
+
+	// background is the color with which the
+	// image is initially filled.
+	background color.Color
 }
 
 const (
@@ -59,14 +65,14 @@ func New(w, h vg.Length) *Canvas {
 
 // NewWith returns a new image canvas created according to the specified
 // options. The currently accepted options are UseWH,
-// UseDPI, UseImage, and UseImageWithContext.
+// UseDPI, UseImage, UseImageWithContext and UseBackgroundColor.
 // Each of the options specifies the size of the canvas (UseWH, UseImage),
 // the resolution of the canvas (UseDPI), or both (useImageWithContext).
 // If size or resolution are not specified, defaults are used.
 // It panics if size and resolution are overspecified (i.e., too many options are
 // passed).
 func NewWith(o ...option) *Canvas {
-	c := new(Canvas)
+	c := &Canvas{background: color.White}
 	var g uint32
 	for _, opt := range o {
 		f := opt(c)
@@ -101,7 +107,9 @@ func NewWith(o ...option) *Canvas {
 		c.gc.Scale(1, -1)
 		c.gc.Translate(0, -h)
 	}
-	draw.Draw(c.img, c.img.Bounds(), image.White, image.ZP, draw.Src)
+	if c.background != nil {
+		draw.Draw(c.img, c.img.Bounds(), image.NewUniform(c.background), image.ZP, draw.Src)
+	}
 	c.color = []color.Color{color.Black}
 	vg.Initialize(c)
 	return c
@@ -141,6 +149,17 @@ func UseDPI(dpi int) option {
 	}
 }
 
+// UseBackgroundColor specifies the color with which the image
+// of the canvas is initially filled. The default is white. If
+// the color is nil the image is not filled, so that an image
+// created by the canvas is transparent where nothing is drawn.
+func UseBackgroundColor(clr color.Color) option {
+	return func(c *Canvas) uint32 {
+		c.background = clr
+		return 0
+	}
+}
+
 // UseImage specifies an image to create
 // the canvas from. The
 // minimum point of the given image
@@ -228,6 +247,15 @@ func (c *Canvas) Stroke(p vg.Path) {
 	c.gc.Stroke()
 }
 
+// SetFillRule implements the vg.FillRuleSetter interface.
+func (c *Canvas) SetFillRule(r vg.FillRule) {
+	if r == vg.NonZero {
+		c.gc.SetFillRule(draw2d.FillRuleWinding)
+		return
+	}
+	c.gc.SetFillRule(draw2d.FillRuleEvenOdd)
+}
+
 func (c *Canvas) Fill(p vg.Path) {
 	c.outline(p)
 	c.gc.Fill()
@@ -296,7 +324,11 @@ func (c *Canvas) DrawImage(rect vg.Rectangle, img image.Image) {
 	c.gc.Scale(1, -1)
 	c.gc.Translate(xmin, -ymin-height)
 	c.gc.Scale(width/dx, height/dy)
-	c.gc.DrawImage(img)
+	// The image is transformed here rather than by the
+	// DrawImage method of the graphic context, which
+	// transposes the rotation of the current transform.
+	tr := c.gc.GetMatrixTransform()
+	xdraw.BiLinear.Transform(c.img, f64.Aff3{tr[0], tr[2], tr[4], tr[1], tr[3], tr[5]}, img, img.Bounds(), draw.Over, nil)
 	c.gc.Restore()
 }
 
@@ -324,13 +356,22 @@ func (w *writerCounter) Write(p []byte) (int, error) {
 // that writes a jpeg image.
 type JpegCanvas struct {
 	*Canvas
+
+	// Quality is the quality of the jpeg encoding,
+	// from 1 to 100. A Quality of zero selects
+	// jpeg.DefaultQuality.
+	Quality int
 }
 
 // WriteTo implements the io.WriterTo interface, writing a jpeg image.
 func (c JpegCanvas) WriteTo(w io.Writer) (int64, error) {
 	wc := writerCounter{Writer: w}
 	b := bufio.NewWriter(&wc)
-	if err := jpeg.Encode(b, c.img, nil); err != nil {
+	var opts *jpeg.Options
+	if c.Quality != 0 {
+		opts = &jpeg.Options{Quality: c.Quality}
+	}
+	if err := jpeg.Encode(b, c.img, opts); err != nil {
 		return wc.n, err
 	}
 	err := b.Flush()
@@ -338,16 +379,21 @@ func (c JpegCanvas) WriteTo(w io.Writer) (int64, error) {
 }
 
 // A PngCanvas is an image canvas with a WriteTo method that
-// writes a png image.
+// writes a png image with 8 bits per channel.
 type PngCanvas struct {
 	*Canvas
+
+	// CompressionLevel is the compression
+	// level of the png encoding.
+	CompressionLevel png.CompressionLevel
 }
 
 // WriteTo implements the io.WriterTo interface, writing a png image.
 func (c PngCanvas) WriteTo(w io.Writer) (int64, error) {
 	wc := writerCounter{Writer: w}
 	b := bufio.NewWriter(&wc)
-	if err := png.Encode(b, c.img); err != nil {
+	enc := png.Encoder{CompressionLevel: c.CompressionLevel}
+	if err := enc.Encode(b, c.img); err != nil {
 		return wc.n, err
 	}
 	err := b.Flush()
@@ -358,13 +404,18 @@ func (c PngCanvas) WriteTo(w io.Writer) (int64, error) {
 // writes a tiff image.
 type TiffCanvas struct {
 	*Canvas
+
+	// Options holds the options of the tiff
+	// encoding. If Options is nil the image
+	// is not compressed.
+	Options *tiff.Options
 }
 
 // WriteTo implements the io.WriterTo interface, writing a tiff image.
 func (c TiffCanvas) WriteTo(w io.Writer) (int64, error) {
 	wc := writerCounter{Writer: w}
 	b := bufio.NewWriter(&wc)
-	if err := tiff.Encode(b, c.img, nil); err != nil {
+	if err := tiff.Encode(b, c.img, c.Options); err != nil {
 		return wc.n, err
 	}
 	err := b.Flush()
@@ -2,13 +2,20 @@ package vgimg_test
 
 import (
 	"bytes"
+	"image"
+	"image/color"
+	imdraw "image/draw"
+	"image/png"
 	"io/ioutil"
 	"log"
+	"math"
 	"os"
 	"path/filepath"
 	"sync"
 	"testing"
 
+	"golang.org/x/image/tiff"
+
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
@@ -66,3 +73,73 @@ func TestConcurrentInit(t *testing.T) {
 	}()
 	wg.Wait()
 }
+
+func TestEncoderOptions(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "Encoder options"
+
+	write := func(format string, opts ...draw.FormatOption) []byte {
+		var buf bytes.Buffer
+		err := p.Stream(&buf, 5*vg.Centimeter, 5*vg.Centimeter, format, opts...)
+		if err != nil {
+			t.Fatalf("unexpected error writing %s: %v", format, err)
+		}
+		return buf.Bytes()
+	}
+
+	img, err := png.Decode(bytes.NewReader(write("png", draw.PngCompression(png.BestSpeed))))
+	if err != nil {
+		t.Fatalf("unexpected error decoding png: %v", err)
+	}
+	if _, ok := img.(*image.RGBA); !ok {
+		t.Errorf("unexpected png image type: %T", img)
+	}
+	if len(write("png", draw.PngCompression(png.NoCompression))) <= len(write("png", draw.PngCompression(png.BestCompression))) {
+		t.Error("expected uncompressed png to be larger than compressed png")
+	}
+
+	if len(write("jpg", draw.JpegQuality(10))) >= len(write("jpg", draw.JpegQuality(95))) {
+		t.Error("expected low quality jpeg to be smaller than high quality jpeg")
+	}
+
+	deflated := write("tiff", draw.TiffOptions(tiff.Options{Compression: tiff.Deflate}))
+	if len(deflated) >= len(write("tiff")) {
+		t.Error("expected deflated tiff to be smaller than uncompressed tiff")
+	}
+	if _, err := tiff.Decode(bytes.NewReader(deflated)); err != nil {
+		t.Errorf("unexpected error decoding tiff: %v", err)
+	}
+}
+
+func TestDrawImageRotated(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	img := image.NewRGBA(image.Rect(0, 0, 20, 10))
+	imdraw.Draw(img, img.Bounds(), image.NewUniform(red), image.Point{}, imdraw.Src)
+
+	c := vgimg.NewWith(vgimg.UseWH(100, 100), vgimg.UseDPI(72))
+	c.Translate(vg.Point{X: 50, Y: 50})
+	c.Rotate(math.Pi / 2)
+	c.DrawImage(vg.Rectangle{Min: vg.Point{X: 10}, Max: vg.Point{X: 30, Y: 10}}, img)
+
+	// The image is rotated a quarter turn about (50, 50)
+	// to cover x in [40, 50] and y in [60, 80], with y
+	// increasing up the canvas.
+	got := c.Image()
+	for _, test := range []struct {
+		x, y int
+		want color.Color
+	}{
+		{x: 45, y: 30, want: red},
+		{x: 70, y: 45, want: color.White},
+		{x: 45, y: 70, want: color.White},
+	} {
+		r, g, b, a := got.At(test.x, test.y).RGBA()
+		wr, wg, wb, wa := test.want.RGBA()
+		if r != wr || g != wg || b != wb || a != wa {
+			t.Errorf("unexpected color at (%d, %d): got:%v want:%v", test.x, test.y, got.At(test.x, test.y), test.want)
+		}
+	}
+}
@@ -4,6 +4,13 @@
 
 // Package vgpdf implements the vg.Canvas interface
 // using gofpdf (github.com/jung-kurt/gofpdf).
+//
+// Compression of the page content streams can be turned
+// on or off with Compress, but gofpdf compresses at a fixed
+// level. Embedded fonts are embedded whole, as gofpdf does
+// not subset them, and the canvas does not write PDF/A
+// documents, as gofpdf cannot write the metadata and output
+// intent they require.
 package vgpdf // import "gonum.org/v1/plot/vg/vgpdf"
 
 import (
@@ -44,12 +51,17 @@ type Canvas struct {
 	// The default is to embed fonts.
 	// This makes the PDF file more portable but also larger.
 	embed bool
+
+	// Switch to compress the page content streams.
+	// The default is to compress.
+	compress bool
 }
 
 type context struct {
 	fill  color.Color
 	line  color.Color
 	width vg.Length
+	rule  vg.FillRule
 }
 
 // New creates a new PDF Canvas.
@@ -66,8 +78,14 @@ func New(w, h vg.Length) *Canvas {
 		stack: make([]context, 1),
 		fonts: make(map[vg.Font]struct{}),
 		embed: true,
+
+		compress: true,
 	}
 	vg.Initialize(c)
+	if vg.Deterministic {
+		c.doc.SetCreationDate(vg.CreationTime)
+		c.doc.SetCatalogSort(true)
+	}
 	c.doc.SetMargins(0, 0, 0)
 	c.doc.AddPage()
 	c.Push()
@@ -86,6 +104,18 @@ func (c *Canvas) EmbedFonts(v bool) bool {
 	return prev
 }
 
+// Compress specifies whether the page content streams of the
+// resulting PDF canvas should be compressed or not. Uncompressed
+// streams are larger but may be edited or inspected as text.
+// Compress returns the previous value before modification.
+// The level of compression is fixed.
+func (c *Canvas) Compress(v bool) bool {
+	prev := c.compress
+	c.compress = v
+	c.doc.SetCompression(v)
+	return prev
+}
+
 func (c *Canvas) DPI() float64 {
 	return float64(c.dpi)
 }
@@ -154,7 +184,16 @@ func (c *Canvas) Stroke(p vg.Path) {
 	}
 }
 
+// SetFillRule implements the vg.FillRuleSetter interface.
+func (c *Canvas) SetFillRule(r vg.FillRule) {
+	c.context().rule = r
+}
+
 func (c *Canvas) Fill(p vg.Path) {
+	if c.context().rule == vg.EvenOdd {
+		c.pdfPath(p, "F*")
+		return
+	}
 	c.pdfPath(p, "F")
 }
 
@@ -15,6 +15,7 @@ import (
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/internal/cmpimg"
 	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 	"gonum.org/v1/plot/vg/vgpdf"
 )
@@ -169,3 +170,29 @@ func TestArc(t *testing.T) {
 		t.Fatalf("plot mismatch")
 	}
 }
+
+func TestCompress(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		c := vgpdf.New(100, 100)
+		c.EmbedFonts(false)
+		if prev := c.Compress(compress); !prev {
+			t.Errorf("unexpected default compression: got:%v want:true", prev)
+		}
+		var p vg.Path
+		p.Move(vg.Point{X: 10, Y: 10})
+		p.Line(vg.Point{X: 90, Y: 90})
+		c.Stroke(p)
+
+		var buf bytes.Buffer
+		_, err := c.WriteTo(&buf)
+		if err != nil {
+			t.Fatalf("could not write canvas: %v", err)
+		}
+		// The page content stream holds the stroked
+		// line as text only when it is uncompressed.
+		plain := bytes.Contains(buf.Bytes(), []byte(" l\nS\n"))
+		if plain == compress {
+			t.Errorf("unexpected page content for compress=%v: plain text stream:%v", compress, plain)
+		}
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/base64"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
@@ -27,15 +29,51 @@ import (
 // to be specified.
 const DPI = 90
 
-// pr is the precision to use when outputting float64s.
+// pr is the default precision to use when outputting float64s.
 const pr = 5
 
+// maxGlyph is the largest number of components of a path
+// that is considered for reuse as a glyph.
+const maxGlyph = 32
+
 type Canvas struct {
 	svg   *svgo.SVG
 	w, h  vg.Length
 	buf   *bytes.Buffer
 	ht    float64
 	stack []context
+
+	// pr is the number of significant digits
+	// of output coordinates and lengths.
+	pr int
+
+	// tol is the path decimation tolerance.
+	tol vg.Length
+
+	// glyphs holds the number of times that each
+	// small path has been drawn, keyed by its path
+	// data relative to its first point, and the
+	// defs id of the path if it has been reused.
+	// If glyphs is nil, paths are not reused.
+	glyphs map[string]*glyph
+	nDefs  int
+
+	// out is the writer of a Canvas created by
+	// NewWriter, to which buf is flushed as the
+	// canvas is drawn. err is the first error
+	// writing to out.
+	out    io.Writer
+	err    error
+	closed bool
+
+	// groups is the number of open
+	// groups begun by BeginGroup.
+	groups int
+}
+
+type glyph struct {
+	n  int
+	id string
 }
 
 type context struct {
@@ -43,9 +81,15 @@ type context struct {
 	dashArray  []vg.Length
 	dashOffset vg.Length
 	lineWidth  vg.Length
+	fillRule   vg.FillRule
 	gEnds      int
 }
 
+// streamChunk is the size of the buffered output
+// at which a Canvas created by NewWriter flushes it
+// to its writer.
+const streamChunk = 64 << 10
+
 func New(w, h vg.Length) *Canvas {
 	buf := new(bytes.Buffer)
 	c := &Canvas{
@@ -55,6 +99,7 @@ func New(w, h vg.Length) *Canvas {
 		buf:   buf,
 		ht:    w.Points(),
 		stack: []context{{}},
+		pr:    pr,
 	}
 
 	// This is like svg.Start, except it uses floats
@@ -77,6 +122,97 @@ func New(w, h vg.Length) *Canvas {
 	return c
 }
 
+// NewWriter returns a new Canvas of the given size that writes
+// the SVG document to out as it is drawn, rather than holding
+// the whole document in memory. The document is completed by
+// calling Close. The WriteTo method of the returned Canvas
+// returns an error.
+func NewWriter(out io.Writer, w, h vg.Length) *Canvas {
+	c := New(w, h)
+	c.out = out
+	return c
+}
+
+// flush writes the buffered output of a Canvas created
+// by NewWriter to its writer if there is at least min
+// bytes of it.
+func (c *Canvas) flush(min int) {
+	if c.out == nil || c.buf.Len() < min {
+		return
+	}
+	if c.err == nil {
+		_, c.err = c.buf.WriteTo(c.out)
+	}
+	c.buf.Reset()
+}
+
+// Close completes the SVG document of a Canvas created by
+// NewWriter, writing the remainder of the document to its
+// writer. It does not close the writer. The Canvas may not
+// be drawn to after Close is called. Close returns the first
+// error encountered while writing the document.
+func (c *Canvas) Close() error {
+	if c.out == nil {
+		return errors.New("vgsvg: close of canvas without writer")
+	}
+	if c.closed {
+		return c.err
+	}
+	c.closed = true
+	for i := 0; i < c.nEnds(); i++ {
+		c.buf.WriteString("</g>\n")
+	}
+	c.buf.WriteString("</svg>\n")
+	c.flush(0)
+	return c.err
+}
+
+// Precision sets the number of significant digits of the
+// coordinates and lengths written to the SVG document. Lower
+// precision gives smaller output at the cost of accuracy.
+// Values are formatted with the %g verb, so the precision is
+// not a number of decimal places: with the default precision
+// of 5, a coordinate of 1234.5678 dots is written as 1234.6
+// and one of 0.012345678 dots as 0.012346. Precision returns
+// the previous value before modification.
+func (c *Canvas) Precision(n int) int {
+	if n < 1 {
+		panic("vgsvg: precision must be > 0")
+	}
+	prev := c.pr
+	c.pr = n
+	return prev
+}
+
+// Decimate sets the tolerance of path decimation. Vertices
+// of runs of straight path segments are dropped where the
+// simplified path is within the tolerance of the original,
+// reducing the size of the output for lines of many points.
+// A tolerance of zero, the default, disables decimation.
+// Decimate returns the previous value before modification.
+func (c *Canvas) Decimate(tol vg.Length) vg.Length {
+	prev := c.tol
+	c.tol = tol
+	return prev
+}
+
+// ReuseGlyphs specifies whether small paths that are drawn
+// repeatedly at different positions, such as the glyphs of
+// a scatter plot, are written once to the defs of the SVG
+// document and referenced at each position. The default is
+// not to reuse paths. ReuseGlyphs returns the previous value
+// before modification.
+func (c *Canvas) ReuseGlyphs(v bool) bool {
+	prev := c.glyphs != nil
+	switch {
+	case v && c.glyphs == nil:
+		c.glyphs = make(map[string]*glyph)
+	case !v:
+		c.glyphs = nil
+	}
+	return prev
+}
+
 func (c *Canvas) Size() (w, h vg.Length) {
 	return c.w, c.h
 }
@@ -105,7 +241,7 @@ func (c *Canvas) Rotate(rot float64) {
 }
 
 func (c *Canvas) Translate(pt vg.Point) {
-	c.svg.Gtransform(fmt.Sprintf("translate(%.*g, %.*g)", pr, pt.X.Dots(DPI), pr, pt.Y.Dots(DPI)))
+	c.svg.Gtransform(fmt.Sprintf("translate(%.*g, %.*g)", c.pr, pt.X.Dots(DPI), c.pr, pt.Y.Dots(DPI)))
 	c.context().gEnds++
 }
 
@@ -131,45 +267,116 @@ func (c *Canvas) Stroke(path vg.Path) {
 	if c.context().lineWidth.Dots(DPI) <= 0 {
 		return
 	}
-	c.svg.Path(c.pathData(path),
+	c.path(path,
 		style(elm("fill", "#000000", "none"),
 			elm("stroke", "none", colorString(c.context().color)),
 			elm("stroke-opacity", "1", opacityString(c.context().color)),
-			elm("stroke-width", "1", "%.*g", pr, c.context().lineWidth.Dots(DPI)),
+			elm("stroke-width", "1", "%.*g", c.pr, c.context().lineWidth.Dots(DPI)),
 			elm("stroke-dasharray", "none", dashArrayString(c)),
-			elm("stroke-dashoffset", "0", "%.*g", pr, c.context().dashOffset.Dots(DPI))))
+			elm("stroke-dashoffset", "0", "%.*g", c.pr, c.context().dashOffset.Dots(DPI))))
+}
+
+// SetFillRule implements the vg.FillRuleSetter interface.
+func (c *Canvas) SetFillRule(r vg.FillRule) {
+	c.context().fillRule = r
 }
 
 func (c *Canvas) Fill(path vg.Path) {
-	c.svg.Path(c.pathData(path),
+	c.path(path,
 		style(elm("fill", "#000000", colorString(c.context().color)),
-			elm("fill-opacity", "1", opacityString(c.context().color))))
+			elm("fill-opacity", "1", opacityString(c.context().color)),
+			elm("fill-rule", "nonzero", "%s", fillRuleString(c.context().fillRule))))
+}
+
+// fillRuleString returns the SVG fill-rule of r.
+func fillRuleString(r vg.FillRule) string {
+	if r == vg.EvenOdd {
+		return "evenodd"
+	}
+	return "nonzero"
 }
 
-func (c *Canvas) pathData(path vg.Path) string {
+// path writes the path with the given style attribute to the
+// SVG document, reusing the path as a glyph if it is small and
+// has been drawn before.
+func (c *Canvas) path(path vg.Path, sty string) {
+	if c.glyphs == nil || len(path) == 0 || len(path) > maxGlyph || path[0].Type != vg.MoveComp {
+		c.svg.Path(c.pathData(path, 0, 0), sty)
+		c.flush(streamChunk)
+		return
+	}
+
+	x0 := path[0].Pos.X.Dots(DPI)
+	y0 := path[0].Pos.Y.Dots(DPI)
+	d := c.pathData(path, x0, y0)
+	g, ok := c.glyphs[d]
+	if !ok {
+		g = &glyph{}
+		c.glyphs[d] = g
+	}
+	g.n++
+	if g.n == 1 {
+		c.svg.Path(c.pathData(path, 0, 0), sty)
+		c.flush(streamChunk)
+		return
+	}
+	if g.id == "" {
+		c.nDefs++
+		g.id = fmt.Sprintf("g%d", c.nDefs)
+		fmt.Fprintf(c.buf, "<defs><path id=%q d=%q/></defs>\n", g.id, d)
+	}
+	if sty != "" {
+		sty = " " + sty
+	}
+	fmt.Fprintf(c.buf, `<use xlink:href="#%s" x="%.*g" y="%.*g"%s/>`+"\n",
+		g.id, c.pr, x0, c.pr, y0, sty)
+	c.flush(streamChunk)
+}
+
+// pathData returns the SVG path data of path, with
+// coordinates relative to the point (x0, y0) in dots.
+func (c *Canvas) pathData(path vg.Path, x0, y0 float64) string {
 	buf := new(bytes.Buffer)
+	pr := c.pr
 	var x, y float64
-	for _, comp := range path {
+	var run [][2]float64
+	for i, comp := range path {
 		switch comp.Type {
 		case vg.MoveComp:
-			fmt.Fprintf(buf, "M%.*g,%.*g", pr, comp.Pos.X.Dots(DPI), pr, comp.Pos.Y.Dots(DPI))
+			fmt.Fprintf(buf, "M%.*g,%.*g", pr, offset(comp.Pos.X.Dots(DPI), x0), pr, offset(comp.Pos.Y.Dots(DPI), y0))
 			x = comp.Pos.X.Dots(DPI)
 			y = comp.Pos.Y.Dots(DPI)
 		case vg.LineComp:
-			fmt.Fprintf(buf, "L%.*g,%.*g", pr, comp.Pos.X.Dots(DPI), pr, comp.Pos.Y.Dots(DPI))
+			if c.tol > 0 {
+				// Collect the run of line segments from
+				// the current point for decimation.
+				if run == nil {
+					run = append(run, [2]float64{x, y})
+				}
+				run = append(run, [2]float64{comp.Pos.X.Dots(DPI), comp.Pos.Y.Dots(DPI)})
+				if i+1 < len(path) && path[i+1].Type == vg.LineComp {
+					break
+				}
+				for _, pt := range decimate(run, c.tol.Dots(DPI))[1:] {
+					fmt.Fprintf(buf, "L%.*g,%.*g", pr, offset(pt[0], x0), pr, offset(pt[1], y0))
+				}
+				run = nil
+			} else {
+				fmt.Fprintf(buf, "L%.*g,%.*g", pr, offset(comp.Pos.X.Dots(DPI), x0), pr, offset(comp.Pos.Y.Dots(DPI), y0))
+			}
 			x = comp.Pos.X.Dots(DPI)
 			y = comp.Pos.Y.Dots(DPI)
 		case vg.ArcComp:
 			r := comp.Radius.Dots(DPI)
-			x0 := comp.Pos.X.Dots(DPI) + r*math.Cos(comp.Start)
-			y0 := comp.Pos.Y.Dots(DPI) + r*math.Sin(comp.Start)
-			if x0 != x || y0 != y {
-				fmt.Fprintf(buf, "L%.*g,%.*g", pr, x0, pr, y0)
+			xs := comp.Pos.X.Dots(DPI) + r*math.Cos(comp.Start)
+			ys := comp.Pos.Y.Dots(DPI) + r*math.Sin(comp.Start)
+			if xs != x || ys != y {
+				fmt.Fprintf(buf, "L%.*g,%.*g", pr, offset(xs, x0), pr, offset(ys, y0))
 			}
 			if math.Abs(comp.Angle) >= 2*math.Pi {
-				x, y = circle(buf, c, &comp)
+				x, y = circle(buf, c, &comp, x0, y0)
 			} else {
-				x, y = arc(buf, c, &comp)
+				x, y = arc(buf, c, &comp, x0, y0)
 			}
 		case vg.CloseComp:
 			buf.WriteString("Z")
@@ -180,11 +387,66 @@ func (c *Canvas) pathData(path vg.Path) string {
 	return buf.String()
 }
 
+// offset returns v-o, rounding differences that are
+// within floating point error of zero to zero.
+func offset(v, o float64) float64 {
+	d := v - o
+	if math.Abs(d) < 1e-9 {
+		return 0
+	}
+	return d
+}
+
+// decimate returns the vertices of the polyline pts simplified
+// by the Ramer-Douglas-Peucker algorithm, so that no dropped
+// vertex is further than tol from the simplified polyline.
+// The first and last vertices are always kept.
+func decimate(pts [][2]float64, tol float64) [][2]float64 {
+	if len(pts) < 3 {
+		return pts
+	}
+	keep := make([]bool, len(pts))
+	keep[0], keep[len(pts)-1] = true, true
+	type span struct{ i, j int }
+	stack := []span{{0, len(pts) - 1}}
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		a, b := pts[s.i], pts[s.j]
+		dx, dy := b[0]-a[0], b[1]-a[1]
+		l := math.Hypot(dx, dy)
+		max, at := 0.0, -1
+		for k := s.i + 1; k < s.j; k++ {
+			px, py := pts[k][0]-a[0], pts[k][1]-a[1]
+			var d float64
+			if l == 0 {
+				d = math.Hypot(px, py)
+			} else {
+				d = math.Abs(px*dy-py*dx) / l
+			}
+			if d > max {
+				max, at = d, k
+			}
+		}
+		if at >= 0 && max > tol {
+			keep[at] = true
+			stack = append(stack, span{s.i, at}, span{at, s.j})
+		}
+	}
+	var simple [][2]float64
+	for i, k := range keep {
+		if k {
+			simple = append(simple, pts[i])
+		}
+	}
+	return simple
+}
+
 // circle adds circle path data to the given writer.
 // Circles must be drawn using two arcs because
 // SVG disallows the start and end point of an arc
 // from being at the same location.
-func circle(w io.Writer, c *Canvas, comp *vg.PathComp) (x, y float64) {
+func circle(w io.Writer, c *Canvas, comp *vg.PathComp, ox, oy float64) (x, y float64) {
 	angle := 2 * math.Pi
 	if comp.Angle < 0 {
 		angle = -2 * math.Pi
@@ -200,10 +462,11 @@ func circle(w io.Writer, c *Canvas, comp *vg.PathComp) (x, y float64) {
 	x = comp.Pos.X.Dots(DPI) + r*math.Cos(comp.Start+angle)
 	y = comp.Pos.Y.Dots(DPI) + r*math.Sin(comp.Start+angle)
 
+	pr := c.pr
 	fmt.Fprintf(w, "A%.*g,%.*g 0 %d %d %.*g,%.*g", pr, r, pr, r,
-		large(angle/2), sweep(angle/2), pr, x0, pr, y0) //
+		large(angle/2), sweep(angle/2), pr, offset(x0, ox), pr, offset(y0, oy)) //
 	fmt.Fprintf(w, "A%.*g,%.*g 0 %d %d %.*g,%.*g", pr, r, pr, r,
-		large(angle/2), sweep(angle/2), pr, x, pr, y)
+		large(angle/2), sweep(angle/2), pr, offset(x, ox), pr, offset(y, oy))
 	return
 }
 
@@ -219,12 +482,13 @@ func remainder(x, y float64) float64 {
 // Arc can only be used if the arc's angle is
 // less than a full circle, if it is greater then
 // circle should be used instead.
-func arc(w io.Writer, c *Canvas, comp *vg.PathComp) (x, y float64) {
+func arc(w io.Writer, c *Canvas, comp *vg.PathComp, ox, oy float64) (x, y float64) {
 	r := comp.Radius.Dots(DPI)
 	x = comp.Pos.X.Dots(DPI) + r*math.Cos(comp.Start+comp.Angle)
 	y = comp.Pos.Y.Dots(DPI) + r*math.Sin(comp.Start+comp.Angle)
+	pr := c.pr
 	fmt.Fprintf(w, "A%.*g,%.*g 0 %d %d %.*g,%.*g", pr, r, pr, r,
-		large(comp.Angle), sweep(comp.Angle), pr, x, pr, y)
+		large(comp.Angle), sweep(comp.Angle), pr, offset(x, ox), pr, offset(y, oy))
 	return
 }
 
@@ -252,13 +516,16 @@ func (c *Canvas) FillString(font vg.Font, pt vg.Point, str string) {
 		panic(fmt.Sprintf("Unknown font: %s", font.Name()))
 	}
 	sty := style(fontStr,
-		elm("font-size", "medium", "%.*gpt", pr, font.Size.Points()),
+		elm("font-size", "medium", "%.*gpt", c.pr, font.Size.Points()),
 		elm("fill", "#000000", colorString(c.context().color)))
 	if sty != "" {
 		sty = "\n\t" + sty
 	}
-	fmt.Fprintf(c.buf, `<text x="%.*g" y="%.*g" transform="scale(1, -1)"%s>%s</text>`+"\n",
-		pr, pt.X.Dots(DPI), pr, -pt.Y.Dots(DPI), sty, str)
+	fmt.Fprintf(c.buf, `<text x="%.*g" y="%.*g" transform="scale(1, -1)"%s>`,
+		c.pr, pt.X.Dots(DPI), c.pr, -pt.Y.Dots(DPI), sty)
+	xml.EscapeText(c.buf, []byte(str))
+	c.buf.WriteString("</text>\n")
+	c.flush(streamChunk)
 }
 
 // DrawImage implements the vg.Canvas.DrawImage method.
@@ -288,6 +555,7 @@ func (c *Canvas) DrawImage(rect vg.Rectangle, img image.Image) {
 		// invert y so image is not upside-down
 		`transform="scale(1, -1)"`,
 	)
+	c.flush(streamChunk)
 }
 
 var (
@@ -311,6 +579,9 @@ var (
 
 // WriteTo writes the canvas to an io.Writer.
 func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
+	if c.out != nil {
+		return 0, errors.New("vgsvg: canvas is written to its writer by Close")
+	}
 	b := bufio.NewWriter(w)
 	n, err := c.buf.WriteTo(b)
 	if err != nil {
@@ -337,10 +608,50 @@ func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
 	return n, b.Flush()
 }
 
+// BeginGroup implements the vg.Grouper interface, starting an
+// SVG group element with the role of g and the title of g as its
+// ARIA label, holding title and desc elements with the title and
+// description of g.
+func (c *Canvas) BeginGroup(g vg.Group) {
+	c.buf.WriteString("<g")
+	if g.Role != "" {
+		c.buf.WriteString(` role="`)
+		xml.EscapeText(c.buf, []byte(g.Role))
+		c.buf.WriteString(`"`)
+	}
+	if g.Title != "" {
+		c.buf.WriteString(` aria-label="`)
+		xml.EscapeText(c.buf, []byte(g.Title))
+		c.buf.WriteString(`"`)
+	}
+	c.buf.WriteString(">\n")
+	if g.Title != "" {
+		c.buf.WriteString("<title>")
+		xml.EscapeText(c.buf, []byte(g.Title))
+		c.buf.WriteString("</title>\n")
+	}
+	if g.Description != "" {
+		c.buf.WriteString("<desc>")
+		xml.EscapeText(c.buf, []byte(g.Description))
+		c.buf.WriteString("</desc>\n")
+	}
+	c.groups++
+}
+
+// EndGroup implements the vg.Grouper interface.
+func (c *Canvas) EndGroup() {
+	if c.groups == 0 {
+		panic("vgsvg: too many group ends")
+	}
+	c.buf.WriteString("</g>\n")
+	c.groups--
+}
+
 // nEnds returns the number of group ends
 // needed before the SVG is saved.
 func (c *Canvas) nEnds() int {
 	n := 1 // close the transform that moves the origin
+	n += c.groups
 	for _, ctx := range c.stack {
 		n += ctx.gEnds
 	}
@@ -384,7 +695,7 @@ func elm(key, def, f string, vls ...interface{}) string {
 func dashArrayString(c *Canvas) string {
 	str := ""
 	for i, d := range c.context().dashArray {
-		str += fmt.Sprintf("%.*g", pr, d.Dots(DPI))
+		str += fmt.Sprintf("%.*g", c.pr, d.Dots(DPI))
 		if i < len(c.context().dashArray)-1 {
 			str += ","
 		}
@@ -0,0 +1,237 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgsvg_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/vgsvg"
+)
+
+// element is an SVG element with its attributes.
+type element struct {
+	name  string
+	attrs map[string]string
+}
+
+// elements returns the elements of the SVG document c,
+// in document order.
+func elements(t *testing.T, c *vgsvg.Canvas) []element {
+	var buf bytes.Buffer
+	_, err := c.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error writing canvas: %v", err)
+	}
+	var elems []element
+	dec := xml.NewDecoder(&buf)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return elems
+		}
+		if err != nil {
+			t.Fatalf("unexpected error parsing SVG: %v", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		e := element{name: start.Name.Local, attrs: make(map[string]string)}
+		for _, a := range start.Attr {
+			e.attrs[a.Name.Local] = a.Value
+		}
+		elems = append(elems, e)
+	}
+}
+
+// paths returns the data of the path elements of elems.
+func paths(elems []element) []string {
+	var d []string
+	for _, e := range elems {
+		if e.name == "path" {
+			d = append(d, e.attrs["d"])
+		}
+	}
+	return d
+}
+
+// numbers returns the numbers in the path data d.
+func numbers(d string) []string {
+	return strings.FieldsFunc(d, func(r rune) bool {
+		return strings.ContainsRune("MLAZ, ", r)
+	})
+}
+
+// dots returns the number of SVG dots in the length l.
+func dots(l vg.Length) float64 { return l.Dots(vgsvg.DPI) }
+
+func TestPrecision(t *testing.T) {
+	for _, prec := range []int{2, 5, 8} {
+		c := vgsvg.New(10*vg.Centimeter, 10*vg.Centimeter)
+		if prev := c.Precision(prec); prev != 5 {
+			t.Errorf("unexpected default precision: got:%d want:5", prev)
+		}
+		var p vg.Path
+		p.Move(vg.Point{X: vg.Points(12.3456789), Y: vg.Points(0.0123456789)})
+		p.Line(vg.Point{X: vg.Points(123.456789), Y: vg.Points(98.7654321)})
+		c.Stroke(p)
+
+		want := []float64{
+			dots(vg.Points(12.3456789)), dots(vg.Points(0.0123456789)),
+			dots(vg.Points(123.456789)), dots(vg.Points(98.7654321)),
+		}
+		d := paths(elements(t, c))
+		if len(d) != 1 {
+			t.Fatalf("unexpected number of paths: got:%d want:1", len(d))
+		}
+		got := numbers(d[0])
+		if len(got) != len(want) {
+			t.Fatalf("unexpected path data for precision %d: %q", prec, d[0])
+		}
+		for i, s := range got {
+			// Precision is a number of significant
+			// digits, not of decimal places.
+			if s != strconv.FormatFloat(want[i], 'g', prec, 64) {
+				t.Errorf("unexpected coordinate for precision %d: got:%s want:%.*g", prec, s, prec, want[i])
+			}
+			v, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				t.Fatalf("invalid coordinate %q: %v", s, err)
+			}
+			if math.Abs(v-want[i]) > math.Abs(want[i])*math.Pow(10, 1-float64(prec)) {
+				t.Errorf("coordinate out of tolerance for precision %d: got:%v want:%v", prec, v, want[i])
+			}
+		}
+	}
+}
+
+func TestDecimate(t *testing.T) {
+	const n = 101
+	var p vg.Path
+	p.Move(vg.Point{})
+	for i := 1; i < n; i++ {
+		// The line wobbles by a tenth of a point
+		// about the diagonal.
+		wobble := vg.Points(0.1 * math.Sin(float64(i)))
+		p.Line(vg.Point{X: vg.Points(float64(i)), Y: vg.Points(float64(i)) + wobble})
+	}
+	// A step away from the diagonal is kept.
+	p.Line(vg.Point{X: vg.Points(n), Y: 0})
+
+	for _, test := range []struct {
+		tol  vg.Length
+		want int
+	}{
+		{tol: 0, want: n + 1},
+		{tol: vg.Points(0.5), want: 3},
+	} {
+		c := vgsvg.New(10*vg.Centimeter, 10*vg.Centimeter)
+		c.Decimate(test.tol)
+		c.Stroke(p)
+		d := paths(elements(t, c))
+		if len(d) != 1 {
+			t.Fatalf("unexpected number of paths: got:%d want:1", len(d))
+		}
+		got := numbers(d[0])
+		if len(got) != 2*test.want {
+			t.Errorf("unexpected number of vertices with tolerance %v: got:%d want:%d", test.tol, len(got)/2, test.want)
+			continue
+		}
+		last := got[len(got)-2:]
+		wantLast := []string{
+			strconv.FormatFloat(dots(vg.Points(n)), 'g', 5, 64),
+			"0",
+		}
+		if last[0] != wantLast[0] || last[1] != wantLast[1] {
+			t.Errorf("unexpected last vertex with tolerance %v: got:%v want:%v", test.tol, last, wantLast)
+		}
+	}
+}
+
+func TestReuseGlyphs(t *testing.T) {
+	glyph := func(x, y vg.Length) vg.Path {
+		var p vg.Path
+		p.Move(vg.Point{X: x, Y: y})
+		p.Line(vg.Point{X: x + 4, Y: y})
+		p.Line(vg.Point{X: x + 2, Y: y + 4})
+		p.Close()
+		return p
+	}
+	at := []vg.Point{{X: 10, Y: 10}, {X: 20, Y: 30}, {X: 50, Y: 40}}
+
+	c := vgsvg.New(10*vg.Centimeter, 10*vg.Centimeter)
+	if c.ReuseGlyphs(true) {
+		t.Error("unexpected default glyph reuse")
+	}
+	for _, pt := range at {
+		c.Fill(glyph(pt.X, pt.Y))
+	}
+	// A larger path is not reused.
+	var big vg.Path
+	big.Move(vg.Point{})
+	for i := 0; i < 40; i++ {
+		big.Line(vg.Point{X: vg.Length(i), Y: vg.Length(i % 2)})
+	}
+	c.Stroke(big)
+	c.Stroke(big)
+
+	var (
+		drawn int
+		defs  = make(map[string]string)
+		uses  []element
+	)
+	inDefs := false
+	for _, e := range elements(t, c) {
+		switch e.name {
+		case "defs":
+			inDefs = true
+		case "path":
+			if inDefs {
+				defs[e.attrs["id"]] = e.attrs["d"]
+				inDefs = false
+				continue
+			}
+			drawn++
+		case "use":
+			uses = append(uses, e)
+		}
+	}
+	// The first glyph and both large paths are drawn
+	// as paths, and the later glyphs as references to
+	// a single definition.
+	if drawn != 3 {
+		t.Errorf("unexpected number of drawn paths: got:%d want:3", drawn)
+	}
+	if len(defs) != 1 {
+		t.Fatalf("unexpected number of definitions: got:%d want:1", len(defs))
+	}
+	if len(uses) != len(at)-1 {
+		t.Fatalf("unexpected number of uses: got:%d want:%d", len(uses), len(at)-1)
+	}
+	for i, u := range uses {
+		id := strings.TrimPrefix(u.attrs["href"], "#")
+		d, ok := defs[id]
+		if !ok {
+			t.Errorf("use %d refers to undefined path %q", i, u.attrs["href"])
+		}
+		if !strings.HasPrefix(d, "M0,0") {
+			t.Errorf("definition %q not relative to its first point: %q", id, d)
+		}
+		pt := at[i+1]
+		wantX := strconv.FormatFloat(dots(pt.X), 'g', 5, 64)
+		wantY := strconv.FormatFloat(dots(pt.Y), 'g', 5, 64)
+		if u.attrs["x"] != wantX || u.attrs["y"] != wantY {
+			t.Errorf("unexpected position of use %d: got:(%s, %s) want:(%s, %s)",
+				i, u.attrs["x"], u.attrs["y"], wantX, wantY)
+		}
+	}
+}
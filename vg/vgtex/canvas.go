@@ -47,6 +47,7 @@ type Canvas struct {
 	// .tex file that can be fed to, e.g., pdflatex.
 	document bool
 	id       int64 // id is a unique identifier for this canvas
+	images   int64 // images is the number of images drawn when vg.Deterministic
 }
 
 type context struct {
@@ -54,6 +55,7 @@ type context struct {
 	dashArray  []vg.Length
 	dashOffset vg.Length
 	linew      vg.Length
+	rule       vg.FillRule
 }
 
 // New returns a new LaTeX canvas.
@@ -73,7 +75,7 @@ func newCanvas(w, h vg.Length, document bool) *Canvas {
 		w:        w,
 		h:        h,
 		document: document,
-		id:       time.Now().UnixNano(),
+		id:       vg.Now().UnixNano(),
 	}
 	if !document {
 		c.wtex(`%%%% gonum/plot created for LaTeX/pgf`)
@@ -153,11 +155,24 @@ func (c *Canvas) Stroke(p vg.Path) {
 	c.wtex("")
 }
 
+// SetFillRule implements the vg.FillRuleSetter interface.
+func (c *Canvas) SetFillRule(r vg.FillRule) {
+	c.context().rule = r
+}
+
 // Fill implements the vg.Canvas.Fill method.
 func (c *Canvas) Fill(p vg.Path) {
 	c.wstyle()
+	eo := c.context().rule == vg.EvenOdd
+	if eo {
+		c.wtex(`\begin{pgfscope}`)
+		c.wtex(`\pgfseteorule`)
+	}
 	c.wpath(p)
 	c.wtex(`\pgfusepath{fill, stroke}`)
+	if eo {
+		c.wtex(`\end{pgfscope}`)
+	}
 	c.wtex("")
 }
 
@@ -171,9 +186,16 @@ func (c *Canvas) FillString(f vg.Font, pt vg.Point, text string) {
 // DrawImage implements the vg.Canvas.DrawImage method.
 // DrawImage will first save the image inside a PNG file and have the
 // generated LaTeX reference that file.
-// The file name will be "gonum-pgf-image-<canvas-id>-<time.Now()>.png
+// The file name will be "gonum-pgf-image-<canvas-id>-<time.Now()>.png,
+// or if vg.Deterministic is true "gonum-pgf-image-<canvas-id>-<n>.png
+// for the nth image drawn to the canvas.
 func (c *Canvas) DrawImage(rect vg.Rectangle, img image.Image) {
-	fname := fmt.Sprintf("gonum-pgf-image-%v-%v.png", c.id, time.Now().UnixNano())
+	stamp := time.Now().UnixNano()
+	if vg.Deterministic {
+		c.images++
+		stamp = c.images
+	}
+	fname := fmt.Sprintf("gonum-pgf-image-%v-%v.png", c.id, stamp)
 	f, err := os.Create(fname)
 	if err != nil {
 		panic(err)